@@ -38,6 +38,8 @@ const (
 	getStatePath             = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath       = "/eth/v1/node/version"
 	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getDepositSnapshotPath   = "/eth/v1/beacon/deposit_snapshot"
+	getGenesisPath           = "/eth/v1/beacon/genesis"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -249,6 +251,36 @@ func (c *Client) GetState(ctx context.Context, stateId StateOrBlockId) ([]byte,
 	return b, nil
 }
 
+// GetGenesis retrieves the genesis time, genesis validators root and genesis fork version of the chain.
+func (c *Client) GetGenesis(ctx context.Context) (*beacon.Genesis, error) {
+	body, err := c.Get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis")
+	}
+	gr := &beacon.GetGenesisResponse{}
+	if err := json.Unmarshal(body, gr); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetGenesis")
+	}
+	if gr.Data == nil {
+		return nil, errors.New("empty genesis response")
+	}
+	return gr.Data, nil
+}
+
+// GetDepositSnapshot retrieves the EIP-4881 deposit tree snapshot of the finalized deposits known to the beacon node.
+// The snapshot is requested in its ssz-encoded form.
+func (c *Client) GetDepositSnapshot(ctx context.Context) (*ethpb.DepositSnapshot, error) {
+	b, err := c.Get(ctx, getDepositSnapshotPath, client.WithSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting deposit snapshot")
+	}
+	snapshot := &ethpb.DepositSnapshot{}
+	if err := snapshot.UnmarshalSSZ(b); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling deposit snapshot")
+	}
+	return snapshot, nil
+}
+
 // GetWeakSubjectivity calls a proposed API endpoint that is unique to prysm
 // This api method does the following:
 // - computes weak subjectivity epoch
@@ -0,0 +1,79 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/light-client"
+)
+
+const (
+	getLightClientBootstrapPath        = "/eth/v1/beacon/light_client/bootstrap"
+	getLightClientUpdatesPath          = "/eth/v1/beacon/light_client/updates"
+	getLightClientFinalityUpdatePath   = "/eth/v1/beacon/light_client/finality_update"
+	getLightClientOptimisticUpdatePath = "/eth/v1/beacon/light_client/optimistic_update"
+)
+
+// GetLightClientBootstrap retrieves the light client bootstrap for the given trusted block root.
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*lightclient.LightClientBootstrapResponse, error) {
+	body, err := c.Get(ctx, path.Join(getLightClientBootstrapPath, fmt.Sprintf("%#x", blockRoot)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client bootstrap for block root %#x", blockRoot)
+	}
+	resp := &lightclient.LightClientBootstrapResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientBootstrap")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty light client bootstrap response")
+	}
+	return resp, nil
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client update of each sync committee period
+// in the range [startPeriod, startPeriod+count).
+func (c *Client) GetLightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]*lightclient.LightClientUpdateWithVersion, error) {
+	q := url.Values{}
+	q.Set("start_period", strconv.FormatUint(startPeriod, 10))
+	q.Set("count", strconv.FormatUint(count, 10))
+	body, err := c.Get(ctx, getLightClientUpdatesPath, client.WithQueryParams(q))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client updates from period %d", startPeriod)
+	}
+	var updates []*lightclient.LightClientUpdateWithVersion
+	if err := json.Unmarshal(body, &updates); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientUpdatesByRange")
+	}
+	return updates, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update known to the beacon node.
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (*lightclient.LightClientUpdateWithVersion, error) {
+	return c.getLightClientUpdate(ctx, getLightClientFinalityUpdatePath)
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update known to the beacon node.
+func (c *Client) GetLightClientOptimisticUpdate(ctx context.Context) (*lightclient.LightClientUpdateWithVersion, error) {
+	return c.getLightClientUpdate(ctx, getLightClientOptimisticUpdatePath)
+}
+
+func (c *Client) getLightClientUpdate(ctx context.Context, updatePath string) (*lightclient.LightClientUpdateWithVersion, error) {
+	body, err := c.Get(ctx, updatePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %s", updatePath)
+	}
+	resp := &lightclient.LightClientUpdateWithVersion{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrapf(err, "error decoding json response from %s", updatePath)
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty response from %s", updatePath)
+	}
+	return resp, nil
+}
@@ -1,12 +1,13 @@
 package api
 
 const (
-	VersionHeader                 = "Eth-Consensus-Version"
-	ExecutionPayloadBlindedHeader = "Eth-Execution-Payload-Blinded"
-	ExecutionPayloadValueHeader   = "Eth-Execution-Payload-Value"
-	ConsensusBlockValueHeader     = "Eth-Consensus-Block-Value"
-	JsonMediaType                 = "application/json"
-	OctetStreamMediaType          = "application/octet-stream"
-	EventStreamMediaType          = "text/event-stream"
-	KeepAlive                     = "keep-alive"
+	VersionHeader                  = "Eth-Consensus-Version"
+	ExecutionPayloadBlindedHeader  = "Eth-Execution-Payload-Blinded"
+	ExecutionPayloadValueHeader    = "Eth-Execution-Payload-Value"
+	ConsensusBlockValueHeader      = "Eth-Consensus-Block-Value"
+	ExecutionPrimaryEndpointHeader = "Prysm-Execution-Primary-Endpoint"
+	JsonMediaType                  = "application/json"
+	OctetStreamMediaType           = "application/octet-stream"
+	EventStreamMediaType           = "text/event-stream"
+	KeepAlive                      = "keep-alive"
 )
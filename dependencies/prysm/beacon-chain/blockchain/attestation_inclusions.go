@@ -0,0 +1,147 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// attInclusionsQueueSize is the number of blocks whose attestation inclusions can be queued for writing.
+const attInclusionsQueueSize = 1024
+
+// attestingIndices returns the validators which took part in the aggregated attestations of a block, and can be
+// replaced by tests.
+var attestingIndices = blockAttestingIndices
+
+// saveAttestationInclusions queues the validators which took part in the aggregated attestations of the block for
+// the attestation inclusions index, so that they are written off the block import path. The index is not part of
+// consensus, so failures are only logged.
+func (s *Service) saveAttestationInclusions(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock, blockRoot [32]byte, postState state.ReadOnlyBeaconState) {
+	indices, err := attestingIndices(ctx, blk, postState)
+	if err != nil {
+		log.WithError(err).Error("Could not get attesting indices of block")
+		return
+	}
+	s.queueAttestationInclusions(&db.BlockAttestationInclusions{Slot: blk.Slot(), BlockRoot: blockRoot, Indices: indices})
+}
+
+// queueAttestationInclusions queues the attestation inclusions of a block. Block import waits for the writer to
+// catch up when the queue is full, so that no block is missing from the index.
+func (s *Service) queueAttestationInclusions(inclusions *db.BlockAttestationInclusions) {
+	select {
+	case s.attInclusions <- inclusions:
+		return
+	default:
+	}
+	attInclusionsQueueFullCount.Inc()
+	log.WithField("slot", inclusions.Slot).Warn("Attestation inclusions queue is full, waiting for the writer to catch up")
+	select {
+	case s.attInclusions <- inclusions:
+	case <-s.ctx.Done():
+	}
+}
+
+// runAttestationInclusionsWriter writes the queued attestation inclusions in batches, once their blocks are an
+// epoch behind the head, skipping the blocks which are not canonical anymore. Inclusions older than the retention
+// period are pruned as the chain finalizes. Queued inclusions are written on shutdown.
+func (s *Service) runAttestationInclusionsWriter() {
+	defer close(s.attInclusionsWriterDone)
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	depth := params.BeaconConfig().SlotsPerEpoch
+	pending := make([]*db.BlockAttestationInclusions, 0)
+	var prunedEpoch primitives.Epoch
+	for {
+		select {
+		case inclusions := <-s.attInclusions:
+			pending = append(pending, inclusions)
+			// Write as soon as a batch is pending, e.g. during initial sync.
+			if primitives.Slot(len(pending)) >= 2*depth {
+				pending = s.writeAttestationInclusions(s.ctx, pending, s.HeadSlot(), depth)
+			}
+		case <-ticker.C:
+			pending = s.writeAttestationInclusions(s.ctx, pending, s.HeadSlot(), depth)
+			prunedEpoch = s.pruneAttestationInclusions(s.ctx, prunedEpoch)
+		case <-s.ctx.Done():
+		drain:
+			for {
+				select {
+				case inclusions := <-s.attInclusions:
+					pending = append(pending, inclusions)
+				default:
+					break drain
+				}
+			}
+			// The service context is done, so write the canonical inclusions with a fresh one.
+			s.writeAttestationInclusions(context.Background(), pending, s.HeadSlot(), 0)
+			return
+		}
+	}
+}
+
+// stopAttestationInclusionsWriter stops the attestation inclusions writer, if running, once it wrote the queued
+// inclusions.
+func (s *Service) stopAttestationInclusionsWriter() {
+	if s.attInclusionsWriterDone == nil {
+		return
+	}
+	s.cancel()
+	<-s.attInclusionsWriterDone
+}
+
+// writeAttestationInclusions writes the pending attestation inclusions of the blocks at least depth slots behind
+// the head slot which are canonical, and drops those which are not. It returns the inclusions left pending.
+func (s *Service) writeAttestationInclusions(
+	ctx context.Context, pending []*db.BlockAttestationInclusions, headSlot, depth primitives.Slot,
+) []*db.BlockAttestationInclusions {
+	batch := make([]*db.BlockAttestationInclusions, 0, len(pending))
+	remaining := make([]*db.BlockAttestationInclusions, 0)
+	for _, inclusions := range pending {
+		if inclusions.Slot > headSlot || headSlot-inclusions.Slot < depth {
+			remaining = append(remaining, inclusions)
+			continue
+		}
+		canonical, err := s.IsCanonical(ctx, inclusions.BlockRoot)
+		if err != nil {
+			log.WithError(err).WithField("slot", inclusions.Slot).Error("Could not check if block is canonical")
+			continue
+		}
+		if canonical {
+			batch = append(batch, inclusions)
+		}
+	}
+	if len(batch) == 0 {
+		return remaining
+	}
+	if err := s.cfg.BeaconDB.SaveAttestationInclusions(ctx, batch); err != nil {
+		log.WithError(err).Error("Could not save attestation inclusions")
+	}
+	return remaining
+}
+
+// pruneAttestationInclusions deletes the attestation inclusions of the blocks finalized more than the retention
+// period ago, at most once per finalized epoch. It returns the epoch before which inclusions are pruned.
+func (s *Service) pruneAttestationInclusions(ctx context.Context, prunedEpoch primitives.Epoch) primitives.Epoch {
+	retention := s.cfg.AttestationInclusionsRetentionEpochs
+	finalized := s.FinalizedCheckpt().Epoch
+	if retention == 0 || finalized <= retention || finalized-retention <= prunedEpoch {
+		return prunedEpoch
+	}
+	cutoff := finalized - retention
+	start, err := slots.EpochStart(cutoff)
+	if err != nil {
+		log.WithError(err).Error("Could not compute attestation inclusions pruning slot")
+		return prunedEpoch
+	}
+	if err := s.cfg.BeaconDB.PruneAttestationInclusions(ctx, start); err != nil {
+		log.WithError(err).Error("Could not prune attestation inclusions")
+		return prunedEpoch
+	}
+	return cutoff
+}
@@ -0,0 +1,91 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_writeAttestationInclusions(t *testing.T) {
+	ctx := context.Background()
+	s, tr := minimalTestService(t)
+
+	blk := util.NewBeaconBlock()
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, tr.db, blk)
+	require.NoError(t, tr.db.SaveGenesisBlockRoot(ctx, root))
+
+	pending := []*db.BlockAttestationInclusions{
+		{Slot: 0, BlockRoot: root, Indices: []primitives.ValidatorIndex{1}},
+		// Neither in fork choice nor finalized, e.g. a block orphaned by a reorg.
+		{Slot: 1, BlockRoot: [32]byte{'a'}, Indices: []primitives.ValidatorIndex{1}},
+		// Not deep enough behind the head yet.
+		{Slot: 40, BlockRoot: [32]byte{'b'}, Indices: []primitives.ValidatorIndex{1}},
+	}
+	remaining := s.writeAttestationInclusions(ctx, pending, 40, params.BeaconConfig().SlotsPerEpoch)
+	require.Equal(t, 1, len(remaining))
+	assert.Equal(t, primitives.Slot(40), remaining[0].Slot)
+
+	inclusions, err := tr.db.AttestationInclusions(ctx, 1, &dbval.AttestationInclusion{}, 100, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(inclusions))
+	assert.DeepEqual(t, root[:], inclusions[0].BlockRoot)
+}
+
+func TestService_pruneAttestationInclusions(t *testing.T) {
+	ctx := context.Background()
+	s, tr := minimalTestService(t, WithAttestationInclusionsRetentionEpochs(2))
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, tr.db.SaveAttestationInclusions(ctx, []*db.BlockAttestationInclusions{
+		{Slot: 0, BlockRoot: [32]byte{'a'}, Indices: []primitives.ValidatorIndex{1}},
+		{Slot: 2 * slotsPerEpoch, BlockRoot: [32]byte{'b'}, Indices: []primitives.ValidatorIndex{1}},
+	}))
+
+	// Nothing is finalized more than the retention period ago.
+	require.NoError(t, tr.fcs.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Epoch: 2}))
+	assert.Equal(t, primitives.Epoch(0), s.pruneAttestationInclusions(ctx, 0))
+
+	require.NoError(t, tr.fcs.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Epoch: 4}))
+	assert.Equal(t, primitives.Epoch(2), s.pruneAttestationInclusions(ctx, 0))
+	inclusions, err := tr.db.AttestationInclusions(ctx, 1, &dbval.AttestationInclusion{}, 100, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(inclusions))
+	assert.Equal(t, uint64(2*slotsPerEpoch), inclusions[0].Slot)
+}
+
+func TestService_queueAttestationInclusions_Full(t *testing.T) {
+	s, _ := minimalTestService(t)
+	inclusions := &db.BlockAttestationInclusions{Slot: 1}
+	for i := 0; i < attInclusionsQueueSize; i++ {
+		s.queueAttestationInclusions(inclusions)
+	}
+
+	// Queueing waits for room in the queue rather than dropping the inclusions.
+	queued := make(chan struct{})
+	go func() {
+		s.queueAttestationInclusions(inclusions)
+		close(queued)
+	}()
+	select {
+	case <-queued:
+		t.Fatal("inclusions queued while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	<-s.attInclusions
+	select {
+	case <-queued:
+	case <-time.After(time.Second):
+		t.Fatal("inclusions not queued once the queue has room")
+	}
+	require.Equal(t, attInclusionsQueueSize, len(s.attInclusions))
+}
@@ -3,8 +3,12 @@ package blockchain
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/prysmaticlabs/go-bitfield"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -14,6 +18,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 type testlc struct {
@@ -158,3 +163,279 @@ func TestLightClient_NewLightClientFinalityUpdateFromBeaconState(t *testing.T) {
 		require.DeepSSZEqual(t, zeroHash, leaf, "Leaf is not zero")
 	}
 }
+
+func TestLightClient_NewLightClientUpdateFromBeaconState(t *testing.T) {
+	l := newTestLc(t).setupTest()
+
+	update, err := NewLightClientUpdateFromBeaconState(l.ctx, l.state, l.block, l.attestedState, nil)
+	require.NoError(t, err)
+	require.NotNil(t, update, "update is nil")
+
+	l.checkSyncAggregate(update)
+	l.checkAttestedHeader(update)
+
+	// The attested header and the signature slot are in the same period.
+	nextSyncCommittee, err := l.attestedState.NextSyncCommittee()
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, nextSyncCommittee.Pubkeys, update.NextSyncCommittee.Pubkeys, "Next sync committee is not equal")
+	nextSyncCommitteeBranch, err := l.attestedState.NextSyncCommitteeProof(l.ctx)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, nextSyncCommitteeBranch, update.NextSyncCommitteeBranch, "Next sync committee branch is not equal")
+	_, err = update.MarshalSSZ()
+	require.NoError(t, err)
+}
+
+func TestLightClient_NewLightClientBootstrapFromBeaconState(t *testing.T) {
+	l := newTestLc(t).setupTest()
+
+	bootstrap, err := NewLightClientBootstrapFromBeaconState(l.ctx, l.state)
+	require.NoError(t, err)
+
+	stateRoot, err := l.state.HashTreeRoot(l.ctx)
+	require.NoError(t, err)
+	blockRoot, err := l.block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	headerRoot, err := bootstrap.Header.HashTreeRoot()
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, stateRoot[:], bootstrap.Header.StateRoot, "Header state root is not equal")
+	require.Equal(t, blockRoot, headerRoot, "Header root is not equal to block root")
+
+	currentSyncCommittee, err := l.state.CurrentSyncCommittee()
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, currentSyncCommittee.Pubkeys, bootstrap.CurrentSyncCommittee.Pubkeys, "Current sync committee is not equal")
+	require.Equal(t, fieldparams.NextSyncCommitteeBranchDepth, len(bootstrap.CurrentSyncCommitteeBranch), "Invalid current sync committee branch depth")
+	_, err = bootstrap.MarshalSSZ()
+	require.NoError(t, err)
+}
+
+func TestLightClient_NewLightClientBootstrapFromBeaconState_PreAltair(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+
+	_, err = NewLightClientBootstrapFromBeaconState(context.Background(), st)
+	require.ErrorContains(t, "light client bootstrap is not supported before Altair", err)
+}
+
+func TestLightClient_IsBetterLightClientUpdate(t *testing.T) {
+	periodSlots := primitives.Slot(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * uint64(params.BeaconConfig().SlotsPerEpoch))
+	nonEmptyBranch := func(depth int) [][]byte {
+		branch := make([][]byte, depth)
+		for i := range branch {
+			branch[i] = make([]byte, fieldparams.RootLength)
+		}
+		branch[0][0] = 1
+		return branch
+	}
+	newUpdate := func(participants uint64, attestedSlot, signatureSlot primitives.Slot, syncCommittee bool, finalizedSlot *primitives.Slot) *ethpbv2.LightClientUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		update := &ethpbv2.LightClientUpdate{
+			AttestedHeader:          &v1.BeaconBlockHeader{Slot: attestedSlot},
+			NextSyncCommitteeBranch: make([][]byte, fieldparams.NextSyncCommitteeBranchDepth),
+			FinalizedHeader:         &v1.BeaconBlockHeader{},
+			FinalityBranch:          make([][]byte, finalityBranchNumOfLeaves),
+			SyncAggregate:           &v1.SyncAggregate{SyncCommitteeBits: bits},
+			SignatureSlot:           signatureSlot,
+		}
+		if syncCommittee {
+			update.NextSyncCommitteeBranch = nonEmptyBranch(fieldparams.NextSyncCommitteeBranchDepth)
+		}
+		if finalizedSlot != nil {
+			update.FinalizedHeader.Slot = *finalizedSlot
+			update.FinalityBranch = nonEmptyBranch(finalityBranchNumOfLeaves)
+		}
+		return update
+	}
+	sameFinalizedPeriod := periodSlots + 1
+	previousFinalizedPeriod := periodSlots - 1
+
+	tests := []struct {
+		name      string
+		newUpdate *ethpbv2.LightClientUpdate
+		oldUpdate *ethpbv2.LightClientUpdate
+		want      bool
+	}{
+		{
+			name:      "supermajority beats no supermajority",
+			newUpdate: newUpdate(400, periodSlots+2, periodSlots+3, false, nil),
+			oldUpdate: newUpdate(300, periodSlots+2, periodSlots+3, true, &sameFinalizedPeriod),
+			want:      true,
+		},
+		{
+			name:      "more participants without supermajority",
+			newUpdate: newUpdate(200, periodSlots+2, periodSlots+3, false, nil),
+			oldUpdate: newUpdate(300, periodSlots+2, periodSlots+3, true, nil),
+			want:      false,
+		},
+		{
+			name:      "relevant sync committee",
+			newUpdate: newUpdate(400, periodSlots+2, periodSlots+3, true, nil),
+			oldUpdate: newUpdate(500, periodSlots+2, periodSlots+3, false, nil),
+			want:      true,
+		},
+		{
+			name:      "sync committee signed in a later period is not relevant",
+			newUpdate: newUpdate(400, 2*periodSlots-1, 2*periodSlots, true, nil),
+			oldUpdate: newUpdate(400, periodSlots+2, periodSlots+3, true, nil),
+			want:      false,
+		},
+		{
+			name:      "finality",
+			newUpdate: newUpdate(400, periodSlots+2, periodSlots+3, true, &previousFinalizedPeriod),
+			oldUpdate: newUpdate(500, periodSlots+2, periodSlots+3, true, nil),
+			want:      true,
+		},
+		{
+			name:      "sync committee finality",
+			newUpdate: newUpdate(400, periodSlots+2, periodSlots+3, true, &sameFinalizedPeriod),
+			oldUpdate: newUpdate(500, periodSlots+2, periodSlots+3, true, &previousFinalizedPeriod),
+			want:      true,
+		},
+		{
+			name:      "more participants beyond supermajority",
+			newUpdate: newUpdate(500, periodSlots+2, periodSlots+3, true, nil),
+			oldUpdate: newUpdate(400, periodSlots+2, periodSlots+3, true, nil),
+			want:      true,
+		},
+		{
+			name:      "older attested header",
+			newUpdate: newUpdate(400, periodSlots+2, periodSlots+5, true, nil),
+			oldUpdate: newUpdate(400, periodSlots+3, periodSlots+4, true, nil),
+			want:      true,
+		},
+		{
+			name:      "older signature slot",
+			newUpdate: newUpdate(400, periodSlots+2, periodSlots+4, true, nil),
+			oldUpdate: newUpdate(400, periodSlots+2, periodSlots+3, true, nil),
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsBetterLightClientUpdate(tt.newUpdate, tt.oldUpdate))
+		})
+	}
+}
+
+func TestService_SaveLightClientUpdate(t *testing.T) {
+	l := newTestLc(t).setupTest()
+	s, _ := minimalTestService(t)
+	require.NoError(t, s.cfg.BeaconDB.SaveState(l.ctx, l.attestedState, l.block.Block().ParentRoot()))
+
+	require.NoError(t, s.saveLightClientUpdate(l.ctx, l.block, l.state, nil))
+	period := uint64(slots.SyncCommitteePeriod(slots.ToEpoch(l.attestedHeader.Slot)))
+	update, err := s.cfg.BeaconDB.LightClientUpdate(l.ctx, period)
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	l.checkAttestedHeader(update)
+	l.checkSyncAggregate(update)
+}
+
+func TestService_SaveLightClientUpdate_Retention(t *testing.T) {
+	l := newTestLc(t).setupTest()
+	s, _ := minimalTestService(t, WithLightClientRetentionPeriods(1))
+	require.NoError(t, s.cfg.BeaconDB.SaveState(l.ctx, l.attestedState, l.block.Block().ParentRoot()))
+	period := uint64(slots.SyncCommitteePeriod(slots.ToEpoch(l.attestedHeader.Slot)))
+	stale, err := NewLightClientUpdateFromBeaconState(l.ctx, l.state, l.block, l.attestedState, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.cfg.BeaconDB.SaveLightClientUpdate(l.ctx, period-1, stale))
+
+	require.NoError(t, s.saveLightClientUpdate(l.ctx, l.block, l.state, nil))
+	updates, err := s.cfg.BeaconDB.LightClientUpdates(l.ctx, period-1, period)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(updates))
+	require.NotNil(t, updates[period])
+}
+
+func TestService_SaveLightClientBootstrap(t *testing.T) {
+	l := newTestLc(t).setupTest()
+	s, _ := minimalTestService(t)
+	root, err := l.block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.cfg.BeaconDB.SaveState(l.ctx, l.state, root))
+
+	cp := &forkchoicetypes.Checkpoint{Epoch: slots.ToEpoch(l.state.Slot()), Root: root}
+	require.NoError(t, s.saveLightClientBootstrap(l.ctx, cp))
+	bootstrap, err := s.cfg.BeaconDB.LightClientBootstrap(l.ctx, root)
+	require.NoError(t, err)
+	require.NotNil(t, bootstrap)
+	require.Equal(t, l.state.Slot(), bootstrap.Header.Slot)
+	require.Equal(t, cp.Epoch, s.lastLightClientBootstrapEpoch)
+}
+
+func TestService_RunLightClientPersistence(t *testing.T) {
+	l := newTestLc(t).setupTest()
+	s, _ := minimalTestService(t)
+	period := uint64(slots.SyncCommitteePeriod(slots.ToEpoch(l.attestedHeader.Slot)))
+
+	// The checkpoint finalized at startup gets a bootstrap.
+	root, err := l.block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.cfg.BeaconDB.SaveState(l.ctx, l.state, root))
+	cp := &forkchoicetypes.Checkpoint{Epoch: slots.ToEpoch(l.state.Slot()), Root: root}
+	require.NoError(t, s.cfg.ForkChoiceStore.UpdateFinalizedCheckpoint(cp))
+
+	// The attested state is the post-state of the parent block, which is not saved in the database.
+	parent, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+	require.NoError(t, err)
+	s.queueLightClientData(&lightClientData{
+		signed:    parent,
+		blockRoot: l.block.Block().ParentRoot(),
+		postState: l.attestedState,
+		finalized: &forkchoicetypes.Checkpoint{},
+	})
+	// Queueing does not persist the data on the block import path.
+	s.queueLightClientData(&lightClientData{signed: l.block, blockRoot: root, postState: l.state, finalized: &forkchoicetypes.Checkpoint{}})
+	update, err := s.cfg.BeaconDB.LightClientUpdate(l.ctx, period)
+	require.NoError(t, err)
+	require.Equal(t, true, update == nil)
+
+	go s.runLightClientPersistence()
+	defer s.cancel()
+	for i := 0; i < 100 && update == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		update, err = s.cfg.BeaconDB.LightClientUpdate(l.ctx, period)
+		require.NoError(t, err)
+	}
+	require.NotNil(t, update)
+	l.checkAttestedHeader(update)
+
+	bootstrap, err := s.cfg.BeaconDB.LightClientBootstrap(l.ctx, root)
+	require.NoError(t, err)
+	require.NotNil(t, bootstrap)
+	require.Equal(t, l.state.Slot(), bootstrap.Header.Slot)
+}
+
+func TestService_QueueLightClientData_Full(t *testing.T) {
+	l := newTestLc(t).setupTest()
+	s, _ := minimalTestService(t)
+	data := &lightClientData{signed: l.block, postState: l.state, finalized: &forkchoicetypes.Checkpoint{}}
+	for i := 0; i < lightClientDataQueueSize; i++ {
+		s.queueLightClientData(data)
+	}
+
+	// Queueing waits for room in the queue rather than dropping the data.
+	queued := make(chan struct{})
+	go func() {
+		s.queueLightClientData(data)
+		close(queued)
+	}()
+	select {
+	case <-queued:
+		t.Fatal("data queued while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	<-s.lightClientData
+	select {
+	case <-queued:
+	case <-time.After(time.Second):
+		t.Fatal("data not queued once the queue has room")
+	}
+	require.Equal(t, lightClientDataQueueSize, len(s.lightClientData))
+}
@@ -221,6 +221,14 @@ var (
 			Buckets: []float64{1, 2, 4, 8, 16, 32},
 		},
 	)
+	attInclusionsQueueFullCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attestation_inclusions_queue_full_total",
+		Help: "Number of times block import waited for the attestation inclusions writer to catch up",
+	})
+	lightClientDataQueueFullCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "light_client_data_queue_full_total",
+		Help: "Number of times block import waited for light client persistence to catch up",
+	})
 )
 
 // reportSlotMetrics reports slot related metrics.
@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -37,6 +38,24 @@ func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) Option {
 	}
 }
 
+// WithLightClientRetentionPeriods sets the number of sync committee periods for which light client
+// updates and bootstraps are kept in the database. A value of zero keeps them indefinitely.
+func WithLightClientRetentionPeriods(periods uint64) Option {
+	return func(s *Service) error {
+		s.cfg.LightClientRetentionPeriods = periods
+		return nil
+	}
+}
+
+// WithAttestationInclusionsRetentionEpochs sets the number of epochs for which attestation inclusions are kept in
+// the database. A value of zero keeps them indefinitely.
+func WithAttestationInclusionsRetentionEpochs(epochs primitives.Epoch) Option {
+	return func(s *Service) error {
+		s.cfg.AttestationInclusionsRetentionEpochs = epochs
+		return nil
+	}
+}
+
 // WithDatabase for head access.
 func WithDatabase(beaconDB db.HeadAccessDatabase) Option {
 	return func(s *Service) error {
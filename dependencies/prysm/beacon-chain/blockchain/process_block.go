@@ -15,6 +15,7 @@ import (
 	coreTime "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filesystem"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -169,6 +170,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []consensusblocks.ROBlo
 	postVersionAndHeaders := make([]*versionAndHeader, len(blks))
 	var set *bls.SignatureBatch
 	boundaries := make(map[[32]byte]state.BeaconState)
+	attesters := make([][]primitives.ValidatorIndex, len(blks))
 	for i, b := range blks {
 		v, h, err := getStateVersionAndPayload(preState)
 		if err != nil {
@@ -189,6 +191,14 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []consensusblocks.ROBlo
 		}
 		jCheckpoints[i] = preState.CurrentJustifiedCheckpoint()
 		fCheckpoints[i] = preState.FinalizedCheckpoint()
+		if features.Get().IndexAttestationInclusions {
+			// The index is not needed for consensus, the block is imported without its inclusions when it fails.
+			attesters[i], err = attestingIndices(ctx, b.Block(), preState)
+			if err != nil {
+				log.WithError(err).WithField("slot", b.Block().Slot()).Error("Could not get attesting indices of block")
+				attesters[i] = nil
+			}
+		}
 
 		v, h, err = getStateVersionAndPayload(preState)
 		if err != nil {
@@ -249,6 +259,9 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []consensusblocks.ROBlo
 			tracing.AnnotateError(span, err)
 			return err
 		}
+		if features.Get().IndexAttestationInclusions && attesters[i] != nil {
+			s.queueAttestationInclusions(&db.BlockAttestationInclusions{Slot: b.Block().Slot(), BlockRoot: root, Indices: attesters[i]})
+		}
 		if i > 0 && jCheckpoints[i].Epoch > jCheckpoints[i-1].Epoch {
 			if err := s.cfg.BeaconDB.SaveJustifiedCheckpoint(ctx, jCheckpoints[i]); err != nil {
 				tracing.AnnotateError(span, err)
@@ -124,7 +124,155 @@ func (s *Service) sendLightClientFeeds(cfg *postBlockProcessConfig) {
 
 		// LightClientFinalityUpdate needs super majority
 		s.tryPublishLightClientFinalityUpdate(cfg.ctx, cfg.signed, finalized, cfg.postState)
+
+		s.queueLightClientData(&lightClientData{
+			signed:    cfg.signed,
+			blockRoot: cfg.blockRoot,
+			postState: cfg.postState,
+			finalized: finalized,
+		})
+	}
+}
+
+// lightClientData is what the light client update and bootstrap of an imported block are persisted from.
+type lightClientData struct {
+	signed    interfaces.ReadOnlySignedBeaconBlock
+	blockRoot [32]byte
+	postState state.BeaconState
+	finalized *forkchoicetypes.Checkpoint
+}
+
+// lightClientDataQueueSize is the number of blocks whose light client data can be queued for persistence.
+const lightClientDataQueueSize = 64
+
+// queueLightClientData queues the light client data of an imported block, so that it is persisted off the block
+// import path. Block import waits for persistence to catch up when the queue is full, so that no block is missed.
+func (s *Service) queueLightClientData(data *lightClientData) {
+	select {
+	case s.lightClientData <- data:
+		return
+	default:
+	}
+	lightClientDataQueueFullCount.Inc()
+	log.WithField("slot", data.signed.Block().Slot()).Warn("Light client data queue is full, waiting for persistence to catch up")
+	select {
+	case s.lightClientData <- data:
+	case <-s.ctx.Done():
+	}
+}
+
+// runLightClientPersistence persists the light client updates and bootstraps of the imported blocks, one block at a
+// time, until the service context is done. The bootstrap of the finalized checkpoint at startup is persisted first,
+// as it may have been finalized by blocks imported before the node restarted.
+func (s *Service) runLightClientPersistence() {
+	cp := s.FinalizedCheckpt()
+	if err := s.saveLightClientBootstrap(s.ctx, &forkchoicetypes.Checkpoint{Epoch: cp.Epoch, Root: bytesutil.ToBytes32(cp.Root)}); err != nil {
+		log.WithError(err).Error("Failed to save light client bootstrap")
+	}
+	// The post-state of the previous block is the attested state of the next block when built on it, which
+	// saves regenerating it.
+	var prev *lightClientData
+	for {
+		select {
+		case data := <-s.lightClientData:
+			var attestedState state.BeaconState
+			if prev != nil && prev.blockRoot == data.signed.Block().ParentRoot() {
+				attestedState = prev.postState
+			}
+			prev = data
+			if err := s.saveLightClientUpdate(s.ctx, data.signed, data.postState, attestedState); err != nil {
+				log.WithError(err).Error("Failed to save light client update")
+			}
+			if err := s.saveLightClientBootstrap(s.ctx, data.finalized); err != nil {
+				log.WithError(err).Error("Failed to save light client bootstrap")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// saveLightClientUpdate persists the light client update signed by the given block if it is
+// better than the update already stored for its sync committee period, and prunes the updates
+// and bootstraps which fell out of the retention window. The attested state, which is the post-state
+// of the parent block, is regenerated if nil.
+func (s *Service) saveLightClientUpdate(
+	ctx context.Context,
+	signed interfaces.ReadOnlySignedBeaconBlock,
+	postState state.BeaconState,
+	attestedState state.BeaconState,
+) error {
+	config := params.BeaconConfig()
+	if slots.ToEpoch(signed.Block().Slot()) < config.AltairForkEpoch {
+		return nil
+	}
+	syncAggregate, err := signed.Block().Body().SyncAggregate()
+	if err != nil || syncAggregate == nil {
+		return nil
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < config.MinSyncCommitteeParticipants {
+		return nil
+	}
+
+	if attestedState == nil || attestedState.IsNil() {
+		attestedState, err = s.cfg.StateGen.StateByRoot(ctx, signed.Block().ParentRoot())
+		if err != nil {
+			return errors.Wrap(err, "could not get attested state")
+		}
 	}
+	if slots.ToEpoch(attestedState.Slot()) < config.AltairForkEpoch {
+		return nil
+	}
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	if cp := attestedState.FinalizedCheckpoint(); cp != nil {
+		finalizedBlock, err = s.cfg.BeaconDB.Block(ctx, bytesutil.ToBytes32(cp.Root))
+		if err != nil {
+			finalizedBlock = nil
+		}
+	}
+	update, err := NewLightClientUpdateFromBeaconState(ctx, postState, signed, attestedState, finalizedBlock)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+
+	period := uint64(slots.SyncCommitteePeriod(slots.ToEpoch(update.AttestedHeader.Slot)))
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return errors.Wrapf(err, "could not get light client update for period %d", period)
+	}
+	if best != nil && !IsBetterLightClientUpdate(update, best) {
+		return nil
+	}
+	if err := s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update); err != nil {
+		return errors.Wrapf(err, "could not save light client update for period %d", period)
+	}
+
+	retention := s.cfg.LightClientRetentionPeriods
+	if retention == 0 || period < retention {
+		return nil
+	}
+	return s.cfg.BeaconDB.DeleteLightClientDataBeforePeriod(ctx, period-retention+1)
+}
+
+// saveLightClientBootstrap persists the light client bootstrap of the finalized checkpoint
+// the first time the checkpoint is seen.
+func (s *Service) saveLightClientBootstrap(ctx context.Context, finalized *forkchoicetypes.Checkpoint) error {
+	if finalized.Epoch <= s.lastLightClientBootstrapEpoch || finalized.Epoch < params.BeaconConfig().AltairForkEpoch {
+		return nil
+	}
+	st, err := s.cfg.StateGen.StateByRoot(ctx, finalized.Root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	bootstrap, err := NewLightClientBootstrapFromBeaconState(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client bootstrap")
+	}
+	if err := s.cfg.BeaconDB.SaveLightClientBootstrap(ctx, finalized.Root, bootstrap); err != nil {
+		return errors.Wrapf(err, "could not save light client bootstrap for root %#x", finalized.Root)
+	}
+	s.lastLightClientBootstrapEpoch = finalized.Epoch
+	return nil
 }
 
 func (s *Service) tryPublishLightClientFinalityUpdate(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock, finalized *forkchoicetypes.Checkpoint, postState state.BeaconState) {
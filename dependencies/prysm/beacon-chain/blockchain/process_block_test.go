@@ -76,6 +76,53 @@ func TestStore_OnBlockBatch(t *testing.T) {
 	require.Equal(t, primitives.Epoch(2), service.cfg.ForkChoiceStore.JustifiedCheckpoint().Epoch)
 }
 
+func TestStore_OnBlockBatch_AttestationInclusionsFailure(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{IndexAttestationInclusions: true})
+	defer resetCfg()
+	hook := logTest.NewGlobal()
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, service.saveGenesisData(ctx, st))
+	bState := st.Copy()
+
+	var blks []consensusblocks.ROBlock
+	for i := 1; i <= 3; i++ {
+		b, err := util.GenerateFullBlock(bState, keys, util.DefaultBlockGenConfig(), primitives.Slot(i))
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		bState, err = transition.ExecuteStateTransition(ctx, bState, wsb)
+		require.NoError(t, err)
+		rwsb, err := consensusblocks.NewROBlock(wsb)
+		require.NoError(t, err)
+		blks = append(blks, rwsb)
+	}
+
+	// Indexing fails for the second block only.
+	attestingIndices = func(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock, st state.ReadOnlyBeaconState) ([]primitives.ValidatorIndex, error) {
+		if blk.Slot() == 2 {
+			return nil, errors.New("committee not found")
+		}
+		return blockAttestingIndices(ctx, blk, st)
+	}
+	defer func() {
+		attestingIndices = blockAttestingIndices
+	}()
+
+	// The whole batch is imported, and only the inclusions of the other blocks are queued.
+	require.NoError(t, service.onBlockBatch(ctx, blks, &das.MockAvailabilityStore{}))
+	for _, b := range blks {
+		assert.Equal(t, true, service.HasBlock(ctx, b.Root()))
+		assert.Equal(t, true, service.cfg.ForkChoiceStore.HasNode(b.Root()))
+	}
+	require.LogsContain(t, hook, "Could not get attesting indices of block")
+	require.Equal(t, 2, len(service.attInclusions))
+	assert.Equal(t, primitives.Slot(1), (<-service.attInclusions).Slot)
+	assert.Equal(t, primitives.Slot(3), (<-service.attInclusions).Slot)
+}
+
 func TestStore_OnBlockBatch_NotifyNewPayload(t *testing.T) {
 	service, tr := minimalTestService(t)
 	ctx := tr.ctx
@@ -712,7 +759,7 @@ func TestInsertFinalizedDeposits(t *testing.T) {
 			WithdrawalCredentials: params.BeaconConfig().ZeroHash[:],
 			Amount:                0,
 			Signature:             zeroSig[:],
-		}, Proof: [][]byte{root}}, 100+i, int64(i), bytesutil.ToBytes32(root)))
+		}, Proof: [][]byte{root}}, 100+i, 0, int64(i), bytesutil.ToBytes32(root)))
 	}
 	service.insertFinalizedDeposits(ctx, [32]byte{'m', 'o', 'c', 'k'})
 	fDeposits, err := depositCache.FinalizedDeposits(ctx)
@@ -742,7 +789,7 @@ func TestInsertFinalizedDeposits_PrunePendingDeposits(t *testing.T) {
 			WithdrawalCredentials: params.BeaconConfig().ZeroHash[:],
 			Amount:                0,
 			Signature:             zeroSig[:],
-		}, Proof: [][]byte{root}}, 100+i, int64(i), bytesutil.ToBytes32(root)))
+		}, Proof: [][]byte{root}}, 100+i, 0, int64(i), bytesutil.ToBytes32(root)))
 		depositCache.InsertPendingDeposit(ctx, &ethpb.Deposit{Data: &ethpb.Deposit_Data{
 			PublicKey:             bytesutil.FromBytes48([fieldparams.BLSPubkeyLength]byte{}),
 			WithdrawalCredentials: params.BeaconConfig().ZeroHash[:],
@@ -786,7 +833,7 @@ func TestInsertFinalizedDeposits_MultipleFinalizedRoutines(t *testing.T) {
 			WithdrawalCredentials: params.BeaconConfig().ZeroHash[:],
 			Amount:                0,
 			Signature:             zeroSig[:],
-		}, Proof: [][]byte{root}}, 100+i, int64(i), bytesutil.ToBytes32(root)))
+		}, Proof: [][]byte{root}}, 100+i, 0, int64(i), bytesutil.ToBytes32(root)))
 	}
 	// Insert 3 deposits before hand.
 	require.NoError(t, depositCache.InsertFinalizedDeposits(ctx, 2, [32]byte{}, 0))
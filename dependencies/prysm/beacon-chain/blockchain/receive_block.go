@@ -178,6 +178,10 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 		go s.sendBlockAttestationsToSlasher(blockCopy, preState)
 	}
 
+	if features.Get().IndexAttestationInclusions {
+		s.saveAttestationInclusions(ctx, blockCopy.Block(), blockRoot, postState)
+	}
+
 	// Handle post block operations such as pruning exits and bls messages if incoming block is the head
 	if err := s.prunePostBlockOperationPools(ctx, blockCopy, blockRoot); err != nil {
 		log.WithError(err).Error("Could not prune canonical objects from pool ")
@@ -453,6 +457,30 @@ func (s *Service) sendBlockAttestationsToSlasher(signed interfaces.ReadOnlySigne
 	}
 }
 
+// blockAttestingIndices returns the indices of the validators which took part in the aggregated attestations of the
+// block, computing the committees from the post state of the block.
+func blockAttestingIndices(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock, postState state.ReadOnlyBeaconState) ([]primitives.ValidatorIndex, error) {
+	seen := make(map[uint64]bool)
+	indices := make([]primitives.ValidatorIndex, 0)
+	for _, att := range blk.Body().Attestations() {
+		committee, err := helpers.BeaconCommitteeFromState(ctx, postState, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			return nil, err
+		}
+		attesting, err := attestation.AttestingIndices(att.AggregationBits, committee)
+		if err != nil {
+			return nil, err
+		}
+		for _, idx := range attesting {
+			if !seen[idx] {
+				seen[idx] = true
+				indices = append(indices, primitives.ValidatorIndex(idx))
+			}
+		}
+	}
+	return indices, nil
+}
+
 // validateExecutionOnBlock notifies the engine of the incoming block execution payload and returns true if the payload is valid
 func (s *Service) validateExecutionOnBlock(ctx context.Context, ver int, header interfaces.ExecutionData, signed interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte) (bool, error) {
 	isValidPayload, err := s.notifyNewPayload(ctx, ver, header, signed)
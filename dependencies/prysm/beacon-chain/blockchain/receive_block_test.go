@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -9,11 +10,14 @@ import (
 	blockchainTesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -255,6 +259,60 @@ func TestService_ReceiveBlockBatch(t *testing.T) {
 	}
 }
 
+func TestService_ReceiveBlock_IndexAttestationInclusions(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{IndexAttestationInclusions: true})
+	defer resetCfg()
+	ctx := context.Background()
+
+	genesis, keys := util.DeterministicGenesisState(t, 64)
+	b1, err := util.GenerateFullBlock(genesis, keys, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	b2, err := util.GenerateFullBlock(genesis, keys, util.DefaultBlockGenConfig(), 2)
+	require.NoError(t, err)
+
+	for _, batch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("batch=%v", batch), func(t *testing.T) {
+			s, tr := minimalTestService(t, WithStateNotifier(&blockchainTesting.MockStateNotifier{}))
+			require.NoError(t, s.saveGenesisData(ctx, genesis))
+			for _, b := range []*ethpb.SignedBeaconBlock{b1, b2} {
+				wsb, err := blocks.NewSignedBeaconBlock(b)
+				require.NoError(t, err)
+				root, err := b.Block.HashTreeRoot()
+				require.NoError(t, err)
+				if batch {
+					rwsb, err := blocks.NewROBlockWithRoot(wsb, root)
+					require.NoError(t, err)
+					require.NoError(t, s.ReceiveBlockBatch(ctx, []blocks.ROBlock{rwsb}, &das.MockAvailabilityStore{}))
+					// The head is not recomputed by batch imports, which the writer relies on to skip non-canonical blocks.
+					s.cfg.ForkChoiceStore.Lock()
+					_, err = s.cfg.ForkChoiceStore.Head(ctx)
+					s.cfg.ForkChoiceStore.Unlock()
+					require.NoError(t, err)
+				} else {
+					require.NoError(t, s.ReceiveBlock(ctx, wsb, root, nil))
+				}
+
+				// Inclusions are queued on import and written by the writer.
+				require.Equal(t, 1, len(s.attInclusions))
+				pending := s.writeAttestationInclusions(ctx, []*db.BlockAttestationInclusions{<-s.attInclusions}, s.HeadSlot(), 0)
+				require.Equal(t, 0, len(pending))
+
+				st, err := s.HeadState(ctx)
+				require.NoError(t, err)
+				indices, err := blockAttestingIndices(ctx, wsb.Block(), st)
+				require.NoError(t, err)
+				require.NotEqual(t, 0, len(indices))
+				for _, idx := range indices {
+					inclusions, err := tr.db.AttestationInclusions(ctx, idx, &dbval.AttestationInclusion{Slot: uint64(b.Block.Slot)}, b.Block.Slot, 10)
+					require.NoError(t, err)
+					require.Equal(t, 1, len(inclusions))
+					assert.DeepEqual(t, root[:], inclusions[0].BlockRoot)
+				}
+			}
+		})
+	}
+}
+
 func TestService_HasBlock(t *testing.T) {
 	s, _ := minimalTestService(t)
 	r := [32]byte{'a'}
@@ -68,31 +68,37 @@ type Service struct {
 	blockBeingSynced              *currentlySyncingBlock
 	blobStorage                   *filesystem.BlobStorage
 	lastPublishedLightClientEpoch primitives.Epoch
+	lastLightClientBootstrapEpoch primitives.Epoch
+	attInclusions                 chan *db.BlockAttestationInclusions
+	attInclusionsWriterDone       chan struct{}
+	lightClientData               chan *lightClientData
 }
 
 // config options for the service.
 type config struct {
-	BeaconBlockBuf          int
-	ChainStartFetcher       execution.ChainStartFetcher
-	BeaconDB                db.HeadAccessDatabase
-	DepositCache            cache.DepositCache
-	PayloadIDCache          *cache.PayloadIDCache
-	TrackedValidatorsCache  *cache.TrackedValidatorsCache
-	AttPool                 attestations.Pool
-	ExitPool                voluntaryexits.PoolManager
-	SlashingPool            slashings.PoolManager
-	BLSToExecPool           blstoexec.PoolManager
-	P2p                     p2p.Broadcaster
-	MaxRoutines             int
-	StateNotifier           statefeed.Notifier
-	ForkChoiceStore         f.ForkChoicer
-	AttService              *attestations.Service
-	StateGen                *stategen.State
-	SlasherAttestationsFeed *event.Feed
-	WeakSubjectivityCheckpt *ethpb.Checkpoint
-	BlockFetcher            execution.POWBlockFetcher
-	FinalizedStateAtStartUp state.BeaconState
-	ExecutionEngineCaller   execution.EngineCaller
+	BeaconBlockBuf                       int
+	ChainStartFetcher                    execution.ChainStartFetcher
+	BeaconDB                             db.HeadAccessDatabase
+	DepositCache                         cache.DepositCache
+	PayloadIDCache                       *cache.PayloadIDCache
+	TrackedValidatorsCache               *cache.TrackedValidatorsCache
+	AttPool                              attestations.Pool
+	ExitPool                             voluntaryexits.PoolManager
+	SlashingPool                         slashings.PoolManager
+	BLSToExecPool                        blstoexec.PoolManager
+	P2p                                  p2p.Broadcaster
+	MaxRoutines                          int
+	StateNotifier                        statefeed.Notifier
+	ForkChoiceStore                      f.ForkChoicer
+	AttService                           *attestations.Service
+	StateGen                             *stategen.State
+	SlasherAttestationsFeed              *event.Feed
+	WeakSubjectivityCheckpt              *ethpb.Checkpoint
+	BlockFetcher                         execution.POWBlockFetcher
+	FinalizedStateAtStartUp              state.BeaconState
+	ExecutionEngineCaller                execution.EngineCaller
+	LightClientRetentionPeriods          uint64
+	AttestationInclusionsRetentionEpochs primitives.Epoch
 }
 
 var ErrMissingClockSetter = errors.New("blockchain Service initialized without a startup.ClockSetter")
@@ -173,6 +179,8 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		blobNotifiers:        bn,
 		cfg:                  &config{},
 		blockBeingSynced:     &currentlySyncingBlock{roots: make(map[[32]byte]struct{})},
+		attInclusions:        make(chan *db.BlockAttestationInclusions, attInclusionsQueueSize),
+		lightClientData:      make(chan *lightClientData, lightClientDataQueueSize),
 	}
 	for _, opt := range opts {
 		if err := opt(srv); err != nil {
@@ -204,11 +212,19 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	if features.Get().IndexAttestationInclusions {
+		s.attInclusionsWriterDone = make(chan struct{})
+		go s.runAttestationInclusionsWriter()
+	}
+	if features.Get().EnableLightClient {
+		go s.runLightClientPersistence()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	defer s.stopAttestationInclusionsWriter()
 
 	// lock before accessing s.head, s.head.state, s.head.state.FinalizedCheckpoint().Root
 	s.headLock.RLock()
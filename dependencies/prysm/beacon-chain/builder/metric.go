@@ -27,4 +27,40 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayGetHeaderLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "relay_get_header_latency_milliseconds",
+			Help:    "Captures RPC latency for get header of each relay in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay"},
+	)
+	relayGetHeaderCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_get_header_total",
+			Help: "Count of get header requests to each relay, by result: won, outbid, invalid or failed",
+		},
+		[]string{"relay", "result"},
+	)
+	relaySubmitBlindedBlockCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_submit_blinded_block_total",
+			Help: "Count of blinded blocks submitted to each relay, by result: success or failed",
+		},
+		[]string{"relay", "result"},
+	)
+	relayRegisterValidatorFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_register_validator_failures_total",
+			Help: "Count of failed validator registration requests to each relay",
+		},
+		[]string{"relay"},
+	)
+	relayUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "relay_up",
+			Help: "Whether the status endpoint of each relay responded successfully when last checked (1) or not (0)",
+		},
+		[]string{"relay"},
+	)
 )
@@ -1,6 +1,9 @@
 package builder
 
 import (
+	"reflect"
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
@@ -13,25 +16,38 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	opts := []Option{
+		WithRelayTimeout(c.Duration(flags.MevRelayTimeout.Name)),
+	}
+	endpoints := append([]string{c.String(flags.MevRelayEndpoint.Name)}, c.StringSlice(flags.MevRelayExtraEndpoint.Name)...)
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			continue
+		}
+		client, err := builder.NewClient(endpoint)
 		if err != nil {
 			return nil, err
 		}
-	}
-	opts := []Option{
-		WithBuilderClient(client),
+		opts = append(opts, WithBuilderClient(client))
 	}
 	return opts, nil
 }
 
-// WithBuilderClient sets the builder client for the beacon chain builder service.
+// WithBuilderClient adds a builder client to the relays of the beacon chain builder service.
+// It can be used several times to fan requests out to multiple relays.
 func WithBuilderClient(client builder.BuilderClient) Option {
 	return func(s *Service) error {
-		s.cfg.builderClient = client
+		if client != nil && !reflect.ValueOf(client).IsNil() {
+			s.cfg.builderClients = append(s.cfg.builderClients, client)
+		}
+		return nil
+	}
+}
+
+// WithRelayTimeout sets the time allowed to each relay to respond with a bid.
+func WithRelayTimeout(d time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.relayTimeout = d
 		return nil
 	}
 }
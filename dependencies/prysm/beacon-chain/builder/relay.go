@@ -0,0 +1,138 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+)
+
+// winningBidsRetention is the number of slots the relay of a winning bid is remembered for,
+// so that the blinded block built from the bid is submitted to that relay.
+const winningBidsRetention = primitives.Slot(32)
+
+var errInvalidBid = errors.New("invalid builder bid")
+
+// relay is one of the builder relays the service fans requests out to.
+type relay struct {
+	client builder.BuilderClient
+	// name identifies the relay in logs and metrics.
+	name string
+}
+
+func newRelays(clients []builder.BuilderClient) []*relay {
+	relays := make([]*relay, len(clients))
+	for i, c := range clients {
+		relays[i] = &relay{client: c, name: relayName(c.NodeURL(), i)}
+	}
+	return relays
+}
+
+// relayName returns the host of a relay endpoint, leaving out the relay public key
+// and path it may contain.
+func relayName(endpoint string, i int) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return fmt.Sprintf("relay-%d", i)
+	}
+	return u.Host
+}
+
+// relayBid is a bid received from a relay.
+type relayBid struct {
+	relay     *relay
+	bid       builder.SignedBid
+	value     *big.Int
+	blockHash [32]byte
+	slot      primitives.Slot
+}
+
+// getHeader requests a bid from the relay, and checks it builds on the given parent and is signed by its builder.
+func (r *relay) getHeader(ctx context.Context, timeout time.Duration, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (*relayBid, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	signed, err := r.client.GetHeader(ctx, slot, parentHash, pubKey)
+	relayGetHeaderLatency.WithLabelValues(r.name).Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+	if signed == nil || signed.IsNil() {
+		return nil, errors.Wrap(errInvalidBid, "nil bid")
+	}
+	bid, err := signed.Message()
+	if err != nil {
+		return nil, errors.Wrap(errInvalidBid, err.Error())
+	}
+	if bid == nil || bid.IsNil() {
+		return nil, errors.Wrap(errInvalidBid, "nil bid message")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(errInvalidBid, err.Error())
+	}
+	if !bytes.Equal(header.ParentHash(), parentHash[:]) {
+		return nil, errors.Wrapf(errInvalidBid, "incorrect parent hash %#x != %#x", header.ParentHash(), parentHash)
+	}
+	value := bytesutil.LittleEndianBytesToBigInt(bid.Value())
+	if value.Sign() <= 0 {
+		return nil, errors.Wrap(errInvalidBid, "zero bid amount")
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := signing.VerifySigningRoot(bid, bid.Pubkey(), signed.Signature(), d); err != nil {
+		return nil, errors.Wrap(errInvalidBid, err.Error())
+	}
+	return &relayBid{
+		relay:     r,
+		bid:       signed,
+		value:     value,
+		blockHash: bytesutil.ToBytes32(header.BlockHash()),
+		slot:      slot,
+	}, nil
+}
+
+// winningBids remembers which relay provided the winning bid of the recent slots, by block hash.
+type winningBids struct {
+	sync.Mutex
+	bids map[[32]byte]*relayBid
+}
+
+func (w *winningBids) add(b *relayBid) {
+	w.Lock()
+	defer w.Unlock()
+	if w.bids == nil {
+		w.bids = make(map[[32]byte]*relayBid)
+	}
+	for h, old := range w.bids {
+		if old.slot+winningBidsRetention < b.slot {
+			delete(w.bids, h)
+		}
+	}
+	w.bids[b.blockHash] = b
+}
+
+func (w *winningBids) relay(blockHash [32]byte) (*relay, bool) {
+	w.Lock()
+	defer w.Unlock()
+	b, ok := w.bids[blockHash]
+	if !ok {
+		return nil, false
+	}
+	return b.relay, true
+}
@@ -2,7 +2,8 @@ package builder
 
 import (
 	"context"
-	"reflect"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -34,15 +35,17 @@ type BlockBuilder interface {
 
 // config defines a config struct for dependencies into the service.
 type config struct {
-	builderClient builder.BuilderClient
-	beaconDB      db.HeadAccessDatabase
-	headFetcher   blockchain.HeadFetcher
+	builderClients []builder.BuilderClient
+	relayTimeout   time.Duration
+	beaconDB       db.HeadAccessDatabase
+	headFetcher    blockchain.HeadFetcher
 }
 
 // Service defines a service that provides a client for interacting with the beacon chain and MEV relay network.
 type Service struct {
 	cfg               *config
-	c                 builder.BuilderClient
+	relays            []*relay
+	winningBids       *winningBids
 	ctx               context.Context
 	cancel            context.CancelFunc
 	registrationCache *cache.RegistrationCache
@@ -52,23 +55,29 @@ type Service struct {
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Service{
-		ctx:    ctx,
-		cancel: cancel,
-		cfg:    &config{},
+		ctx:         ctx,
+		cancel:      cancel,
+		cfg:         &config{},
+		winningBids: &winningBids{},
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
 			return nil, err
 		}
 	}
-	if s.cfg.builderClient != nil && !reflect.ValueOf(s.cfg.builderClient).IsNil() {
-		s.c = s.cfg.builderClient
-
-		// Is the builder up?
-		if err := s.c.Status(ctx); err != nil {
-			log.WithError(err).Error("Failed to check builder status")
-		} else {
-			log.WithField("endpoint", s.c.NodeURL()).Info("Builder has been configured")
+	s.relays = newRelays(s.cfg.builderClients)
+	if len(s.relays) > 0 {
+		// Are the builders up?
+		var up int
+		for _, r := range s.relays {
+			if err := s.checkRelayStatus(ctx, r); err != nil {
+				log.WithError(err).WithField("relay", r.name).Error("Failed to check builder status")
+				continue
+			}
+			log.WithField("endpoint", r.client.NodeURL()).Info("Builder has been configured")
+			up++
+		}
+		if up > 0 {
 			log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
 				"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
 		}
@@ -87,7 +96,7 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-// SubmitBlindedBlock submits a blinded block to the builder relay network.
+// SubmitBlindedBlock submits a blinded block to the relay which provided the bid it was built from.
 func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.SubmitBlindedBlock")
 	defer span.End()
@@ -95,14 +104,47 @@ func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlyS
 	defer func() {
 		submitBlindedBlockLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return nil, nil, ErrNoBuilder
 	}
 
-	return s.c.SubmitBlindedBlock(ctx, b)
+	r, err := s.winningRelay(b)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	span.AddAttributes(trace.StringAttribute("relay", r.name))
+	payload, bundle, err := r.client.SubmitBlindedBlock(ctx, b)
+	if err != nil {
+		relaySubmitBlindedBlockCount.WithLabelValues(r.name, "failed").Inc()
+		tracing.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	relaySubmitBlindedBlockCount.WithLabelValues(r.name, "success").Inc()
+	return payload, bundle, nil
+}
+
+// winningRelay returns the relay whose bid the blinded block was built from.
+func (s *Service) winningRelay(b interfaces.ReadOnlySignedBeaconBlock) (*relay, error) {
+	if len(s.relays) == 1 {
+		return s.relays[0], nil
+	}
+	if b == nil || b.IsNil() {
+		return nil, errors.New("nil blinded block")
+	}
+	header, err := b.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution header")
+	}
+	r, ok := s.winningBids.relay(bytesutil.ToBytes32(header.BlockHash()))
+	if !ok {
+		return nil, fmt.Errorf("no relay bid found for block hash %#x", header.BlockHash())
+	}
+	return r, nil
 }
 
-// GetHeader retrieves the header for a given slot and parent hash from the builder relay network.
+// GetHeader requests a header for a given slot and parent hash from all the relays in parallel,
+// and returns the highest valid bid received within the relay timeout.
 func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.GetHeader")
 	defer span.End()
@@ -110,28 +152,77 @@ func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHas
 	defer func() {
 		getHeaderLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		tracing.AnnotateError(span, ErrNoBuilder)
 		return nil, ErrNoBuilder
 	}
 
-	h, err := s.c.GetHeader(ctx, slot, parentHash, pubKey)
-	tracing.AnnotateError(span, err)
-	return h, err
+	bids := make([]*relayBid, len(s.relays))
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			bids[i], errs[i] = r.getHeader(ctx, s.cfg.relayTimeout, slot, parentHash, pubKey)
+		}(i, r)
+	}
+	wg.Wait()
+
+	var best *relayBid
+	var lastErr error
+	for i, r := range s.relays {
+		if errs[i] != nil {
+			result := "failed"
+			if errors.Is(errs[i], errInvalidBid) {
+				result = "invalid"
+			}
+			relayGetHeaderCount.WithLabelValues(r.name, result).Inc()
+			log.WithError(errs[i]).WithField("relay", r.name).Warn("Could not get header from relay")
+			lastErr = errs[i]
+			continue
+		}
+		if best == nil || bids[i].value.Cmp(best.value) > 0 {
+			best = bids[i]
+		}
+	}
+	if best == nil {
+		err := errors.Wrapf(lastErr, "no valid bid received from %d relay(s)", len(s.relays))
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	for _, b := range bids {
+		if b == nil {
+			continue
+		}
+		result := "outbid"
+		if b == best {
+			result = "won"
+		}
+		relayGetHeaderCount.WithLabelValues(b.relay.name, result).Inc()
+	}
+	s.winningBids.add(best)
+	span.AddAttributes(trace.StringAttribute("relay", best.relay.name))
+	log.WithFields(log.Fields{
+		"relay": best.relay.name,
+		"value": best.value.String(),
+		"slot":  slot,
+	}).Debug("Selected highest relay bid")
+	return best.bid, nil
 }
 
 // Status retrieves the status of the builder relay network.
 func (s *Service) Status() error {
 	// Return early if builder isn't initialized in service.
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return nil
 	}
 
 	return nil
 }
 
-// RegisterValidator registers a validator with the builder relay network.
-// It also saves the registration object to the DB.
+// RegisterValidator registers a validator with all the relays of the builder relay network.
+// It also saves the registration object to the DB, unless no relay accepted the registrations.
 func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
 	ctx, span := trace.StartSpan(ctx, "builder.RegisterValidator")
 	defer span.End()
@@ -139,7 +230,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 	defer func() {
 		registerValidatorLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return ErrNoBuilder
 	}
 
@@ -164,7 +255,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		valid = append(valid, r)
 		indexToRegistration[nx] = r.Message
 	}
-	if err := s.c.RegisterValidator(ctx, valid); err != nil {
+	if err := s.registerValidator(ctx, valid); err != nil {
 		return errors.Wrap(err, "could not register validator(s)")
 	}
 
@@ -191,9 +282,46 @@ func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.V
 	}
 }
 
+// registerValidator sends the registrations to all the relays in parallel. It only fails if all the relays do.
+func (s *Service) registerValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			errs[i] = r.client.RegisterValidator(ctx, reg)
+		}(i, r)
+	}
+	wg.Wait()
+	var failed int
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		relayRegisterValidatorFailures.WithLabelValues(s.relays[i].name).Inc()
+		if failed == len(s.relays) {
+			return err
+		}
+		log.WithError(err).WithField("relay", s.relays[i].name).Warn("Could not register validators with relay")
+	}
+	return nil
+}
+
 // Configured returns true if the user has configured a builder client.
 func (s *Service) Configured() bool {
-	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
+	return len(s.relays) > 0
+}
+
+// checkRelayStatus checks whether the relay is up, updating its health metric.
+func (s *Service) checkRelayStatus(ctx context.Context, r *relay) error {
+	if err := r.client.Status(ctx); err != nil {
+		relayUp.WithLabelValues(r.name).Set(0)
+		return err
+	}
+	relayUp.WithLabelValues(r.name).Set(1)
+	return nil
 }
 
 func (s *Service) pollRelayerStatus(ctx context.Context) {
@@ -202,9 +330,9 @@ func (s *Service) pollRelayerStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if s.c != nil {
-				if err := s.c.Status(ctx); err != nil {
-					log.WithError(err).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+			for _, r := range s.relays {
+				if err := s.checkRelayStatus(ctx, r); err != nil {
+					log.WithError(err).WithField("relay", r.name).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
 				}
 			}
 		case <-ctx.Done():
@@ -5,13 +5,24 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	buildertesting "github.com/prysmaticlabs/prysm/v4/api/client/builder/testing"
 	blockchainTesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	dbtesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
 )
 
 func Test_NewServiceWithBuilder(t *testing.T) {
@@ -68,3 +79,136 @@ func Test_BuilderMethodsWithouClient(t *testing.T) {
 	err = s.RegisterValidator(context.Background(), nil)
 	assert.ErrorContains(t, ErrNoBuilder.Error(), err)
 }
+
+// testRelay is a builder client returning a fixed bid, after an optional delay.
+type testRelay struct {
+	url          string
+	bid          builder.SignedBid
+	err          error
+	delay        time.Duration
+	registered   int
+	submitted    int
+	registerErr  error
+	submitResult interfaces.ExecutionData
+}
+
+func (r *testRelay) NodeURL() string {
+	return r.url
+}
+
+func (r *testRelay) GetHeader(ctx context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	select {
+	case <-time.After(r.delay):
+		return r.bid, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *testRelay) RegisterValidator(_ context.Context, _ []*eth.SignedValidatorRegistrationV1) error {
+	r.registered++
+	return r.registerErr
+}
+
+func (r *testRelay) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	r.submitted++
+	return r.submitResult, nil, nil
+}
+
+func (r *testRelay) Status(_ context.Context) error {
+	return nil
+}
+
+// testBid returns a bid of the given value signed by a random builder key, for a payload building on parentHash.
+func testBid(t *testing.T, parentHash [32]byte, blockHash []byte, value uint64) *eth.SignedBuilderBidCapella {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	bid := &eth.BuilderBidCapella{
+		Header: &v1.ExecutionPayloadHeaderCapella{
+			ParentHash:       parentHash[:],
+			FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+			StateRoot:        make([]byte, fieldparams.RootLength),
+			ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+			LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+			PrevRandao:       make([]byte, fieldparams.RootLength),
+			BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+			BlockHash:        bytesutil.PadTo(blockHash, fieldparams.RootLength),
+			TransactionsRoot: make([]byte, fieldparams.RootLength),
+			WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+		},
+		Value:  bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(value), fieldparams.RootLength),
+		Pubkey: sk.PublicKey().Marshal(),
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	sr, err := signing.ComputeSigningRoot(bid, d)
+	require.NoError(t, err)
+	return &eth.SignedBuilderBidCapella{Message: bid, Signature: sk.Sign(sr[:]).Marshal()}
+}
+
+func wrapBid(t *testing.T, b *eth.SignedBuilderBidCapella) builder.SignedBid {
+	signed, err := builder.WrappedSignedBuilderBidCapella(b)
+	require.NoError(t, err)
+	return signed
+}
+
+func Test_GetHeader_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	forged := testBid(t, parentHash, []byte("forged"), 5)
+	forged.Message.Value = bytesutil.PadTo([]byte{6}, fieldparams.RootLength)
+
+	low := &testRelay{url: "http://low.relay", bid: wrapBid(t, testBid(t, parentHash, []byte("low"), 1))}
+	high := &testRelay{url: "http://0xab@high.relay:18550", bid: wrapBid(t, testBid(t, parentHash, []byte("high"), 3))}
+	invalid := &testRelay{url: "http://invalid.relay", bid: wrapBid(t, forged)}
+	wrongParent := &testRelay{url: "http://parent.relay", bid: wrapBid(t, testBid(t, [32]byte{'x'}, []byte("parent"), 4))}
+	slow := &testRelay{url: "http://slow.relay", bid: wrapBid(t, testBid(t, parentHash, []byte("slow"), 10)), delay: time.Second}
+	failed := &testRelay{url: "http://failed.relay", err: errors.New("unavailable")}
+	s, err := NewService(ctx, WithRelayTimeout(50*time.Millisecond),
+		WithBuilderClient(low), WithBuilderClient(high), WithBuilderClient(invalid),
+		WithBuilderClient(wrongParent), WithBuilderClient(slow), WithBuilderClient(failed))
+	require.NoError(t, err)
+	assert.Equal(t, "high.relay:18550", s.relays[1].name)
+
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{})
+	require.NoError(t, err)
+	assert.Equal(t, high.bid, bid)
+
+	// The blinded block built from the winning bid is only submitted to its relay.
+	blk := util.NewBlindedBeaconBlockCapella()
+	blk.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte("high"), fieldparams.RootLength)
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	_, _, err = s.SubmitBlindedBlock(ctx, wsb)
+	require.NoError(t, err)
+	assert.Equal(t, 1, high.submitted)
+	assert.Equal(t, 0, low.submitted+invalid.submitted+wrongParent.submitted+slow.submitted+failed.submitted)
+
+	blk.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte("unknown"), fieldparams.RootLength)
+	wsb, err = blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	_, _, err = s.SubmitBlindedBlock(ctx, wsb)
+	require.ErrorContains(t, "no relay bid found", err)
+
+	s, err = NewService(ctx, WithRelayTimeout(50*time.Millisecond), WithBuilderClient(invalid), WithBuilderClient(slow))
+	require.NoError(t, err)
+	_, err = s.GetHeader(ctx, 1, parentHash, [48]byte{})
+	require.ErrorContains(t, "no valid bid received from 2 relay(s)", err)
+}
+
+func Test_RegisterValidator_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	headFetcher := &blockchainTesting.ChainService{}
+	ok := &testRelay{}
+	failed := &testRelay{registerErr: errors.New("unavailable")}
+	s, err := NewService(ctx, WithRegistrationCache(), WithHeadFetcher(headFetcher), WithBuilderClient(ok), WithBuilderClient(failed))
+	require.NoError(t, err)
+	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
+	reg := &eth.ValidatorRegistrationV1{Pubkey: pubkey[:], FeeRecipient: make([]byte, fieldparams.FeeRecipientLength)}
+	require.NoError(t, s.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{{Message: reg}}))
+	assert.Equal(t, 1, ok.registered)
+	assert.Equal(t, 1, failed.registered)
+
+	ok.registerErr = errors.New("unavailable")
+	require.ErrorContains(t, "could not register validator(s)", s.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{{Message: reg}}))
+}
@@ -26,7 +26,7 @@ func TestInsertDeposit_LogsOnNilDepositInsertion(t *testing.T) {
 	dc, err := New()
 	require.NoError(t, err)
 
-	assert.ErrorContains(t, "nil deposit inserted into the cache", dc.InsertDeposit(context.Background(), nil, 1, 0, [32]byte{}))
+	assert.ErrorContains(t, "nil deposit inserted into the cache", dc.InsertDeposit(context.Background(), nil, 1, 0, 0, [32]byte{}))
 
 	require.Equal(t, 0, len(dc.deposits), "Number of deposits changed")
 	assert.Equal(t, nilDepositErr, hook.LastEntry().Message)
@@ -76,9 +76,9 @@ func TestInsertDeposit_MaintainsSortedOrderByIndex(t *testing.T) {
 
 	for _, ins := range insertions {
 		if ins.expectedErr != "" {
-			assert.ErrorContains(t, ins.expectedErr, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+			assert.ErrorContains(t, ins.expectedErr, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 		} else {
-			assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+			assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 		}
 	}
 
@@ -880,7 +880,7 @@ func TestPruneProofs_Ok(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 1))
@@ -925,7 +925,7 @@ func TestPruneProofs_SomeAlreadyPruned(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 2))
@@ -969,7 +969,7 @@ func TestPruneProofs_PruneAllWhenDepositIndexTooBig(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 99))
@@ -1016,7 +1016,7 @@ func TestPruneProofs_CorrectlyHandleLastIndex(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 4))
@@ -1037,14 +1037,14 @@ func TestDepositMap_WorksCorrectly(t *testing.T) {
 	assert.DeepEqual(t, nilDep, dep)
 
 	dep = &ethpb.Deposit{Proof: makeDepositProof(), Data: &ethpb.Deposit_Data{PublicKey: pk0, Amount: 1000}}
-	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 0, [32]byte{}))
+	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 0, 0, [32]byte{}))
 
 	dep, _ = dc.DepositByPubkey(context.Background(), pk0)
 	assert.NotEqual(t, nilDep, dep)
 	assert.Equal(t, uint64(1000), dep.Data.Amount)
 
 	dep = &ethpb.Deposit{Proof: makeDepositProof(), Data: &ethpb.Deposit_Data{PublicKey: pk0, Amount: 10000}}
-	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 1, [32]byte{}))
+	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 0, 1, [32]byte{}))
 
 	// Make sure we have the same deposit returned over here.
 	dep, _ = dc.DepositByPubkey(context.Background(), pk0)
@@ -939,7 +939,7 @@ func TestPruneProofs_Ok(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 1))
@@ -984,7 +984,7 @@ func TestPruneProofs_SomeAlreadyPruned(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 2))
@@ -1028,7 +1028,7 @@ func TestPruneProofs_PruneAllWhenDepositIndexTooBig(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 99))
@@ -1075,7 +1075,7 @@ func TestPruneProofs_CorrectlyHandleLastIndex(t *testing.T) {
 	}
 
 	for _, ins := range deposits {
-		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, ins.index, [32]byte{}))
+		assert.NoError(t, dc.InsertDeposit(context.Background(), ins.deposit, ins.blkNum, 0, ins.index, [32]byte{}))
 	}
 
 	require.NoError(t, dc.PruneProofs(context.Background(), 4))
@@ -1096,14 +1096,14 @@ func TestDepositMap_WorksCorrectly(t *testing.T) {
 	assert.DeepEqual(t, nilDep, dep)
 
 	dep = &ethpb.Deposit{Proof: makeDepositProof(), Data: &ethpb.Deposit_Data{PublicKey: pk0, Amount: 1000}}
-	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 0, [32]byte{}))
+	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 0, 0, [32]byte{}))
 
 	dep, _ = dc.DepositByPubkey(context.Background(), pk0)
 	assert.NotEqual(t, nilDep, dep)
 	assert.Equal(t, uint64(1000), dep.Data.Amount)
 
 	dep = &ethpb.Deposit{Proof: makeDepositProof(), Data: &ethpb.Deposit_Data{PublicKey: pk0, Amount: 10000}}
-	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 1, [32]byte{}))
+	assert.NoError(t, dc.InsertDeposit(context.Background(), dep, 1000, 0, 1, [32]byte{}))
 
 	// Make sure we have the same deposit returned over here.
 	dep, _ = dc.DepositByPubkey(context.Background(), pk0)
@@ -1197,3 +1197,71 @@ func emptyEth1data() *ethpb.Eth1Data {
 		BlockHash:    make([]byte, 32),
 	}
 }
+
+func TestInsertFinalizedDepositTree_InitializesFromSnapshot(t *testing.T) {
+	dc, err := New()
+	require.NoError(t, err)
+	depositData := func(i int) *ethpb.Deposit_Data {
+		return &ethpb.Deposit_Data{
+			PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			Signature:             make([]byte, 96),
+		}
+	}
+
+	var leaves [][]byte
+	snapshotTree := NewDepositTree()
+	for i := 0; i < 3; i++ {
+		root, err := depositData(i).HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, snapshotTree.Insert(root[:], i))
+		leaves = append(leaves, root[:])
+	}
+	require.NoError(t, snapshotTree.Finalize(2, [32]byte{'a'}, 10))
+	snapshotRoot, err := snapshotTree.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, dc.InsertFinalizedDepositTree(context.Background(), snapshotTree))
+
+	fd, err := dc.FinalizedDeposits(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), fd.MerkleTrieIndex())
+
+	// Deposits covered by the snapshot are not expected anymore.
+	pending := &ethpb.Deposit{Proof: makeDepositProof(), Data: depositData(2)}
+	require.ErrorContains(t, "wanted deposit with index 3 to be inserted but received 2",
+		dc.InsertDeposit(context.Background(), pending, 15, 0, 2, [32]byte{}))
+	for i := 3; i < 5; i++ {
+		d := &ethpb.Deposit{Proof: makeDepositProof(), Data: depositData(i)}
+		root, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		leaves = append(leaves, root[:])
+		require.NoError(t, dc.InsertDeposit(context.Background(), d, uint64(17+i), 0, int64(i), [32]byte{byte(i)}))
+	}
+
+	count, root := dc.DepositsNumberAndRootAtHeight(context.Background(), big.NewInt(15))
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, snapshotRoot, root)
+	count, root = dc.DepositsNumberAndRootAtHeight(context.Background(), big.NewInt(20))
+	assert.Equal(t, uint64(4), count)
+	assert.Equal(t, [32]byte{3}, root)
+
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 3, [32]byte{'b'}, 0))
+	fd, err = dc.FinalizedDeposits(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), fd.MerkleTrieIndex())
+	generatedTrie, err := trie.GenerateTrieFromItems(leaves[:4], params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	wantRoot, err := generatedTrie.HashTreeRoot()
+	require.NoError(t, err)
+	gotRoot, err := fd.Deposits().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+	// The height of the last finalized deposit is used when none is provided.
+	snapshot, err := dc.finalizedDeposits.depositTree.ToProto()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), snapshot.ExecutionDepth)
+
+	require.NoError(t, dc.PruneProofs(context.Background(), 3))
+	assert.DeepEqual(t, [][]byte(nil), dc.deposits[0].Deposit.Proof)
+	assert.NotNil(t, dc.deposits[1].Deposit.Proof)
+}
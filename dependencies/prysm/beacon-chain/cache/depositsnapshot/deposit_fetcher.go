@@ -134,9 +134,15 @@ func (c *Cache) DepositsNumberAndRootAtHeight(ctx context.Context, blockHeight *
 	// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
 	// deposit.
 	if heightIdx == 0 {
+		// If the cache was initialized from a deposit snapshot, the deposits preceding
+		// the earliest cached deposit are only known through the finalized trie.
+		tree := c.finalizedDeposits.depositTree
+		if tree != nil && tree.depositCount > 0 && (len(c.deposits) == 0 || uint64(c.deposits[0].Index) == tree.depositCount) {
+			return tree.depositCount, tree.getRoot()
+		}
 		return 0, [32]byte{}
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(c.deposits[heightIdx-1].DepositRoot)
+	return uint64(c.deposits[heightIdx-1].Index + 1), bytesutil.ToBytes32(c.deposits[heightIdx-1].DepositRoot)
 }
 
 // FinalizedDeposits returns the finalized deposits trie.
@@ -185,6 +191,12 @@ func (c *Cache) PruneProofs(ctx context.Context, untilDepositIndex int64) error
 	c.depositsLock.Lock()
 	defer c.depositsLock.Unlock()
 
+	if len(c.deposits) == 0 {
+		return nil
+	}
+	// Deposits in the cache don't necessarily start at index 0 if the cache
+	// was initialized from a deposit snapshot.
+	untilDepositIndex -= c.deposits[0].Index
 	if untilDepositIndex >= int64(len(c.deposits)) {
 		untilDepositIndex = int64(len(c.deposits) - 1)
 	}
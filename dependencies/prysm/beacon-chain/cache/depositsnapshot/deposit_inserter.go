@@ -25,7 +25,7 @@ var (
 
 // InsertDeposit into the database. If deposit or block number are nil
 // then this method does nothing.
-func (c *Cache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blockNum uint64, index int64, depositRoot [32]byte) error {
+func (c *Cache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blockNum, logIndex uint64, index int64, depositRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "Cache.InsertDeposit")
 	defer span.End()
 	if ctx.Err() != nil {
@@ -43,12 +43,12 @@ func (c *Cache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blockNum ui
 	c.depositsLock.Lock()
 	defer c.depositsLock.Unlock()
 
-	if int(index) != len(c.deposits) {
-		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", len(c.deposits), index)
+	if wantIndex := c.nextDepositIndex(); index != wantIndex {
+		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", wantIndex, index)
 	}
 	// Keep the slice sorted on insertion in order to avoid costly sorting on retrieval.
 	heightIdx := sort.Search(len(c.deposits), func(i int) bool { return c.deposits[i].Index >= index })
-	depCtr := &ethpb.DepositContainer{Deposit: d, Eth1BlockHeight: blockNum, DepositRoot: depositRoot[:], Index: index}
+	depCtr := &ethpb.DepositContainer{Deposit: d, Eth1BlockHeight: blockNum, LogIndex: logIndex, DepositRoot: depositRoot[:], Index: index}
 	newDeposits := append(
 		[]*ethpb.DepositContainer{depCtr},
 		c.deposits[heightIdx:]...)
@@ -106,8 +106,8 @@ func (c *Cache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex in
 	}
 	// In the event we have less deposits than we need to
 	// finalize we finalize till the index on which we do have it.
-	if len(c.deposits) <= int(eth1DepositIndex) {
-		eth1DepositIndex = int64(len(c.deposits)) - 1
+	if lastIndex := c.deposits[len(c.deposits)-1].Index; lastIndex < eth1DepositIndex {
+		eth1DepositIndex = lastIndex
 	}
 	// If we finalize to some lower deposit index, we
 	// ignore it.
@@ -127,6 +127,12 @@ func (c *Cache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex in
 				return err
 			}
 		}
+		// Callers without access to the execution block height fall back to the
+		// height of the last finalized deposit, so that the persisted snapshot
+		// still tells its consumers where to resume processing deposit logs.
+		if ctr.Index == eth1DepositIndex && executionNumber == 0 {
+			executionNumber = ctr.Eth1BlockHeight
+		}
 	}
 
 	if err := depositTrie.Finalize(eth1DepositIndex, executionHash, executionNumber); err != nil {
@@ -139,3 +145,33 @@ func (c *Cache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex in
 	}
 	return nil
 }
+
+// InsertFinalizedDepositTree seeds the finalized deposits cache with the provided deposit tree, which
+// is used when the node was initialized from a deposit snapshot rather than the full list of deposits.
+// The tree is ignored if the cache has already finalized the same or a larger number of deposits.
+func (c *Cache) InsertFinalizedDepositTree(ctx context.Context, tree *DepositTree) error {
+	_, span := trace.StartSpan(ctx, "Cache.InsertFinalizedDepositTree")
+	defer span.End()
+	c.depositsLock.Lock()
+	defer c.depositsLock.Unlock()
+
+	if tree == nil {
+		return errors.New("nil deposit tree inserted into the cache")
+	}
+	lastIndex := int64(tree.depositCount) - 1
+	if lastIndex <= c.finalizedDeposits.merkleTrieIndex {
+		return nil
+	}
+	c.finalizedDeposits = toFinalizedDepositsContainer(tree, lastIndex)
+	return nil
+}
+
+// nextDepositIndex returns the index of the next deposit expected by the cache. Deposits which were
+// only received as part of a finalized snapshot are not stored individually, so the count of the
+// finalized deposit tree is used when no deposits are present.
+func (c *Cache) nextDepositIndex() int64 {
+	if len(c.deposits) > 0 {
+		return c.deposits[len(c.deposits)-1].Index + 1
+	}
+	return int64(c.finalizedDeposits.depositTree.depositCount)
+}
@@ -30,7 +30,7 @@ type DepositFetcher interface {
 
 // DepositInserter defines a struct which can insert deposit information from a store.
 type DepositInserter interface {
-	InsertDeposit(ctx context.Context, d *ethpb.Deposit, blockNum uint64, index int64, depositRoot [32]byte) error
+	InsertDeposit(ctx context.Context, d *ethpb.Deposit, blockNum, logIndex uint64, index int64, depositRoot [32]byte) error
 	InsertDepositContainers(ctx context.Context, ctrs []*ethpb.DepositContainer)
 	InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex int64, executionHash common.Hash, executionNumber uint64) error
 }
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// payloadDecisionsSize is the number of the most recent payload decisions kept in the cache.
+const payloadDecisionsSize = 64
+
+// PayloadDecision records whether a proposed block used the local execution payload or the builder bid, and why.
+type PayloadDecision struct {
+	Slot               primitives.Slot
+	ProposerIndex      primitives.ValidatorIndex
+	Builder            bool
+	Reason             string
+	LocalValueGwei     uint64
+	BuilderValueGwei   uint64
+	BuilderBoostFactor uint64
+	MinBidGwei         uint64
+}
+
+// PayloadDecisionCache keeps the most recent payload decisions, to be inspected through the debug endpoints.
+type PayloadDecisionCache struct {
+	sync.RWMutex
+	decisions []PayloadDecision
+}
+
+// NewPayloadDecisionCache creates an empty payload decision cache.
+func NewPayloadDecisionCache() *PayloadDecisionCache {
+	return &PayloadDecisionCache{
+		decisions: make([]PayloadDecision, 0, payloadDecisionsSize),
+	}
+}
+
+// Add records a payload decision, evicting the oldest one when the cache is full.
+func (c *PayloadDecisionCache) Add(d PayloadDecision) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if len(c.decisions) == payloadDecisionsSize {
+		copy(c.decisions, c.decisions[1:])
+		c.decisions = c.decisions[:payloadDecisionsSize-1]
+	}
+	c.decisions = append(c.decisions, d)
+}
+
+// Decisions returns the payload decisions in the cache, the oldest one first.
+func (c *PayloadDecisionCache) Decisions() []PayloadDecision {
+	if c == nil {
+		return nil
+	}
+	c.RLock()
+	defer c.RUnlock()
+	decisions := make([]PayloadDecision, len(c.decisions))
+	copy(decisions, c.decisions)
+	return decisions
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestPayloadDecisionCache_Add(t *testing.T) {
+	c := NewPayloadDecisionCache()
+	require.Equal(t, 0, len(c.Decisions()))
+
+	for i := 0; i < payloadDecisionsSize+2; i++ {
+		c.Add(PayloadDecision{Slot: primitives.Slot(i), Reason: "local_higher_value"})
+	}
+	decisions := c.Decisions()
+	require.Equal(t, payloadDecisionsSize, len(decisions))
+	require.Equal(t, primitives.Slot(2), decisions[0].Slot)
+	require.Equal(t, primitives.Slot(payloadDecisionsSize+1), decisions[payloadDecisionsSize-1].Slot)
+
+	// Decisions are not recorded without a cache.
+	var nilCache *PayloadDecisionCache
+	nilCache.Add(PayloadDecision{})
+	require.Equal(t, 0, len(nilCache.Decisions()))
+}
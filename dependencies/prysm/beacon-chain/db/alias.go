@@ -26,6 +26,9 @@ type Database = iface.Database
 // SlasherDatabase defines necessary methods for Prysm's slasher implementation.
 type SlasherDatabase = iface.SlasherDatabase
 
+// BlockAttestationInclusions are the validators which took part in the aggregated attestations included in a block.
+type BlockAttestationInclusions = iface.BlockAttestationInclusions
+
 // ErrExistingGenesisState is an error when the user attempts to save a different genesis state
 // when one already exists in a database.
 var ErrExistingGenesisState = iface.ErrExistingGenesisState
@@ -0,0 +1,268 @@
+// Package archive defines a portable, versioned format to move finalized history between beacon databases.
+//
+// An archive is a tar stream of SSZ encoded blocks, blob sidecars and states, in ascending slot order.
+// The first entry of the stream is a header describing the exported range, and the last one a manifest
+// listing every entry with its SHA-256 checksum. Each entry also carries its own metadata and checksum
+// in a PAX record, so that an archive can be verified and imported while it is being read.
+package archive
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// Version of the archive format written by this package.
+const Version = 1
+
+const (
+	headerName   = "header.json"
+	manifestName = "manifest.json"
+	// entryRecord is the PAX record holding the JSON encoded Entry of a tar entry.
+	entryRecord = "PRYSM.entry"
+)
+
+var (
+	// ErrUnsupportedVersion is returned when reading an archive written in an unknown format version.
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	// ErrChecksumMismatch is returned when the content of an entry does not match its checksum.
+	ErrChecksumMismatch = errors.New("archive entry checksum mismatch")
+	// ErrInvalidManifest is returned when the manifest of an archive is missing or does not list the entries read.
+	ErrInvalidManifest = errors.New("invalid archive manifest")
+)
+
+// Kind of the object stored in an archive entry.
+type Kind string
+
+const (
+	KindBlock       Kind = "block"
+	KindBlobSidecar Kind = "blob_sidecar"
+	KindState       Kind = "state"
+)
+
+// Header is the first entry of an archive.
+type Header struct {
+	Version               int             `json:"version"`
+	Created               time.Time       `json:"created"`
+	GenesisValidatorsRoot hexutil.Bytes   `json:"genesis_validators_root,omitempty"`
+	StartSlot             primitives.Slot `json:"start_slot"`
+	EndSlot               primitives.Slot `json:"end_slot"`
+	// Anchor is the root of the highest block of the archive whose post state is also archived.
+	// An empty database can be initialized from it, the rest of the archive becoming its history.
+	AnchorRoot hexutil.Bytes   `json:"anchor_root,omitempty"`
+	AnchorSlot primitives.Slot `json:"anchor_slot"`
+}
+
+// Entry describes an object stored in an archive.
+type Entry struct {
+	Name string          `json:"name"`
+	Kind Kind            `json:"kind"`
+	Slot primitives.Slot `json:"slot"`
+	// Root is the block root of blocks and blob sidecars, and the root of the block states are the post state of.
+	Root hexutil.Bytes `json:"root"`
+	// Index is the index of blob sidecars.
+	Index   uint64 `json:"index,omitempty"`
+	Fork    string `json:"fork,omitempty"`
+	Blinded bool   `json:"blinded,omitempty"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifest is the last entry of an archive.
+type Manifest struct {
+	Blocks       uint64   `json:"blocks"`
+	BlobSidecars uint64   `json:"blob_sidecars"`
+	States       uint64   `json:"states"`
+	Entries      []*Entry `json:"entries"`
+}
+
+func (m *Manifest) add(e *Entry) {
+	switch e.Kind {
+	case KindBlock:
+		m.Blocks++
+	case KindBlobSidecar:
+		m.BlobSidecars++
+	case KindState:
+		m.States++
+	}
+	m.Entries = append(m.Entries, e)
+}
+
+func checksum(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func entryName(e *Entry) string {
+	switch e.Kind {
+	case KindBlock:
+		return fmt.Sprintf("blocks/%d_%#x.ssz", e.Slot, []byte(e.Root))
+	case KindBlobSidecar:
+		return fmt.Sprintf("blobs/%d_%#x_%d.ssz", e.Slot, []byte(e.Root), e.Index)
+	default:
+		return fmt.Sprintf("states/%d_%#x.ssz", e.Slot, []byte(e.Root))
+	}
+}
+
+// Writer writes the entries of an archive to an underlying stream.
+type Writer struct {
+	tw       *tar.Writer
+	manifest *Manifest
+}
+
+// NewWriter starts an archive with the given header. Its version is set to the current format version.
+func NewWriter(w io.Writer, h *Header) (*Writer, error) {
+	h.Version = Version
+	enc, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	aw := &Writer{tw: tar.NewWriter(w), manifest: &Manifest{}}
+	if err := aw.writeFile(headerName, enc, nil); err != nil {
+		return nil, errors.Wrap(err, "could not write archive header")
+	}
+	return aw, nil
+}
+
+// Write adds an entry with the given content to the archive. The name, size and checksum of the entry are set from it.
+func (w *Writer) Write(e *Entry, b []byte) error {
+	e.Name = entryName(e)
+	e.Size = int64(len(b))
+	e.SHA256 = checksum(b)
+	enc, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := w.writeFile(e.Name, b, map[string]string{entryRecord: string(enc)}); err != nil {
+		return errors.Wrapf(err, "could not write archive entry %s", e.Name)
+	}
+	w.manifest.add(e)
+	return nil
+}
+
+// Close writes the manifest of the entries written so far, and terminates the archive.
+// It does not close the underlying stream.
+func (w *Writer) Close() (*Manifest, error) {
+	enc, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := w.writeFile(manifestName, enc, nil); err != nil {
+		return nil, errors.Wrap(err, "could not write archive manifest")
+	}
+	return w.manifest, w.tw.Close()
+}
+
+func (w *Writer) writeFile(name string, b []byte, records map[string]string) error {
+	hdr := &tar.Header{
+		Typeflag:   tar.TypeReg,
+		Name:       name,
+		Size:       int64(len(b)),
+		Mode:       0600,
+		PAXRecords: records,
+		Format:     tar.FormatPAX,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := w.tw.Write(b)
+	return err
+}
+
+// Reader reads and verifies the entries of an archive from an underlying stream.
+type Reader struct {
+	tr       *tar.Reader
+	header   *Header
+	manifest *Manifest
+	read     []*Entry
+}
+
+// NewReader reads the header of an archive, checking its format version.
+func NewReader(r io.Reader) (*Reader, error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read archive header")
+	}
+	if hdr.Name != headerName {
+		return nil, fmt.Errorf("expected %s as the first archive entry, found %s", headerName, hdr.Name)
+	}
+	h := &Header{}
+	if err := json.NewDecoder(tr).Decode(h); err != nil {
+		return nil, errors.Wrap(err, "could not decode archive header")
+	}
+	if h.Version != Version {
+		return nil, errors.Wrapf(ErrUnsupportedVersion, "archive version %d, supported version %d", h.Version, Version)
+	}
+	return &Reader{tr: tr, header: h}, nil
+}
+
+// Header of the archive.
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// Manifest of the archive, once all its entries have been read.
+func (r *Reader) Manifest() *Manifest {
+	return r.manifest
+}
+
+// Next returns the next entry of the archive with its content, after checking it matches its checksum.
+// It returns io.EOF once the manifest has been read and checked against the entries of the archive.
+func (r *Reader) Next() (*Entry, []byte, error) {
+	if r.manifest != nil {
+		return nil, nil, io.EOF
+	}
+	hdr, err := r.tr.Next()
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, nil, errors.Wrap(ErrInvalidManifest, "archive ends without a manifest")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := io.ReadAll(r.tr)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read archive entry %s", hdr.Name)
+	}
+	if hdr.Name == manifestName {
+		return nil, nil, r.checkManifest(b)
+	}
+	rec, ok := hdr.PAXRecords[entryRecord]
+	if !ok {
+		return nil, nil, fmt.Errorf("archive entry %s has no %s record", hdr.Name, entryRecord)
+	}
+	e := &Entry{}
+	if err := json.Unmarshal([]byte(rec), e); err != nil {
+		return nil, nil, errors.Wrapf(err, "could not decode archive entry %s", hdr.Name)
+	}
+	if e.Name != hdr.Name || e.Size != int64(len(b)) || e.SHA256 != checksum(b) {
+		return nil, nil, errors.Wrapf(ErrChecksumMismatch, "entry %s", hdr.Name)
+	}
+	r.read = append(r.read, e)
+	return e, b, nil
+}
+
+func (r *Reader) checkManifest(b []byte) error {
+	m := &Manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return errors.Wrap(err, "could not decode archive manifest")
+	}
+	if len(m.Entries) != len(r.read) {
+		return errors.Wrapf(ErrInvalidManifest, "manifest lists %d entries, archive has %d", len(m.Entries), len(r.read))
+	}
+	for i, e := range m.Entries {
+		if e.Name != r.read[i].Name || e.SHA256 != r.read[i].SHA256 {
+			return errors.Wrapf(ErrInvalidManifest, "manifest entry %d is %s, archive entry is %s", i, e.Name, r.read[i].Name)
+		}
+	}
+	r.manifest = m
+	return io.EOF
+}
@@ -0,0 +1,228 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func setupDB(t *testing.T) *kv.Store {
+	s, err := kv.NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Fatalf("failed to close database: %v", err)
+		}
+	})
+	return s
+}
+
+type testChain struct {
+	db    *kv.Store
+	blobs *filesystem.BlobStorage
+	// roots of the canonical blocks, indexed by slot.
+	roots  [][32]byte
+	forked [32]byte
+}
+
+// exportTestChain saves a finalized chain of phase0 blocks at slots 0 to 3, with states at slots 0 and 2, followed by
+// a Deneb block at slot 4 with 2 blob sidecars, and a block at slot 3 forking off the chain. It then exports the given
+// slot range from the database reopened for reading only, and closes it, as only one database can be open at a time.
+func exportTestChain(t *testing.T, start, end primitives.Slot) (*testChain, *Manifest, *bytes.Buffer, error) {
+	// The genesis state of the mainnet config is embedded, and would take precedence over the saved one.
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.InteropConfig())
+	ctx := context.Background()
+	db, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	c := &testChain{db: db, blobs: filesystem.NewEphemeralBlobStorage(t)}
+	st, _ := util.DeterministicGenesisState(t, 16)
+	require.NoError(t, c.db.SaveGenesisData(ctx, st))
+	genesisRoot, err := c.db.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	c.roots = append(c.roots, genesisRoot)
+
+	for slot := primitives.Slot(1); slot <= 3; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = c.roots[slot-1][:]
+		c.roots = append(c.roots, saveBlock(t, c.db, b))
+	}
+	forked := util.NewBeaconBlock()
+	forked.Block.Slot = 3
+	forked.Block.ParentRoot = c.roots[2][:]
+	forked.Block.Body.Graffiti = bytesutil.PadTo([]byte("forked"), 32)
+	c.forked = saveBlock(t, c.db, forked)
+
+	st2 := st.Copy()
+	require.NoError(t, st2.SetSlot(2))
+	require.NoError(t, c.db.SaveState(ctx, st2, c.roots[2]))
+
+	blk, scs := util.GenerateTestDenebBlockWithSidecar(t, c.roots[3], 4, 2)
+	require.NoError(t, c.db.SaveBlock(ctx, blk))
+	for _, sc := range scs {
+		v, err := verification.BlobSidecarNoop(sc)
+		require.NoError(t, err)
+		require.NoError(t, c.blobs.Save(v))
+	}
+	c.roots = append(c.roots, blk.Root())
+	require.NoError(t, c.db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 4, Root: c.roots[4][:]}))
+	require.NoError(t, c.db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: c.roots[4][:]}))
+
+	// The database is exported for reading only, as once its beacon node stopped.
+	require.NoError(t, c.db.Close())
+	c.db, err = kv.NewReadOnlyKVStore(ctx, c.db.DatabasePath(), time.Second)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	m, err := Export(ctx, c.db, c.blobs, buf, start, end)
+	require.NoError(t, c.db.Close())
+	return c, m, buf, err
+}
+
+func saveBlock(t *testing.T, db *kv.Store, b *ethpb.SignedBeaconBlock) [32]byte {
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(context.Background(), wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	return root
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	c, m, buf, err := exportTestChain(t, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), m.Blocks)
+	assert.Equal(t, uint64(2), m.BlobSidecars)
+	assert.Equal(t, uint64(2), m.States)
+
+	db, blobs := setupDB(t), filesystem.NewEphemeralBlobStorage(t)
+	imported, err := Import(ctx, db, blobs, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.DeepEqual(t, m, imported)
+
+	for _, root := range c.roots {
+		assert.Equal(t, true, db.HasBlock(ctx, root))
+	}
+	assert.Equal(t, false, db.HasBlock(ctx, c.forked))
+	indices, err := blobs.Indices(c.roots[4])
+	require.NoError(t, err)
+	assert.Equal(t, true, indices[0] && indices[1])
+
+	// The empty database is initialized from the state at slot 2, with the blocks below it as history.
+	origin, err := db.OriginCheckpointBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, c.roots[2], origin)
+	st, err := db.State(ctx, origin)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(2), st.Slot())
+	genesisRoot, err := db.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, c.roots[0], genesisRoot)
+	bf, err := db.BackfillStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), bf.LowSlot)
+	assert.Equal(t, uint64(2), bf.OriginSlot)
+}
+
+func TestExport_SlotRange(t *testing.T) {
+	c, m, buf, err := exportTestChain(t, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), m.Blocks)
+	assert.Equal(t, uint64(0), m.BlobSidecars)
+	assert.Equal(t, uint64(1), m.States)
+
+	r, err := NewReader(buf)
+	require.NoError(t, err)
+	h := r.Header()
+	assert.Equal(t, primitives.Slot(1), h.StartSlot)
+	assert.Equal(t, primitives.Slot(3), h.EndSlot)
+	assert.Equal(t, primitives.Slot(2), h.AnchorSlot)
+	assert.DeepEqual(t, c.roots[2][:], []byte(h.AnchorRoot))
+
+	_, _, _, err = exportTestChain(t, 5, 10)
+	require.ErrorContains(t, "no finalized block found", err)
+}
+
+func TestImport_GenesisMismatch(t *testing.T) {
+	ctx := context.Background()
+	_, _, buf, err := exportTestChain(t, 0, 100)
+	require.NoError(t, err)
+
+	db := setupDB(t)
+	st, _ := util.DeterministicGenesisState(t, 16)
+	require.NoError(t, st.SetGenesisValidatorsRoot(bytesutil.PadTo([]byte("other"), 32)))
+	require.NoError(t, db.SaveGenesisData(ctx, st))
+	_, err = Import(ctx, db, nil, bytes.NewReader(buf.Bytes()))
+	require.ErrorIs(t, err, ErrGenesisMismatch)
+}
+
+func TestImport_Truncated(t *testing.T) {
+	ctx := context.Background()
+	c, _, buf, err := exportTestChain(t, 0, 100)
+	require.NoError(t, err)
+
+	// Nothing is written from an archive missing its manifest.
+	db := setupDB(t)
+	truncated := buf.Bytes()[:buf.Len()/2]
+	_, err = Import(ctx, db, nil, bytes.NewReader(truncated))
+	require.ErrorContains(t, "could not verify archive", err)
+	for _, root := range c.roots {
+		assert.Equal(t, false, db.HasBlock(ctx, root))
+	}
+	empty, err := isEmpty(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, true, empty)
+}
+
+func TestReader_Verification(t *testing.T) {
+	content := []byte("archived block content")
+	write := func(closed bool) []byte {
+		buf := &bytes.Buffer{}
+		w, err := NewWriter(buf, &Header{})
+		require.NoError(t, err)
+		require.NoError(t, w.Write(&Entry{Kind: KindBlock, Slot: 1, Root: make([]byte, 32)}, content))
+		if closed {
+			_, err = w.Close()
+			require.NoError(t, err)
+		}
+		return buf.Bytes()
+	}
+
+	r, err := NewReader(bytes.NewReader(write(true)))
+	require.NoError(t, err)
+	e, b, err := r.Next()
+	require.NoError(t, err)
+	assert.DeepEqual(t, content, b)
+	assert.Equal(t, "blocks/1_0x0000000000000000000000000000000000000000000000000000000000000000.ssz", e.Name)
+	_, _, err = r.Next()
+	require.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, uint64(1), r.Manifest().Blocks)
+
+	tampered := bytes.Replace(write(true), content, []byte("archived block c0ntent"), 1)
+	r, err = NewReader(bytes.NewReader(tampered))
+	require.NoError(t, err)
+	_, _, err = r.Next()
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	r, err = NewReader(bytes.NewReader(write(false)))
+	require.NoError(t, err)
+	_, _, err = r.Next()
+	require.NoError(t, err)
+	_, _, err = r.Next()
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
@@ -0,0 +1,216 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// Export writes the finalized blocks of the given slot range to an archive, along with their blob sidecars
+// and the states saved for them. The canonical chain is walked back from the finalized checkpoint, so end is
+// capped to the finalized slot, and start to the lowest block of the database for backfilled ones.
+// Blob sidecars are skipped if blobs is nil.
+func Export(ctx context.Context, db iface.ReadOnlyDatabase, blobs *filesystem.BlobStorage, w io.Writer, start, end primitives.Slot) (*Manifest, error) {
+	if start > end {
+		return nil, errors.Errorf("start slot %d is greater than end slot %d", start, end)
+	}
+	roots, err := canonicalRoots(ctx, db, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) == 0 {
+		return nil, errors.Errorf("no finalized block found in slots [%d, %d]", start, end)
+	}
+	h := &Header{Created: time.Now().UTC()}
+	h.GenesisValidatorsRoot, err = genesisValidatorsRoot(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if h.StartSlot, err = blockSlot(ctx, db, roots[0]); err != nil {
+		return nil, err
+	}
+	if h.EndSlot, err = blockSlot(ctx, db, roots[len(roots)-1]); err != nil {
+		return nil, err
+	}
+	for i := len(roots) - 1; i >= 0; i-- {
+		if db.HasState(ctx, roots[i]) {
+			h.AnchorRoot = bytesutil.SafeCopyBytes(roots[i][:])
+			if h.AnchorSlot, err = blockSlot(ctx, db, roots[i]); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	aw, err := NewWriter(w, h)
+	if err != nil {
+		return nil, err
+	}
+	for _, root := range roots {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		blk, err := db.Block(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		slot := blk.Block().Slot()
+		b, err := blk.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal block %#x", root)
+		}
+		e := &Entry{Kind: KindBlock, Slot: slot, Root: root[:], Fork: version.String(blk.Version()), Blinded: blk.IsBlinded()}
+		if err := aw.Write(e, b); err != nil {
+			return nil, err
+		}
+		if err := exportBlobSidecars(aw, blobs, root, slot); err != nil {
+			return nil, err
+		}
+		if !db.HasState(ctx, root) {
+			continue
+		}
+		st, err := db.State(ctx, root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read state of block %#x", root)
+		}
+		b, err = st.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal state of block %#x", root)
+		}
+		e = &Entry{Kind: KindState, Slot: st.Slot(), Root: root[:], Fork: version.String(st.Version())}
+		if err := aw.Write(e, b); err != nil {
+			return nil, err
+		}
+		log.WithField("slot", slot).Debug("Exported state")
+	}
+	m, err := aw.Close()
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"blocks":       m.Blocks,
+		"blobSidecars": m.BlobSidecars,
+		"states":       m.States,
+	}).Info("Exported beacon database archive")
+	return m, nil
+}
+
+func exportBlobSidecars(aw *Writer, blobs *filesystem.BlobStorage, root [32]byte, slot primitives.Slot) error {
+	if blobs == nil {
+		return nil
+	}
+	indices, err := blobs.Indices(root)
+	if err != nil {
+		return errors.Wrapf(err, "could not list blob sidecars of block %#x", root)
+	}
+	for i, ok := range indices {
+		if !ok {
+			continue
+		}
+		sc, err := blobs.Get(root, uint64(i))
+		if err != nil {
+			return errors.Wrapf(err, "could not read blob sidecar %d of block %#x", i, root)
+		}
+		b, err := sc.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		e := &Entry{Kind: KindBlobSidecar, Slot: slot, Root: root[:], Index: uint64(i), Fork: version.String(version.Deneb)}
+		if err := aw.Write(e, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blockSlot(ctx context.Context, db iface.ReadOnlyDatabase, root [32]byte) (primitives.Slot, error) {
+	blk, err := db.Block(ctx, root)
+	if err != nil {
+		return 0, err
+	}
+	if blk == nil || blk.IsNil() {
+		return 0, errors.Errorf("block %#x not found", root)
+	}
+	return blk.Block().Slot(), nil
+}
+
+// canonicalRoots returns the roots of the finalized blocks in [start, end], in ascending slot order.
+func canonicalRoots(ctx context.Context, db iface.ReadOnlyDatabase, start, end primitives.Slot) ([][32]byte, error) {
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	if root == params.BeaconConfig().ZeroHash {
+		root, err = db.GenesisBlockRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read genesis block root")
+		}
+	}
+	var roots [][32]byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		blk, err := db.Block(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		// Blocks below the origin of a checkpoint synced database may not have been backfilled.
+		if blk == nil {
+			break
+		}
+		slot := blk.Block().Slot()
+		if slot < start {
+			break
+		}
+		if slot <= end {
+			roots = append(roots, root)
+		}
+		if slot == 0 {
+			break
+		}
+		root = blk.Block().ParentRoot()
+	}
+	for i, j := 0, len(roots)-1; i < j; i, j = i+1, j-1 {
+		roots[i], roots[j] = roots[j], roots[i]
+	}
+	return roots, nil
+}
+
+// genesisValidatorsRoot returns the genesis validators root of the chain of the database,
+// read from its genesis state or from the state it was checkpoint synced from.
+// It returns nil for a database without any of them.
+func genesisValidatorsRoot(ctx context.Context, db iface.ReadOnlyDatabase) ([]byte, error) {
+	st, err := db.GenesisState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read genesis state")
+	}
+	if st == nil || st.IsNil() {
+		origin, err := db.OriginCheckpointBlockRoot(ctx)
+		if errors.Is(err, kv.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		st, err = db.State(ctx, origin)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read origin state")
+		}
+	}
+	if st == nil || st.IsNil() {
+		return nil, nil
+	}
+	return st.GenesisValidatorsRoot(), nil
+}
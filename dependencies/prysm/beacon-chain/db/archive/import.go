@@ -0,0 +1,292 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrGenesisMismatch is returned when importing an archive of another chain than the one of the database.
+var ErrGenesisMismatch = errors.New("archive and database genesis validators roots differ")
+
+// Database is the beacon database an archive is imported into.
+type Database interface {
+	iface.HeadAccessDatabase
+	SaveOriginCheckpointBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveArchiveImportInProgress(ctx context.Context, inProgress bool) error
+}
+
+// Import saves the blocks, blob sidecars and states of an archive to the database. The whole archive is first read to
+// verify the checksums of its entries against its manifest, so that nothing is written from a truncated or corrupted
+// archive. The database is then marked as being imported into until the import completes, so that a database left half
+// imported, e.g. by a crash, cannot be opened.
+//
+// An empty database is initialized from the anchor of the archive, as if checkpoint synced from it, the blocks before
+// it becoming its backfilled history. In a checkpoint synced database, the backfilled range is extended down to the
+// lowest block now linked to the origin. Blob sidecars are skipped if blobs is nil.
+func Import(ctx context.Context, db Database, blobs *filesystem.BlobStorage, r io.ReadSeeker) (*Manifest, error) {
+	if err := verify(r); err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "could not rewind archive")
+	}
+	ar, err := NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	h := ar.Header()
+	gvr, err := genesisValidatorsRoot(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if len(gvr) > 0 && len(h.GenesisValidatorsRoot) > 0 && !bytes.Equal(gvr, h.GenesisValidatorsRoot) {
+		return nil, errors.Wrapf(ErrGenesisMismatch, "database %#x, archive %#x", gvr, []byte(h.GenesisValidatorsRoot))
+	}
+	empty, err := isEmpty(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if empty && len(h.AnchorRoot) == 0 {
+		return nil, errors.New("an empty database can only be initialized from an archive with a state")
+	}
+	anchorRoot := bytesutil.ToBytes32(h.AnchorRoot)
+
+	if err := db.SaveArchiveImportInProgress(ctx, true); err != nil {
+		return nil, errors.Wrap(err, "could not mark database as being imported into")
+	}
+	var anchor interfaces.ReadOnlySignedBeaconBlock
+	for {
+		e, b, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		root := bytesutil.ToBytes32(e.Root)
+		switch e.Kind {
+		case KindBlock:
+			blk, err := importBlock(ctx, db, e, b)
+			if err != nil {
+				return nil, err
+			}
+			if root == anchorRoot {
+				anchor = blk
+			}
+		case KindBlobSidecar:
+			if blobs == nil {
+				continue
+			}
+			if err := importBlobSidecar(blobs, e, b); err != nil {
+				return nil, err
+			}
+		case KindState:
+			st, err := unmarshalState(e, b)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not unmarshal state %s", e.Name)
+			}
+			if empty && root == anchorRoot {
+				if anchor == nil {
+					return nil, errors.Errorf("anchor state %s precedes its block", e.Name)
+				}
+				if err := saveOrigin(ctx, db, anchor, st); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := saveState(ctx, db, st, root); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("archive entry %s has unknown kind %s", e.Name, e.Kind)
+		}
+	}
+	if err := extendBackfill(ctx, db); err != nil {
+		return nil, err
+	}
+	if err := db.SaveArchiveImportInProgress(ctx, false); err != nil {
+		return nil, errors.Wrap(err, "could not mark database import as complete")
+	}
+	m := ar.Manifest()
+	log.WithFields(logrus.Fields{
+		"blocks":       m.Blocks,
+		"blobSidecars": m.BlobSidecars,
+		"states":       m.States,
+	}).Info("Imported beacon database archive")
+	return m, nil
+}
+
+// verify reads a whole archive, checking its entries against their checksums and its manifest.
+func verify(r io.Reader) error {
+	ar, err := NewReader(bufio.NewReader(r))
+	if err != nil {
+		return err
+	}
+	for {
+		_, _, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not verify archive")
+		}
+	}
+}
+
+func importBlock(ctx context.Context, db Database, e *Entry, b []byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	blk, err := unmarshalBlock(e, b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal block %s", e.Name)
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(root[:], e.Root) {
+		return nil, errors.Errorf("block %s has root %#x", e.Name, root)
+	}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		return nil, errors.Wrapf(err, "could not save block %s", e.Name)
+	}
+	if blk.Block().Slot() == 0 {
+		if _, err := db.GenesisBlockRoot(ctx); errors.Is(err, kv.ErrNotFound) {
+			return blk, db.SaveGenesisBlockRoot(ctx, root)
+		}
+	}
+	return blk, nil
+}
+
+func importBlobSidecar(blobs *filesystem.BlobStorage, e *Entry, b []byte) error {
+	sc := &ethpb.BlobSidecar{}
+	if err := sc.UnmarshalSSZ(b); err != nil {
+		return errors.Wrapf(err, "could not unmarshal blob sidecar %s", e.Name)
+	}
+	ro, err := blocks.NewROBlob(sc)
+	if err != nil {
+		return err
+	}
+	root := ro.BlockRoot()
+	if !bytes.Equal(root[:], e.Root) || ro.Index != e.Index {
+		return errors.Errorf("blob sidecar %s is sidecar %d of block %#x", e.Name, ro.Index, root)
+	}
+	// Archived sidecars were verified before being saved by the exporting node.
+	v, err := verification.BlobSidecarNoop(ro)
+	if err != nil {
+		return err
+	}
+	return blobs.Save(v)
+}
+
+func saveState(ctx context.Context, db Database, st state.ReadOnlyBeaconState, root [32]byte) error {
+	if err := db.SaveState(ctx, st, root); err != nil {
+		return errors.Wrapf(err, "could not save state of block %#x", root)
+	}
+	return db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: st.Slot(), Root: root[:]})
+}
+
+// saveOrigin initializes an empty database from the given block and its post state, the same way
+// checkpoint sync does, without requiring the config of the chain to be known to the binary.
+func saveOrigin(ctx context.Context, db Database, blk interfaces.ReadOnlySignedBeaconBlock, st state.BeaconState) error {
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	pr := blk.Block().ParentRoot()
+	if err := db.SaveBackfillStatus(ctx, &dbval.BackfillStatus{
+		LowSlot:       uint64(blk.Block().Slot()),
+		LowRoot:       root[:],
+		LowParentRoot: pr[:],
+		OriginSlot:    uint64(blk.Block().Slot()),
+		OriginRoot:    root[:],
+	}); err != nil {
+		return errors.Wrap(err, "could not save backfill status")
+	}
+	if err := saveState(ctx, db, st, root); err != nil {
+		return err
+	}
+	if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	if err := db.SaveOriginCheckpointBlockRoot(ctx, root); err != nil {
+		return errors.Wrap(err, "could not save origin block root")
+	}
+	cp := &ethpb.Checkpoint{
+		Epoch: slots.ToEpoch(blk.Block().Slot()),
+		Root:  root[:],
+	}
+	if err := db.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	log.WithField("slot", blk.Block().Slot()).WithField("root", fmt.Sprintf("%#x", root)).Info("Initialized database from archive anchor")
+	return nil
+}
+
+// extendBackfill lowers the backfilled range of a checkpoint synced database to the lowest block
+// reachable from its current low block through parent roots.
+func extendBackfill(ctx context.Context, db Database) error {
+	bf, err := db.BackfillStatus(ctx)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	low := bytesutil.ToBytes32(bf.LowParentRoot)
+	var lowest interfaces.ReadOnlySignedBeaconBlock
+	for {
+		blk, err := db.Block(ctx, low)
+		if err != nil {
+			return err
+		}
+		if blk == nil || blk.IsNil() {
+			break
+		}
+		lowest = blk
+		if blk.Block().Slot() == 0 {
+			break
+		}
+		low = blk.Block().ParentRoot()
+	}
+	if lowest == nil {
+		return nil
+	}
+	root, err := lowest.Block().HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	pr := lowest.Block().ParentRoot()
+	bf.LowSlot = uint64(lowest.Block().Slot())
+	bf.LowRoot = root[:]
+	bf.LowParentRoot = pr[:]
+	return db.SaveBackfillStatus(ctx, bf)
+}
+
+func isEmpty(ctx context.Context, db Database) (bool, error) {
+	if _, err := db.GenesisBlockRoot(ctx); !errors.Is(err, kv.ErrNotFound) {
+		return false, err
+	}
+	if _, err := db.OriginCheckpointBlockRoot(ctx); !errors.Is(err, kv.ErrNotFound) {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,5 @@
+package archive
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "archive")
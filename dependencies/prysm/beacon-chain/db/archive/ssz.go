@@ -0,0 +1,89 @@
+package archive
+
+import (
+	"fmt"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// unmarshalBlock decodes an archived block according to the fork of its entry, so that archives
+// can be read regardless of the config the binary runs with.
+func unmarshalBlock(e *Entry, b []byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	fork, err := version.FromString(e.Fork)
+	if err != nil {
+		return nil, err
+	}
+	var raw ssz.Unmarshaler
+	switch {
+	case fork == version.Phase0:
+		raw = &ethpb.SignedBeaconBlock{}
+	case fork == version.Altair:
+		raw = &ethpb.SignedBeaconBlockAltair{}
+	case fork == version.Bellatrix && e.Blinded:
+		raw = &ethpb.SignedBlindedBeaconBlockBellatrix{}
+	case fork == version.Bellatrix:
+		raw = &ethpb.SignedBeaconBlockBellatrix{}
+	case fork == version.Capella && e.Blinded:
+		raw = &ethpb.SignedBlindedBeaconBlockCapella{}
+	case fork == version.Capella:
+		raw = &ethpb.SignedBeaconBlockCapella{}
+	case fork == version.Deneb && e.Blinded:
+		raw = &ethpb.SignedBlindedBeaconBlockDeneb{}
+	case fork == version.Deneb:
+		raw = &ethpb.SignedBeaconBlockDeneb{}
+	default:
+		return nil, fmt.Errorf("unsupported block fork %s", e.Fork)
+	}
+	if err := raw.UnmarshalSSZ(b); err != nil {
+		return nil, err
+	}
+	return blocks.NewSignedBeaconBlock(raw)
+}
+
+// unmarshalState decodes an archived state according to the fork of its entry.
+func unmarshalState(e *Entry, b []byte) (state.BeaconState, error) {
+	fork, err := version.FromString(e.Fork)
+	if err != nil {
+		return nil, err
+	}
+	switch fork {
+	case version.Phase0:
+		st := &ethpb.BeaconState{}
+		if err := st.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return statenative.InitializeFromProtoUnsafePhase0(st)
+	case version.Altair:
+		st := &ethpb.BeaconStateAltair{}
+		if err := st.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return statenative.InitializeFromProtoUnsafeAltair(st)
+	case version.Bellatrix:
+		st := &ethpb.BeaconStateBellatrix{}
+		if err := st.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return statenative.InitializeFromProtoUnsafeBellatrix(st)
+	case version.Capella:
+		st := &ethpb.BeaconStateCapella{}
+		if err := st.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return statenative.InitializeFromProtoUnsafeCapella(st)
+	case version.Deneb:
+		st := &ethpb.BeaconStateDeneb{}
+		if err := st.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return statenative.InitializeFromProtoUnsafeDeneb(st)
+	default:
+		return nil, fmt.Errorf("unsupported state fork %s", e.Fork)
+	}
+}
@@ -16,6 +16,9 @@ var ErrNotFound = kv.ErrNotFound
 // ErrNotFoundState wraps ErrNotFound for an error specific to a state not being found in the database.
 var ErrNotFoundState = kv.ErrNotFoundState
 
+// ErrNotFoundStateDiff wraps ErrNotFound for an error specific to a state diff not being found in the database.
+var ErrNotFoundStateDiff = kv.ErrNotFoundStateDiff
+
 // ErrNotFoundOriginBlockRoot wraps ErrNotFound for an error specific to the origin block root.
 var ErrNotFoundOriginBlockRoot = kv.ErrNotFoundOriginBlockRoot
 
@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/backup"
 	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -41,6 +42,8 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	HasStateDiff(ctx context.Context, blockRoot [32]byte) bool
+	StateDiffBase(ctx context.Context, blockRoot [32]byte) ([32]byte, error)
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -56,6 +59,20 @@ type ReadOnlyDatabase interface {
 	// Fee recipients operations.
 	FeeRecipientByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (common.Address, error)
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	// Validator history operations.
+	ValidatorHistory(ctx context.Context, idx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch) ([]*dbval.ValidatorHistory, error)
+	LastValidatorHistoryEpoch(ctx context.Context) (primitives.Epoch, error)
+	// Attestation inclusions operations.
+	AttestationInclusions(ctx context.Context, idx primitives.ValidatorIndex, from *dbval.AttestationInclusion, endSlot primitives.Slot, limit int) ([]*dbval.AttestationInclusion, error)
+	// Deposits and withdrawals operations.
+	ValidatorDeposits(ctx context.Context, idx primitives.ValidatorIndex) ([]*dbval.ValidatorDeposit, error)
+	ValidatorWithdrawals(ctx context.Context, idx primitives.ValidatorIndex) ([]*dbval.ValidatorWithdrawal, error)
+	TransfersProgress(ctx context.Context) (*dbval.TransfersProgress, error)
+
+	// Light client operations.
+	LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdate, error)
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*ethpbv2.LightClientUpdate, error)
+	LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*ethpbv2.LightClientBootstrap, error)
 
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
@@ -79,6 +96,7 @@ type NoHeadAccessDatabase interface {
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveStateDiff(ctx context.Context, blockRoot [32]byte, st state.ReadOnlyBeaconState, baseRoot [32]byte, base state.ReadOnlyBeaconState) error
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
@@ -92,6 +110,17 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Validator history operations.
+	SaveValidatorHistory(ctx context.Context, epoch primitives.Epoch, history map[primitives.ValidatorIndex]*dbval.ValidatorHistory) error
+	// Attestation inclusions operations.
+	SaveAttestationInclusions(ctx context.Context, inclusions []*BlockAttestationInclusions) error
+	PruneAttestationInclusions(ctx context.Context, beforeSlot primitives.Slot) error
+	// Deposits and withdrawals operations.
+	SaveTransfers(ctx context.Context, progress *dbval.TransfersProgress, deposits []*dbval.ValidatorDeposit, withdrawals []*dbval.ValidatorWithdrawal) error
+	// Light client operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error
+	SaveLightClientBootstrap(ctx context.Context, blockRoot [32]byte, bootstrap *ethpbv2.LightClientBootstrap) error
+	DeleteLightClientDataBeforePeriod(ctx context.Context, period uint64) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 }
@@ -159,6 +188,18 @@ type SlasherDatabase interface {
 		ctx context.Context,
 		indices []primitives.ValidatorIndex,
 	) ([]*ethpb.HighestAttestation, error)
+	SaveAttesterSlashings(
+		ctx context.Context, slashings []*ethpb.AttesterSlashing,
+	) error
+	SaveProposerSlashings(
+		ctx context.Context, slashings []*ethpb.ProposerSlashing,
+	) error
+	AttesterSlashings(
+		ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+	) ([]*ethpb.AttesterSlashing, error)
+	ProposerSlashings(
+		ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+	) ([]*ethpb.ProposerSlashing, error)
 	DatabasePath() string
 	ClearDB() error
 }
@@ -172,3 +213,10 @@ type Database interface {
 	DatabasePath() string
 	ClearDB() error
 }
+
+// BlockAttestationInclusions are the validators which took part in the aggregated attestations included in a block.
+type BlockAttestationInclusions struct {
+	Slot      primitives.Slot
+	BlockRoot [32]byte
+	Indices   []primitives.ValidatorIndex
+}
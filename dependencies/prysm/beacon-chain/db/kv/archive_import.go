@@ -0,0 +1,27 @@
+package kv
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveArchiveImportInProgress marks the database as being written by an archive import, or clears the mark once the
+// import completed. A database still marked when it is opened was left half imported, and is refused.
+func (s *Store) SaveArchiveImportInProgress(ctx context.Context, inProgress bool) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveArchiveImportInProgress")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(chainMetadataBucket)
+		if !inProgress {
+			return bkt.Delete(archiveImportKey)
+		}
+		return bkt.Put(archiveImportKey, []byte{1})
+	})
+}
+
+// archiveImportInProgress returns whether an archive import into the database did not complete.
+func archiveImportInProgress(tx *bolt.Tx) bool {
+	return len(tx.Bucket(chainMetadataBucket).Get(archiveImportKey)) > 0
+}
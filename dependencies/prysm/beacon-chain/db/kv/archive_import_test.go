@@ -0,0 +1,26 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ArchiveImportInProgress(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveArchiveImportInProgress(ctx, true))
+	require.NoError(t, db.SaveArchiveImportInProgress(ctx, false))
+	require.NoError(t, db.Close())
+
+	// A database whose import did not complete cannot be opened.
+	db, err = NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveArchiveImportInProgress(ctx, true))
+	require.NoError(t, db.Close())
+	_, err = NewKVStore(ctx, dir)
+	require.ErrorIs(t, err, ErrArchiveImportInProgress)
+}
@@ -0,0 +1,115 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveAttestationInclusions records that the validators took part in aggregated attestations included in the
+// blocks, in a single transaction.
+func (s *Store) SaveAttestationInclusions(ctx context.Context, inclusions []*iface.BlockAttestationInclusions) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveAttestationInclusions")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(attestationInclusionsBucket)
+		for _, inclusion := range inclusions {
+			for _, idx := range inclusion.Indices {
+				if err := bkt.Put(attestationInclusionKey(idx, inclusion.Slot, inclusion.BlockRoot), []byte{}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// PruneAttestationInclusions deletes the attestation inclusions of the blocks with a slot before the given one.
+// As inclusions are keyed by validator index first, every validator is visited, and deletions are spread over
+// several transactions to keep them short.
+func (s *Store) PruneAttestationInclusions(ctx context.Context, beforeSlot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.PruneAttestationInclusions")
+	defer span.End()
+	var next []byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket(attestationInclusionsBucket)
+			c := bkt.Cursor()
+			keys := make([][]byte, 0, attestationInclusionsPruneBatchSize)
+			k, _ := c.First()
+			if next != nil {
+				k, _ = c.Seek(next)
+			}
+			for k != nil && len(keys) < attestationInclusionsPruneBatchSize {
+				if primitives.Slot(bytesutil.BytesToUint64BigEndian(k[8:16])) < beforeSlot {
+					keys = append(keys, bytesutil.SafeCopyBytes(k))
+					k, _ = c.Next()
+					continue
+				}
+				// The remaining inclusions of the validator are not old enough, skip to the next validator.
+				idx := bytesutil.BytesToUint64BigEndian(k[:8])
+				if idx == math.MaxUint64 {
+					k = nil
+					break
+				}
+				k, _ = c.Seek(attestationInclusionKey(primitives.ValidatorIndex(idx+1), 0, [32]byte{}))
+			}
+			next = bytesutil.SafeCopyBytes(k)
+			for _, key := range keys {
+				if err := bkt.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil || next == nil {
+			return err
+		}
+	}
+}
+
+// AttestationInclusions retrieves at most limit blocks including aggregated attestations the validator took part
+// in, starting from the given inclusion, inclusive, up to the given end slot, inclusive, in ascending order of slot
+// and block root. Only the blocks which were canonical when saved are indexed.
+func (s *Store) AttestationInclusions(
+	ctx context.Context, idx primitives.ValidatorIndex, from *dbval.AttestationInclusion, endSlot primitives.Slot, limit int,
+) ([]*dbval.AttestationInclusion, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttestationInclusions")
+	defer span.End()
+	inclusions := make([]*dbval.AttestationInclusion, 0)
+	start := attestationInclusionKey(idx, primitives.Slot(from.Slot), bytesutil.ToBytes32(from.BlockRoot))
+	end := attestationInclusionKey(idx, endSlot, [32]byte{})
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(attestationInclusionsBucket).Cursor()
+		for k, _ := c.Seek(start); k != nil && bytes.Compare(k[:16], end[:16]) <= 0 && len(inclusions) < limit; k, _ = c.Next() {
+			inclusions = append(inclusions, &dbval.AttestationInclusion{
+				Slot:      bytesutil.BytesToUint64BigEndian(k[8:16]),
+				BlockRoot: bytesutil.SafeCopyBytes(k[16:]),
+			})
+		}
+		return nil
+	})
+	return inclusions, err
+}
+
+// attestationInclusionsPruneBatchSize is the maximum number of attestation inclusions deleted in a transaction.
+const attestationInclusionsPruneBatchSize = 100_000
+
+// Keys are prefixed by the validator index, so that the inclusions of a validator are contiguous
+// and sorted by slot.
+func attestationInclusionKey(idx primitives.ValidatorIndex, slot primitives.Slot, blockRoot [32]byte) []byte {
+	key := make([]byte, 0, 48)
+	key = append(key, bytesutil.Uint64ToBytesBigEndian(uint64(idx))...)
+	key = append(key, bytesutil.Uint64ToBytesBigEndian(uint64(slot))...)
+	return append(key, blockRoot[:]...)
+}
@@ -0,0 +1,90 @@
+package kv
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_AttestationInclusions(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	require.NoError(t, db.SaveAttestationInclusions(ctx, []*iface.BlockAttestationInclusions{
+		{Slot: 10, BlockRoot: [32]byte{'A'}, Indices: []primitives.ValidatorIndex{1, 2}},
+		{Slot: 11, BlockRoot: [32]byte{'B'}, Indices: []primitives.ValidatorIndex{1}},
+	}))
+	// Blocks of different forks at the same slot are both recorded.
+	require.NoError(t, db.SaveAttestationInclusions(ctx, []*iface.BlockAttestationInclusions{
+		{Slot: 11, BlockRoot: [32]byte{'C'}, Indices: []primitives.ValidatorIndex{1}},
+		{Slot: 12, BlockRoot: [32]byte{'D'}, Indices: []primitives.ValidatorIndex{1, 2}},
+	}))
+
+	inclusions, err := db.AttestationInclusions(ctx, 1, &dbval.AttestationInclusion{}, 100, 100)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(inclusions))
+	for i, want := range []struct {
+		slot uint64
+		root [32]byte
+	}{{10, [32]byte{'A'}}, {11, [32]byte{'B'}}, {11, [32]byte{'C'}}, {12, [32]byte{'D'}}} {
+		assert.Equal(t, want.slot, inclusions[i].Slot)
+		assert.DeepEqual(t, want.root[:], inclusions[i].BlockRoot)
+	}
+
+	// The range is inclusive and does not spill over the inclusions of the next validator.
+	inclusions, err = db.AttestationInclusions(ctx, 1, &dbval.AttestationInclusion{Slot: 11}, 11, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(inclusions))
+	inclusions, err = db.AttestationInclusions(ctx, 1, &dbval.AttestationInclusion{Slot: 12}, 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(inclusions))
+	inclusions, err = db.AttestationInclusions(ctx, 2, &dbval.AttestationInclusion{Slot: 11}, 100, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(inclusions))
+	assert.Equal(t, uint64(12), inclusions[0].Slot)
+	inclusions, err = db.AttestationInclusions(ctx, 3, &dbval.AttestationInclusion{}, 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(inclusions))
+
+	// Pages start from an inclusion, inclusive, and hold at most the given number of inclusions.
+	inclusions, err = db.AttestationInclusions(ctx, 1, &dbval.AttestationInclusion{Slot: 11, BlockRoot: bytesutil.PadTo([]byte{'C'}, 32)}, 100, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(inclusions))
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'C'}, 32), inclusions[0].BlockRoot)
+	assert.Equal(t, uint64(12), inclusions[1].Slot)
+}
+
+func TestStore_PruneAttestationInclusions(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	inclusions := make([]*iface.BlockAttestationInclusions, 0)
+	for slot := primitives.Slot(1); slot <= 10; slot++ {
+		inclusions = append(inclusions, &iface.BlockAttestationInclusions{
+			Slot:      slot,
+			BlockRoot: [32]byte{byte(slot)},
+			Indices:   []primitives.ValidatorIndex{0, primitives.ValidatorIndex(slot % 3), math.MaxUint64},
+		})
+	}
+	require.NoError(t, db.SaveAttestationInclusions(ctx, inclusions))
+	require.NoError(t, db.PruneAttestationInclusions(ctx, 6))
+
+	for _, idx := range []primitives.ValidatorIndex{0, 1, 2, math.MaxUint64} {
+		remaining, err := db.AttestationInclusions(ctx, idx, &dbval.AttestationInclusion{}, 100, 100)
+		require.NoError(t, err)
+		require.NotEqual(t, 0, len(remaining))
+		for _, inclusion := range remaining {
+			assert.Equal(t, true, inclusion.Slot >= 6, "inclusion at slot %d of validator %d was not pruned", inclusion.Slot, idx)
+		}
+	}
+	remaining, err := db.AttestationInclusions(ctx, 0, &dbval.AttestationInclusion{}, 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(remaining))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/snappy"
 	fastssz "github.com/prysmaticlabs/fastssz"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/proto"
@@ -80,6 +81,10 @@ func isSSZStorageFormat(obj interface{}) bool {
 		return true
 	case *ethpb.ValidatorRegistrationV1:
 		return true
+	case *ethpbv2.LightClientUpdate:
+		return true
+	case *ethpbv2.LightClientBootstrap:
+		return true
 	default:
 		return false
 	}
@@ -5,11 +5,17 @@ import "github.com/pkg/errors"
 // ErrDeleteJustifiedAndFinalized is raised when we attempt to delete a finalized block/state
 var ErrDeleteJustifiedAndFinalized = errors.New("cannot delete finalized block or state")
 
+// ErrArchiveImportInProgress is returned when opening a database an archive import was interrupted on.
+var ErrArchiveImportInProgress = errors.New("database was left half imported by an interrupted archive import, remove it and import the archive again")
+
 // ErrNotFound can be used directly, or as a wrapped DBError, whenever a db method needs to
 // indicate that a value couldn't be found.
 var ErrNotFound = errors.New("not found in db")
 var ErrNotFoundState = errors.Wrap(ErrNotFound, "state not found")
 
+// ErrNotFoundStateDiff is a not found error specifically for the state diff getters
+var ErrNotFoundStateDiff = errors.Wrap(ErrNotFound, "state diff not found")
+
 // ErrNotFoundOriginBlockRoot is an error specifically for the origin block root getter
 var ErrNotFoundOriginBlockRoot = errors.Wrap(ErrNotFound, "OriginBlockRoot")
 
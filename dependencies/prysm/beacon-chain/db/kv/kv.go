@@ -111,6 +111,11 @@ var Buckets = [][]byte{
 	powchainBucket,
 	stateSummaryBucket,
 	stateValidatorsBucket,
+	stateDiffBucket,
+	validatorHistoryBucket,
+	attestationInclusionsBucket,
+	validatorDepositsBucket,
+	validatorWithdrawalsBucket,
 	// Indices buckets.
 	attestationHeadBlockRootBucket,
 	attestationSourceRootIndicesBucket,
@@ -131,6 +136,10 @@ var Buckets = [][]byte{
 	registrationBucket,
 
 	blobsBucket,
+
+	lightClientUpdatesBucket,
+	lightClientBootstrapBucket,
+	lightClientBootstrapSlotIndicesBucket,
 }
 
 // KVStoreOption is a functional option that modifies a kv.Store.
@@ -166,6 +175,80 @@ func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*St
 		return nil, err
 	}
 	boltDB.AllocSize = boltAllocSize
+	kv, err := newStore(ctx, boltDB, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range opts {
+		o(kv)
+	}
+	if err := kv.db.Update(func(tx *bolt.Tx) error {
+		if err := createBuckets(tx, Buckets...); err != nil {
+			return err
+		}
+		if archiveImportInProgress(tx) {
+			return ErrArchiveImportInProgress
+		}
+		return nil
+	}); err != nil {
+		if closeErr := boltDB.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close database")
+		}
+		return nil, err
+	}
+	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
+		return nil, err
+	}
+	// Setup the type of block storage used depending on whether or not this is a fresh database.
+	if err := kv.setupBlockStorageType(ctx); err != nil {
+		return nil, err
+	}
+
+	return kv, nil
+}
+
+// NewReadOnlyKVStore opens the existing boltDB key-value store at the directory path specified for reading only,
+// without creating buckets or running migrations. A beacon node holds an exclusive lock on its database while it
+// runs, so opening its database fails once the timeout elapsed until the node is stopped.
+func NewReadOnlyKVStore(ctx context.Context, dirPath string, timeout time.Duration) (*Store, error) {
+	datafile := StoreDatafilePath(dirPath)
+	if _, err := os.Stat(datafile); err != nil {
+		return nil, err
+	}
+	log.Infof("Opening Bolt DB at %s for reading only", datafile)
+	boltDB, err := bolt.Open(
+		datafile,
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{
+			Timeout:  timeout,
+			ReadOnly: true,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by a running beacon node")
+		}
+		return nil, err
+	}
+	kv, err := newStore(ctx, boltDB, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := kv.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(chainMetadataBucket) != nil && archiveImportInProgress(tx) {
+			return ErrArchiveImportInProgress
+		}
+		return nil
+	}); err != nil {
+		if closeErr := boltDB.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close database")
+		}
+		return nil, err
+	}
+	return kv, nil
+}
+
+func newStore(ctx context.Context, boltDB *bolt.DB, dirPath string) (*Store, error) {
 	blockCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,           // number of keys to track frequency of (1000).
 		MaxCost:     BlockCacheSize, // maximum cost of cache (1000 Blocks).
@@ -184,31 +267,14 @@ func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*St
 		return nil, err
 	}
 
-	kv := &Store{
+	return &Store{
 		db:                  boltDB,
 		databasePath:        dirPath,
 		blockCache:          blockCache,
 		validatorEntryCache: validatorCache,
 		stateSummaryCache:   newStateSummaryCache(),
 		ctx:                 ctx,
-	}
-	for _, o := range opts {
-		o(kv)
-	}
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
-		return createBuckets(tx, Buckets...)
-	}); err != nil {
-		return nil, err
-	}
-	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
-		return nil, err
-	}
-	// Setup the type of block storage used depending on whether or not this is a fresh database.
-	if err := kv.setupBlockStorageType(ctx); err != nil {
-		return nil, err
-	}
-
-	return kv, nil
+	}, nil
 }
 
 // ClearDB removes the previously stored database in the data directory.
@@ -227,6 +293,11 @@ func (s *Store) ClearDB() error {
 func (s *Store) Close() error {
 	prometheus.Unregister(createBoltCollector(s.db))
 
+	// A database opened for reading only has nothing to write back.
+	if s.db.IsReadOnly() {
+		return s.db.Close()
+	}
+
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
 		return err
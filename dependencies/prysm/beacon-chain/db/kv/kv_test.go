@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	bolt "go.etcd.io/bbolt"
@@ -213,3 +216,27 @@ func Test_setupBlockStorageType(t *testing.T) {
 		require.ErrorContains(t, fmt.Sprintf(errMsg, features.SaveFullExecutionPayloads.Name), err)
 	})
 }
+
+func TestNewReadOnlyKVStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, err := NewReadOnlyKVStore(ctx, dir, time.Second)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	db, err := NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	root := [32]byte{'A'}
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, root))
+	// The database cannot be opened while a beacon node holds it.
+	_, err = NewReadOnlyKVStore(ctx, dir, 10*time.Millisecond)
+	require.ErrorContains(t, "cannot obtain database lock", err)
+	require.NoError(t, db.Close())
+
+	ro, err := NewReadOnlyKVStore(ctx, dir, time.Second)
+	require.NoError(t, err)
+	got, err := ro.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, got)
+	require.ErrorIs(t, ro.SaveGenesisBlockRoot(ctx, [32]byte{'B'}), bolt.ErrDatabaseReadOnly)
+	require.NoError(t, ro.Close())
+}
@@ -0,0 +1,147 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveLightClientUpdate saves the best light client update known for the given sync committee period,
+// replacing any update previously stored for that period.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+
+	enc, err := encode(ctx, update)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(lightClientUpdatesBucket)
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
+
+// LightClientUpdate retrieves the light client update stored for the given sync committee period.
+// A nil update is returned if there is none.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+
+	var update *ethpbv2.LightClientUpdate
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(lightClientUpdatesBucket).Get(bytesutil.Uint64ToBytesBigEndian(period))
+		if enc == nil {
+			return nil
+		}
+		update = &ethpbv2.LightClientUpdate{}
+		return decode(ctx, enc, update)
+	})
+	return update, err
+}
+
+// LightClientUpdates retrieves the light client updates stored for the sync committee periods
+// in the inclusive range [startPeriod, endPeriod], keyed by period. Periods without an update are absent from the result.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+
+	if startPeriod > endPeriod {
+		return nil, errors.Errorf("start period %d is greater than end period %d", startPeriod, endPeriod)
+	}
+	updates := make(map[uint64]*ethpbv2.LightClientUpdate)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		end := bytesutil.Uint64ToBytesBigEndian(endPeriod)
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			update := &ethpbv2.LightClientUpdate{}
+			if err := decode(ctx, v, update); err != nil {
+				return err
+			}
+			updates[bytesutil.BytesToUint64BigEndian(k)] = update
+		}
+		return nil
+	})
+	return updates, err
+}
+
+// SaveLightClientBootstrap saves the light client bootstrap for the given block root.
+func (s *Store) SaveLightClientBootstrap(ctx context.Context, blockRoot [32]byte, bootstrap *ethpbv2.LightClientBootstrap) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientBootstrap")
+	defer span.End()
+
+	if bootstrap.Header == nil {
+		return errors.New("cannot save light client bootstrap with nil header")
+	}
+	enc, err := encode(ctx, bootstrap)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(lightClientBootstrapBucket).Put(blockRoot[:], enc); err != nil {
+			return err
+		}
+		// The slot index allows pruning bootstraps without decoding them.
+		indexKey := append(bytesutil.SlotToBytesBigEndian(bootstrap.Header.Slot), blockRoot[:]...)
+		return tx.Bucket(lightClientBootstrapSlotIndicesBucket).Put(indexKey, blockRoot[:])
+	})
+}
+
+// LightClientBootstrap retrieves the light client bootstrap for the given block root.
+// A nil bootstrap is returned if there is none.
+func (s *Store) LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*ethpbv2.LightClientBootstrap, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientBootstrap")
+	defer span.End()
+
+	var bootstrap *ethpbv2.LightClientBootstrap
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(lightClientBootstrapBucket).Get(blockRoot[:])
+		if enc == nil {
+			return nil
+		}
+		bootstrap = &ethpbv2.LightClientBootstrap{}
+		return decode(ctx, enc, bootstrap)
+	})
+	return bootstrap, err
+}
+
+// DeleteLightClientDataBeforePeriod deletes all light client updates of sync committee periods
+// lower than the given period, as well as all bootstraps whose header slot precedes that period.
+func (s *Store) DeleteLightClientDataBeforePeriod(ctx context.Context, period uint64) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteLightClientDataBeforePeriod")
+	defer span.End()
+
+	cfg := params.BeaconConfig()
+	slotsPerPeriod := uint64(cfg.EpochsPerSyncCommitteePeriod) * uint64(cfg.SlotsPerEpoch)
+	periodStart := bytesutil.SlotToBytesBigEndian(primitives.Slot(period * slotsPerPeriod))
+	periodKey := bytesutil.Uint64ToBytesBigEndian(period)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		updates := tx.Bucket(lightClientUpdatesBucket)
+		c := updates.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, periodKey) < 0; k, _ = c.First() {
+			if err := updates.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		bootstraps := tx.Bucket(lightClientBootstrapBucket)
+		indices := tx.Bucket(lightClientBootstrapSlotIndicesBucket)
+		c = indices.Cursor()
+		for k, root := c.First(); k != nil && bytes.Compare(k[:8], periodStart) < 0; k, root = c.First() {
+			if err := bootstraps.Delete(root); err != nil {
+				return err
+			}
+			if err := indices.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
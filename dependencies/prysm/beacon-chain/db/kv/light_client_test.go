@@ -0,0 +1,157 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func lightClientTestHeader(slot primitives.Slot) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+func lightClientTestSyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func lightClientTestBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func lightClientTestUpdate(attestedSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader:          lightClientTestHeader(attestedSlot),
+		NextSyncCommittee:       lightClientTestSyncCommittee(),
+		NextSyncCommitteeBranch: lightClientTestBranch(5),
+		FinalizedHeader:         lightClientTestHeader(0),
+		FinalityBranch:          lightClientTestBranch(6),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		SignatureSlot: attestedSlot + 1,
+	}
+}
+
+func lightClientTestBootstrap(slot primitives.Slot) *ethpbv2.LightClientBootstrap {
+	return &ethpbv2.LightClientBootstrap{
+		Header:                     lightClientTestHeader(slot),
+		CurrentSyncCommittee:       lightClientTestSyncCommittee(),
+		CurrentSyncCommitteeBranch: lightClientTestBranch(5),
+	}
+}
+
+func slotsPerSyncCommitteePeriod() primitives.Slot {
+	cfg := params.BeaconConfig()
+	return primitives.Slot(uint64(cfg.EpochsPerSyncCommitteePeriod) * uint64(cfg.SlotsPerEpoch))
+}
+
+func TestStore_LightClientUpdate_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientUpdate)(nil), update)
+
+	want := lightClientTestUpdate(slotsPerSyncCommitteePeriod() + 1)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, update)
+
+	// Saving an update for the same period replaces the previous one.
+	want = lightClientTestUpdate(slotsPerSyncCommitteePeriod() + 2)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, update)
+}
+
+func TestStore_LightClientUpdates_Range(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	for _, period := range []uint64{1, 2, 4, 6} {
+		slot := primitives.Slot(period) * slotsPerSyncCommitteePeriod()
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, lightClientTestUpdate(slot)))
+	}
+
+	updates, err := db.LightClientUpdates(ctx, 2, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(updates))
+	assert.Equal(t, 2*slotsPerSyncCommitteePeriod(), updates[2].AttestedHeader.Slot)
+	assert.Equal(t, 4*slotsPerSyncCommitteePeriod(), updates[4].AttestedHeader.Slot)
+
+	updates, err = db.LightClientUpdates(ctx, 7, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(updates))
+
+	_, err = db.LightClientUpdates(ctx, 3, 2)
+	require.ErrorContains(t, "start period 3 is greater than end period 2", err)
+}
+
+func TestStore_LightClientBootstrap_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	root := [32]byte{'a'}
+
+	bootstrap, err := db.LightClientBootstrap(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientBootstrap)(nil), bootstrap)
+
+	want := lightClientTestBootstrap(100)
+	require.NoError(t, db.SaveLightClientBootstrap(ctx, root, want))
+	bootstrap, err = db.LightClientBootstrap(ctx, root)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, bootstrap)
+
+	require.ErrorContains(t, "nil header", db.SaveLightClientBootstrap(ctx, root, &ethpbv2.LightClientBootstrap{}))
+}
+
+func TestStore_DeleteLightClientDataBeforePeriod(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	periodSlots := slotsPerSyncCommitteePeriod()
+	for period := uint64(0); period < 4; period++ {
+		slot := primitives.Slot(period)*periodSlots + 1
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, lightClientTestUpdate(slot)))
+		require.NoError(t, db.SaveLightClientBootstrap(ctx, [32]byte{byte(period)}, lightClientTestBootstrap(slot)))
+	}
+
+	require.NoError(t, db.DeleteLightClientDataBeforePeriod(ctx, 2))
+
+	updates, err := db.LightClientUpdates(ctx, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(updates))
+	assert.NotNil(t, updates[2])
+	assert.NotNil(t, updates[3])
+	for period := uint64(0); period < 4; period++ {
+		bootstrap, err := db.LightClientBootstrap(ctx, [32]byte{byte(period)})
+		require.NoError(t, err)
+		assert.Equal(t, period >= 2, bootstrap != nil, "Unexpected bootstrap presence for period %d", period)
+	}
+}
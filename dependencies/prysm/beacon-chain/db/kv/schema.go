@@ -7,20 +7,30 @@ package kv
 // it easy to scan for keys that have a certain shard number as a prefix and return those
 // corresponding attestations.
 var (
-	attestationsBucket      = []byte("attestations")
-	blobsBucket             = []byte("blobs")
-	blocksBucket            = []byte("blocks")
-	stateBucket             = []byte("state")
-	stateSummaryBucket      = []byte("state-summary")
-	proposerSlashingsBucket = []byte("proposer-slashings")
-	attesterSlashingsBucket = []byte("attester-slashings")
-	voluntaryExitsBucket    = []byte("voluntary-exits")
-	chainMetadataBucket     = []byte("chain-metadata")
-	checkpointBucket        = []byte("check-point")
-	powchainBucket          = []byte("powchain")
-	stateValidatorsBucket   = []byte("state-validators")
-	feeRecipientBucket      = []byte("fee-recipient")
-	registrationBucket      = []byte("registration")
+	attestationsBucket          = []byte("attestations")
+	blobsBucket                 = []byte("blobs")
+	blocksBucket                = []byte("blocks")
+	stateBucket                 = []byte("state")
+	stateSummaryBucket          = []byte("state-summary")
+	proposerSlashingsBucket     = []byte("proposer-slashings")
+	attesterSlashingsBucket     = []byte("attester-slashings")
+	voluntaryExitsBucket        = []byte("voluntary-exits")
+	chainMetadataBucket         = []byte("chain-metadata")
+	checkpointBucket            = []byte("check-point")
+	powchainBucket              = []byte("powchain")
+	stateValidatorsBucket       = []byte("state-validators")
+	feeRecipientBucket          = []byte("fee-recipient")
+	registrationBucket          = []byte("registration")
+	stateDiffBucket             = []byte("state-diff")
+	validatorHistoryBucket      = []byte("validator-history")
+	attestationInclusionsBucket = []byte("attestation-inclusions")
+	validatorDepositsBucket     = []byte("validator-deposits")
+	validatorWithdrawalsBucket  = []byte("validator-withdrawals")
+
+	// Light client buckets.
+	lightClientUpdatesBucket              = []byte("light-client-updates")
+	lightClientBootstrapBucket            = []byte("light-client-bootstrap")
+	lightClientBootstrapSlotIndicesBucket = []byte("light-client-bootstrap-slot-indices")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -63,6 +73,11 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// tracking data about an ongoing backfill
 	backfillStatusKey = []byte("backfill-status")
+	// last epoch indexed by the validator history service
+	validatorHistoryEpochKey = []byte("validator-history-epoch")
+	transfersProgressKey     = []byte("transfers-progress")
+	// set while an archive is being imported into the database
+	archiveImportKey = []byte("archive-import-in-progress")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
@@ -0,0 +1,120 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/statediff"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveStateDiff saves the state of a block as its difference with the state of a base block. The state
+// of the base block must already be saved in the database, either in full or as a state diff itself.
+// DeleteState does not check whether a state is the base of a diff, so full states used as a base must
+// never be deleted, as is the case for the states of archived points.
+func (s *Store) SaveStateDiff(
+	ctx context.Context,
+	blockRoot [32]byte,
+	st state.ReadOnlyBeaconState,
+	baseRoot [32]byte,
+	base state.ReadOnlyBeaconState,
+) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+
+	if blockRoot == baseRoot {
+		return errors.New("cannot save a state diff against itself")
+	}
+	if !s.HasState(ctx, baseRoot) && !s.HasStateDiff(ctx, baseRoot) {
+		return errors.Wrap(ErrNotFoundState, fmt.Sprintf("no base state with blockroot=%#x", baseRoot))
+	}
+	diff, err := statediff.Diff(base, st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute state diff")
+	}
+	enc := make([]byte, 0, len(baseRoot)+snappy.MaxEncodedLen(len(diff)))
+	enc = append(enc, baseRoot[:]...)
+	enc = append(enc, snappy.Encode(nil, diff)...)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateDiffBucket).Put(blockRoot[:], enc)
+	})
+}
+
+// HasStateDiff checks if the state of a block is saved as a state diff.
+func (s *Store) HasStateDiff(ctx context.Context, blockRoot [32]byte) bool {
+	_, span := trace.StartSpan(ctx, "BeaconDB.HasStateDiff")
+	defer span.End()
+	hasDiff := false
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		hasDiff = len(tx.Bucket(stateDiffBucket).Get(blockRoot[:])) > 0
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+	return hasDiff
+}
+
+// StateDiffBase returns the root of the block whose state the state diff of a block applies to.
+func (s *Store) StateDiffBase(ctx context.Context, blockRoot [32]byte) ([32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.StateDiffBase")
+	defer span.End()
+	var baseRoot [32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(stateDiffBucket).Get(blockRoot[:])
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFoundStateDiff, fmt.Sprintf("no state diff with blockroot=%#x", blockRoot))
+		}
+		if len(enc) < len(baseRoot) {
+			return errors.New("state diff is too short")
+		}
+		copy(baseRoot[:], enc)
+		return nil
+	})
+	return baseRoot, err
+}
+
+// Rebuilds the state of a block from its state diff, applied on the state of the base block which
+// is itself rebuilt from its own state diff if needed. It returns nil if there is no state diff.
+func (s *Store) stateFromDiff(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.stateFromDiff")
+	defer span.End()
+
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(stateDiffBucket).Get(blockRoot[:])
+		// The value is copied, as it is only valid during the transaction.
+		enc = make([]byte, len(v))
+		copy(enc, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	if len(enc) < 32 {
+		return nil, errors.New("state diff is too short")
+	}
+	baseRoot := bytesutil.ToBytes32(enc[:32])
+	base, err := s.State(ctx, baseRoot)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil || base.IsNil() {
+		return nil, errors.Wrap(ErrNotFoundState, fmt.Sprintf("no base state with blockroot=%#x", baseRoot))
+	}
+	diff, err := snappy.Decode(nil, enc[32:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not snappy decode state diff")
+	}
+	st, err := statediff.Apply(base, diff)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not apply state diff of blockroot=%#x", blockRoot)
+	}
+	return st, nil
+}
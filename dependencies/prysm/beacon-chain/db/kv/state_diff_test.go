@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestStore_SaveStateDiff(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	base, _ := util.DeterministicGenesisStateAltair(t, 32)
+	baseRoot := [32]byte{'A'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+
+	first := base.Copy()
+	require.NoError(t, first.SetSlot(32))
+	require.NoError(t, first.UpdateBalancesAtIndex(1, 10))
+	firstRoot := [32]byte{'B'}
+	second := first.Copy()
+	require.NoError(t, second.SetSlot(64))
+	require.NoError(t, second.UpdateBalancesAtIndex(2, 20))
+	secondRoot := [32]byte{'C'}
+
+	// The base state of a diff must be saved first.
+	require.ErrorIs(t, db.SaveStateDiff(ctx, secondRoot, second, firstRoot, first), ErrNotFoundState)
+	require.NoError(t, db.SaveStateDiff(ctx, firstRoot, first, baseRoot, base))
+	require.NoError(t, db.SaveStateDiff(ctx, secondRoot, second, firstRoot, first))
+
+	assert.Equal(t, true, db.HasStateDiff(ctx, secondRoot))
+	assert.Equal(t, false, db.HasState(ctx, secondRoot))
+	assert.Equal(t, false, db.HasStateDiff(ctx, baseRoot))
+	diffBase, err := db.StateDiffBase(ctx, secondRoot)
+	require.NoError(t, err)
+	assert.Equal(t, firstRoot, diffBase)
+	_, err = db.StateDiffBase(ctx, baseRoot)
+	require.ErrorIs(t, err, ErrNotFoundStateDiff)
+
+	// The state is rebuilt from the chain of diffs down to the saved state.
+	got, err := db.State(ctx, secondRoot)
+	require.NoError(t, err)
+	wanted, err := second.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wanted, gotRoot)
+
+	st, err := db.State(ctx, [32]byte{'D'})
+	require.NoError(t, err)
+	assert.Equal(t, true, st == nil)
+}
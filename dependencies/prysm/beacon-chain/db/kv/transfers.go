@@ -0,0 +1,128 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// SaveTransfers saves deposits processed for validators and withdrawals paid to them, along with how far the
+// deposits and withdrawals index went through the finalized blocks.
+func (s *Store) SaveTransfers(
+	ctx context.Context,
+	progress *dbval.TransfersProgress,
+	deposits []*dbval.ValidatorDeposit,
+	withdrawals []*dbval.ValidatorWithdrawal,
+) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveTransfers")
+	defer span.End()
+	if progress == nil {
+		return errors.New("nil transfers progress")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorDepositsBucket)
+		for _, d := range deposits {
+			enc, err := proto.Marshal(d)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(transferKey(d.ValidatorIndex, d.DepositIndex), enc); err != nil {
+				return err
+			}
+		}
+		bkt = tx.Bucket(validatorWithdrawalsBucket)
+		for _, w := range withdrawals {
+			enc, err := proto.Marshal(w)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(transferKey(w.ValidatorIndex, w.WithdrawalIndex), enc); err != nil {
+				return err
+			}
+		}
+		enc, err := proto.Marshal(progress)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(chainMetadataBucket).Put(transfersProgressKey, enc)
+	})
+}
+
+// ValidatorDeposits retrieves the deposits processed for a validator, in ascending order of deposit index.
+func (s *Store) ValidatorDeposits(ctx context.Context, idx primitives.ValidatorIndex) ([]*dbval.ValidatorDeposit, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorDeposits")
+	defer span.End()
+	deposits := make([]*dbval.ValidatorDeposit, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return forEachTransfer(tx.Bucket(validatorDepositsBucket), idx, func(v []byte) error {
+			d := &dbval.ValidatorDeposit{}
+			if err := proto.Unmarshal(v, d); err != nil {
+				return err
+			}
+			deposits = append(deposits, d)
+			return nil
+		})
+	})
+	return deposits, err
+}
+
+// ValidatorWithdrawals retrieves the withdrawals paid to a validator, in ascending order of withdrawal index.
+func (s *Store) ValidatorWithdrawals(ctx context.Context, idx primitives.ValidatorIndex) ([]*dbval.ValidatorWithdrawal, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorWithdrawals")
+	defer span.End()
+	withdrawals := make([]*dbval.ValidatorWithdrawal, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return forEachTransfer(tx.Bucket(validatorWithdrawalsBucket), idx, func(v []byte) error {
+			w := &dbval.ValidatorWithdrawal{}
+			if err := proto.Unmarshal(v, w); err != nil {
+				return err
+			}
+			withdrawals = append(withdrawals, w)
+			return nil
+		})
+	})
+	return withdrawals, err
+}
+
+// TransfersProgress returns how far the deposits and withdrawals index went through the finalized blocks.
+// ErrNotFound is returned if no block was indexed yet.
+func (s *Store) TransfersProgress(ctx context.Context) (*dbval.TransfersProgress, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.TransfersProgress")
+	defer span.End()
+	progress := &dbval.TransfersProgress{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(transfersProgressKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "transfers progress not found")
+		}
+		return proto.Unmarshal(enc, progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func forEachTransfer(bkt *bolt.Bucket, idx primitives.ValidatorIndex, f func(v []byte) error) error {
+	prefix := bytesutil.Uint64ToBytesBigEndian(uint64(idx))
+	c := bkt.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keys are prefixed by the validator index, so that the transfers of a validator are contiguous
+// and sorted by the global index of the deposit or the withdrawal.
+func transferKey(validatorIndex, index uint64) []byte {
+	return append(bytesutil.Uint64ToBytesBigEndian(validatorIndex), bytesutil.Uint64ToBytesBigEndian(index)...)
+}
@@ -0,0 +1,62 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_Transfers(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	_, err := db.TransfersProgress(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.SaveTransfers(ctx, &dbval.TransfersProgress{Slot: 10, NextDepositIndex: 3},
+		[]*dbval.ValidatorDeposit{
+			{ValidatorIndex: 1, Slot: 0, DepositIndex: 1, Amount: 32, Eth1BlockNumber: 100, LogIndex: 2},
+			{ValidatorIndex: 2, Slot: 0, DepositIndex: 0, Amount: 32},
+		},
+		[]*dbval.ValidatorWithdrawal{
+			{ValidatorIndex: 1, Slot: 9, WithdrawalIndex: 5, Address: []byte{'A'}, Amount: 7, ExecutionBlockNumber: 200},
+		},
+	))
+	require.NoError(t, db.SaveTransfers(ctx, &dbval.TransfersProgress{Slot: 20, NextDepositIndex: 4},
+		[]*dbval.ValidatorDeposit{{ValidatorIndex: 1, Slot: 15, DepositIndex: 3, Amount: 1}},
+		[]*dbval.ValidatorWithdrawal{{ValidatorIndex: 1, Slot: 18, WithdrawalIndex: 8, Amount: 9}},
+	))
+
+	progress, err := db.TransfersProgress(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), progress.Slot)
+	assert.Equal(t, uint64(4), progress.NextDepositIndex)
+
+	deposits, err := db.ValidatorDeposits(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deposits))
+	assert.Equal(t, uint64(1), deposits[0].DepositIndex)
+	assert.Equal(t, uint64(100), deposits[0].Eth1BlockNumber)
+	assert.Equal(t, uint64(2), deposits[0].LogIndex)
+	assert.Equal(t, uint64(3), deposits[1].DepositIndex)
+	assert.Equal(t, uint64(15), deposits[1].Slot)
+
+	withdrawals, err := db.ValidatorWithdrawals(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(withdrawals))
+	assert.Equal(t, uint64(5), withdrawals[0].WithdrawalIndex)
+	assert.DeepEqual(t, []byte{'A'}, withdrawals[0].Address)
+	assert.Equal(t, uint64(8), withdrawals[1].WithdrawalIndex)
+
+	// Transfers of a validator do not spill over the ones of the next validator.
+	deposits, err = db.ValidatorDeposits(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deposits))
+	assert.Equal(t, uint64(0), deposits[0].DepositIndex)
+	withdrawals, err = db.ValidatorWithdrawals(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(withdrawals))
+}
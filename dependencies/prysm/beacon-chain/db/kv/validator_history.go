@@ -0,0 +1,85 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// SaveValidatorHistory saves the balances and the rewards of validators over an epoch, and marks the epoch
+// as the last one indexed by the validator history service.
+func (s *Store) SaveValidatorHistory(
+	ctx context.Context, epoch primitives.Epoch, history map[primitives.ValidatorIndex]*dbval.ValidatorHistory,
+) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorHistory")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		for idx, h := range history {
+			if primitives.Epoch(h.Epoch) != epoch {
+				return errors.Errorf("history of validator %d is for epoch %d instead of %d", idx, h.Epoch, epoch)
+			}
+			enc, err := proto.Marshal(h)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(validatorHistoryKey(idx, epoch), enc); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(chainMetadataBucket).Put(validatorHistoryEpochKey, bytesutil.Uint64ToBytesBigEndian(uint64(epoch)))
+	})
+}
+
+// ValidatorHistory retrieves the balances and the rewards of a validator over the epochs in the given range,
+// inclusive, in ascending order of epoch. Epochs which were not indexed are left out.
+func (s *Store) ValidatorHistory(
+	ctx context.Context, idx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*dbval.ValidatorHistory, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorHistory")
+	defer span.End()
+	history := make([]*dbval.ValidatorHistory, 0)
+	end := validatorHistoryKey(idx, endEpoch)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(validatorHistoryBucket).Cursor()
+		for k, v := c.Seek(validatorHistoryKey(idx, startEpoch)); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			h := &dbval.ValidatorHistory{}
+			if err := proto.Unmarshal(v, h); err != nil {
+				return err
+			}
+			history = append(history, h)
+		}
+		return nil
+	})
+	return history, err
+}
+
+// LastValidatorHistoryEpoch returns the last epoch indexed by the validator history service.
+// ErrNotFound is returned if no epoch was indexed yet.
+func (s *Store) LastValidatorHistoryEpoch(ctx context.Context) (primitives.Epoch, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastValidatorHistoryEpoch")
+	defer span.End()
+	var epoch primitives.Epoch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(validatorHistoryEpochKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "validator history epoch not found")
+		}
+		epoch = primitives.Epoch(bytesutil.BytesToUint64BigEndian(enc))
+		return nil
+	})
+	return epoch, err
+}
+
+// Keys are prefixed by the validator index, so that the history of a validator is contiguous
+// and sorted by epoch.
+func validatorHistoryKey(idx primitives.ValidatorIndex, epoch primitives.Epoch) []byte {
+	return append(bytesutil.Uint64ToBytesBigEndian(uint64(idx)), bytesutil.Uint64ToBytesBigEndian(uint64(epoch))...)
+}
@@ -0,0 +1,49 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ValidatorHistory(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	_, err := db.LastValidatorHistoryEpoch(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+		require.NoError(t, db.SaveValidatorHistory(ctx, epoch, map[primitives.ValidatorIndex]*dbval.ValidatorHistory{
+			1: {Epoch: uint64(epoch), Balance: 100 + uint64(epoch), AttestationSource: -1},
+			2: {Epoch: uint64(epoch), Balance: 200 + uint64(epoch), Proposer: 5},
+		}))
+	}
+	last, err := db.LastValidatorHistoryEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(4), last)
+
+	history, err := db.ValidatorHistory(ctx, 1, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+	for i, epoch := range []uint64{2, 3} {
+		assert.Equal(t, epoch, history[i].Epoch)
+		assert.Equal(t, 100+epoch, history[i].Balance)
+		assert.Equal(t, int64(-1), history[i].AttestationSource)
+	}
+
+	// The range does not spill over the history of the next validator.
+	history, err = db.ValidatorHistory(ctx, 1, 3, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(history))
+	history, err = db.ValidatorHistory(ctx, 3, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+
+	err = db.SaveValidatorHistory(ctx, 5, map[primitives.ValidatorIndex]*dbval.ValidatorHistory{1: {Epoch: 4}})
+	require.ErrorContains(t, "history of validator 1 is for epoch 4 instead of 5", err)
+}
@@ -0,0 +1,179 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Size of the writes batched in a single transaction of the destination database when compacting.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+// BucketStats defines the size of a bucket of the slasher database.
+type BucketStats struct {
+	Name    string
+	NumKeys int
+	// Size is the number of bytes of the pages allocated to the bucket.
+	Size int
+}
+
+// DatabaseStats defines the size of the slasher database, and of its buckets.
+type DatabaseStats struct {
+	FileSize int64
+	// ReclaimableBytes is the number of bytes of free pages, which compacting the database gives back to the OS.
+	ReclaimableBytes int
+	Buckets          []*BucketStats
+	MinSpanChunks    int
+	MaxSpanChunks    int
+	// HighestAttestedEpoch is the highest epoch attested by any validator, as recorded by the slasher.
+	HighestAttestedEpoch primitives.Epoch
+}
+
+// Stats computes the size of the slasher database and of its buckets, and counts the min and max
+// span chunks stored.
+func (s *Store) Stats(ctx context.Context) (*DatabaseStats, error) {
+	info, err := os.Stat(path.Join(s.databasePath, DatabaseFileName))
+	if err != nil {
+		return nil, err
+	}
+	stats := &DatabaseStats{
+		FileSize:         info.Size(),
+		ReclaimableBytes: s.reclaimableBytes(),
+		Buckets:          make([]*BucketStats, 0, len(buckets)),
+	}
+	err = s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			bs := tx.Bucket(name).Stats()
+			stats.Buckets = append(stats.Buckets, &BucketStats{
+				Name:    string(name),
+				NumKeys: bs.KeyN,
+				Size:    bs.BranchAlloc + bs.LeafAlloc,
+			})
+		}
+
+		// Chunk keys are prefixed by the kind of span they hold.
+		c := tx.Bucket(slasherChunksBucket).Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			switch slashertypes.ChunkKind(k[0]) {
+			case slashertypes.MinSpan:
+				stats.MinSpanChunks++
+			case slashertypes.MaxSpan:
+				stats.MaxSpanChunks++
+			}
+		}
+
+		c = tx.Bucket(attestedEpochsByValidator).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var epoch primitives.Epoch
+			if err := epoch.UnmarshalSSZ(v); err != nil {
+				return err
+			}
+			if epoch > stats.HighestAttestedEpoch {
+				stats.HighestAttestedEpoch = epoch
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// CompactTo copies the slasher database into the destination store, expected to be empty, leaving out
+// the attestations and proposals of epochs before the minimum epoch as pruning does. Bolt never gives
+// the pages of deleted data back to the OS, so rewriting the database into a fresh file is the only way
+// to reclaim the space freed by pruning.
+func (s *Store) CompactTo(ctx context.Context, dst *Store, minEpoch primitives.Epoch) error {
+	minSlot, err := slots.EpochStart(minEpoch)
+	if err != nil {
+		return err
+	}
+	keep := map[string]func(k, v []byte) (bool, error){
+		string(attestationDataRootsBucket): func(k, _ []byte) (bool, error) {
+			return primitives.Epoch(binary.LittleEndian.Uint64(k[:8])) >= minEpoch, nil
+		},
+		string(attestationRecordsBucket): func(_, v []byte) (bool, error) {
+			record, err := decodeAttestationRecord(v)
+			if err != nil {
+				return false, err
+			}
+			return record.IndexedAttestation.Data.Target.Epoch >= minEpoch, nil
+		},
+		string(proposalRecordsBucket): func(k, _ []byte) (bool, error) {
+			return slotFromProposalKey(k) >= minSlot, nil
+		},
+	}
+	return s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			if err := copyBucket(ctx, tx, dst.db, name, keep[string(name)]); err != nil {
+				return errors.Wrapf(err, "could not copy bucket %s", name)
+			}
+		}
+		return nil
+	})
+}
+
+// Copies the key-values of a bucket accepted by the keep function, or all of them if nil,
+// to the same bucket of the destination database.
+func copyBucket(
+	ctx context.Context, src *bolt.Tx, dst *bolt.DB, bucket []byte, keep func(k, v []byte) (bool, error),
+) error {
+	dstTx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Rolling back an already committed transaction is a no-op.
+		if dstTx != nil {
+			_ = dstTx.Rollback()
+		}
+	}()
+
+	var size int
+	c := src.Bucket(bucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if keep != nil {
+			ok, err := keep(k, v)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		if size+len(k)+len(v) > compactTxMaxSize {
+			if err := dstTx.Commit(); err != nil {
+				return err
+			}
+			dstTx, err = dst.Begin(true)
+			if err != nil {
+				return err
+			}
+			size = 0
+		}
+		if err := dstTx.Bucket(bucket).Put(k, v); err != nil {
+			return err
+		}
+		size += len(k) + len(v)
+	}
+	return dstTx.Commit()
+}
+
+// Number of bytes of the free pages of the database, as of the last write transaction.
+func (s *Store) reclaimableBytes() int {
+	return s.db.Stats().FreeAlloc
+}
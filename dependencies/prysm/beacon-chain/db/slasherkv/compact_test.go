@@ -0,0 +1,70 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_CompactTo(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	proposals := make([]*slashertypes.SignedBlockHeaderWrapper, 0)
+	for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+		atts = append(atts, createAttestationWrapper(epoch-1, epoch, []uint64{1, 2}, []byte{byte(epoch)}))
+		proposals = append(proposals, createProposalWrapper(t, slotsPerEpoch.Mul(uint64(epoch)), 1, []byte{byte(epoch)}))
+	}
+	require.NoError(t, beaconDB.SaveAttestationRecordsForValidators(ctx, atts))
+	require.NoError(t, beaconDB.SaveBlockProposals(ctx, proposals))
+	require.NoError(t, beaconDB.SaveLastEpochsWrittenForValidators(ctx, map[primitives.ValidatorIndex]primitives.Epoch{1: 4, 2: 3}))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, [][]byte{{0}, {1}}, [][]uint16{{1}, {2}}))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, [][]byte{{0}}, [][]uint16{{3}}))
+
+	stats, err := beaconDB.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(4), stats.HighestAttestedEpoch)
+	assert.Equal(t, 2, stats.MinSpanChunks)
+	assert.Equal(t, 1, stats.MaxSpanChunks)
+	numKeys := make(map[string]int)
+	for _, b := range stats.Buckets {
+		numKeys[b.Name] = b.NumKeys
+	}
+	assert.Equal(t, 8, numKeys[string(attestationDataRootsBucket)])
+	assert.Equal(t, 4, numKeys[string(proposalRecordsBucket)])
+
+	dst := setupDB(t)
+	require.NoError(t, beaconDB.CompactTo(ctx, dst, 3))
+
+	// Attestations and proposals before epoch 3 are left out.
+	for i, att := range atts {
+		for _, validatorIdx := range att.IndexedAttestation.AttestingIndices {
+			record, err := dst.AttestationRecordForValidator(ctx, primitives.ValidatorIndex(validatorIdx), att.IndexedAttestation.Data.Target.Epoch)
+			require.NoError(t, err)
+			assert.Equal(t, i >= 2, record != nil)
+		}
+	}
+	for i, proposal := range proposals {
+		record, err := dst.BlockProposalForValidator(ctx, 1, proposal.SignedBeaconBlockHeader.Header.Slot)
+		require.NoError(t, err)
+		assert.Equal(t, i >= 2, record != nil)
+	}
+
+	// Everything else is copied as is.
+	chunks, exists, err := dst.LoadSlasherChunks(ctx, slashertypes.MinSpan, [][]byte{{0}, {1}})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []bool{true, true}, exists)
+	assert.DeepEqual(t, [][]uint16{{1}, {2}}, chunks)
+	dstStats, err := dst.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stats.HighestAttestedEpoch, dstStats.HighestAttestedEpoch)
+	assert.Equal(t, stats.MinSpanChunks, dstStats.MinSpanChunks)
+	assert.Equal(t, stats.MaxSpanChunks, dstStats.MaxSpanChunks)
+}
@@ -69,15 +69,7 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
-		return createBuckets(
-			tx,
-			// Slasher buckets.
-			attestedEpochsByValidator,
-			attestationRecordsBucket,
-			attestationDataRootsBucket,
-			proposalRecordsBucket,
-			slasherChunksBucket,
-		)
+		return createBuckets(tx, buckets...)
 	}); err != nil {
 		return nil, err
 	}
@@ -14,4 +14,8 @@ var (
 		Name: "slasher_proposals_pruned_total",
 		Help: "Total number of old proposals pruned by slasher",
 	})
+	slasherReclaimableBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_db_reclaimable_bytes",
+		Help: "Number of bytes of free pages in the slasher database, given back to the OS by compacting the database",
+	})
 )
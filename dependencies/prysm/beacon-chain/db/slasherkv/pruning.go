@@ -79,6 +79,7 @@ func (s *Store) PruneAttestationsAtEpoch(
 	}); err != nil {
 		return
 	}
+	slasherReclaimableBytes.Set(float64(s.reclaimableBytes()))
 	return
 }
 
@@ -153,6 +154,7 @@ func (s *Store) PruneProposalsAtEpoch(
 	}); err != nil {
 		return
 	}
+	slasherReclaimableBytes.Set(float64(s.reclaimableBytes()))
 	return
 }
 
@@ -24,4 +24,23 @@ var (
 	// value: (encoded) SignedBlockHeaderWrapper
 	proposalRecordsBucket = []byte("proposal-records")
 	slasherChunksBucket   = []byte("slasher-chunks")
+
+	// key: (big-endian encoded) Target Epoch + AttesterSlashing HashTreeRoot
+	// value: (encoded + compressed) AttesterSlashing
+	attesterSlashingsBucket = []byte("attester-slashings")
+
+	// key: (big-endian encoded) Epoch + ProposerSlashing HashTreeRoot
+	// value: (encoded + compressed) ProposerSlashing
+	proposerSlashingsBucket = []byte("proposer-slashings")
 )
+
+// The buckets of the slasher database.
+var buckets = [][]byte{
+	attestedEpochsByValidator,
+	attestationRecordsBucket,
+	attestationDataRootsBucket,
+	proposalRecordsBucket,
+	slasherChunksBucket,
+	attesterSlashingsBucket,
+	proposerSlashingsBucket,
+}
@@ -0,0 +1,151 @@
+package slasherkv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveAttesterSlashings saves the attester slashings detected by the slasher, keyed by the
+// highest target epoch of their two attestations.
+func (s *Store) SaveAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveAttesterSlashings")
+	defer span.End()
+
+	keys := make([][]byte, len(slashings))
+	values := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil ||
+			slashing.Attestation_1.Data == nil || slashing.Attestation_2.Data == nil ||
+			slashing.Attestation_1.Data.Target == nil || slashing.Attestation_2.Data.Target == nil {
+			return errors.New("nil attester slashing")
+		}
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		enc, err := slashing.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		epoch := slashing.Attestation_1.Data.Target.Epoch
+		if target := slashing.Attestation_2.Data.Target.Epoch; target > epoch {
+			epoch = target
+		}
+		keys[i] = append(bytesutil.EpochToBytesBigEndian(epoch), root[:]...)
+		values[i] = snappy.Encode(nil, enc)
+	}
+	return s.saveSlashings(attesterSlashingsBucket, keys, values)
+}
+
+// SaveProposerSlashings saves the proposer slashings detected by the slasher, keyed by the
+// epoch of their proposals.
+func (s *Store) SaveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveProposerSlashings")
+	defer span.End()
+
+	keys := make([][]byte, len(slashings))
+	values := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		if slashing == nil || slashing.Header_1 == nil || slashing.Header_1.Header == nil {
+			return errors.New("nil proposer slashing")
+		}
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		enc, err := slashing.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		epoch := slots.ToEpoch(slashing.Header_1.Header.Slot)
+		keys[i] = append(bytesutil.EpochToBytesBigEndian(epoch), root[:]...)
+		values[i] = snappy.Encode(nil, enc)
+	}
+	return s.saveSlashings(proposerSlashingsBucket, keys, values)
+}
+
+// AttesterSlashings retrieves the attester slashings detected by the slasher with a target epoch
+// between the start and end epochs, both inclusive, ordered by epoch.
+func (s *Store) AttesterSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*ethpb.AttesterSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttesterSlashings")
+	defer span.End()
+
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+	err := s.slashingsInRange(attesterSlashingsBucket, startEpoch, endEpoch, func(enc []byte) error {
+		slashing := &ethpb.AttesterSlashing{}
+		if err := slashing.UnmarshalSSZ(enc); err != nil {
+			return err
+		}
+		slashings = append(slashings, slashing)
+		return nil
+	})
+	return slashings, err
+}
+
+// ProposerSlashings retrieves the proposer slashings detected by the slasher with an epoch
+// between the start and end epochs, both inclusive, ordered by epoch.
+func (s *Store) ProposerSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*ethpb.ProposerSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ProposerSlashings")
+	defer span.End()
+
+	slashings := make([]*ethpb.ProposerSlashing, 0)
+	err := s.slashingsInRange(proposerSlashingsBucket, startEpoch, endEpoch, func(enc []byte) error {
+		slashing := &ethpb.ProposerSlashing{}
+		if err := slashing.UnmarshalSSZ(enc); err != nil {
+			return err
+		}
+		slashings = append(slashings, slashing)
+		return nil
+	})
+	return slashings, err
+}
+
+func (s *Store) saveSlashings(bucket []byte, keys, values [][]byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(bucket)
+		for i := range keys {
+			if err := bkt.Put(keys[i], values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Calls f with the decompressed slashings of the bucket whose key epoch is between the start
+// and end epochs, both inclusive.
+func (s *Store) slashingsInRange(bucket []byte, startEpoch, endEpoch primitives.Epoch, f func(enc []byte) error) error {
+	if startEpoch > endEpoch {
+		return errors.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+	encEndEpoch := bytesutil.EpochToBytesBigEndian(endEpoch)
+	return s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(startEpoch)); k != nil; k, v = c.Next() {
+			if bytes.Compare(k[:8], encEndEpoch) > 0 {
+				return nil
+			}
+			enc, err := snappy.Decode(nil, v)
+			if err != nil {
+				return err
+			}
+			if err := f(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_AttesterSlashings_SaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+	for target := primitives.Epoch(1); target <= 4; target++ {
+		slashings = append(slashings, &ethpb.AttesterSlashing{
+			Attestation_1: createAttestationWrapper(target-1, target, []uint64{1}, []byte{1}).IndexedAttestation,
+			Attestation_2: createAttestationWrapper(target-1, target+1, []uint64{1}, []byte{2}).IndexedAttestation,
+		})
+	}
+	// Saved out of order, and twice.
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, []*ethpb.AttesterSlashing{slashings[3], slashings[1]}))
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, slashings))
+
+	retrieved, err := beaconDB.AttesterSlashings(ctx, 0, 100)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings, retrieved)
+
+	// Slashings are keyed by their highest target epoch.
+	retrieved, err = beaconDB.AttesterSlashings(ctx, 3, 4)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings[1:3], retrieved)
+
+	retrieved, err = beaconDB.AttesterSlashings(ctx, 6, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(retrieved))
+
+	_, err = beaconDB.AttesterSlashings(ctx, 4, 3)
+	require.ErrorContains(t, "start epoch 4 is greater than end epoch 3", err)
+}
+
+func TestStore_ProposerSlashings_SaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	slashings := make([]*ethpb.ProposerSlashing, 0)
+	for epoch := primitives.Epoch(0); epoch < 3; epoch++ {
+		slot := slotsPerEpoch.Mul(uint64(epoch)) + 1
+		slashings = append(slashings, &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, slot, 1, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, slot, 1, []byte{2}).SignedBeaconBlockHeader,
+		})
+	}
+	require.NoError(t, beaconDB.SaveProposerSlashings(ctx, slashings))
+
+	retrieved, err := beaconDB.ProposerSlashings(ctx, 0, 2)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings, retrieved)
+
+	retrieved, err = beaconDB.ProposerSlashings(ctx, 1, 1)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings[1:2], retrieved)
+
+	require.ErrorContains(t, "nil proposer slashing", beaconDB.SaveProposerSlashings(ctx, []*ethpb.ProposerSlashing{{}}))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -22,6 +23,7 @@ import (
 	payloadattribute "github.com/prysmaticlabs/prysm/v4/consensus-types/payload-attribute"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/logs"
 	pb "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -117,6 +119,24 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		newPayloadLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	lvh, err := newPayload(ctx, s.primaryClient(), payload, versionedHashes, parentBlockRoot)
+	if !shouldFailover(err) {
+		return lvh, err
+	}
+	for _, e := range s.backupEngines(true) {
+		backupLvh, backupErr := newPayload(ctx, e.client, payload, versionedHashes, parentBlockRoot)
+		if shouldFailover(backupErr) {
+			log.WithError(backupErr).WithField("endpoint", e.name()).Debug("Backup execution endpoint could not process payload")
+			continue
+		}
+		engineCallFailoverCount.WithLabelValues("new_payload").Inc()
+		log.WithError(err).WithField("endpoint", e.name()).Warn("Processed payload with a backup execution endpoint")
+		return backupLvh, backupErr
+	}
+	return lvh, err
+}
+
+func newPayload(ctx context.Context, client RPCClient, payload interfaces.ExecutionData, versionedHashes []common.Hash, parentBlockRoot *common.Hash) ([]byte, error) {
 	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
@@ -128,7 +148,7 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		if !ok {
 			return nil, errors.New("execution data must be a Bellatrix or Capella execution payload")
 		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethod, payloadPb)
+		err := client.CallContext(ctx, result, NewPayloadMethod, payloadPb)
 		if err != nil {
 			return nil, handleRPCError(err)
 		}
@@ -137,7 +157,7 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		if !ok {
 			return nil, errors.New("execution data must be a Capella execution payload")
 		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV2, payloadPb)
+		err := client.CallContext(ctx, result, NewPayloadMethodV2, payloadPb)
 		if err != nil {
 			return nil, handleRPCError(err)
 		}
@@ -146,7 +166,7 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		if !ok {
 			return nil, errors.New("execution data must be a Deneb execution payload")
 		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV3, payloadPb, versionedHashes, parentBlockRoot)
+		err := client.CallContext(ctx, result, NewPayloadMethodV3, payloadPb, versionedHashes, parentBlockRoot)
 		if err != nil {
 			return nil, handleRPCError(err)
 		}
@@ -181,6 +201,57 @@ func (s *Service) ForkchoiceUpdated(
 		forkchoiceUpdatedLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	// Backup endpoints are sent the same forkchoice update, to keep them ready to take over.
+	type fcuResult struct {
+		id  *pb.PayloadIDBytes
+		lvh []byte
+		err error
+	}
+	backups := s.backupEngines(false)
+	results := make([]fcuResult, len(backups))
+	var wg sync.WaitGroup
+	for i, e := range backups {
+		wg.Add(1)
+		go func(i int, client RPCClient) {
+			defer wg.Done()
+			id, lvh, err := forkchoiceUpdated(ctx, client, state, attrs)
+			results[i] = fcuResult{id: id, lvh: lvh, err: err}
+		}(i, e.client)
+	}
+	id, lvh, err := forkchoiceUpdated(ctx, s.primaryClient(), state, attrs)
+	wg.Wait()
+
+	ids := make([]enginePayloadID, 0, len(backups)+1)
+	if id != nil {
+		ids = append(ids, enginePayloadID{url: s.primaryURL(), id: *id})
+	}
+	served := -1
+	for i, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).WithField("endpoint", backups[i].name()).Debug("Backup execution endpoint could not update forkchoice")
+			continue
+		}
+		if served < 0 && shouldFailover(err) && backups[i].synced {
+			served = i
+		}
+		if r.id != nil {
+			ids = append(ids, enginePayloadID{url: backups[i].endpoint.Url, id: *r.id})
+		}
+	}
+	if served >= 0 {
+		engineCallFailoverCount.WithLabelValues("forkchoice_updated").Inc()
+		log.WithError(err).WithField("endpoint", backups[served].name()).Warn("Updated forkchoice with a backup execution endpoint")
+		id, lvh, err = results[served].id, results[served].lvh, nil
+	}
+	if id != nil && len(backups) > 0 {
+		s.payloadIDs.add(*id, ids)
+	}
+	return id, lvh, err
+}
+
+func forkchoiceUpdated(
+	ctx context.Context, client RPCClient, state *pb.ForkchoiceState, attrs payloadattribute.Attributer,
+) (*pb.PayloadIDBytes, []byte, error) {
 	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
@@ -195,7 +266,7 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, a)
+		err = client.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -204,7 +275,7 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
+		err = client.CallContext(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -213,7 +284,7 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
+		err = client.CallContext(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -250,13 +321,47 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 		getPayloadLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	// The payload is requested from the execution endpoints that were sent the forkchoice update
+	// it is built from, starting with the one that returned the payload ID.
+	ids := s.payloadIDs.get(payloadId)
+	if len(ids) == 0 {
+		ids = []enginePayloadID{{url: s.primaryURL(), id: payloadId}}
+		for _, e := range s.backupEngines(true) {
+			ids = append(ids, enginePayloadID{url: e.endpoint.Url, id: payloadId})
+		}
+	}
+	var firstErr error
+	for i, eid := range ids {
+		client, ok := s.engineClient(eid.url)
+		if !ok {
+			continue
+		}
+		ed, bundle, override, err := getPayload(ctx, client, eid.id, slot)
+		if err == nil {
+			if i > 0 {
+				engineCallFailoverCount.WithLabelValues("get_payload").Inc()
+				log.WithError(firstErr).WithField("endpoint", logs.MaskCredentialsLogging(eid.url)).Warn("Retrieved payload from a backup execution endpoint")
+			}
+			return ed, bundle, override, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = errors.New("no execution endpoint available to retrieve payload")
+	}
+	return nil, nil, false, firstErr
+}
+
+func getPayload(ctx context.Context, client RPCClient, payloadId [8]byte, slot primitives.Slot) (interfaces.ExecutionData, *pb.BlobsBundle, bool, error) {
 	d := time.Now().Add(defaultEngineTimeout)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
 
 	if slots.ToEpoch(slot) >= params.BeaconConfig().DenebForkEpoch {
 		result := &pb.ExecutionPayloadDenebWithValueAndBlobsBundle{}
-		err := s.rpcClient.CallContext(ctx, result, GetPayloadMethodV3, pb.PayloadIDBytes(payloadId))
+		err := client.CallContext(ctx, result, GetPayloadMethodV3, pb.PayloadIDBytes(payloadId))
 		if err != nil {
 			return nil, nil, false, handleRPCError(err)
 		}
@@ -269,7 +374,7 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 
 	if slots.ToEpoch(slot) >= params.BeaconConfig().CapellaForkEpoch {
 		result := &pb.ExecutionPayloadCapellaWithValue{}
-		err := s.rpcClient.CallContext(ctx, result, GetPayloadMethodV2, pb.PayloadIDBytes(payloadId))
+		err := client.CallContext(ctx, result, GetPayloadMethodV2, pb.PayloadIDBytes(payloadId))
 		if err != nil {
 			return nil, nil, false, handleRPCError(err)
 		}
@@ -281,7 +386,7 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 	}
 
 	result := &pb.ExecutionPayload{}
-	err := s.rpcClient.CallContext(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
+	err := client.CallContext(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
 	if err != nil {
 		return nil, nil, false, handleRPCError(err)
 	}
@@ -297,7 +402,7 @@ func (s *Service) ExchangeCapabilities(ctx context.Context) ([]string, error) {
 	defer span.End()
 
 	result := &pb.ExchangeCapabilities{}
-	err := s.rpcClient.CallContext(ctx, &result, ExchangeCapabilities, supportedEngineEndpoints)
+	err := s.primaryClient().CallContext(ctx, &result, ExchangeCapabilities, supportedEngineEndpoints)
 
 	var unsupported []string
 	for _, s1 := range supportedEngineEndpoints {
@@ -408,7 +513,7 @@ func (s *Service) LatestExecutionBlock(ctx context.Context) (*pb.ExecutionBlock,
 	defer span.End()
 
 	result := &pb.ExecutionBlock{}
-	err := s.rpcClient.CallContext(
+	err := s.primaryClient().CallContext(
 		ctx,
 		result,
 		BlockByNumberMethod,
@@ -424,7 +529,7 @@ func (s *Service) ExecutionBlockByHash(ctx context.Context, hash common.Hash, wi
 	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.ExecutionBlockByHash")
 	defer span.End()
 	result := &pb.ExecutionBlock{}
-	err := s.rpcClient.CallContext(ctx, result, BlockByHashMethod, hash, withTxs)
+	err := s.primaryClient().CallContext(ctx, result, BlockByHashMethod, hash, withTxs)
 	return result, handleRPCError(err)
 }
 
@@ -450,7 +555,7 @@ func (s *Service) ExecutionBlocksByHashes(ctx context.Context, hashes []common.H
 		})
 		execBlks = append(execBlks, blk)
 	}
-	ioErr := s.rpcClient.BatchCall(elems)
+	ioErr := s.primaryClient().BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
 	}
@@ -465,7 +570,7 @@ func (s *Service) ExecutionBlocksByHashes(ctx context.Context, hashes []common.H
 // HeaderByHash returns the relevant header details for the provided block hash.
 func (s *Service) HeaderByHash(ctx context.Context, hash common.Hash) (*types.HeaderInfo, error) {
 	var hdr *types.HeaderInfo
-	err := s.rpcClient.CallContext(ctx, &hdr, BlockByHashMethod, hash, false /* no transactions */)
+	err := s.primaryClient().CallContext(ctx, &hdr, BlockByHashMethod, hash, false /* no transactions */)
 	if err == nil && hdr == nil {
 		err = ethereum.NotFound
 	}
@@ -475,7 +580,7 @@ func (s *Service) HeaderByHash(ctx context.Context, hash common.Hash) (*types.He
 // HeaderByNumber returns the relevant header details for the provided block number.
 func (s *Service) HeaderByNumber(ctx context.Context, number *big.Int) (*types.HeaderInfo, error) {
 	var hdr *types.HeaderInfo
-	err := s.rpcClient.CallContext(ctx, &hdr, BlockByNumberMethod, toBlockNumArg(number), false /* no transactions */)
+	err := s.primaryClient().CallContext(ctx, &hdr, BlockByNumberMethod, toBlockNumArg(number), false /* no transactions */)
 	if err == nil && hdr == nil {
 		err = ethereum.NotFound
 	}
@@ -492,7 +597,7 @@ func (s *Service) GetPayloadBodiesByHash(ctx context.Context, executionBlockHash
 	if len(executionBlockHashes) == 0 {
 		return result, nil
 	}
-	err := s.rpcClient.CallContext(ctx, &result, GetPayloadBodiesByHashV1, executionBlockHashes)
+	err := s.primaryClient().CallContext(ctx, &result, GetPayloadBodiesByHashV1, executionBlockHashes)
 
 	for i, item := range result {
 		if item == nil {
@@ -511,7 +616,7 @@ func (s *Service) GetPayloadBodiesByRange(ctx context.Context, start, count uint
 	defer span.End()
 
 	result := make([]*pb.ExecutionPayloadBodyV1, 0)
-	err := s.rpcClient.CallContext(ctx, &result, GetPayloadBodiesByRangeV1, start, count)
+	err := s.primaryClient().CallContext(ctx, &result, GetPayloadBodiesByRangeV1, start, count)
 
 	for i, item := range result {
 		if item == nil {
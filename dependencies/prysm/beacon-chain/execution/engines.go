@@ -0,0 +1,275 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/io/logs"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// syncingMethod request string for JSON-RPC, used to check the health of execution endpoints.
+	syncingMethod = "eth_syncing"
+	// payloadIDsRetention is the number of payload IDs whose per endpoint IDs are remembered.
+	payloadIDsRetention = 32
+)
+
+// engine is a backup execution endpoint, kept in sync with the primary one by forkchoice updates
+// so that it can take over engine API calls when the primary endpoint is down or syncing.
+type engine struct {
+	endpoint network.Endpoint
+	client   RPCClient
+	healthy  bool
+	synced   bool
+}
+
+func (e *engine) name() string {
+	return logs.MaskCredentialsLogging(e.endpoint.Url)
+}
+
+// engineSynced checks whether the execution endpoint is reachable and synced.
+func engineSynced(ctx context.Context, client RPCClient) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineTimeout)
+	defer cancel()
+	// The result is false once the execution client is synced, or an object describing its sync progress otherwise.
+	var syncing interface{}
+	if err := client.CallContext(ctx, &syncing, syncingMethod); err != nil {
+		return false, handleRPCError(err)
+	}
+	s, ok := syncing.(bool)
+	return ok && !s, nil
+}
+
+// backupEngines returns copies of the backup execution endpoints, only the healthy ones or only the synced ones.
+func (s *Service) backupEngines(syncedOnly bool) []engine {
+	s.enginesLock.RLock()
+	defer s.enginesLock.RUnlock()
+	engines := make([]engine, 0, len(s.engines))
+	for _, e := range s.engines {
+		if e.client == nil || !e.healthy || (syncedOnly && !e.synced) {
+			continue
+		}
+		engines = append(engines, *e)
+	}
+	return engines
+}
+
+// primaryEndpoint returns the primary execution endpoint, which changes when failing over to a backup endpoint.
+func (s *Service) primaryEndpoint() network.Endpoint {
+	if s.cfg == nil {
+		return network.Endpoint{}
+	}
+	s.enginesLock.RLock()
+	defer s.enginesLock.RUnlock()
+	return s.cfg.currHttpEndpoint
+}
+
+// primaryURL returns the URL of the primary execution endpoint.
+func (s *Service) primaryURL() string {
+	return s.primaryEndpoint().Url
+}
+
+// primaryClient returns the client of the primary execution endpoint.
+func (s *Service) primaryClient() RPCClient {
+	s.enginesLock.RLock()
+	defer s.enginesLock.RUnlock()
+	return s.rpcClient
+}
+
+// engineClient returns the client of the execution endpoint with the given URL, if it is the primary
+// endpoint or a healthy backup one.
+func (s *Service) engineClient(url string) (RPCClient, bool) {
+	if url == s.primaryURL() {
+		return s.primaryClient(), true
+	}
+	for _, e := range s.backupEngines(false) {
+		if e.endpoint.Url == url {
+			return e.client, true
+		}
+	}
+	return nil, false
+}
+
+// ExecutionClientEndpoints returns the URLs of all the execution endpoints, the primary one first.
+func (s *Service) ExecutionClientEndpoints() []string {
+	s.enginesLock.RLock()
+	defer s.enginesLock.RUnlock()
+	urls := []string{s.cfg.currHttpEndpoint.Url}
+	for _, e := range s.engines {
+		urls = append(urls, e.endpoint.Url)
+	}
+	return urls
+}
+
+// Every engineHealthCheckPeriod, checks the health of the execution endpoints, and fails over
+// to a synced backup endpoint when the primary one is down or syncing.
+func (s *Service) monitorEngines(ctx context.Context) {
+	s.checkEngines(ctx)
+	ticker := time.NewTicker(engineHealthCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.checkEngines(ctx)
+		case <-ctx.Done():
+			s.enginesLock.Lock()
+			for _, e := range s.engines {
+				if e.client != nil {
+					e.client.Close()
+				}
+			}
+			s.enginesLock.Unlock()
+			return
+		}
+	}
+}
+
+func (s *Service) checkEngines(ctx context.Context) {
+	primary := logs.MaskCredentialsLogging(s.primaryURL())
+	primarySynced, err := engineSynced(ctx, s.primaryClient())
+	if err != nil {
+		log.WithError(err).WithField("endpoint", primary).Debug("Primary execution endpoint is unhealthy")
+	}
+	updateEngineMetrics(primary, true, err == nil, primarySynced)
+
+	var candidate *engine
+	for _, e := range s.engines {
+		client := e.client
+		if client == nil {
+			client, err = s.dialEngine(ctx, e.endpoint)
+			if err != nil {
+				log.WithError(err).WithField("endpoint", e.name()).Debug("Could not connect to backup execution endpoint")
+			}
+		}
+		synced := false
+		if client != nil {
+			synced, err = engineSynced(ctx, client)
+			if err != nil {
+				log.WithError(err).WithField("endpoint", e.name()).Debug("Backup execution endpoint is unhealthy")
+			}
+		}
+		s.enginesLock.Lock()
+		e.client = client
+		e.healthy = client != nil && err == nil
+		e.synced = e.healthy && synced
+		s.enginesLock.Unlock()
+		updateEngineMetrics(e.name(), false, e.healthy, e.synced)
+		if candidate == nil && e.synced {
+			candidate = e
+		}
+	}
+	if primarySynced || candidate == nil {
+		return
+	}
+	if err := s.failover(ctx, candidate); err != nil {
+		log.WithError(err).WithField("endpoint", candidate.name()).Error("Could not fail over to backup execution endpoint")
+	}
+}
+
+// failover makes the given backup endpoint the primary one, the primary endpoint becoming a backup.
+func (s *Service) failover(ctx context.Context, e *engine) error {
+	prev := s.primaryEndpoint()
+	prevClient := s.primaryClient()
+	if err := s.setupExecutionClientConnections(ctx, e.endpoint); err != nil {
+		return err
+	}
+	s.enginesLock.Lock()
+	s.cfg.currHttpEndpoint = e.endpoint
+	backupClient := e.client
+	e.endpoint = prev
+	// The client of the previous primary endpoint, on which engine API calls may still be in flight,
+	// is kept for the backup endpoint, which is checked again by the next health check.
+	e.client = prevClient
+	e.healthy, e.synced = false, false
+	s.enginesLock.Unlock()
+	// The new primary endpoint was dialed again, so its backup client is closed once the engine API
+	// calls in flight on it timed out.
+	if backupClient != nil {
+		time.AfterFunc(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue)*time.Second, backupClient.Close)
+	}
+
+	curr := logs.MaskCredentialsLogging(s.primaryURL())
+	updateEngineMetrics(curr, true, true, true)
+	updateEngineMetrics(e.name(), false, false, false)
+	executionFailoverCount.Inc()
+	log.WithFields(logrus.Fields{
+		"previousEndpoint": e.name(),
+		"endpoint":         curr,
+	}).Warn("Primary execution endpoint is unavailable or syncing, failed over to a backup endpoint")
+	return nil
+}
+
+// dialEngine connects to a backup execution endpoint, checking it follows the expected chain.
+func (s *Service) dialEngine(ctx context.Context, endpoint network.Endpoint) (RPCClient, error) {
+	client, err := s.newRPCClientWithAuth(ctx, endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not dial execution node")
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineTimeout)
+	defer cancel()
+	if err := ensureCorrectExecutionChain(ctx, ethclient.NewClient(client)); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+func updateEngineMetrics(name string, primary, healthy, synced bool) {
+	b := func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	executionEndpointPrimary.WithLabelValues(name).Set(b(primary))
+	executionEndpointUp.WithLabelValues(name).Set(b(healthy))
+	executionEndpointSynced.WithLabelValues(name).Set(b(synced))
+}
+
+// shouldFailover returns whether an engine API call failing with the given error could succeed with
+// another execution endpoint, as opposed to the payload being invalid.
+func shouldFailover(err error) bool {
+	return err != nil && !errors.Is(err, ErrInvalidPayloadStatus) && !errors.Is(err, ErrInvalidBlockHashPayloadStatus)
+}
+
+// enginePayloadID is the payload ID an execution endpoint returned for a forkchoice update.
+type enginePayloadID struct {
+	url string
+	id  [8]byte
+}
+
+// payloadIDs maps the payload IDs returned by ForkchoiceUpdated to the IDs returned by each execution
+// endpoint for the same forkchoice update, so that GetPayload can be served by any of them.
+type payloadIDs struct {
+	sync.Mutex
+	ids   map[[8]byte][]enginePayloadID
+	order [][8]byte
+}
+
+func (p *payloadIDs) add(id [8]byte, ids []enginePayloadID) {
+	p.Lock()
+	defer p.Unlock()
+	if p.ids == nil {
+		p.ids = make(map[[8]byte][]enginePayloadID)
+	}
+	if _, ok := p.ids[id]; !ok {
+		p.order = append(p.order, id)
+	}
+	p.ids[id] = ids
+	if len(p.order) > payloadIDsRetention {
+		delete(p.ids, p.order[0])
+		p.order = p.order[1:]
+	}
+}
+
+func (p *payloadIDs) get(id [8]byte) []enginePayloadID {
+	p.Lock()
+	defer p.Unlock()
+	return p.ids[id]
+}
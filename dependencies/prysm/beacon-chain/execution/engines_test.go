@@ -0,0 +1,193 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	payloadattribute "github.com/prysmaticlabs/prysm/v4/consensus-types/payload-attribute"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// testEngine is a fake execution endpoint answering the engine API calls sent during the tests.
+type testEngine struct {
+	sync.Mutex
+	srv       *httptest.Server
+	syncing   bool
+	down      bool
+	status    pb.PayloadStatus_Status
+	payloadID pb.PayloadIDBytes
+	calls     map[string]int
+}
+
+func newTestEngine(t *testing.T, payloadID byte) *testEngine {
+	e := &testEngine{status: pb.PayloadStatus_VALID, payloadID: pb.PayloadIDBytes{payloadID}, calls: make(map[string]int)}
+	fix := fixtures()
+	e.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		e.Lock()
+		defer e.Unlock()
+		if e.down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		e.calls[req.Method]++
+		var result interface{}
+		switch req.Method {
+		case syncingMethod:
+			result = e.syncing
+		case "eth_chainId":
+			result = hexutil.Uint64(params.BeaconConfig().DepositChainID)
+		case NewPayloadMethod:
+			result = &pb.PayloadStatus{Status: e.status, LatestValidHash: make([]byte, 32)}
+		case ForkchoiceUpdatedMethod:
+			id := e.payloadID
+			result = &ForkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: e.status, LatestValidHash: make([]byte, 32)}, PayloadId: &id}
+		case GetPayloadMethod:
+			var id pb.PayloadIDBytes
+			require.NoError(t, json.Unmarshal(req.Params[0], &id))
+			if id != e.payloadID {
+				writeRPCError(t, w, req.ID, -38001, "unknown payload")
+				return
+			}
+			result = fix["ExecutionPayload"]
+		default:
+			writeRPCError(t, w, req.ID, -32601, "method not found")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result}))
+	}))
+	t.Cleanup(e.srv.Close)
+	return e
+}
+
+func writeRPCError(t *testing.T, w http.ResponseWriter, id json.RawMessage, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": id, "error": map[string]interface{}{"code": code, "message": msg}}
+	require.NoError(t, json.NewEncoder(w).Encode(resp))
+}
+
+func (e *testEngine) set(f func(e *testEngine)) {
+	e.Lock()
+	defer e.Unlock()
+	f(e)
+}
+
+func (e *testEngine) callCount(method string) int {
+	e.Lock()
+	defer e.Unlock()
+	return e.calls[method]
+}
+
+// setupEngines returns a service using the primary endpoint, with the given backup endpoints.
+func setupEngines(t *testing.T, primary *testEngine, backups ...*testEngine) *Service {
+	client, err := rpc.DialHTTP(primary.srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	s := &Service{
+		cfg: &config{
+			currHttpEndpoint:       network.HttpEndpoint(primary.srv.URL),
+			beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{},
+		},
+		rpcClient: client,
+	}
+	for _, b := range backups {
+		bc, err := rpc.DialHTTP(b.srv.URL)
+		require.NoError(t, err)
+		t.Cleanup(bc.Close)
+		s.engines = append(s.engines, &engine{endpoint: network.HttpEndpoint(b.srv.URL), client: bc, healthy: true, synced: true})
+	}
+	return s
+}
+
+func TestNewPayload_FailsOverToBackup(t *testing.T) {
+	ctx := context.Background()
+	primary, backup := newTestEngine(t, 1), newTestEngine(t, 2)
+	primary.set(func(e *testEngine) { e.status = pb.PayloadStatus_SYNCING })
+	s := setupEngines(t, primary, backup)
+	payload, err := blocks.WrappedExecutionPayload(fixtures()["ExecutionPayload"].(*pb.ExecutionPayload))
+	require.NoError(t, err)
+
+	_, err = s.NewPayload(ctx, payload, []common.Hash{}, &common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.callCount(NewPayloadMethod))
+	assert.Equal(t, 1, backup.callCount(NewPayloadMethod))
+
+	// An invalid payload is not sent to the backup endpoints.
+	primary.set(func(e *testEngine) { e.status = pb.PayloadStatus_INVALID })
+	_, err = s.NewPayload(ctx, payload, []common.Hash{}, &common.Hash{})
+	require.ErrorIs(t, err, ErrInvalidPayloadStatus)
+	assert.Equal(t, 1, backup.callCount(NewPayloadMethod))
+}
+
+func TestForkchoiceUpdated_BackupsServeGetPayload(t *testing.T) {
+	ctx := context.Background()
+	primary, backup := newTestEngine(t, 1), newTestEngine(t, 2)
+	s := setupEngines(t, primary, backup)
+	attrs, err := payloadattribute.New(&pb.PayloadAttributes{})
+	require.NoError(t, err)
+
+	id, _, err := s.ForkchoiceUpdated(ctx, &pb.ForkchoiceState{}, attrs)
+	require.NoError(t, err)
+	assert.Equal(t, primary.payloadID, *id)
+	assert.Equal(t, 1, primary.callCount(ForkchoiceUpdatedMethod))
+	assert.Equal(t, 1, backup.callCount(ForkchoiceUpdatedMethod))
+
+	// The payload is built by the backup endpoint under its own payload ID.
+	primary.set(func(e *testEngine) { e.down = true })
+	_, _, _, err = s.GetPayload(ctx, *id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backup.callCount(GetPayloadMethod))
+
+	// The forkchoice update of the backup endpoint is used when the primary one is down.
+	id, _, err = s.ForkchoiceUpdated(ctx, &pb.ForkchoiceState{}, attrs)
+	require.NoError(t, err)
+	assert.Equal(t, backup.payloadID, *id)
+}
+
+func TestCheckEngines_Failover(t *testing.T) {
+	ctx := context.Background()
+	primary, backup := newTestEngine(t, 1), newTestEngine(t, 2)
+	s := setupEngines(t, primary, backup)
+	primaryClient := s.rpcClient
+
+	s.checkEngines(ctx)
+	assert.Equal(t, primary.srv.URL, s.ExecutionClientEndpoint())
+
+	// A syncing backup endpoint is not failed over to.
+	primary.set(func(e *testEngine) { e.syncing = true })
+	backup.set(func(e *testEngine) { e.syncing = true })
+	s.checkEngines(ctx)
+	assert.Equal(t, primary.srv.URL, s.ExecutionClientEndpoint())
+
+	backup.set(func(e *testEngine) { e.syncing = false })
+	s.checkEngines(ctx)
+	assert.Equal(t, backup.srv.URL, s.ExecutionClientEndpoint())
+	assert.DeepEqual(t, []string{backup.srv.URL, primary.srv.URL}, s.ExecutionClientEndpoints())
+	assert.Equal(t, true, s.ExecutionClientConnected())
+
+	// The previous primary endpoint is kept as a backup endpoint, with the same client.
+	s.checkEngines(ctx)
+	engines := s.backupEngines(false)
+	require.Equal(t, 1, len(engines))
+	assert.Equal(t, primary.srv.URL, engines[0].endpoint.Url)
+	assert.Equal(t, primaryClient, engines[0].client)
+	assert.Equal(t, false, engines[0].synced)
+}
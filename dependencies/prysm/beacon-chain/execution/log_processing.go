@@ -168,7 +168,7 @@ func (s *Service) ProcessDepositLog(ctx context.Context, depositLog *gethtypes.L
 	if err != nil {
 		return errors.Wrap(err, "unable to determine root of deposit trie")
 	}
-	err = s.cfg.depositCache.InsertDeposit(ctx, deposit, depositLog.BlockNumber, index, root)
+	err = s.cfg.depositCache.InsertDeposit(ctx, deposit, depositLog.BlockNumber, uint64(depositLog.Index), index, root)
 	if err != nil {
 		return errors.Wrap(err, "unable to insert deposit into cache")
 	}
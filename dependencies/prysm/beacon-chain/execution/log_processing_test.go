@@ -92,6 +92,11 @@ func TestProcessDepositLog_OK(t *testing.T) {
 	require.LogsDoNotContain(t, hook, "deposit merkle branch of deposit root did not verify for root")
 	require.LogsContain(t, hook, "Deposit registered from deposit contract")
 
+	ctrs := depositCache.AllDepositContainers(context.Background())
+	require.Equal(t, 1, len(ctrs))
+	assert.Equal(t, logs[0].BlockNumber, ctrs[0].Eth1BlockHeight)
+	assert.Equal(t, uint64(logs[0].Index), ctrs[0].LogIndex)
+
 	hook.Reset()
 }
 
@@ -71,4 +71,24 @@ var (
 		Name: "execution_payload_bodies_count",
 		Help: "The number of requested payload bodies is too large",
 	})
+	executionEndpointPrimary = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_endpoint_primary",
+		Help: "Whether the execution endpoint is the primary one (1) or a backup (0)",
+	}, []string{"endpoint"})
+	executionEndpointUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_endpoint_up",
+		Help: "Whether the execution endpoint is reachable (1) or not (0)",
+	}, []string{"endpoint"})
+	executionEndpointSynced = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_endpoint_synced",
+		Help: "Whether the execution endpoint is synced (1) or not (0)",
+	}, []string{"endpoint"})
+	executionFailoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "execution_endpoint_failover_count",
+		Help: "The number of times a backup execution endpoint became the primary one",
+	})
+	engineCallFailoverCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "execution_engine_call_failover_count",
+		Help: "The number of engine API calls served by a backup execution endpoint after the primary one failed",
+	}, []string{"method"})
 )
@@ -37,6 +37,20 @@ func WithHttpEndpointAndJWTSecret(endpointString string, secret []byte) Option {
 	}
 }
 
+// WithBackupHttpEndpoint adds an execution endpoint to fail over to when the primary one is down or syncing.
+// The secret, if any, is used to authenticate the requests to the endpoint.
+func WithBackupHttpEndpoint(endpointString string, secret []byte) Option {
+	return func(s *Service) error {
+		hEndpoint := network.HttpEndpoint(endpointString)
+		if len(secret) > 0 {
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+		}
+		s.engines = append(s.engines, &engine{endpoint: hEndpoint})
+		return nil
+	}
+}
+
 // WithHeaders adds headers to the execution node JSON-RPC requests.
 func WithHeaders(headers []string) Option {
 	return func(s *Service) error {
@@ -24,7 +24,9 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 	}
 	// Attach the clients to the service struct.
 	fetcher := ethclient.NewClient(client)
+	s.enginesLock.Lock()
 	s.rpcClient = client
+	s.enginesLock.Unlock()
 	s.httpLogger = fetcher
 
 	depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, fetcher)
@@ -67,9 +69,9 @@ func (s *Service) pollConnectionStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
-			currClient := s.rpcClient
-			if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(s.primaryURL()))
+			currClient := s.primaryClient()
+			if err := s.setupExecutionClientConnections(ctx, s.primaryEndpoint()); err != nil {
 				errorLogger(err, "Could not connect to execution client endpoint")
 				continue
 			}
@@ -77,7 +79,7 @@ func (s *Service) pollConnectionStatus(ctx context.Context) {
 			if currClient != nil {
 				currClient.Close()
 			}
-			log.Infof("Connected to new endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
+			log.Infof("Connected to new endpoint: %s", logs.MaskCredentialsLogging(s.primaryURL()))
 			return
 		case <-s.ctx.Done():
 			log.Debug("Received cancelled context,closing existing powchain service")
@@ -92,8 +94,8 @@ func (s *Service) retryExecutionClientConnection(ctx context.Context, err error)
 	s.updateConnectedETH1(false)
 	// Back off for a while before redialing.
 	time.Sleep(backOffPeriod)
-	currClient := s.rpcClient
-	if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+	currClient := s.primaryClient()
+	if err := s.setupExecutionClientConnections(ctx, s.primaryEndpoint()); err != nil {
 		s.runError = errors.Wrap(err, "setupExecutionClientConnections")
 		return
 	}
@@ -64,6 +64,8 @@ var (
 	logThreshold = 8
 	// period to log chainstart related information
 	logPeriod = 1 * time.Minute
+	// period to check the health of the execution endpoints, when backup endpoints are configured.
+	engineHealthCheckPeriod = 6 * time.Second
 )
 
 // ChainStartFetcher retrieves information pertaining to the chain start event
@@ -79,6 +81,7 @@ type ChainInfoFetcher interface {
 	GenesisExecutionChainInfo() (uint64, *big.Int)
 	ExecutionClientConnected() bool
 	ExecutionClientEndpoint() string
+	ExecutionClientEndpoints() []string
 	ExecutionClientConnectionErr() error
 }
 
@@ -147,7 +150,7 @@ type Service struct {
 	cancel                  context.CancelFunc
 	eth1HeadTicker          *time.Ticker
 	httpLogger              bind.ContractFilterer
-	rpcClient               RPCClient
+	rpcClient               RPCClient    // guarded by enginesLock, along with cfg.currHttpEndpoint.
 	headerCache             *headerCache // cache to store block hash/block height.
 	latestEth1Data          *ethpb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
@@ -156,6 +159,9 @@ type Service struct {
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         state.BeaconState
+	engines                 []*engine // backup execution endpoints.
+	enginesLock             sync.RWMutex
+	payloadIDs              payloadIDs
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -239,6 +245,10 @@ func (s *Service) Start() {
 
 	s.isRunning = true
 
+	if len(s.engines) > 0 {
+		go s.monitorEngines(s.ctx)
+	}
+
 	// Poll the execution client connection and fallback if errors occur.
 	s.pollConnectionStatus(s.ctx)
 
@@ -250,8 +260,8 @@ func (s *Service) Stop() error {
 	if s.cancel != nil {
 		defer s.cancel()
 	}
-	if s.rpcClient != nil {
-		s.rpcClient.Close()
+	if client := s.primaryClient(); client != nil {
+		client.Close()
 	}
 	return nil
 }
@@ -290,7 +300,7 @@ func (s *Service) ExecutionClientConnected() bool {
 
 // ExecutionClientEndpoint returns the URL of the current, connected execution client.
 func (s *Service) ExecutionClientEndpoint() string {
-	return s.cfg.currHttpEndpoint.Url
+	return s.primaryURL()
 }
 
 // ExecutionClientConnectionErr returns the error (if any) of the current connection.
@@ -380,10 +390,10 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositCo
 		}
 	}
 	validDepositsCount.Add(float64(currIndex))
-	// Only add pending deposits if the container slice length
-	// is more than the current index in state.
-	if uint64(len(ctrs)) > currIndex {
-		for _, c := range ctrs[currIndex:] {
+	// Only add pending deposits with an index equal
+	// to or greater than the current index in state.
+	for _, c := range ctrs {
+		if uint64(c.Index) >= currIndex {
 			s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
 		}
 	}
@@ -428,7 +438,7 @@ func (s *Service) batchRequestHeaders(startBlock, endBlock uint64) ([]*types.Hea
 		})
 		headers = append(headers, header)
 	}
-	ioErr := s.rpcClient.BatchCall(elems)
+	ioErr := s.primaryClient().BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
 	}
@@ -592,7 +602,7 @@ func (s *Service) run(done <-chan struct{}) {
 		case <-done:
 			s.isRunning = false
 			s.runError = nil
-			s.rpcClient.Close()
+			s.primaryClient().Close()
 			s.updateConnectedETH1(false)
 			log.Debug("Context closed, exiting goroutine")
 			return
@@ -788,15 +798,43 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 	}
 	numOfItems := s.depositTrie.NumOfItems()
 	s.lastReceivedMerkleIndex = int64(numOfItems - 1)
+	if features.Get().EnableEIP4881 && eth1DataInDB.DepositSnapshot != nil {
+		if err := s.initFinalizedDepositsFromSnapshot(ctx, eth1DataInDB); err != nil {
+			return errors.Wrap(err, "could not initialize finalized deposits from snapshot")
+		}
+	}
 	if err := s.initDepositCaches(ctx, eth1DataInDB.DepositContainers); err != nil {
 		return errors.Wrap(err, "could not initialize caches")
 	}
 	return nil
 }
 
+// initFinalizedDepositsFromSnapshot seeds the finalized deposits of the deposit cache with the
+// persisted deposit snapshot when the node does not hold the deposits covered by it. This is the
+// case for a node which was initialized from a deposit snapshot served by another beacon node.
+func (s *Service) initFinalizedDepositsFromSnapshot(ctx context.Context, eth1DataInDB *ethpb.ETH1ChainData) error {
+	for _, c := range eth1DataInDB.DepositContainers {
+		if c.Index == 0 {
+			// All deposits are available, the finalized deposits are
+			// rebuilt from them when initializing the caches.
+			return nil
+		}
+	}
+	dc, ok := s.cfg.depositCache.(*depositsnapshot.Cache)
+	if !ok {
+		return errors.Errorf("wrong deposit cache initialized: %T", s.cfg.depositCache)
+	}
+	tree, err := depositsnapshot.DepositTreeFromSnapshotProto(eth1DataInDB.DepositSnapshot)
+	if err != nil {
+		return err
+	}
+	return dc.InsertFinalizedDepositTree(ctx, tree)
+}
+
 // Validates that all deposit containers are valid and have their relevant indices
-// in order.
-func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
+// in order. Deposit containers may only start at a non-zero index if the deposits
+// preceding them are covered by the finalized deposit snapshot.
+func validateDepositContainers(ctrs []*ethpb.DepositContainer, snapshotDepositCount uint64) bool {
 	ctrLen := len(ctrs)
 	// Exit for empty containers.
 	if ctrLen == 0 {
@@ -807,6 +845,9 @@ func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
 		return ctrs[i].Index < ctrs[j].Index
 	})
 	startIndex := int64(0)
+	if ctrs[0].Index > 0 && uint64(ctrs[0].Index) <= snapshotDepositCount {
+		startIndex = ctrs[0].Index
+	}
 	for _, c := range ctrs {
 		if c.Index != startIndex {
 			log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
@@ -831,7 +872,7 @@ func (s *Service) validPowchainData(ctx context.Context) (*ethpb.ETH1ChainData,
 	if genState == nil || genState.IsNil() {
 		return eth1Data, nil
 	}
-	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers) {
+	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers, eth1Data.DepositSnapshot.GetDepositCount()) {
 		pbState, err := native.ProtobufBeaconStatePhase0(s.preGenesisState.ToProtoUnsafe())
 		if err != nil {
 			return nil, err
@@ -16,11 +16,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	dbutil "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/types"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
 	contracts "github.com/prysmaticlabs/prysm/v4/contracts/deposit"
@@ -650,9 +652,10 @@ func TestService_EnsureValidPowchainData(t *testing.T) {
 
 func TestService_ValidateDepositContainers(t *testing.T) {
 	var tt = []struct {
-		name        string
-		ctrsFunc    func() []*ethpb.DepositContainer
-		expectedRes bool
+		name          string
+		ctrsFunc      func() []*ethpb.DepositContainer
+		snapshotCount uint64
+		expectedRes   bool
 	}{
 		{
 			name: "zero containers",
@@ -683,6 +686,30 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 			},
 			expectedRes: false,
 		},
+		{
+			name: "containers following snapshot",
+			ctrsFunc: func() []*ethpb.DepositContainer {
+				ctrs := make([]*ethpb.DepositContainer, 0)
+				for i := 5; i < 10; i++ {
+					ctrs = append(ctrs, &ethpb.DepositContainer{Index: int64(i), Eth1BlockHeight: uint64(i + 10)})
+				}
+				return ctrs
+			},
+			snapshotCount: 5,
+			expectedRes:   true,
+		},
+		{
+			name: "gap between snapshot and containers",
+			ctrsFunc: func() []*ethpb.DepositContainer {
+				ctrs := make([]*ethpb.DepositContainer, 0)
+				for i := 6; i < 10; i++ {
+					ctrs = append(ctrs, &ethpb.DepositContainer{Index: int64(i), Eth1BlockHeight: uint64(i + 10)})
+				}
+				return ctrs
+			},
+			snapshotCount: 5,
+			expectedRes:   false,
+		},
 		{
 			name: "skipped containers",
 			ctrsFunc: func() []*ethpb.DepositContainer {
@@ -700,7 +727,7 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 	}
 
 	for _, test := range tt {
-		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc()))
+		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc(), test.snapshotCount))
 	}
 }
 
@@ -853,3 +880,91 @@ func TestService_migrateOldDepositTree(t *testing.T) {
 	require.NoError(t, err)
 	require.DeepEqual(t, oldDepositTreeRoot, newDepositTreeRoot)
 }
+
+func TestService_InitializeFromDepositSnapshot(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableEIP4881: true})
+	defer resetCfg()
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+
+	depositContainer := func(i int) *ethpb.DepositContainer {
+		return &ethpb.DepositContainer{
+			Index:           int64(i),
+			Eth1BlockHeight: uint64(100 + i),
+			Deposit: &ethpb.Deposit{
+				Data: &ethpb.Deposit_Data{
+					PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+					WithdrawalCredentials: make([]byte, 32),
+					Signature:             make([]byte, 96),
+				},
+			},
+		}
+	}
+	// The snapshot covers the first 10 deposits, which are not stored individually.
+	tree := depositsnapshot.NewDepositTree()
+	for i := 0; i < 12; i++ {
+		root, err := depositContainer(i).Deposit.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(root[:], i))
+		if i == 9 {
+			require.NoError(t, tree.Finalize(9, common.Hash{'a'}, 109))
+		}
+	}
+	fullRoot, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+	snapshotTree := depositsnapshot.NewDepositTree()
+	for i := 0; i < 10; i++ {
+		root, err := depositContainer(i).Deposit.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, snapshotTree.Insert(root[:], i))
+	}
+	require.NoError(t, snapshotTree.Finalize(9, common.Hash{'a'}, 109))
+	snapshot, err := snapshotTree.ToProto()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveExecutionChainData(ctx, &ethpb.ETH1ChainData{
+		CurrentEth1Data: &ethpb.LatestETH1Data{
+			BlockHeight:        109,
+			LastRequestedBlock: 109,
+		},
+		ChainstartData: &ethpb.ChainStartData{
+			Chainstarted: true,
+			Eth1Data:     &ethpb.Eth1Data{},
+		},
+		DepositContainers: []*ethpb.DepositContainer{depositContainer(10), depositContainer(11)},
+		DepositSnapshot:   snapshot,
+	}))
+	genesisState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	genesisRoot := [32]byte{'g'}
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, genesisState, genesisRoot))
+
+	depositCache, err := depositsnapshot.New()
+	require.NoError(t, err)
+	srv, endpoint, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		srv.Stop()
+	})
+	s, err := NewService(ctx,
+		WithHttpEndpoint(endpoint),
+		WithDatabase(beaconDB),
+		WithDepositCache(depositCache),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(11), s.lastReceivedMerkleIndex)
+	assert.Equal(t, uint64(109), s.latestEth1Data.LastRequestedBlock)
+	trieRoot, err := s.depositTrie.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, fullRoot, trieRoot)
+
+	fd, err := depositCache.FinalizedDeposits(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), fd.MerkleTrieIndex())
+	finalizedRoot, err := fd.Deposits().HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, snapshot.DepositRoot, finalizedRoot[:])
+	assert.Equal(t, 2, len(depositCache.AllDepositContainers(ctx)))
+	assert.Equal(t, 2, len(depositCache.PendingContainers(ctx, nil)))
+}
@@ -127,6 +127,10 @@ func (m *Chain) ExecutionClientEndpoint() string {
 	return m.CurrEndpoint
 }
 
+func (m *Chain) ExecutionClientEndpoints() []string {
+	return m.Endpoints
+}
+
 func (m *Chain) ExecutionClientConnectionErr() error {
 	return m.CurrError
 }
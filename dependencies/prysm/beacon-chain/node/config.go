@@ -74,6 +74,13 @@ func configureBuilderCircuitBreaker(cliCtx *cli.Context) error {
 			return err
 		}
 	}
+	if cliCtx.IsSet(flags.LocalBlockEpochsAfterStall.Name) {
+		c := params.BeaconConfig().Copy()
+		c.LocalBlockEpochsAfterStall = primitives.Epoch(cliCtx.Uint64(flags.LocalBlockEpochsAfterStall.Name))
+		if err := params.SetActive(c); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -44,6 +44,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -54,6 +55,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/genesis"
 	initialsync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/transfers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/validatorhistory"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
@@ -305,6 +308,16 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		return nil, err
 	}
 
+	log.Debugln("Registering Validator History Service")
+	if err := beacon.registerValidatorHistoryService(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Transfers Service")
+	if err := beacon.registerTransfersService(); err != nil {
+		return nil, err
+	}
+
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -547,6 +560,9 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs coverage.AvailableBlocker, fc forkchoice.ForkChoicer) error {
 	opts := []stategen.Option{stategen.WithAvailableBlocker(bfs)}
+	if b.cliCtx.Bool(flags.ArchiveStateDiffs.Name) {
+		opts = append(opts, stategen.WithStateDiffs())
+	}
 	sg := stategen.New(b.db, fc, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -793,6 +809,14 @@ func (b *BeaconNode) registerSlasherService() error {
 	if err := b.services.FetchService(&syncService); err != nil {
 		return err
 	}
+	var backfill *slasher.BackfillConfig
+	if b.cliCtx.IsSet(flags.SlasherBackfillStartEpoch.Name) {
+		backfill = &slasher.BackfillConfig{
+			StartEpoch: primitives.Epoch(b.cliCtx.Uint64(flags.SlasherBackfillStartEpoch.Name)),
+			EndEpoch:   primitives.Epoch(b.cliCtx.Uint64(flags.SlasherBackfillEndEpoch.Name)),
+			BatchSize:  b.cliCtx.Uint64(flags.SlasherBackfillBatchSize.Name),
+		}
+	}
 
 	slasherSrv, err := slasher.New(b.ctx, &slasher.ServiceConfig{
 		IndexedAttestationsFeed: b.slasherAttestationsFeed,
@@ -805,6 +829,8 @@ func (b *BeaconNode) registerSlasherService() error {
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
+		BeaconDB:                b.db,
+		Backfill:                backfill,
 	})
 	if err != nil {
 		return err
@@ -1038,6 +1064,57 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 	return b.services.RegisterService(svc)
 }
 
+func (b *BeaconNode) registerValidatorHistoryService() error {
+	cliSlice := b.cliCtx.IntSlice(flags.ValidatorHistoryIndices.Name)
+	if cliSlice == nil {
+		return nil
+	}
+	tracked := make([]primitives.ValidatorIndex, len(cliSlice))
+	for i := range tracked {
+		tracked[i] = primitives.ValidatorIndex(cliSlice[i])
+	}
+
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	ch := stategen.NewCanonicalHistory(b.db, chainService, chainService)
+	svc, err := validatorhistory.NewService(b.ctx, &validatorhistory.Config{
+		BeaconDB:           b.db,
+		StateNotifier:      b,
+		ReplayerBuilder:    ch,
+		BlockRewardFetcher: &rewards.BlockRewardService{Replayer: ch},
+		TrackedValidators:  tracked,
+	})
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerTransfersService() error {
+	if !b.cliCtx.Bool(flags.IndexDepositsWithdrawals.Name) {
+		return nil
+	}
+
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	var web3Service *execution.Service
+	if err := b.services.FetchService(&web3Service); err != nil {
+		return err
+	}
+	svc := transfers.NewService(b.ctx, &transfers.Config{
+		BeaconDB:                      b.db,
+		StateNotifier:                 b,
+		HeadFetcher:                   chainService,
+		DepositFetcher:                b.depositCache,
+		ExecutionPayloadReconstructor: web3Service,
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerBuilderService(cliCtx *cli.Context) error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
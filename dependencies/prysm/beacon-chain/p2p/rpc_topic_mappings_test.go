@@ -23,6 +23,11 @@ func TestVerifyRPCMappings(t *testing.T) {
 	assert.NotNil(t, VerifyTopicMapping(RPCStatusTopicV1, new([]byte)), "Incorrect message type verified for metadata rpc topic")
 
 	assert.NoError(t, VerifyTopicMapping(RPCBlocksByRootTopicV1, new(types.BeaconBlockByRootsReq)), "Failed to verify blocks by root rpc topic")
+
+	assert.NoError(t, VerifyTopicMapping(RPCLightClientBootstrapTopicV1, new(types.LightClientBootstrapReq)), "Failed to verify light client bootstrap rpc topic")
+	assert.NoError(t, VerifyTopicMapping(RPCLightClientUpdatesByRangeTopicV1, new(pb.LightClientUpdatesByRangeRequest)), "Failed to verify light client updates by range rpc topic")
+	assert.NoError(t, VerifyTopicMapping(RPCLightClientFinalityUpdateTopicV1, new(interface{})), "Failed to verify light client finality update rpc topic")
+	assert.NoError(t, VerifyTopicMapping(RPCLightClientOptimisticUpdateTopicV1, new(interface{})), "Failed to verify light client optimistic update rpc topic")
 }
 
 func TestTopicDeconstructor(t *testing.T) {
@@ -57,6 +62,12 @@ func TestTopicDeconstructor(t *testing.T) {
 			expectedError: "",
 			output:        []string{protocolPrefix, BeaconBlocksByRangeMessageName, SchemaVersionV1},
 		},
+		{
+			name:          "valid light client updates by range topic",
+			topic:         protocolPrefix + LightClientUpdatesByRangeName + SchemaVersionV1 + "/ssz_snappy",
+			expectedError: "",
+			output:        []string{protocolPrefix, LightClientUpdatesByRangeName, SchemaVersionV1},
+		},
 		{
 			name:          "beacon block by range topic with malformed version",
 			topic:         protocolPrefix + BeaconBlocksByRangeMessageName + "/v" + "/ssz_snappy",
@@ -112,6 +112,7 @@ func TestErrorResponse_Limit(t *testing.T) {
 func TestRoundTripSerialization(t *testing.T) {
 	roundTripTestBlocksByRootReq(t)
 	roundTripTestErrorMessage(t)
+	roundTripTestLightClientBootstrapReq(t)
 }
 
 func roundTripTestBlocksByRootReq(t *testing.T) {
@@ -142,6 +143,24 @@ func roundTripTestErrorMessage(t *testing.T) {
 	assert.DeepEqual(t, []byte(newVal), errMsg)
 }
 
+func roundTripTestLightClientBootstrapReq(t *testing.T) {
+	req := LightClientBootstrapReq{'a', 'b', 'c'}
+
+	marshalledObj, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, rootLength, len(marshalledObj))
+	newVal := LightClientBootstrapReq{}
+
+	require.NoError(t, newVal.UnmarshalSSZ(marshalledObj))
+	assert.DeepEqual(t, req, newVal)
+}
+
+func TestLightClientBootstrapReq_UnmarshalSSZ(t *testing.T) {
+	req := LightClientBootstrapReq{}
+	require.ErrorIs(t, req.UnmarshalSSZ(make([]byte, rootLength+1)), ssz.ErrIncorrectByteSize)
+	require.ErrorIs(t, req.UnmarshalSSZ(make([]byte, rootLength-1)), ssz.ErrIncorrectByteSize)
+}
+
 func TestSSZBytes_HashTreeRoot(t *testing.T) {
 	tests := []struct {
 		name        string
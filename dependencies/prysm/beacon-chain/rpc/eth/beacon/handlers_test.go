@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -19,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v4/api"
 	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
@@ -3617,3 +3619,67 @@ func TestGetGenesis(t *testing.T) {
 		assert.StringContains(t, "Chain genesis info is not yet known", e.Message)
 	})
 }
+
+func TestGetDepositSnapshot(t *testing.T) {
+	beaconDB := dbTest.SetupDB(t)
+	mockTrie := depositsnapshot.NewDepositTree()
+	require.NoError(t, beaconDB.SaveExecutionChainData(context.Background(), &eth.ETH1ChainData{DepositSnapshot: nil}))
+	s := Server{
+		BeaconDB: beaconDB,
+	}
+
+	t.Run("no snapshot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusNotFound, e.Code)
+		assert.StringContains(t, "No finalized snapshot available", e.Message)
+	})
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, mockTrie.Insert(bytesutil.PadTo([]byte{byte(i)}, 32), i))
+	}
+	executionHash := bytesutil.PadTo([]byte("execution"), 32)
+	require.NoError(t, mockTrie.Finalize(9, common.BytesToHash(executionHash), 100))
+	snapshot, err := mockTrie.ToProto()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveExecutionChainData(context.Background(), &eth.ETH1ChainData{DepositSnapshot: snapshot}))
+
+	t.Run("JSON response", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetDepositSnapshotResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+
+		assert.Equal(t, hexutil.Encode(snapshot.DepositRoot), resp.Data.DepositRoot)
+		assert.Equal(t, hexutil.Encode(snapshot.ExecutionHash), resp.Data.ExecutionBlockHash)
+		assert.Equal(t, "10", resp.Data.DepositCount)
+		assert.Equal(t, "100", resp.Data.ExecutionBlockHeight)
+		require.Equal(t, len(snapshot.Finalized), len(resp.Data.Finalized))
+		for i, f := range snapshot.Finalized {
+			assert.Equal(t, hexutil.Encode(f), resp.Data.Finalized[i])
+		}
+	})
+	t.Run("SSZ response", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &eth.DepositSnapshot{}
+		require.NoError(t, resp.UnmarshalSSZ(writer.Body.Bytes()))
+		assert.DeepSSZEqual(t, snapshot, resp)
+	})
+}
@@ -66,6 +66,18 @@ type Genesis struct {
 	GenesisForkVersion    string `json:"genesis_fork_version"`
 }
 
+type GetDepositSnapshotResponse struct {
+	Data *DepositSnapshot `json:"data"`
+}
+
+type DepositSnapshot struct {
+	Finalized            []string `json:"finalized"`
+	DepositRoot          string   `json:"deposit_root"`
+	DepositCount         string   `json:"deposit_count"`
+	ExecutionBlockHash   string   `json:"execution_block_hash"`
+	ExecutionBlockHeight string   `json:"execution_block_height"`
+}
+
 type GetBlockHeadersResponse struct {
 	Data                []*shared.SignedBeaconBlockHeaderContainer `json:"data"`
 	ExecutionOptimistic bool                                       `json:"execution_optimistic"`
@@ -13,10 +13,8 @@ import (
 	"github.com/wealdtech/go-bytesutil"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
-	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
-	types "github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/network/httputil"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 )
@@ -27,35 +25,41 @@ func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, req *http.Reques
 	ctx, span := trace.StartSpan(req.Context(), "beacon.GetLightClientBootstrap")
 	defer span.End()
 
-	// Get the block
 	blockRootParam, err := hexutil.Decode(mux.Vars(req)["block_root"])
 	if err != nil {
 		httputil.HandleError(w, "invalid block root: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	// Bootstraps are persisted for the finalized checkpoint roots at startup and as blocks are imported
 	blockRoot := bytesutil.ToBytes32(blockRootParam)
-	blk, err := s.Blocker.Block(ctx, blockRoot[:])
-	if !shared.WriteBlockFetchError(w, blk, err) {
+	bootstrap, err := s.BeaconDB.LightClientBootstrap(ctx, blockRoot)
+	if err != nil {
+		httputil.HandleError(w, "could not get light client bootstrap: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	// Get the state
-	state, err := s.Stater.StateBySlot(ctx, blk.Block().Slot())
-	if err != nil {
-		httputil.HandleError(w, "could not get state: "+err.Error(), http.StatusInternalServerError)
+	if bootstrap == nil {
+		// Bootstraps which were not persisted, e.g. before the node was upgraded, are rebuilt from the state of the block
+		bootstrap, err = s.lightClientBootstrapFromState(ctx, blockRoot)
+		if err != nil {
+			httputil.HandleError(w, "could not get light client bootstrap: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if bootstrap == nil {
+		httputil.HandleError(w, "light client bootstrap not found", http.StatusNotFound)
 		return
 	}
 
-	bootstrap, err := createLightClientBootstrap(ctx, state)
+	v, err := versionAtSlot(bootstrap.Header.Slot)
 	if err != nil {
-		httputil.HandleError(w, "could not get light client bootstrap: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "could not get light client bootstrap version: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	response := &LightClientBootstrapResponse{
-		Version: version.String(blk.Version()),
-		Data:    bootstrap,
+		Version: v,
+		Data:    lightClientBootstrapToJSON(bootstrap),
 	}
 
 	httputil.WriteJson(w, response)
@@ -67,9 +71,7 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 	ctx, span := trace.StartSpan(req.Context(), "beacon.GetLightClientUpdatesByRange")
 	defer span.End()
 
-	// Determine slots per period
 	config := params.BeaconConfig()
-	slotsPerPeriod := uint64(config.EpochsPerSyncCommitteePeriod) * uint64(config.SlotsPerEpoch)
 
 	// Adjust count based on configuration
 	_, count, gotCount := shared.UintFromQuery(w, req, "count", true)
@@ -89,130 +91,41 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 	if count > config.MaxRequestLightClientUpdates {
 		count = config.MaxRequestLightClientUpdates
 	}
+	endPeriod := startPeriod + count - 1
 
-	// max possible slot is current head
-	headState, err := s.HeadFetcher.HeadState(ctx)
+	// The best update of each period is persisted as blocks are imported
+	stored, err := s.BeaconDB.LightClientUpdates(ctx, startPeriod, endPeriod)
 	if err != nil {
-		httputil.HandleError(w, "could not get head state: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "could not get light client updates: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	maxSlot := uint64(headState.Slot())
-
-	// min possible slot is Altair fork period
-	minSlot := uint64(config.AltairForkEpoch) * uint64(config.SlotsPerEpoch)
-
-	// Adjust startPeriod, the end of start period must be later than Altair fork epoch, otherwise, can not get the sync committee votes
-	startPeriodEndSlot := (startPeriod+1)*slotsPerPeriod - 1
-	if startPeriodEndSlot < minSlot {
-		startPeriod = minSlot / slotsPerPeriod
-	}
-
-	// Get the initial endPeriod, then we will adjust
-	endPeriod := startPeriod + count - 1
-
-	// Adjust endPeriod, the end of end period must be earlier than current head slot
-	endPeriodEndSlot := (endPeriod+1)*slotsPerPeriod - 1
-	if endPeriodEndSlot > maxSlot {
-		endPeriod = maxSlot / slotsPerPeriod
-	}
-
 	// Populate updates
 	var updates []*LightClientUpdateWithVersion
 	for period := startPeriod; period <= endPeriod; period++ {
-		// Get the last known state of the period,
-		//    1. We wish the block has a parent in the same period if possible
-		//	  2. We wish the block has a state in the same period
-		lastSlotInPeriod := period*slotsPerPeriod + slotsPerPeriod - 1
-		if lastSlotInPeriod > maxSlot {
-			lastSlotInPeriod = maxSlot
-		}
-		firstSlotInPeriod := period * slotsPerPeriod
-
-		// Let's not use the first slot in the period, otherwise the attested header will be in previous period
-		firstSlotInPeriod++
-
-		var state state.BeaconState
-		var block interfaces.ReadOnlySignedBeaconBlock
-		for slot := lastSlotInPeriod; slot >= firstSlotInPeriod; slot-- {
-			state, err = s.Stater.StateBySlot(ctx, types.Slot(slot))
-			if err != nil {
-				continue
-			}
-
-			// Get the block
-			latestBlockHeader := state.LatestBlockHeader()
-			latestStateRoot, err := state.HashTreeRoot(ctx)
-			if err != nil {
-				continue
-			}
-			latestBlockHeader.StateRoot = latestStateRoot[:]
-			blockRoot, err := latestBlockHeader.HashTreeRoot()
+		update, ok := stored[period]
+		if !ok {
+			// Updates which were not persisted, e.g. before the node was upgraded, are rebuilt from the states of the period
+			update, err = s.lightClientUpdateFromStates(ctx, period)
 			if err != nil {
-				continue
-			}
-
-			block, err = s.Blocker.Block(ctx, blockRoot[:])
-			if err != nil || block == nil {
-				continue
+				httputil.HandleError(w, "could not get light client update: "+err.Error(), http.StatusInternalServerError)
+				return
 			}
-
-			syncAggregate, err := block.Block().Body().SyncAggregate()
-			if err != nil || syncAggregate == nil {
-				continue
-			}
-
-			if syncAggregate.SyncCommitteeBits.Count()*3 < config.SyncCommitteeSize*2 {
-				// Not enough votes
-				continue
-			}
-
-			break
 		}
-
-		if block == nil {
-			// No valid block found for the period
-			continue
-		}
-
-		// Get attested state
-		attestedRoot := block.Block().ParentRoot()
-		attestedBlock, err := s.Blocker.Block(ctx, attestedRoot[:])
-		if err != nil || attestedBlock == nil {
+		if update == nil {
+			// No update is known for the period
 			continue
 		}
 
-		attestedSlot := attestedBlock.Block().Slot()
-		attestedState, err := s.Stater.StateBySlot(ctx, attestedSlot)
+		v, err := versionAtSlot(update.AttestedHeader.Slot)
 		if err != nil {
-			continue
-		}
-
-		// Get finalized block
-		var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
-		finalizedCheckPoint := attestedState.FinalizedCheckpoint()
-		if finalizedCheckPoint != nil {
-			finalizedRoot := bytesutil.ToBytes32(finalizedCheckPoint.Root)
-			finalizedBlock, err = s.Blocker.Block(ctx, finalizedRoot[:])
-			if err != nil {
-				finalizedBlock = nil
-			}
-		}
-
-		update, err := createLightClientUpdate(
-			ctx,
-			state,
-			block,
-			attestedState,
-			finalizedBlock,
-		)
-
-		if err == nil {
-			updates = append(updates, &LightClientUpdateWithVersion{
-				Version: version.String(attestedState.Version()),
-				Data:    update,
-			})
+			httputil.HandleError(w, "could not get light client update version: "+err.Error(), http.StatusInternalServerError)
+			return
 		}
+		updates = append(updates, &LightClientUpdateWithVersion{
+			Version: v,
+			Data:    newLightClientUpdateToJSON(update),
+		})
 	}
 
 	if len(updates) == 0 {
@@ -12,22 +12,78 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
 
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
+func lightClientTestSyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func lightClientTestBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func lightClientTestUpdate(attestedSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader: &ethpbv1.BeaconBlockHeader{
+			Slot:       attestedSlot,
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   bytesutil.PadTo(bytesutil.SlotToBytesBigEndian(attestedSlot), fieldparams.RootLength),
+		},
+		NextSyncCommittee:       lightClientTestSyncCommittee(),
+		NextSyncCommitteeBranch: lightClientTestBranch(fieldparams.NextSyncCommitteeBranchDepth),
+		FinalizedHeader: &ethpbv1.BeaconBlockHeader{
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		},
+		FinalityBranch: lightClientTestBranch(6),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		SignatureSlot: attestedSlot + 1,
+	}
+}
+
+func slotsPerSyncCommitteePeriod() primitives.Slot {
+	config := params.BeaconConfig()
+	return primitives.Slot(uint64(config.EpochsPerSyncCommitteePeriod) * uint64(config.SlotsPerEpoch))
+}
+
 func TestLightClientHandler_GetLightClientBootstrap(t *testing.T) {
 	helpers.ClearCache()
+	ctx := context.Background()
 	slot := primitives.Slot(params.BeaconConfig().AltairForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
 
 	b := util.NewBeaconBlockCapella()
@@ -52,14 +108,13 @@ func TestLightClientHandler_GetLightClientBootstrap(t *testing.T) {
 	require.NoError(t, bs.SetSlot(slot))
 	require.NoError(t, bs.SetLatestBlockHeader(header.Header))
 
-	mockBlocker := &testutil.MockBlocker{BlockToReturn: signedBlock}
-	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot}
+	bootstrap, err := blockchain.NewLightClientBootstrapFromBeaconState(ctx, bs)
+	require.NoError(t, err)
+	beaconDB := dbtest.SetupDB(t)
+	require.NoError(t, beaconDB.SaveLightClientBootstrap(ctx, r, bootstrap))
+
 	s := &Server{
-		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
-			slot: bs,
-		}},
-		Blocker:     mockBlocker,
-		HeadFetcher: mockChainService,
+		BeaconDB: beaconDB,
 	}
 	muxVars := make(map[string]string)
 	muxVars["block_root"] = hexutil.Encode(r[:])
@@ -72,201 +127,96 @@ func TestLightClientHandler_GetLightClientBootstrap(t *testing.T) {
 	require.Equal(t, http.StatusOK, writer.Code)
 	resp := &LightClientBootstrapResponse{}
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
-	require.Equal(t, "capella", resp.Version)
-	require.Equal(t, hexutil.Encode(header.Header.BodyRoot), resp.Data.Header.BodyRoot)
+	require.Equal(t, "altair", resp.Version)
 	require.NotNil(t, resp.Data)
+	require.Equal(t, hexutil.Encode(header.Header.BodyRoot), resp.Data.Header.BodyRoot)
 }
 
-func TestLightClientHandler_GetLightClientUpdatesByRange(t *testing.T) {
-	helpers.ClearCache()
-	ctx := context.Background()
-	config := params.BeaconConfig()
-	slot := primitives.Slot(config.AltairForkEpoch * primitives.Epoch(config.SlotsPerEpoch)).Add(1)
-
-	attestedState, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = attestedState.SetSlot(slot.Sub(1))
-	require.NoError(t, err)
-
-	parent := util.NewBeaconBlockCapella()
-	parent.Block.Slot = slot.Sub(1)
-
-	signedParent, err := blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	parentHeader, err := signedParent.Header()
-	require.NoError(t, err)
-	attestedHeader := parentHeader.Header
-
-	err = attestedState.SetLatestBlockHeader(attestedHeader)
-	require.NoError(t, err)
-	attestedStateRoot, err := attestedState.HashTreeRoot(ctx)
-	require.NoError(t, err)
-
-	// get a new signed block so the root is updated with the new state root
-	parent.Block.StateRoot = attestedStateRoot[:]
-	signedParent, err = blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
+func TestLightClientHandler_GetLightClientBootstrap_NotFound(t *testing.T) {
+	s := &Server{
+		BeaconDB: dbtest.SetupDB(t),
+		Blocker:  &testutil.MockBlocker{},
+	}
+	muxVars := make(map[string]string)
+	muxVars["block_root"] = hexutil.Encode(make([]byte, 32))
+	request := httptest.NewRequest("GET", "http://foo.com/", nil)
+	request = mux.SetURLVars(request, muxVars)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
 
-	st, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = st.SetSlot(slot)
-	require.NoError(t, err)
+	s.GetLightClientBootstrap(writer, request)
+	require.Equal(t, http.StatusNotFound, writer.Code)
+}
 
-	parentRoot, err := signedParent.Block().HashTreeRoot()
-	require.NoError(t, err)
+func TestLightClientHandler_GetLightClientBootstrap_NotPersisted(t *testing.T) {
+	helpers.ClearCache()
+	slot := primitives.Slot(params.BeaconConfig().AltairForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
 
-	block := util.NewBeaconBlockCapella()
-	block.Block.Slot = slot
-	block.Block.ParentRoot = parentRoot[:]
+	b := util.NewBeaconBlockCapella()
+	b.Block.StateRoot = bytesutil.PadTo([]byte("foo"), 32)
+	b.Block.Slot = slot
 
-	for i := uint64(0); i < config.SyncCommitteeSize; i++ {
-		block.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
-	}
+	signedBlock, err := blocks.NewSignedBeaconBlock(b)
 
-	signedBlock, err := blocks.NewSignedBeaconBlock(block)
 	require.NoError(t, err)
-
-	h, err := signedBlock.Header()
+	header, err := signedBlock.Header()
 	require.NoError(t, err)
 
-	err = st.SetLatestBlockHeader(h.Header)
-	require.NoError(t, err)
-	stateRoot, err := st.HashTreeRoot(ctx)
+	r, err := b.Block.HashTreeRoot()
 	require.NoError(t, err)
 
-	// get a new signed block so the root is updated with the new state root
-	block.Block.StateRoot = stateRoot[:]
-	signedBlock, err = blocks.NewSignedBeaconBlock(block)
+	bs, err := util.NewBeaconStateCapella(func(state *ethpb.BeaconStateCapella) error {
+		state.BlockRoots[0] = r[:]
+		return nil
+	})
 	require.NoError(t, err)
 
-	root, err := block.Block.HashTreeRoot()
-	require.NoError(t, err)
+	require.NoError(t, bs.SetSlot(slot))
+	require.NoError(t, bs.SetLatestBlockHeader(header.Header))
 
-	mockBlocker := &testutil.MockBlocker{
-		RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{
-			parentRoot: signedParent,
-			root:       signedBlock,
-		},
-		SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
-			slot.Sub(1): signedParent,
-			slot:        signedBlock,
-		},
-	}
-	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot, State: st}
+	mockBlocker := &testutil.MockBlocker{BlockToReturn: signedBlock}
+	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot}
+	// The bootstrap was not persisted, and is rebuilt from the state of the block.
 	s := &Server{
+		BeaconDB: dbtest.SetupDB(t),
 		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
-			slot.Sub(1): attestedState,
-			slot:        st,
+			slot: bs,
 		}},
 		Blocker:     mockBlocker,
 		HeadFetcher: mockChainService,
 	}
-	startPeriod := slot.Div(uint64(config.EpochsPerSyncCommitteePeriod)).Div(uint64(config.SlotsPerEpoch))
-	url := fmt.Sprintf("http://foo.com/?count=1&start_period=%d", startPeriod)
-	request := httptest.NewRequest("GET", url, nil)
+	muxVars := make(map[string]string)
+	muxVars["block_root"] = hexutil.Encode(r[:])
+	request := httptest.NewRequest("GET", "http://foo.com/", nil)
+	request = mux.SetURLVars(request, muxVars)
 	writer := httptest.NewRecorder()
 	writer.Body = &bytes.Buffer{}
 
-	s.GetLightClientUpdatesByRange(writer, request)
-
+	s.GetLightClientBootstrap(writer, request)
 	require.Equal(t, http.StatusOK, writer.Code)
-	var resp []LightClientUpdateWithVersion
-	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
-	require.Equal(t, 1, len(resp))
-	require.Equal(t, "capella", resp[0].Version)
-	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), resp[0].Data.AttestedHeader.BodyRoot)
-	require.NotNil(t, resp)
+	resp := &LightClientBootstrapResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, "altair", resp.Version)
+	require.Equal(t, hexutil.Encode(header.Header.BodyRoot), resp.Data.Header.BodyRoot)
+	require.NotNil(t, resp.Data)
 }
 
-func TestLightClientHandler_GetLightClientUpdatesByRange_TooBigInputCount(t *testing.T) {
-	helpers.ClearCache()
+func TestLightClientHandler_GetLightClientUpdatesByRange(t *testing.T) {
 	ctx := context.Background()
 	config := params.BeaconConfig()
-	slot := primitives.Slot(config.AltairForkEpoch * primitives.Epoch(config.SlotsPerEpoch)).Add(1)
-
-	attestedState, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = attestedState.SetSlot(slot.Sub(1))
-	require.NoError(t, err)
-
-	parent := util.NewBeaconBlockCapella()
-	parent.Block.Slot = slot.Sub(1)
-
-	signedParent, err := blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	parentHeader, err := signedParent.Header()
-	require.NoError(t, err)
-	attestedHeader := parentHeader.Header
-
-	err = attestedState.SetLatestBlockHeader(attestedHeader)
-	require.NoError(t, err)
-	attestedStateRoot, err := attestedState.HashTreeRoot(ctx)
-	require.NoError(t, err)
-
-	// get a new signed block so the root is updated with the new state root
-	parent.Block.StateRoot = attestedStateRoot[:]
-	signedParent, err = blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	st, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = st.SetSlot(slot)
-	require.NoError(t, err)
-
-	parentRoot, err := signedParent.Block().HashTreeRoot()
-	require.NoError(t, err)
-
-	block := util.NewBeaconBlockCapella()
-	block.Block.Slot = slot
-	block.Block.ParentRoot = parentRoot[:]
-
-	for i := uint64(0); i < config.SyncCommitteeSize; i++ {
-		block.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	startPeriod := uint64(slots.SyncCommitteePeriod(config.AltairForkEpoch)) + 1
+	beaconDB := dbtest.SetupDB(t)
+	var stored []*ethpbv2.LightClientUpdate
+	for period := startPeriod; period < startPeriod+3; period++ {
+		update := lightClientTestUpdate(primitives.Slot(period) * slotsPerSyncCommitteePeriod())
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, update))
+		stored = append(stored, update)
 	}
 
-	signedBlock, err := blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
-
-	h, err := signedBlock.Header()
-	require.NoError(t, err)
-
-	err = st.SetLatestBlockHeader(h.Header)
-	require.NoError(t, err)
-	stateRoot, err := st.HashTreeRoot(ctx)
-	require.NoError(t, err)
-
-	// get a new signed block so the root is updated with the new state root
-	block.Block.StateRoot = stateRoot[:]
-	signedBlock, err = blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
-
-	root, err := block.Block.HashTreeRoot()
-	require.NoError(t, err)
-
-	mockBlocker := &testutil.MockBlocker{
-		RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{
-			parentRoot: signedParent,
-			root:       signedBlock,
-		},
-		SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
-			slot.Sub(1): signedParent,
-			slot:        signedBlock,
-		},
-	}
-	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot, State: st}
 	s := &Server{
-		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
-			slot.Sub(1): attestedState,
-			slot:        st,
-		}},
-		Blocker:     mockBlocker,
-		HeadFetcher: mockChainService,
+		BeaconDB: beaconDB,
 	}
-	startPeriod := slot.Div(uint64(config.EpochsPerSyncCommitteePeriod)).Div(uint64(config.SlotsPerEpoch))
-	count := 129 // config.MaxRequestLightClientUpdates is 128
-	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", count, startPeriod)
+	url := fmt.Sprintf("http://foo.com/?count=2&start_period=%d", startPeriod)
 	request := httptest.NewRequest("GET", url, nil)
 	writer := httptest.NewRecorder()
 	writer.Body = &bytes.Buffer{}
@@ -276,13 +226,14 @@ func TestLightClientHandler_GetLightClientUpdatesByRange_TooBigInputCount(t *tes
 	require.Equal(t, http.StatusOK, writer.Code)
 	var resp []LightClientUpdateWithVersion
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
-	require.Equal(t, 1, len(resp)) // Even with big count input, the response is still the max available period, which is 1 in test case.
-	require.Equal(t, "capella", resp[0].Version)
-	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), resp[0].Data.AttestedHeader.BodyRoot)
-	require.NotNil(t, resp)
+	require.Equal(t, 2, len(resp))
+	for i, update := range resp {
+		require.Equal(t, "altair", update.Version)
+		require.Equal(t, hexutil.Encode(stored[i].AttestedHeader.BodyRoot), update.Data.AttestedHeader.BodyRoot)
+	}
 }
 
-func TestLightClientHandler_GetLightClientUpdatesByRange_TooEarlyPeriod(t *testing.T) {
+func TestLightClientHandler_GetLightClientUpdatesByRange_NotPersisted(t *testing.T) {
 	helpers.ClearCache()
 	ctx := context.Background()
 	config := params.BeaconConfig()
@@ -359,7 +310,9 @@ func TestLightClientHandler_GetLightClientUpdatesByRange_TooEarlyPeriod(t *testi
 		},
 	}
 	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot, State: st}
+	// The update was not persisted, and is rebuilt from the states of the period.
 	s := &Server{
+		BeaconDB: dbtest.SetupDB(t),
 		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
 			slot.Sub(1): attestedState,
 			slot:        st,
@@ -367,9 +320,8 @@ func TestLightClientHandler_GetLightClientUpdatesByRange_TooEarlyPeriod(t *testi
 		Blocker:     mockBlocker,
 		HeadFetcher: mockChainService,
 	}
-	startPeriod := 1 // very early period before Altair fork
-	count := 1
-	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", count, startPeriod)
+	startPeriod := slot.Div(uint64(config.EpochsPerSyncCommitteePeriod)).Div(uint64(config.SlotsPerEpoch))
+	url := fmt.Sprintf("http://foo.com/?count=1&start_period=%d", startPeriod)
 	request := httptest.NewRequest("GET", url, nil)
 	writer := httptest.NewRecorder()
 	writer.Body = &bytes.Buffer{}
@@ -380,99 +332,28 @@ func TestLightClientHandler_GetLightClientUpdatesByRange_TooEarlyPeriod(t *testi
 	var resp []LightClientUpdateWithVersion
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
 	require.Equal(t, 1, len(resp))
-	require.Equal(t, "capella", resp[0].Version)
+	require.Equal(t, "altair", resp[0].Version)
 	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), resp[0].Data.AttestedHeader.BodyRoot)
 	require.NotNil(t, resp)
 }
 
 func TestLightClientHandler_GetLightClientUpdatesByRange_TooBigCount(t *testing.T) {
-	helpers.ClearCache()
+	params.SetupTestConfigCleanup(t)
+	config := params.BeaconConfig().Copy()
+	config.MaxRequestLightClientUpdates = 2
+	params.OverrideBeaconConfig(config)
 	ctx := context.Background()
-	config := params.BeaconConfig()
-	slot := primitives.Slot(config.AltairForkEpoch * primitives.Epoch(config.SlotsPerEpoch)).Add(1)
-
-	attestedState, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = attestedState.SetSlot(slot.Sub(1))
-	require.NoError(t, err)
-
-	parent := util.NewBeaconBlockCapella()
-	parent.Block.Slot = slot.Sub(1)
-
-	signedParent, err := blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	parentHeader, err := signedParent.Header()
-	require.NoError(t, err)
-	attestedHeader := parentHeader.Header
-
-	err = attestedState.SetLatestBlockHeader(attestedHeader)
-	require.NoError(t, err)
-	attestedStateRoot, err := attestedState.HashTreeRoot(ctx)
-	require.NoError(t, err)
-
-	// get a new signed block so the root is updated with the new state root
-	parent.Block.StateRoot = attestedStateRoot[:]
-	signedParent, err = blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	st, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = st.SetSlot(slot)
-	require.NoError(t, err)
-
-	parentRoot, err := signedParent.Block().HashTreeRoot()
-	require.NoError(t, err)
-
-	block := util.NewBeaconBlockCapella()
-	block.Block.Slot = slot
-	block.Block.ParentRoot = parentRoot[:]
-
-	for i := uint64(0); i < config.SyncCommitteeSize; i++ {
-		block.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	startPeriod := uint64(slots.SyncCommitteePeriod(config.AltairForkEpoch))
+	beaconDB := dbtest.SetupDB(t)
+	for period := startPeriod; period < startPeriod+3; period++ {
+		update := lightClientTestUpdate(primitives.Slot(period) * slotsPerSyncCommitteePeriod())
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, update))
 	}
 
-	signedBlock, err := blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
-
-	h, err := signedBlock.Header()
-	require.NoError(t, err)
-
-	err = st.SetLatestBlockHeader(h.Header)
-	require.NoError(t, err)
-	stateRoot, err := st.HashTreeRoot(ctx)
-	require.NoError(t, err)
-
-	// get a new signed block so the root is updated with the new state root
-	block.Block.StateRoot = stateRoot[:]
-	signedBlock, err = blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
-
-	root, err := block.Block.HashTreeRoot()
-	require.NoError(t, err)
-
-	mockBlocker := &testutil.MockBlocker{
-		RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{
-			parentRoot: signedParent,
-			root:       signedBlock,
-		},
-		SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
-			slot.Sub(1): signedParent,
-			slot:        signedBlock,
-		},
-	}
-	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot, State: st}
 	s := &Server{
-		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
-			slot.Sub(1): attestedState,
-			slot:        st,
-		}},
-		Blocker:     mockBlocker,
-		HeadFetcher: mockChainService,
+		BeaconDB: beaconDB,
 	}
-	startPeriod := 1 // very early period before Altair fork
-	count := 10      // This is big count as we only have one period in test case.
-	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", count, startPeriod)
+	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", 10, startPeriod)
 	request := httptest.NewRequest("GET", url, nil)
 	writer := httptest.NewRecorder()
 	writer.Body = &bytes.Buffer{}
@@ -482,100 +363,46 @@ func TestLightClientHandler_GetLightClientUpdatesByRange_TooBigCount(t *testing.
 	require.Equal(t, http.StatusOK, writer.Code)
 	var resp []LightClientUpdateWithVersion
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
-	require.Equal(t, 1, len(resp))
-	require.Equal(t, "capella", resp[0].Version)
-	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), resp[0].Data.AttestedHeader.BodyRoot)
-	require.NotNil(t, resp)
+	require.Equal(t, 2, len(resp))
 }
 
-func TestLightClientHandler_GetLightClientUpdatesByRange_BeforeAltair(t *testing.T) {
-	helpers.ClearCache()
+func TestLightClientHandler_GetLightClientUpdatesByRange_MissingPeriod(t *testing.T) {
 	ctx := context.Background()
-	config := params.BeaconConfig()
-	slot := primitives.Slot(config.AltairForkEpoch * primitives.Epoch(config.SlotsPerEpoch)).Sub(1)
-
-	attestedState, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = attestedState.SetSlot(slot.Sub(1))
-	require.NoError(t, err)
-
-	parent := util.NewBeaconBlockCapella()
-	parent.Block.Slot = slot.Sub(1)
-
-	signedParent, err := blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	parentHeader, err := signedParent.Header()
-	require.NoError(t, err)
-	attestedHeader := parentHeader.Header
-
-	err = attestedState.SetLatestBlockHeader(attestedHeader)
-	require.NoError(t, err)
-	attestedStateRoot, err := attestedState.HashTreeRoot(ctx)
-	require.NoError(t, err)
-
-	// get a new signed block so the root is updated with the new state root
-	parent.Block.StateRoot = attestedStateRoot[:]
-	signedParent, err = blocks.NewSignedBeaconBlock(parent)
-	require.NoError(t, err)
-
-	st, err := util.NewBeaconStateCapella()
-	require.NoError(t, err)
-	err = st.SetSlot(slot)
-	require.NoError(t, err)
-
-	parentRoot, err := signedParent.Block().HashTreeRoot()
-	require.NoError(t, err)
-
-	block := util.NewBeaconBlockCapella()
-	block.Block.Slot = slot
-	block.Block.ParentRoot = parentRoot[:]
-
-	for i := uint64(0); i < config.SyncCommitteeSize; i++ {
-		block.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	startPeriod := uint64(slots.SyncCommitteePeriod(params.BeaconConfig().AltairForkEpoch))
+	beaconDB := dbtest.SetupDB(t)
+	for _, period := range []uint64{startPeriod, startPeriod + 2} {
+		update := lightClientTestUpdate(primitives.Slot(period) * slotsPerSyncCommitteePeriod())
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, update))
 	}
-
-	signedBlock, err := blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
-
-	h, err := signedBlock.Header()
+	headState, err := util.NewBeaconStateCapella()
 	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(primitives.Slot(startPeriod+2)*slotsPerSyncCommitteePeriod()))
 
-	err = st.SetLatestBlockHeader(h.Header)
-	require.NoError(t, err)
-	stateRoot, err := st.HashTreeRoot(ctx)
-	require.NoError(t, err)
+	// The missing period has no state to rebuild its update from
+	s := &Server{
+		BeaconDB:    beaconDB,
+		Stater:      &testutil.MockStater{},
+		Blocker:     &testutil.MockBlocker{},
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", 3, startPeriod)
+	request := httptest.NewRequest("GET", url, nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
 
-	// get a new signed block so the root is updated with the new state root
-	block.Block.StateRoot = stateRoot[:]
-	signedBlock, err = blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
+	s.GetLightClientUpdatesByRange(writer, request)
 
-	root, err := block.Block.HashTreeRoot()
-	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, writer.Code)
+	var resp []LightClientUpdateWithVersion
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+	require.Equal(t, 2, len(resp))
+}
 
-	mockBlocker := &testutil.MockBlocker{
-		RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{
-			parentRoot: signedParent,
-			root:       signedBlock,
-		},
-		SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
-			slot.Sub(1): signedParent,
-			slot:        signedBlock,
-		},
-	}
-	mockChainService := &mock.ChainService{Optimistic: true, Slot: &slot, State: st}
+func TestLightClientHandler_GetLightClientUpdatesByRange_NoUpdates(t *testing.T) {
 	s := &Server{
-		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
-			slot.Sub(1): attestedState,
-			slot:        st,
-		}},
-		Blocker:     mockBlocker,
-		HeadFetcher: mockChainService,
+		BeaconDB: dbtest.SetupDB(t),
 	}
-	startPeriod := slot.Div(uint64(config.EpochsPerSyncCommitteePeriod)).Div(uint64(config.SlotsPerEpoch))
-	count := 1
-	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", count, startPeriod)
+	url := fmt.Sprintf("http://foo.com/?count=%d&start_period=%d", 1, 0)
 	request := httptest.NewRequest("GET", url, nil)
 	writer := httptest.NewRecorder()
 	writer.Body = &bytes.Buffer{}
@@ -9,198 +9,151 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
-	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	v1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
 	v2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v4/proto/migration"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
-// createLightClientBootstrap - implements https://github.com/ethereum/consensus-specs/blob/3d235740e5f1e641d3b160c8688f26e7dc5a1894/specs/altair/light-client/full-node.md#create_light_client_bootstrap
-// def create_light_client_bootstrap(state: BeaconState) -> LightClientBootstrap:
-//
-//	assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
-//	assert state.slot == state.latest_block_header.slot
-//
-//	return LightClientBootstrap(
-//	    header=BeaconBlockHeader(
-//	        slot=state.latest_block_header.slot,
-//	        proposer_index=state.latest_block_header.proposer_index,
-//	        parent_root=state.latest_block_header.parent_root,
-//	        state_root=hash_tree_root(state),
-//	        body_root=state.latest_block_header.body_root,
-//	    ),
-//	    current_sync_committee=state.current_sync_committee,
-//	    current_sync_committee_branch=compute_merkle_proof_for_state(state, CURRENT_SYNC_COMMITTEE_INDEX)
-//	)
-func createLightClientBootstrap(ctx context.Context, state state.BeaconState) (*LightClientBootstrap, error) {
-	// assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
-	if slots.ToEpoch(state.Slot()) < params.BeaconConfig().AltairForkEpoch {
-		return nil, fmt.Errorf("light client bootstrap is not supported before Altair, invalid slot %d", state.Slot())
-	}
-
-	// assert state.slot == state.latest_block_header.slot
-	latestBlockHeader := state.LatestBlockHeader()
-	if state.Slot() != latestBlockHeader.Slot {
-		return nil, fmt.Errorf("state slot %d not equal to latest block header slot %d", state.Slot(), latestBlockHeader.Slot)
+// lightClientBootstrapToJSON converts the light client bootstrap to its JSON representation.
+func lightClientBootstrapToJSON(bootstrap *v2.LightClientBootstrap) *LightClientBootstrap {
+	return &LightClientBootstrap{
+		Header:                     shared.BeaconBlockHeaderFromConsensus(migration.V1HeaderToV1Alpha1(bootstrap.Header)),
+		CurrentSyncCommittee:       shared.SyncCommitteeFromConsensus(migration.V2SyncCommitteeToV1Alpha1(bootstrap.CurrentSyncCommittee)),
+		CurrentSyncCommitteeBranch: branchToJSON(bootstrap.CurrentSyncCommitteeBranch),
 	}
+}
 
-	// Prepare data
-	currentSyncCommittee, err := state.CurrentSyncCommittee()
+// versionAtSlot returns the name of the fork active at the given slot.
+func versionAtSlot(slot primitives.Slot) (string, error) {
+	cfg := params.BeaconConfig()
+	forkVersion, err := forks.NewOrderedSchedule(cfg).VersionForEpoch(slots.ToEpoch(slot))
 	if err != nil {
-		return nil, fmt.Errorf("could not get current sync committee: %s", err.Error())
+		return "", err
 	}
-
-	committee := shared.SyncCommitteeFromConsensus(currentSyncCommittee)
-
-	currentSyncCommitteeProof, err := state.CurrentSyncCommitteeProof(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("could not get current sync committee proof: %s", err.Error())
+	v, ok := params.ConfigForkVersions(cfg)[forkVersion]
+	if !ok {
+		return "", fmt.Errorf("unknown fork version %#x", forkVersion)
 	}
+	return version.String(v), nil
+}
 
-	branch := make([]string, fieldparams.NextSyncCommitteeBranchDepth)
-	for i, proof := range currentSyncCommitteeProof {
-		branch[i] = hexutil.Encode(proof)
+// lightClientBootstrapFromState builds the light client bootstrap of a block from its state. It returns nil if the block
+// is not known.
+func (s *Server) lightClientBootstrapFromState(ctx context.Context, blockRoot [32]byte) (*v2.LightClientBootstrap, error) {
+	blk, err := s.Blocker.Block(ctx, blockRoot[:])
+	if err != nil {
+		return nil, fmt.Errorf("could not get block: %v", err)
 	}
-
-	header := shared.BeaconBlockHeaderFromConsensus(latestBlockHeader)
-	if header == nil {
-		return nil, fmt.Errorf("could not get beacon block header")
+	if blk == nil || blk.IsNil() {
+		return nil, nil
 	}
-
-	// Above shared util function won't calculate state root, so we need to do it manually
-	stateRoot, err := state.HashTreeRoot(ctx)
+	st, err := s.Stater.StateBySlot(ctx, blk.Block().Slot())
 	if err != nil {
-		return nil, fmt.Errorf("could not get state root: %s", err.Error())
+		return nil, fmt.Errorf("could not get state: %v", err)
 	}
-	header.StateRoot = hexutil.Encode(stateRoot[:])
-
-	// Return result
-	result := &LightClientBootstrap{
-		Header:                     header,
-		CurrentSyncCommittee:       committee,
-		CurrentSyncCommitteeBranch: branch,
+	if st == nil || st.IsNil() {
+		return nil, nil
 	}
-
-	return result, nil
+	return blockchain.NewLightClientBootstrapFromBeaconState(ctx, st)
 }
 
-// createLightClientUpdate - implements https://github.
-// com/ethereum/consensus-specs/blob/d70dcd9926a4bbe987f1b4e65c3e05bd029fcfb8/specs/altair/light-client/full-node.md#create_light_client_update
-// def create_light_client_update(state: BeaconState,
-//
-//	                           block: SignedBeaconBlock,
-//	                           attested_state: BeaconState,
-//	                           finalized_block: Optional[SignedBeaconBlock]) -> LightClientUpdate:
-//	assert compute_epoch_at_slot(attested_state.slot) >= ALTAIR_FORK_EPOCH
-//	assert sum(block.message.body.sync_aggregate.sync_committee_bits) >= MIN_SYNC_COMMITTEE_PARTICIPANTS
-//
-//	assert state.slot == state.latest_block_header.slot
-//	header = state.latest_block_header.copy()
-//	header.state_root = hash_tree_root(state)
-//	assert hash_tree_root(header) == hash_tree_root(block.message)
-//	update_signature_period = compute_sync_committee_period(compute_epoch_at_slot(block.message.slot))
-//
-//	assert attested_state.slot == attested_state.latest_block_header.slot
-//	attested_header = attested_state.latest_block_header.copy()
-//	attested_header.state_root = hash_tree_root(attested_state)
-//	assert hash_tree_root(attested_header) == block.message.parent_root
-//	update_attested_period = compute_sync_committee_period(compute_epoch_at_slot(attested_header.slot))
-//
-//	# `next_sync_committee` is only useful if the message is signed by the current sync committee
-//	if update_attested_period == update_signature_period:
-//	    next_sync_committee = attested_state.next_sync_committee
-//	    next_sync_committee_branch = compute_merkle_proof_for_state(attested_state, NEXT_SYNC_COMMITTEE_INDEX)
-//	else:
-//	    next_sync_committee = SyncCommittee()
-//	    next_sync_committee_branch = [Bytes32() for _ in range(floorlog2(NEXT_SYNC_COMMITTEE_INDEX))]
-//
-//	# Indicate finality whenever possible
-//	if finalized_block is not None:
-//	    if finalized_block.message.slot != GENESIS_SLOT:
-//	        finalized_header = BeaconBlockHeader(
-//	            slot=finalized_block.message.slot,
-//	            proposer_index=finalized_block.message.proposer_index,
-//	            parent_root=finalized_block.message.parent_root,
-//	            state_root=finalized_block.message.state_root,
-//	            body_root=hash_tree_root(finalized_block.message.body),
-//	        )
-//	        assert hash_tree_root(finalized_header) == attested_state.finalized_checkpoint.root
-//	    else:
-//	        assert attested_state.finalized_checkpoint.root == Bytes32()
-//	        finalized_header = BeaconBlockHeader()
-//	    finality_branch = compute_merkle_proof_for_state(attested_state, FINALIZED_ROOT_INDEX)
-//	else:
-//	    finalized_header = BeaconBlockHeader()
-//	    finality_branch = [Bytes32() for _ in range(floorlog2(FINALIZED_ROOT_INDEX))]
-//
-//	return LightClientUpdate(
-//	    attested_header=attested_header,
-//	    next_sync_committee=next_sync_committee,
-//	    next_sync_committee_branch=next_sync_committee_branch,
-//	    finalized_header=finalized_header,
-//	    finality_branch=finality_branch,
-//	    sync_aggregate=block.message.body.sync_aggregate,
-//	    signature_slot=block.message.slot,
-//	)
-func createLightClientUpdate(
-	ctx context.Context,
-	state state.BeaconState,
-	block interfaces.ReadOnlySignedBeaconBlock,
-	attestedState state.BeaconState,
-	finalizedBlock interfaces.ReadOnlySignedBeaconBlock) (*LightClientUpdate, error) {
-	result, err := blockchain.NewLightClientFinalityUpdateFromBeaconState(ctx, state, block, attestedState, finalizedBlock)
+// lightClientUpdateFromStates builds the light client update of a sync committee period from the last block of the
+// period with a supermajority of sync committee votes whose state is still available. It returns nil if there is none.
+func (s *Server) lightClientUpdateFromStates(ctx context.Context, period uint64) (*v2.LightClientUpdate, error) {
+	config := params.BeaconConfig()
+	slotsPerPeriod := uint64(config.EpochsPerSyncCommitteePeriod) * uint64(config.SlotsPerEpoch)
+
+	// Sync committee votes are only available between the Altair fork and the current head
+	minSlot := uint64(config.AltairForkEpoch) * uint64(config.SlotsPerEpoch)
+	lastSlotInPeriod := period*slotsPerPeriod + slotsPerPeriod - 1
+	if lastSlotInPeriod < minSlot {
+		return nil, nil
+	}
+	headState, err := s.HeadFetcher.HeadState(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not get head state: %v", err)
 	}
-
-	// Generate next sync committee and proof
-	var nextSyncCommittee *v2.SyncCommittee
-	var nextSyncCommitteeBranch [][]byte
-
-	// update_signature_period = compute_sync_committee_period(compute_epoch_at_slot(block.message.slot))
-	updateSignaturePeriod := slots.ToEpoch(block.Block().Slot())
-
-	// update_attested_period = compute_sync_committee_period(compute_epoch_at_slot(attested_header.slot))
-	updateAttestedPeriod := slots.ToEpoch(result.AttestedHeader.Slot)
-
-	if updateAttestedPeriod == updateSignaturePeriod {
-		tempNextSyncCommittee, err := attestedState.NextSyncCommittee()
-		if err != nil {
-			return nil, fmt.Errorf("could not get next sync committee: %s", err.Error())
+	maxSlot := uint64(headState.Slot())
+	if period*slotsPerPeriod > maxSlot {
+		return nil, nil
+	}
+	if lastSlotInPeriod > maxSlot {
+		lastSlotInPeriod = maxSlot
+	}
+	// The first slot of the period is skipped, otherwise the attested header would be in the previous period
+	firstSlotInPeriod := period*slotsPerPeriod + 1
+
+	var st state.BeaconState
+	var block interfaces.ReadOnlySignedBeaconBlock
+	for slot := lastSlotInPeriod; slot >= firstSlotInPeriod; slot-- {
+		st, err = s.Stater.StateBySlot(ctx, primitives.Slot(slot))
+		if err != nil || st == nil || st.IsNil() {
+			continue
 		}
 
-		nextSyncCommittee = &v2.SyncCommittee{
-			Pubkeys:         tempNextSyncCommittee.Pubkeys,
-			AggregatePubkey: tempNextSyncCommittee.AggregatePubkey,
+		// Get the block of the state
+		latestBlockHeader := st.LatestBlockHeader()
+		latestStateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			continue
 		}
-
-		nextSyncCommitteeBranch, err = attestedState.NextSyncCommitteeProof(ctx)
+		latestBlockHeader.StateRoot = latestStateRoot[:]
+		blockRoot, err := latestBlockHeader.HashTreeRoot()
 		if err != nil {
-			return nil, fmt.Errorf("could not get next sync committee proof: %s", err.Error())
+			continue
 		}
-	} else {
-		syncCommitteeSize := params.BeaconConfig().SyncCommitteeSize
-		pubKeys := make([][]byte, syncCommitteeSize)
-		for i := uint64(0); i < syncCommitteeSize; i++ {
-			pubKeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+		block, err = s.Blocker.Block(ctx, blockRoot[:])
+		if err != nil || block == nil || block.IsNil() {
+			block = nil
+			continue
 		}
-		nextSyncCommittee = &v2.SyncCommittee{
-			Pubkeys:         pubKeys,
-			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+
+		syncAggregate, err := block.Block().Body().SyncAggregate()
+		if err != nil || syncAggregate == nil || syncAggregate.SyncCommitteeBits.Count()*3 < config.SyncCommitteeSize*2 {
+			// Not enough votes
+			block = nil
+			continue
 		}
+		break
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	// Get attested state
+	attestedRoot := block.Block().ParentRoot()
+	attestedBlock, err := s.Blocker.Block(ctx, attestedRoot[:])
+	if err != nil || attestedBlock == nil || attestedBlock.IsNil() {
+		return nil, nil
+	}
+	attestedState, err := s.Stater.StateBySlot(ctx, attestedBlock.Block().Slot())
+	if err != nil || attestedState == nil || attestedState.IsNil() {
+		return nil, nil
+	}
 
-		nextSyncCommitteeBranch = make([][]byte, fieldparams.NextSyncCommitteeBranchDepth)
-		for i := 0; i < fieldparams.NextSyncCommitteeBranchDepth; i++ {
-			nextSyncCommitteeBranch[i] = make([]byte, fieldparams.RootLength)
+	// Get finalized block
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	if finalizedCheckpoint := attestedState.FinalizedCheckpoint(); finalizedCheckpoint != nil {
+		finalizedRoot := bytesutil.ToBytes32(finalizedCheckpoint.Root)
+		finalizedBlock, err = s.Blocker.Block(ctx, finalizedRoot[:])
+		if err != nil {
+			finalizedBlock = nil
 		}
 	}
 
-	result.NextSyncCommittee = nextSyncCommittee
-	result.NextSyncCommitteeBranch = nextSyncCommitteeBranch
-	return newLightClientUpdateToJSON(result), nil
+	update, err := blockchain.NewLightClientUpdateFromBeaconState(ctx, st, block, attestedState, finalizedBlock)
+	if err != nil {
+		// The update can't be built from these states, as before updates were persisted
+		return nil, nil
+	}
+	return update, nil
 }
 
 func newLightClientFinalityUpdateFromBeaconState(
@@ -7,8 +7,10 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/io/logs"
 	"github.com/prysmaticlabs/prysm/v4/network/httputil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
@@ -117,6 +119,10 @@ func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "syncing_status is not a valid HTTP status code", http.StatusBadRequest)
 		return
 	}
+	// The execution endpoint currently used, which changes when failing over to a backup endpoint.
+	if s.ExecutionChainInfoFetcher != nil {
+		w.Header().Set(api.ExecutionPrimaryEndpointHeader, logs.MaskCredentialsLogging(s.ExecutionChainInfoFetcher.ExecutionClientEndpoint()))
+	}
 
 	if s.SyncChecker.Synced() {
 		return
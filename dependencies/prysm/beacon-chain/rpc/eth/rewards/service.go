@@ -5,14 +5,18 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 )
 
 // BlockRewardsFetcher is a interface that provides access to reward related responses
@@ -130,3 +134,31 @@ func (rs *BlockRewardService) GetStateForRewards(ctx context.Context, blk interf
 	}
 	return st, nil
 }
+
+// AttestationDeltas returns the attestation rewards and penalties of the given validators for the previous epoch
+// of the state, in the same order as the validator indices. The state must be at least an Altair state.
+func AttestationDeltas(ctx context.Context, st state.BeaconState, valIndices []primitives.ValidatorIndex) ([]*altair.AttDelta, error) {
+	if st.Version() == version.Phase0 {
+		return nil, errors.New("attestation rewards are not supported for Phase 0")
+	}
+	allVals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize precompute validators")
+	}
+	allVals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, allVals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process epoch participation")
+	}
+	vals := make([]*precompute.Validator, len(valIndices))
+	for i, idx := range valIndices {
+		if uint64(idx) >= uint64(len(allVals)) {
+			return nil, errors.Errorf("validator index %d is too large", idx)
+		}
+		vals[i] = allVals[idx]
+	}
+	deltas, err := altair.AttestationsDelta(st, bal, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestations delta")
+	}
+	return deltas, nil
+}
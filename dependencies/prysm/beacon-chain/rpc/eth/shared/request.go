@@ -10,8 +10,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/api/pagination"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/network/httputil"
 )
 
@@ -36,6 +39,45 @@ func UintFromRoute(w http.ResponseWriter, r *http.Request, name string) (string,
 	return raw, v, true
 }
 
+// PageFromQuery parses the page_size and page_token query parameters, returning the bounds of the requested page
+// of a list with the given size and the token of the next page.
+func PageFromQuery(w http.ResponseWriter, r *http.Request, total int) (int, int, string, bool) {
+	pageSize, ok := PageSizeFromQuery(w, r)
+	if !ok {
+		return 0, 0, "", false
+	}
+	if total == 0 {
+		return 0, 0, "", true
+	}
+	start, end, nextPageToken, err := pagination.StartAndEndPage(r.URL.Query().Get("page_token"), pageSize, total)
+	if err != nil {
+		httputil.HandleError(w, "Could not paginate results: "+err.Error(), http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+	return start, end, nextPageToken, true
+}
+
+// PageSizeFromQuery parses the page_size query parameter, which defaults to the default page size and can not be
+// greater than the max page size.
+func PageSizeFromQuery(w http.ResponseWriter, r *http.Request) (int, bool) {
+	_, pageSize, ok := UintFromQuery(w, r, "page_size", false)
+	if !ok {
+		return 0, false
+	}
+	if pageSize > uint64(cmd.Get().MaxRPCPageSize) {
+		httputil.HandleError(
+			w,
+			fmt.Sprintf("Requested page size %d can not be greater than max size %d", pageSize, cmd.Get().MaxRPCPageSize),
+			http.StatusBadRequest,
+		)
+		return 0, false
+	}
+	if pageSize == 0 {
+		return params.BeaconConfig().DefaultPageSize, true
+	}
+	return int(pageSize), true
+}
+
 func HexFromQuery(w http.ResponseWriter, r *http.Request, name string, length int, required bool) (string, []byte, bool) {
 	raw := r.URL.Query().Get(name)
 	if raw == "" && !required {
@@ -1085,6 +1085,22 @@ func (s *Server) GetLiveness(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
+// BeaconCommitteeSelections responds with appropriate message and status code according the spec:
+// https://ethereum.github.io/beacon-APIs/#/Validator/submitBeaconCommitteeSelections.
+// The endpoint is meant to be implemented by distributed validator middleware, so a beacon node
+// is expected to respond with 501 Not Implemented.
+func (s *Server) BeaconCommitteeSelections(w http.ResponseWriter, _ *http.Request) {
+	httputil.HandleError(w, "Endpoint not implemented", http.StatusNotImplemented)
+}
+
+// SyncCommitteeSelections responds with appropriate message and status code according the spec:
+// https://ethereum.github.io/beacon-APIs/#/Validator/submitSyncCommitteeSelections.
+// The endpoint is meant to be implemented by distributed validator middleware, so a beacon node
+// is expected to respond with 501 Not Implemented.
+func (s *Server) SyncCommitteeSelections(w http.ResponseWriter, _ *http.Request) {
+	httputil.HandleError(w, "Endpoint not implemented", http.StatusNotImplemented)
+}
+
 // attestationDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch - 1) - 1)
 // or the genesis block root in the case of underflow.
 func attestationDependentRoot(s state.BeaconState, epoch primitives.Epoch) ([]byte, error) {
@@ -99,6 +99,11 @@ func (s *Server) ProduceBlindedBlock(w http.ResponseWriter, r *http.Request) {
 	rawRandaoReveal := r.URL.Query().Get("randao_reveal")
 	rawGraffiti := r.URL.Query().Get("graffiti")
 	rawSkipRandaoVerification := r.URL.Query().Get("skip_randao_verification")
+	// builder_min_bid_gwei is a Prysm extension, set by Prysm validator clients from their proposer settings.
+	_, minBid, ok := shared.UintFromQuery(w, r, "builder_min_bid_gwei", false)
+	if !ok {
+		return
+	}
 
 	slot, valid := shared.ValidateUint(w, "slot", rawSlot)
 	if !valid {
@@ -127,10 +132,11 @@ func (s *Server) ProduceBlindedBlock(w http.ResponseWriter, r *http.Request) {
 	}
 
 	s.produceBlockV3(ctx, w, r, &eth.BlockRequest{
-		Slot:         primitives.Slot(slot),
-		RandaoReveal: randaoReveal,
-		Graffiti:     graffiti,
-		SkipMevBoost: false,
+		Slot:              primitives.Slot(slot),
+		RandaoReveal:      randaoReveal,
+		Graffiti:          graffiti,
+		SkipMevBoost:      false,
+		BuilderMinBidGwei: minBid,
 	}, blinded)
 }
 
@@ -164,6 +170,11 @@ func (s *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
 	if rawBbFactor != "" {
 		bbFactor = &wrapperspb.UInt64Value{Value: bbValue}
 	}
+	// builder_min_bid_gwei is a Prysm extension, set by Prysm validator clients from their proposer settings.
+	_, minBid, ok := shared.UintFromQuery(w, r, "builder_min_bid_gwei", false)
+	if !ok {
+		return
+	}
 
 	slot, valid := shared.ValidateUint(w, "slot", rawSlot)
 	if !valid {
@@ -197,6 +208,7 @@ func (s *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
 		Graffiti:           graffiti,
 		SkipMevBoost:       false,
 		BuilderBoostFactor: bbFactor,
+		BuilderMinBidGwei:  minBid,
 	}, any)
 }
 
@@ -2576,6 +2576,34 @@ func TestGetLiveness(t *testing.T) {
 	})
 }
 
+func TestBeaconCommitteeSelections(t *testing.T) {
+	s := &Server{}
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/beacon_committee_selections", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.BeaconCommitteeSelections(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+	e := &httputil.DefaultJsonError{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.Equal(t, http.StatusNotImplemented, e.Code)
+	assert.Equal(t, "Endpoint not implemented", e.Message)
+}
+
+func TestSyncCommitteeSelections(t *testing.T) {
+	s := &Server{}
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/sync_committee_selections", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.SyncCommitteeSelections(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+	e := &httputil.DefaultJsonError{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.Equal(t, http.StatusNotImplemented, e.Code)
+	assert.Equal(t, "Endpoint not implemented", e.Message)
+}
+
 var (
 	singleContribution = `[
   {
@@ -2,14 +2,21 @@ package beacon
 
 import (
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/archive"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/network/httputil"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"go.opencensus.io/trace"
@@ -68,3 +75,84 @@ func (s *Server) GetWeakSubjectivity(w http.ResponseWriter, r *http.Request) {
 	}
 	httputil.WriteJson(w, resp)
 }
+
+// GetStateRebuildCost estimates the work needed to rebuild the canonical state of a slot: the saved or
+// cached state it is rebuilt from, the number of state diffs applied to get that state, and the number
+// of blocks and slots processed on top of it. The state itself is not rebuilt.
+func (s *Server) GetStateRebuildCost(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetStateRebuildCost")
+	defer span.End()
+
+	_, slot, ok := shared.UintFromRoute(w, r, "slot")
+	if !ok {
+		return
+	}
+	cost, err := s.CanonicalHistory.RebuildCost(ctx, primitives.Slot(slot))
+	if err != nil {
+		if errors.Is(err, stategen.ErrFutureSlotRequested) {
+			httputil.HandleError(w, "Could not estimate state rebuild cost: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		httputil.HandleError(w, "Could not estimate state rebuild cost: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := &GetStateRebuildCostResponse{
+		Data: &StateRebuildCost{
+			Slot:       strconv.FormatUint(slot, 10),
+			BlockRoot:  hexutil.Encode(cost.BlockRoot[:]),
+			BaseSlot:   strconv.FormatUint(uint64(cost.BaseSlot), 10),
+			BaseRoot:   hexutil.Encode(cost.BaseRoot[:]),
+			StateDiffs: strconv.Itoa(cost.StateDiffs),
+			Blocks:     strconv.Itoa(cost.Blocks),
+			Slots:      strconv.FormatUint(uint64(cost.Slots), 10),
+		},
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// ExportArchive streams the finalized blocks, blob sidecars and states of a slot range as a portable archive, in the
+// same format as `prysmctl db export`, so that a running node's db can be exported. The end slot defaults to the
+// finalized slot.
+func (s *Server) ExportArchive(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.ExportArchive")
+	defer span.End()
+
+	_, start, ok := shared.UintFromQuery(w, r, "start_slot", false)
+	if !ok {
+		return
+	}
+	rawEnd, end, ok := shared.UintFromQuery(w, r, "end_slot", false)
+	if !ok {
+		return
+	}
+	if rawEnd == "" {
+		end = math.MaxUint64
+	}
+	if start > end {
+		httputil.HandleError(w, fmt.Sprintf("Start slot %d is greater than end slot %d", start, end), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", api.OctetStreamMediaType)
+	cw := &countingWriter{w: w}
+	if _, err := archive.Export(ctx, s.BeaconDB, s.BlobStorage, cw, primitives.Slot(start), primitives.Slot(end)); err != nil {
+		if cw.n == 0 {
+			httputil.HandleError(w, "Could not export archive: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// The archive is already being streamed, so it is left truncated, which importing it detects.
+		log.Printf("Could not export archive of slots [%d, %d]: %v", start, end, err)
+	}
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
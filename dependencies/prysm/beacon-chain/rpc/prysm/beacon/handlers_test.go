@@ -0,0 +1,83 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/archive"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestExportArchive(t *testing.T) {
+	// The genesis state of the mainnet config is embedded, and would take precedence over the saved one.
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.InteropConfig())
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	st, _ := util.DeterministicGenesisState(t, 16)
+	require.NoError(t, db.SaveGenesisData(ctx, st))
+	parentRoot, err := db.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	for slot := primitives.Slot(1); slot <= 2; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parentRoot[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		parentRoot, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 2, Root: parentRoot[:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: parentRoot[:]}))
+	s := &Server{BeaconDB: db}
+
+	t.Run("OK", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/archive?start_slot=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ExportArchive(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		r, err := archive.NewReader(writer.Body)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Slot(1), r.Header().StartSlot)
+		assert.Equal(t, primitives.Slot(2), r.Header().EndSlot)
+		for {
+			_, _, err = r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		assert.Equal(t, uint64(2), r.Manifest().Blocks)
+	})
+	t.Run("no block in range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/archive?start_slot=3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ExportArchive(writer, request)
+		require.Equal(t, http.StatusInternalServerError, writer.Code)
+		require.StringContains(t, "no finalized block found", writer.Body.String())
+	})
+	t.Run("start after end", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/archive?start_slot=2&end_slot=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ExportArchive(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
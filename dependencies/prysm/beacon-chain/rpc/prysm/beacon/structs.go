@@ -10,3 +10,17 @@ type WeakSubjectivityData struct {
 	WsCheckpoint *shared.Checkpoint `json:"ws_checkpoint"`
 	StateRoot    string             `json:"state_root"`
 }
+
+type GetStateRebuildCostResponse struct {
+	Data *StateRebuildCost `json:"data"`
+}
+
+type StateRebuildCost struct {
+	Slot       string `json:"slot"`
+	BlockRoot  string `json:"block_root"`
+	BaseSlot   string `json:"base_slot"`
+	BaseRoot   string `json:"base_root"`
+	StateDiffs string `json:"state_diffs"`
+	Blocks     string `json:"blocks"`
+	Slots      string `json:"slots"`
+}
@@ -0,0 +1,249 @@
+package slasher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// GetHighestAttestations retrieves the highest source and target epochs attested by the requested validators,
+// as recorded by the slasher.
+func (s *Server) GetHighestAttestations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetHighestAttestations")
+	defer span.End()
+
+	var req HighestAttestationsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case err == io.EOF:
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	indices := make([]primitives.ValidatorIndex, len(req.ValidatorIndices))
+	for i, rawIndex := range req.ValidatorIndices {
+		index, valid := shared.ValidateUint(w, fmt.Sprintf("ValidatorIndices[%d]", i), rawIndex)
+		if !valid {
+			return
+		}
+		indices[i] = primitives.ValidatorIndex(index)
+	}
+
+	atts, err := s.SlashingChecker.HighestAttestations(ctx, indices)
+	if err != nil {
+		httputil.HandleError(w, "Could not get highest attestations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*HighestAttestation, len(atts))
+	for i, att := range atts {
+		data[i] = &HighestAttestation{
+			ValidatorIndex:     strconv.FormatUint(att.ValidatorIndex, 10),
+			HighestSourceEpoch: strconv.FormatUint(uint64(att.HighestSourceEpoch), 10),
+			HighestTargetEpoch: strconv.FormatUint(uint64(att.HighestTargetEpoch), 10),
+		}
+	}
+	httputil.WriteJson(w, &HighestAttestationsResponse{Data: data})
+}
+
+// IsSlashableBlock checks whether the submitted block header is slashable with respect to the proposals
+// recorded by the slasher, returning the resulting proposer slashings.
+func (s *Server) IsSlashableBlock(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.IsSlashableBlock")
+	defer span.End()
+
+	var req shared.SignedBeaconBlockHeader
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case err == io.EOF:
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	header, err := req.ToConsensus()
+	if err != nil {
+		httputil.HandleError(w, "Could not convert request block header to consensus block header: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	slashing, err := s.SlashingChecker.IsSlashableBlock(ctx, header)
+	if err != nil {
+		httputil.HandleError(w, "Could not check if block is slashable: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*shared.ProposerSlashing, 0)
+	if slashing != nil {
+		data = append(data, shared.ProposerSlashingFromConsensus(slashing))
+	}
+	httputil.WriteJson(w, &IsSlashableBlockResponse{Data: data})
+}
+
+// IsSlashableAttestation checks whether the submitted indexed attestation is slashable with respect to the
+// attestations recorded by the slasher, returning the resulting attester slashings. As when checked through
+// gRPC, an attestation which is not slashable is recorded by the slasher.
+func (s *Server) IsSlashableAttestation(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.IsSlashableAttestation")
+	defer span.End()
+
+	var req shared.IndexedAttestation
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case err == io.EOF:
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	att, err := req.ToConsensus()
+	if err != nil {
+		httputil.HandleError(w, "Could not convert request attestation to consensus attestation: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	slashings, err := s.SlashingChecker.IsSlashableAttestation(ctx, att)
+	if err != nil {
+		httputil.HandleError(w, "Could not check if attestation is slashable: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &IsSlashableAttestationResponse{Data: shared.AttesterSlashingsFromConsensus(slashings)})
+}
+
+// GetAttesterSlashings pages through the attester slashings detected by the slasher with a target epoch
+// between the start_epoch and end_epoch query parameters, both inclusive.
+func (s *Server) GetAttesterSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetAttesterSlashings")
+	defer span.End()
+
+	startEpoch, endEpoch, ok := slashingsEpochRange(w, r)
+	if !ok {
+		return
+	}
+	slashings, err := s.SlashingChecker.AttesterSlashings(ctx, startEpoch, endEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get attester slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	start, end, nextPageToken, ok := shared.PageFromQuery(w, r, len(slashings))
+	if !ok {
+		return
+	}
+	httputil.WriteJson(w, &AttesterSlashingsResponse{
+		Data:          shared.AttesterSlashingsFromConsensus(slashings[start:end]),
+		NextPageToken: nextPageToken,
+		TotalSize:     len(slashings),
+	})
+}
+
+// GetProposerSlashings pages through the proposer slashings detected by the slasher with an epoch
+// between the start_epoch and end_epoch query parameters, both inclusive.
+func (s *Server) GetProposerSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetProposerSlashings")
+	defer span.End()
+
+	startEpoch, endEpoch, ok := slashingsEpochRange(w, r)
+	if !ok {
+		return
+	}
+	slashings, err := s.SlashingChecker.ProposerSlashings(ctx, startEpoch, endEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get proposer slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	start, end, nextPageToken, ok := shared.PageFromQuery(w, r, len(slashings))
+	if !ok {
+		return
+	}
+	httputil.WriteJson(w, &ProposerSlashingsResponse{
+		Data:          shared.ProposerSlashingsFromConsensus(slashings[start:end]),
+		NextPageToken: nextPageToken,
+		TotalSize:     len(slashings),
+	})
+}
+
+// GetValidatorSpans dumps the min and max spans recorded by the slasher for a validator, grouped by chunk,
+// for the epochs between the start_epoch and end_epoch query parameters, both inclusive. The end epoch
+// defaults to the current epoch, and the start epoch to the end epoch.
+func (s *Server) GetValidatorSpans(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetValidatorSpans")
+	defer span.End()
+
+	_, index, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return
+	}
+	rawStartEpoch, startEpoch, ok := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !ok {
+		return
+	}
+	rawEndEpoch, endEpoch, ok := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !ok {
+		return
+	}
+	if rawEndEpoch == "" {
+		endEpoch = uint64(slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot()))
+	}
+	if rawStartEpoch == "" {
+		startEpoch = endEpoch
+	}
+	if startEpoch > endEpoch {
+		httputil.HandleError(w, "Start epoch cannot be greater than end epoch", http.StatusBadRequest)
+		return
+	}
+
+	spans, err := s.SlashingChecker.ValidatorSpans(
+		ctx, primitives.ValidatorIndex(index), primitives.Epoch(startEpoch), primitives.Epoch(endEpoch),
+	)
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator spans: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	data := make([]*ValidatorSpans, len(spans))
+	for i, sp := range spans {
+		data[i] = &ValidatorSpans{
+			ChunkIndex: strconv.FormatUint(sp.ChunkIndex, 10),
+			StartEpoch: strconv.FormatUint(uint64(sp.StartEpoch), 10),
+			MinSpans:   make([]string, len(sp.MinSpans)),
+			MaxSpans:   make([]string, len(sp.MaxSpans)),
+		}
+		for j, minSpan := range sp.MinSpans {
+			data[i].MinSpans[j] = strconv.FormatUint(uint64(minSpan), 10)
+		}
+		for j, maxSpan := range sp.MaxSpans {
+			data[i].MaxSpans[j] = strconv.FormatUint(uint64(maxSpan), 10)
+		}
+	}
+	httputil.WriteJson(w, &ValidatorSpansResponse{Data: data})
+}
+
+// slashingsEpochRange parses the start_epoch and end_epoch query parameters, both optional.
+func slashingsEpochRange(w http.ResponseWriter, r *http.Request) (primitives.Epoch, primitives.Epoch, bool) {
+	_, startEpoch, ok := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !ok {
+		return 0, 0, false
+	}
+	rawEndEpoch, endEpoch, ok := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !ok {
+		return 0, 0, false
+	}
+	if rawEndEpoch == "" {
+		endEpoch = uint64(params.BeaconConfig().FarFutureEpoch)
+	}
+	if startEpoch > endEpoch {
+		httputil.HandleError(w, "Start epoch cannot be greater than end epoch", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return primitives.Epoch(startEpoch), primitives.Epoch(endEpoch), true
+}
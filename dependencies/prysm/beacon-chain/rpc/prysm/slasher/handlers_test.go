@@ -0,0 +1,288 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockSlashingChecker struct {
+	highestAtts       []*ethpb.HighestAttestation
+	proposerSlashing  *ethpb.ProposerSlashing
+	attesterSlashings []*ethpb.AttesterSlashing
+	proposerSlashings []*ethpb.ProposerSlashing
+	spans             []*slashertypes.ValidatorSpans
+	startEpoch        primitives.Epoch
+	endEpoch          primitives.Epoch
+}
+
+func (m *mockSlashingChecker) IsSlashableBlock(context.Context, *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashing, error) {
+	return m.proposerSlashing, nil
+}
+
+func (m *mockSlashingChecker) IsSlashableAttestation(context.Context, *ethpb.IndexedAttestation) ([]*ethpb.AttesterSlashing, error) {
+	return m.attesterSlashings, nil
+}
+
+func (m *mockSlashingChecker) HighestAttestations(context.Context, []primitives.ValidatorIndex) ([]*ethpb.HighestAttestation, error) {
+	return m.highestAtts, nil
+}
+
+func (m *mockSlashingChecker) AttesterSlashings(_ context.Context, startEpoch, endEpoch primitives.Epoch) ([]*ethpb.AttesterSlashing, error) {
+	m.startEpoch, m.endEpoch = startEpoch, endEpoch
+	return m.attesterSlashings, nil
+}
+
+func (m *mockSlashingChecker) ProposerSlashings(_ context.Context, startEpoch, endEpoch primitives.Epoch) ([]*ethpb.ProposerSlashing, error) {
+	m.startEpoch, m.endEpoch = startEpoch, endEpoch
+	return m.proposerSlashings, nil
+}
+
+func (m *mockSlashingChecker) ValidatorSpans(
+	_ context.Context, _ primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.ValidatorSpans, error) {
+	m.startEpoch, m.endEpoch = startEpoch, endEpoch
+	return m.spans, nil
+}
+
+func proposerSlashing(slot primitives.Slot) *ethpb.ProposerSlashing {
+	header := func(root byte) *ethpb.SignedBeaconBlockHeader {
+		return &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:       slot,
+				ParentRoot: make([]byte, 32),
+				StateRoot:  bytes.Repeat([]byte{root}, 32),
+				BodyRoot:   make([]byte, 32),
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+	return &ethpb.ProposerSlashing{Header_1: header(1), Header_2: header(2)}
+}
+
+func TestGetHighestAttestations(t *testing.T) {
+	s := &Server{SlashingChecker: &mockSlashingChecker{
+		highestAtts: []*ethpb.HighestAttestation{{ValidatorIndex: 1, HighestSourceEpoch: 2, HighestTargetEpoch: 3}},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"validator_indices":["1"]}`)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/highest_attestations", body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetHighestAttestations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &HighestAttestationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.DeepEqual(t, []*HighestAttestation{{ValidatorIndex: "1", HighestSourceEpoch: "2", HighestTargetEpoch: "3"}}, resp.Data)
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"validator_indices":["foo"]}`)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/highest_attestations", body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetHighestAttestations(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "ValidatorIndices[0] is invalid", e.Message)
+	})
+	t.Run("no body", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/highest_attestations", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetHighestAttestations(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No data submitted", e.Message)
+	})
+}
+
+func TestIsSlashableBlock(t *testing.T) {
+	slashing := proposerSlashing(1)
+	checker := &mockSlashingChecker{}
+	s := &Server{SlashingChecker: checker}
+	body, err := json.Marshal(shared.SignedBeaconBlockHeaderFromConsensus(slashing.Header_2))
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/is_slashable/block", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.IsSlashableBlock(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &IsSlashableBlockResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, 0, len(resp.Data))
+
+	checker.proposerSlashing = slashing
+	request = httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/is_slashable/block", bytes.NewReader(body))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.IsSlashableBlock(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &IsSlashableBlockResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.DeepEqual(t, []*shared.ProposerSlashing{shared.ProposerSlashingFromConsensus(slashing)}, resp.Data)
+}
+
+func TestIsSlashableAttestation(t *testing.T) {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}})
+	slashing := &ethpb.AttesterSlashing{Attestation_1: att, Attestation_2: att}
+	s := &Server{SlashingChecker: &mockSlashingChecker{attesterSlashings: []*ethpb.AttesterSlashing{slashing}}}
+	body, err := json.Marshal(shared.AttesterSlashingFromConsensus(slashing).Attestation1)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/is_slashable/attestation", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.IsSlashableAttestation(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &IsSlashableAttestationResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.DeepEqual(t, []*shared.AttesterSlashing{shared.AttesterSlashingFromConsensus(slashing)}, resp.Data)
+}
+
+func TestGetProposerSlashings(t *testing.T) {
+	slashings := []*ethpb.ProposerSlashing{proposerSlashing(1), proposerSlashing(2), proposerSlashing(3)}
+	checker := &mockSlashingChecker{proposerSlashings: slashings}
+	s := &Server{SlashingChecker: checker}
+
+	t.Run("pages", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings/proposer?start_epoch=1&end_epoch=2&page_size=2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposerSlashings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ProposerSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, primitives.Epoch(1), checker.startEpoch)
+		assert.Equal(t, primitives.Epoch(2), checker.endEpoch)
+		assert.Equal(t, 3, resp.TotalSize)
+		assert.Equal(t, "1", resp.NextPageToken)
+		require.DeepEqual(t, shared.ProposerSlashingsFromConsensus(slashings[:2]), resp.Data)
+
+		request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings/proposer?page_size=2&page_token=1", nil)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposerSlashings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &ProposerSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, primitives.Epoch(0), checker.startEpoch)
+		assert.Equal(t, params.BeaconConfig().FarFutureEpoch, checker.endEpoch)
+		assert.Equal(t, "", resp.NextPageToken)
+		require.DeepEqual(t, shared.ProposerSlashingsFromConsensus(slashings[2:]), resp.Data)
+	})
+	t.Run("no slashings", func(t *testing.T) {
+		s := &Server{SlashingChecker: &mockSlashingChecker{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings/proposer", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposerSlashings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ProposerSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, resp.TotalSize)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("start epoch after end epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings/proposer?start_epoch=3&end_epoch=2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposerSlashings(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Start epoch cannot be greater than end epoch", e.Message)
+	})
+	t.Run("page out of range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings/proposer?page_size=2&page_token=2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposerSlashings(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Could not paginate results", e.Message)
+	})
+}
+
+func TestGetAttesterSlashings(t *testing.T) {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}})
+	slashings := []*ethpb.AttesterSlashing{{Attestation_1: att, Attestation_2: att}}
+	s := &Server{SlashingChecker: &mockSlashingChecker{attesterSlashings: slashings}}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings/attester", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetAttesterSlashings(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &AttesterSlashingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, 1, resp.TotalSize)
+	assert.Equal(t, "", resp.NextPageToken)
+	require.DeepEqual(t, shared.AttesterSlashingsFromConsensus(slashings), resp.Data)
+}
+
+func TestGetValidatorSpans(t *testing.T) {
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(5)
+	checker := &mockSlashingChecker{spans: []*slashertypes.ValidatorSpans{
+		{ChunkIndex: 1, StartEpoch: 4, MinSpans: []uint16{1, 2}, MaxSpans: []uint16{3, 4}},
+	}}
+	s := &Server{SlashingChecker: checker, GenesisTimeFetcher: &mock.ChainService{Slot: &slot}}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/spans/1?start_epoch=4", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorSpans(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorSpansResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		// The end epoch defaults to the current epoch.
+		assert.Equal(t, primitives.Epoch(4), checker.startEpoch)
+		assert.Equal(t, primitives.Epoch(5), checker.endEpoch)
+		require.DeepEqual(t, []*ValidatorSpans{
+			{ChunkIndex: "1", StartEpoch: "4", MinSpans: []string{"1", "2"}, MaxSpans: []string{"3", "4"}},
+		}, resp.Data)
+	})
+	t.Run("invalid validator index", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/spans/foo", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "foo"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorSpans(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("start epoch after end epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/spans/1?start_epoch=6", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorSpans(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Start epoch cannot be greater than end epoch", e.Message)
+	})
+}
@@ -0,0 +1,11 @@
+package slasher
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+)
+
+type Server struct {
+	SlashingChecker    slasherservice.SlashingChecker
+	GenesisTimeFetcher blockchain.TimeFetcher
+}
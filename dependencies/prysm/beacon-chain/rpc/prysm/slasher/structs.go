@@ -0,0 +1,48 @@
+package slasher
+
+import "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+
+type HighestAttestationsRequest struct {
+	ValidatorIndices []string `json:"validator_indices"`
+}
+
+type HighestAttestationsResponse struct {
+	Data []*HighestAttestation `json:"data"`
+}
+
+type HighestAttestation struct {
+	ValidatorIndex     string `json:"validator_index"`
+	HighestSourceEpoch string `json:"highest_source_epoch"`
+	HighestTargetEpoch string `json:"highest_target_epoch"`
+}
+
+type IsSlashableBlockResponse struct {
+	Data []*shared.ProposerSlashing `json:"data"`
+}
+
+type IsSlashableAttestationResponse struct {
+	Data []*shared.AttesterSlashing `json:"data"`
+}
+
+type AttesterSlashingsResponse struct {
+	Data          []*shared.AttesterSlashing `json:"data"`
+	NextPageToken string                     `json:"next_page_token"`
+	TotalSize     int                        `json:"total_size"`
+}
+
+type ProposerSlashingsResponse struct {
+	Data          []*shared.ProposerSlashing `json:"data"`
+	NextPageToken string                     `json:"next_page_token"`
+	TotalSize     int                        `json:"total_size"`
+}
+
+type ValidatorSpansResponse struct {
+	Data []*ValidatorSpans `json:"data"`
+}
+
+type ValidatorSpans struct {
+	ChunkIndex string   `json:"chunk_index"`
+	StartEpoch string   `json:"start_epoch"`
+	MinSpans   []string `json:"min_spans"`
+	MaxSpans   []string `json:"max_spans"`
+}
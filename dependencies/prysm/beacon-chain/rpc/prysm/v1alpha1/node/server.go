@@ -231,7 +231,7 @@ func (ns *Server) GetETH1ConnectionStatus(_ context.Context, _ *empty.Empty) (*e
 	return &ethpb.ETH1ConnectionStatus{
 		CurrentAddress:         ns.POWChainInfoFetcher.ExecutionClientEndpoint(),
 		CurrentConnectionError: currErr,
-		Addresses:              []string{ns.POWChainInfoFetcher.ExecutionClientEndpoint()},
+		Addresses:              ns.POWChainInfoFetcher.ExecutionClientEndpoints(),
 	}, nil
 }
 
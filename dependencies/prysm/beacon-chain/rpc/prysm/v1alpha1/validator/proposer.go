@@ -114,7 +114,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		builderBoostFactor = req.BuilderBoostFactor.Value
 	}
 
-	if err = vs.BuildBlockParallel(ctx, sBlk, head, req.SkipMevBoost, builderBoostFactor); err != nil {
+	if err = vs.BuildBlockParallel(ctx, sBlk, head, req.SkipMevBoost, builderBoostFactor, req.BuilderMinBidGwei); err != nil {
 		return nil, errors.Wrap(err, "could not build block in parallel")
 	}
 
@@ -134,7 +134,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	return vs.constructGenericBeaconBlock(sBlk, bundleCache.get(req.Slot))
 }
 
-func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState, skipMevBoost bool, builderBoostFactor uint64) error {
+func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState, skipMevBoost bool, builderBoostFactor, builderMinBid uint64) error {
 	// Build consensus fields in background
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -180,21 +180,35 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 		return status.Errorf(codes.Internal, "Could not get local payload: %v", err)
 	}
 
+	decision := &cache.PayloadDecision{
+		Slot:               sBlk.Block().Slot(),
+		ProposerIndex:      sBlk.Block().ProposerIndex(),
+		BuilderBoostFactor: builderBoostFactor,
+		MinBidGwei:         builderMinBid,
+	}
 	// There's no reason to try to get a builder bid if local override is true.
 	var builderPayload interfaces.ExecutionData
 	var builderKzgCommitments [][]byte
-	overrideBuilder = overrideBuilder || skipMevBoost // Skip using mev-boost if requested by the caller.
-	if !overrideBuilder {
-		builderPayload, builderKzgCommitments, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+	switch {
+	case overrideBuilder:
+		decision.Reason = reasonExecutionOverride
+	case skipMevBoost: // Skip using mev-boost if requested by the caller.
+		decision.Reason = reasonSkipMevBoost
+	default:
+		builderPayload, builderKzgCommitments, decision.BuilderValueGwei, decision.Reason, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
 		if err != nil {
 			builderGetPayloadMissCount.Inc()
 			log.WithError(err).Error("Could not get builder payload")
+			decision.Reason = reasonBuilderError
 		}
 	}
 
-	if err := setExecutionData(ctx, sBlk, localPayload, builderPayload, builderKzgCommitments, builderBoostFactor); err != nil {
+	if err := setExecutionData(ctx, sBlk, localPayload, builderPayload, builderKzgCommitments, decision); err != nil {
 		return status.Errorf(codes.Internal, "Could not set execution data: %v", err)
 	}
+	if slots.ToEpoch(decision.Slot) >= params.BeaconConfig().BellatrixForkEpoch {
+		vs.recordPayloadDecision(*decision)
+	}
 
 	wg.Wait() // Wait until block is built via consensus and execution fields.
 
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
@@ -18,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v4/math"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
@@ -41,7 +43,8 @@ var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24
 const blockBuilderTimeout = 1 * time.Second
 
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
-func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData, builderKzgCommitments [][]byte, builderBoostFactor uint64) error {
+// The decision is filled with the source of the execution data and the reason for it, its reason being already set when the builder couldn't be used.
+func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData, builderKzgCommitments [][]byte, decision *cache.PayloadDecision) error {
 	_, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
 	defer span.End()
 
@@ -56,6 +59,9 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 
 	// Use local payload if builder payload is nil.
 	if builderPayload == nil {
+		if decision.Reason == "" {
+			decision.Reason = reasonNoBuilderBid
+		}
 		return setLocalExecution(blk, localPayload)
 	}
 
@@ -66,22 +72,28 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		if err != nil {
 			return errors.Wrap(err, "failed to get local payload value")
 		}
+		decision.LocalValueGwei = localValueGwei
 		builderValueGwei, err := builderPayload.ValueInGwei()
 		if err != nil {
 			log.WithError(err).Warn("Proposer: failed to get builder payload value") // Default to local if can't get builder value.
+			decision.Reason = reasonInvalidBuilderBid
 			return setLocalExecution(blk, localPayload)
 		}
+		decision.BuilderValueGwei = builderValueGwei
 
 		withdrawalsMatched, err := matchingWithdrawalsRoot(localPayload, builderPayload)
 		if err != nil {
 			tracing.AnnotateError(span, err)
 			log.WithError(err).Warn("Proposer: failed to match withdrawals root")
+			decision.Reason = reasonWithdrawalsMismatch
 			return setLocalExecution(blk, localPayload)
 		}
 
 		// Use builder payload if the following in true:
 		// builder_bid_value * builderBoostFactor(default 100) > local_block_value * (local-block-value-boost + 100)
+		// and builder_bid_value >= min_bid, the minimum bid set in the proposer settings of the validator.
 		boost := params.BeaconConfig().LocalBlockValueBoost
+		builderBoostFactor := decision.BuilderBoostFactor
 		higherValueBuilder := builderValueGwei*builderBoostFactor > localValueGwei*(100+boost)
 		if boost > 0 && builderBoostFactor != defaultBuilderBoostFactor {
 			log.WithFields(logrus.Fields{
@@ -91,23 +103,37 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 				"builderBoostFactor":   builderBoostFactor,
 			}).Warn("Proposer: both local boost and builder boost are using non default values")
 		}
+		minBidMet := builderValueGwei >= decision.MinBidGwei
 
 		// If we can't get the builder value, just use local block.
-		if higherValueBuilder && withdrawalsMatched { // Builder value is higher and withdrawals match.
+		if higherValueBuilder && withdrawalsMatched && minBidMet { // Builder value is higher and withdrawals match.
 			if err := setBuilderExecution(blk, builderPayload, builderKzgCommitments); err != nil {
 				log.WithError(err).Warn("Proposer: failed to set builder payload")
+				decision.Reason = reasonInvalidBuilderPayload
 				return setLocalExecution(blk, localPayload)
 			} else {
+				decision.Builder = true
+				decision.Reason = reasonBuilderHigherValue
 				return nil
 			}
 		}
-		if !higherValueBuilder {
+		switch {
+		case !higherValueBuilder:
 			log.WithFields(logrus.Fields{
 				"localGweiValue":       localValueGwei,
 				"localBoostPercentage": boost,
 				"builderGweiValue":     builderValueGwei,
 				"builderBoostFactor":   builderBoostFactor,
 			}).Warn("Proposer: using local execution payload because higher value")
+			decision.Reason = reasonLocalHigherValue
+		case !minBidMet:
+			log.WithFields(logrus.Fields{
+				"builderGweiValue": builderValueGwei,
+				"minBidGwei":       decision.MinBidGwei,
+			}).Warn("Proposer: using local execution payload because builder bid is below the minimum bid")
+			decision.Reason = reasonBelowMinBid
+		default:
+			decision.Reason = reasonWithdrawalsMismatch
 		}
 		span.AddAttributes(
 			trace.BoolAttribute("higherValueBuilder", higherValueBuilder),
@@ -115,40 +141,53 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 			trace.Int64Attribute("localBoostPercentage", int64(boost)),            // lint:ignore uintcast -- This is OK for tracing.
 			trace.Int64Attribute("builderGweiValue", int64(builderValueGwei)),     // lint:ignore uintcast -- This is OK for tracing.
 			trace.Int64Attribute("builderBoostFactor", int64(builderBoostFactor)), // lint:ignore uintcast -- This is OK for tracing.
+			trace.Int64Attribute("minBidGwei", int64(decision.MinBidGwei)),        // lint:ignore uintcast -- This is OK for tracing.
 		)
 		return setLocalExecution(blk, localPayload)
 	default: // Bellatrix case.
+		// Bellatrix headers don't carry the bid value, which is recorded in the decision when the bid is received.
+		if decision.BuilderValueGwei < decision.MinBidGwei {
+			log.WithFields(logrus.Fields{
+				"builderGweiValue": decision.BuilderValueGwei,
+				"minBidGwei":       decision.MinBidGwei,
+			}).Warn("Proposer: using local execution payload because builder bid is below the minimum bid")
+			decision.Reason = reasonBelowMinBid
+			return setLocalExecution(blk, localPayload)
+		}
 		if err := setBuilderExecution(blk, builderPayload, builderKzgCommitments); err != nil {
 			log.WithError(err).Warn("Proposer: failed to set builder payload")
+			decision.Reason = reasonInvalidBuilderPayload
 			return setLocalExecution(blk, localPayload)
 		} else {
+			decision.Builder = true
+			decision.Reason = reasonBuilderBid
 			return nil
 		}
 	}
 }
 
-// This function retrieves the payload header and kzg commitments given the slot number and the validator index.
+// This function retrieves the payload header, kzg commitments and bid value in Gwei given the slot number and the validator index.
 // It's a no-op if the latest head block is not versioned bellatrix.
-func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (interfaces.ExecutionData, [][]byte, error) {
+func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (interfaces.ExecutionData, [][]byte, uint64, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.getPayloadHeaderFromBuilder")
 	defer span.End()
 
 	if slots.ToEpoch(slot) < params.BeaconConfig().BellatrixForkEpoch {
-		return nil, nil, errors.New("can't get payload header from builder before bellatrix epoch")
+		return nil, nil, 0, errors.New("can't get payload header from builder before bellatrix epoch")
 	}
 
 	b, err := vs.HeadFetcher.HeadBlock(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 
 	h, err := b.Block().Body().Execution()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to get execution header")
+		return nil, nil, 0, errors.Wrap(err, "failed to get execution header")
 	}
 	pk, err := vs.HeadFetcher.HeadValidatorIndexToPublicKey(ctx, idx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
@@ -156,76 +195,76 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 
 	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 	if signedBid.IsNil() {
-		return nil, nil, errors.New("builder returned nil bid")
+		return nil, nil, 0, errors.New("builder returned nil bid")
 	}
 	fork, err := forks.Fork(slots.ToEpoch(slot))
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "unable to get fork information")
+		return nil, nil, 0, errors.Wrap(err, "unable to get fork information")
 	}
 	forkName, ok := params.BeaconConfig().ForkVersionNames[bytesutil.ToBytes4(fork.CurrentVersion)]
 	if !ok {
-		return nil, nil, errors.New("unable to find current fork in schedule")
+		return nil, nil, 0, errors.New("unable to find current fork in schedule")
 	}
 	if !strings.EqualFold(version.String(signedBid.Version()), forkName) {
-		return nil, nil, fmt.Errorf("builder bid response version: %d is different from head block version: %d for epoch %d", signedBid.Version(), b.Version(), slots.ToEpoch(slot))
+		return nil, nil, 0, fmt.Errorf("builder bid response version: %d is different from head block version: %d for epoch %d", signedBid.Version(), b.Version(), slots.ToEpoch(slot))
 	}
 
 	bid, err := signedBid.Message()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not get bid")
+		return nil, nil, 0, errors.Wrap(err, "could not get bid")
 	}
 	if bid.IsNil() {
-		return nil, nil, errors.New("builder returned nil bid")
+		return nil, nil, 0, errors.New("builder returned nil bid")
 	}
 
 	v := bytesutil.LittleEndianBytesToBigInt(bid.Value())
 	if v.String() == "0" {
-		return nil, nil, errors.New("builder returned header with 0 bid amount")
+		return nil, nil, 0, errors.New("builder returned header with 0 bid amount")
 	}
 
 	header, err := bid.Header()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not get bid header")
+		return nil, nil, 0, errors.Wrap(err, "could not get bid header")
 	}
 	txRoot, err := header.TransactionsRoot()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not get transaction root")
+		return nil, nil, 0, errors.Wrap(err, "could not get transaction root")
 	}
 	if bytesutil.ToBytes32(txRoot) == emptyTransactionsRoot {
-		return nil, nil, errors.New("builder returned header with an empty tx root")
+		return nil, nil, 0, errors.New("builder returned header with an empty tx root")
 	}
 
 	if !bytes.Equal(header.ParentHash(), h.BlockHash()) {
-		return nil, nil, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), h.BlockHash())
+		return nil, nil, 0, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), h.BlockHash())
 	}
 
 	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 	if header.Timestamp() != uint64(t.Unix()) {
-		return nil, nil, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
+		return nil, nil, 0, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
 	}
 
 	if err := validateBuilderSignature(signedBid); err != nil {
-		return nil, nil, errors.Wrap(err, "could not validate builder signature")
+		return nil, nil, 0, errors.Wrap(err, "could not validate builder signature")
 	}
 
 	var kzgCommitments [][]byte
 	if bid.Version() >= version.Deneb {
 		kzgCommitments, err = bid.BlobKzgCommitments()
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "could not get blob kzg commitments")
+			return nil, nil, 0, errors.Wrap(err, "could not get blob kzg commitments")
 		}
 		if len(kzgCommitments) > fieldparams.MaxBlobsPerBlock {
-			return nil, nil, fmt.Errorf("builder returned too many kzg commitments: %d", len(kzgCommitments))
+			return nil, nil, 0, fmt.Errorf("builder returned too many kzg commitments: %d", len(kzgCommitments))
 		}
 		for _, c := range kzgCommitments {
 			if len(c) != fieldparams.BLSPubkeyLength {
-				return nil, nil, fmt.Errorf("builder returned invalid kzg commitment length: %d", len(c))
+				return nil, nil, 0, fmt.Errorf("builder returned invalid kzg commitment length: %d", len(c))
 			}
 		}
 	}
@@ -249,7 +288,7 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 		trace.StringAttribute("blockHash", fmt.Sprintf("%#x", header.BlockHash())),
 	)
 
-	return header, kzgCommitments, nil
+	return header, kzgCommitments, uint64(math.WeiToGwei(v)), nil
 }
 
 // Validates builder signature and returns an error if the signature is invalid.
@@ -2,37 +2,57 @@ package validator
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
-// Returns true if builder (ie outsourcing block construction) can be used. Both conditions have to meet:
+// Returns true if builder (ie outsourcing block construction) can be used, or the reason why it can't otherwise. All conditions have to meet:
 // - Validator has registered to use builder (ie called registerBuilder API end point)
 // - Circuit breaker has not been activated (ie the liveness of the chain is healthy)
-func (vs *Server) canUseBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (bool, error) {
+// - Local block construction is not forced after the liveness of the chain degraded (see localBlockPolicy)
+func (vs *Server) canUseBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (bool, string, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.canUseBuilder")
 	defer span.End()
 
 	if !vs.BlockBuilder.Configured() {
-		return false, nil
+		return false, reasonBuilderNotConfigured, nil
 	}
 	activated, err := vs.circuitBreakBuilder(slot)
 	span.AddAttributes(trace.BoolAttribute("circuitBreakerActivated", activated))
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return false, err
+		return false, "", err
 	}
+	epoch := slots.ToEpoch(slot)
 	if activated {
-		return false, nil
+		vs.localBlockPolicy.force(epoch, reasonCircuitBreaker)
+		return false, reasonCircuitBreaker, nil
+	}
+	if vs.finalityStalled(epoch) {
+		vs.localBlockPolicy.force(epoch, reasonFinalityStalled)
+		return false, reasonFinalityStalled, nil
+	}
+	if vs.localBlockPolicy.forced(epoch) {
+		return false, reasonLocalBlockPolicy, nil
+	}
+	registered, err := vs.validatorRegistered(ctx, idx)
+	if err != nil {
+		return false, "", err
+	}
+	if !registered {
+		return false, reasonValidatorNotRegistered, nil
 	}
-	return vs.validatorRegistered(ctx, idx)
+	return true, "", nil
 }
 
 // validatorRegistered returns true if validator with index `id` was previously registered in the database.
@@ -98,3 +118,74 @@ func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
 
 	return false, nil
 }
+
+// finalityStalled returns true if the chain has not finalized for long enough to be in an inactivity leak, in which case
+// local block construction is forced when the local block policy is enabled.
+func (vs *Server) finalityStalled(epoch primitives.Epoch) bool {
+	if params.BeaconConfig().LocalBlockEpochsAfterStall == 0 || vs.FinalizationFetcher == nil {
+		return false
+	}
+	finalized := vs.FinalizationFetcher.FinalizedCheckpt()
+	if finalized == nil {
+		return false
+	}
+	prevEpoch := epoch
+	if prevEpoch > 0 {
+		prevEpoch--
+	}
+	if !helpers.IsInInactivityLeak(prevEpoch, finalized.Epoch) {
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"epoch":          epoch,
+		"finalizedEpoch": finalized.Epoch,
+	}).Warn("Finality has stalled, using local execution payloads instead of the builder")
+	return true
+}
+
+// localBlockPolicy forces local block construction for `LocalBlockEpochsAfterStall` epochs after the liveness of the chain
+// degraded, ie after the circuit breaker was activated or finality stalled, so that the builder is not used again
+// as soon as the chain starts recovering.
+type localBlockPolicy struct {
+	sync.Mutex
+	active bool
+	until  primitives.Epoch
+	cause  string
+}
+
+// force forces local block construction up to `LocalBlockEpochsAfterStall` epochs after the given epoch.
+func (p *localBlockPolicy) force(epoch primitives.Epoch, cause string) {
+	n := params.BeaconConfig().LocalBlockEpochsAfterStall
+	if n == 0 {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	until := epoch.Add(uint64(n))
+	if p.active && until <= p.until {
+		return
+	}
+	p.active, p.until, p.cause = true, until, cause
+	log.WithFields(logrus.Fields{
+		"cause":      cause,
+		"untilEpoch": until,
+	}).Info("Forcing local execution payloads until the chain has recovered")
+}
+
+// forced returns true if local block construction is forced at the given epoch.
+func (p *localBlockPolicy) forced(epoch primitives.Epoch) bool {
+	p.Lock()
+	defer p.Unlock()
+	if !p.active {
+		return false
+	}
+	if epoch > p.until {
+		p.active = false
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"cause":      p.cause,
+		"untilEpoch": p.until,
+	}).Debug("Local execution payloads are forced after the chain liveness degraded")
+	return true
+}
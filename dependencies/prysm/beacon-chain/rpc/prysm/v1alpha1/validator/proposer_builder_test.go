@@ -19,6 +19,7 @@ import (
 	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -110,38 +111,112 @@ func TestServer_canUseBuilder(t *testing.T) {
 			HasConfigured: false,
 		},
 	}
-	reg, err := proposerServer.canUseBuilder(context.Background(), 0, 0)
+	reg, reason, err := proposerServer.canUseBuilder(context.Background(), 0, 0)
 	require.NoError(t, err)
 	require.Equal(t, false, reg)
+	require.Equal(t, reasonBuilderNotConfigured, reason)
 
 	ctx := context.Background()
 
 	proposerServer.ForkchoiceFetcher = &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New()}
 	proposerServer.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
-	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots+1, 0)
-	require.NoError(t, err)
-	require.Equal(t, false, reg)
 	db := dbTest.SetupDB(t)
 
 	proposerServer.BlockBuilder = &testing2.MockBuilderService{
 		HasConfigured: true,
 		Cfg:           &testing2.Config{BeaconDB: db},
 	}
+	reg, reason, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots+1, 0)
+	require.NoError(t, err)
+	require.Equal(t, false, reg)
+	require.Equal(t, reasonCircuitBreaker, reason)
 
-	reg, err = proposerServer.canUseBuilder(ctx, 1, 0)
+	reg, reason, err = proposerServer.canUseBuilder(ctx, 1, 0)
 	require.NoError(t, err)
 	require.Equal(t, false, reg)
+	require.Equal(t, reasonValidatorNotRegistered, reason)
 
 	f := bytesutil.PadTo([]byte{}, fieldparams.FeeRecipientLength)
 	p := bytesutil.PadTo([]byte{}, fieldparams.BLSPubkeyLength)
 	require.NoError(t, db.SaveRegistrationsByValidatorIDs(ctx, []primitives.ValidatorIndex{0},
 		[]*ethpb.ValidatorRegistrationV1{{FeeRecipient: f, Timestamp: uint64(time.Now().Unix()), Pubkey: p}}))
 
-	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots-1, 0)
+	reg, _, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots-1, 0)
 	require.NoError(t, err)
 	require.Equal(t, true, reg)
 }
 
+func TestServer_canUseBuilder_LocalBlockPolicy(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.LocalBlockEpochsAfterStall = 2
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	db := dbTest.SetupDB(t)
+	f := bytesutil.PadTo([]byte{}, fieldparams.FeeRecipientLength)
+	p := bytesutil.PadTo([]byte{}, fieldparams.BLSPubkeyLength)
+	require.NoError(t, db.SaveRegistrationsByValidatorIDs(ctx, []primitives.ValidatorIndex{0},
+		[]*ethpb.ValidatorRegistrationV1{{FeeRecipient: f, Timestamp: uint64(time.Now().Unix()), Pubkey: p}}))
+	chain := &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New(), FinalizedCheckPoint: &ethpb.Checkpoint{Epoch: 0}}
+	proposerServer := &Server{
+		BlockBuilder: &testing2.MockBuilderService{
+			HasConfigured: true,
+			Cfg:           &testing2.Config{BeaconDB: db},
+		},
+		ForkchoiceFetcher:   chain,
+		FinalizationFetcher: chain,
+	}
+	proposerServer.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	insertBlock := func(slot primitives.Slot) {
+		st, blkRoot, err := createState(slot, [32]byte{byte(slot)}, [32]byte{byte(slot - 1)}, params.BeaconConfig().ZeroHash, ojc, ofc)
+		require.NoError(t, err)
+		require.NoError(t, proposerServer.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
+	}
+
+	// The circuit breaker forces local blocks for two more epochs.
+	slot := params.BeaconConfig().MaxBuilderConsecutiveMissedSlots + 1
+	reg, reason, err := proposerServer.canUseBuilder(ctx, slot, 0)
+	require.NoError(t, err)
+	require.Equal(t, false, reg)
+	require.Equal(t, reasonCircuitBreaker, reason)
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	for s := primitives.Slot(1); s < 3*slotsPerEpoch; s++ {
+		insertBlock(s)
+	}
+	reg, reason, err = proposerServer.canUseBuilder(ctx, 3*slotsPerEpoch-1, 0)
+	require.NoError(t, err)
+	require.Equal(t, false, reg)
+	require.Equal(t, reasonLocalBlockPolicy, reason)
+
+	insertBlock(3 * slotsPerEpoch)
+	reg, _, err = proposerServer.canUseBuilder(ctx, 3*slotsPerEpoch+1, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, reg)
+
+	// Local blocks are forced while finality is stalled, and for two epochs after it recovered.
+	stalled := 3 + params.BeaconConfig().MinEpochsToInactivityPenalty
+	startSlot := 3*slotsPerEpoch + 1
+	endSlot, err := slots.EpochStart(stalled.Add(1))
+	require.NoError(t, err)
+	for s := startSlot; s <= endSlot; s++ {
+		insertBlock(s)
+	}
+	reg, reason, err = proposerServer.canUseBuilder(ctx, endSlot+1, 0)
+	require.NoError(t, err)
+	require.Equal(t, false, reg)
+	require.Equal(t, reasonFinalityStalled, reason)
+
+	chain.FinalizedCheckPoint = &ethpb.Checkpoint{Epoch: stalled}
+	reg, reason, err = proposerServer.canUseBuilder(ctx, endSlot+1, 0)
+	require.NoError(t, err)
+	require.Equal(t, false, reg)
+	require.Equal(t, reasonLocalBlockPolicy, reason)
+}
+
 func createState(
 	slot primitives.Slot,
 	blockRoot [32]byte,
@@ -224,25 +224,28 @@ func (vs *Server) getTerminalBlockHashIfExists(ctx context.Context, transitionTi
 	return vs.ExecutionEngineCaller.GetTerminalBlockHash(ctx, transitionTime)
 }
 
+// getBuilderPayloadAndBlobs returns the builder payload header, kzg commitments and bid value in Gwei for the given slot and
+// validator index, or the reason why the builder can't be used when the payload is nil.
 func (vs *Server) getBuilderPayloadAndBlobs(ctx context.Context,
 	slot primitives.Slot,
-	vIdx primitives.ValidatorIndex) (interfaces.ExecutionData, [][]byte, error) {
+	vIdx primitives.ValidatorIndex) (interfaces.ExecutionData, [][]byte, uint64, string, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.getBuilderPayloadAndBlobs")
 	defer span.End()
 
 	if slots.ToEpoch(slot) < params.BeaconConfig().BellatrixForkEpoch {
-		return nil, nil, nil
+		return nil, nil, 0, "", nil
 	}
-	canUseBuilder, err := vs.canUseBuilder(ctx, slot, vIdx)
+	canUseBuilder, reason, err := vs.canUseBuilder(ctx, slot, vIdx)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to check if we can use the builder")
+		return nil, nil, 0, "", errors.Wrap(err, "failed to check if we can use the builder")
 	}
 	span.AddAttributes(trace.BoolAttribute("canUseBuilder", canUseBuilder))
 	if !canUseBuilder {
-		return nil, nil, nil
+		return nil, nil, 0, reason, nil
 	}
 
-	return vs.getPayloadHeaderFromBuilder(ctx, slot, vIdx)
+	payload, kzgCommitments, valueGwei, err := vs.getPayloadHeaderFromBuilder(ctx, slot, vIdx)
+	return payload, kzgCommitments, valueGwei, "", err
 }
 
 var errActivationNotReached = errors.New("activation epoch not reached")
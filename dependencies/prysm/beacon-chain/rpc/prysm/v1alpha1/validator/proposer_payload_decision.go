@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/sirupsen/logrus"
+)
+
+// Reasons for a proposed block to use the local execution payload or the builder bid.
+const (
+	reasonBuilderHigherValue     = "builder_higher_value"
+	reasonBuilderBid             = "builder_bid"
+	reasonLocalHigherValue       = "local_higher_value"
+	reasonBelowMinBid            = "below_min_bid"
+	reasonWithdrawalsMismatch    = "withdrawals_mismatch"
+	reasonInvalidBuilderBid      = "invalid_builder_bid"
+	reasonInvalidBuilderPayload  = "invalid_builder_payload"
+	reasonNoBuilderBid           = "no_builder_bid"
+	reasonBuilderError           = "builder_error"
+	reasonBuilderNotConfigured   = "builder_not_configured"
+	reasonValidatorNotRegistered = "validator_not_registered"
+	reasonCircuitBreaker         = "circuit_breaker"
+	reasonFinalityStalled        = "finality_stalled"
+	reasonLocalBlockPolicy       = "local_only_after_stall"
+	reasonSkipMevBoost           = "skip_mev_boost"
+	reasonExecutionOverride      = "execution_override"
+)
+
+// payloadDecisionCount tracks the number of proposed blocks using the local execution payload or the builder bid, by reason.
+var payloadDecisionCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "proposer_payload_decision_total",
+	Help: "The number of proposed blocks using the local execution payload or the builder bid, by reason",
+}, []string{"source", "reason"})
+
+// recordPayloadDecision logs the execution payload decision of a proposed block, and records it in the metrics and the
+// payload decision cache served by the debug endpoint.
+func (vs *Server) recordPayloadDecision(d cache.PayloadDecision) {
+	source := "local"
+	if d.Builder {
+		source = "builder"
+	}
+	payloadDecisionCount.WithLabelValues(source, d.Reason).Inc()
+	vs.PayloadDecisionCache.Add(d)
+	log.WithFields(logrus.Fields{
+		"slot":               d.Slot,
+		"proposerIndex":      d.ProposerIndex,
+		"source":             source,
+		"reason":             d.Reason,
+		"localGweiValue":     d.LocalValueGwei,
+		"builderGweiValue":   d.BuilderValueGwei,
+		"builderBoostFactor": d.BuilderBoostFactor,
+		"minBidGwei":         d.MinBidGwei,
+	}).Info("Proposer: selected execution payload")
+}
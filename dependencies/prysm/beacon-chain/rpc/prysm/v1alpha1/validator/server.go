@@ -46,6 +46,7 @@ type Server struct {
 	Ctx                    context.Context
 	PayloadIDCache         *cache.PayloadIDCache
 	TrackedValidatorsCache *cache.TrackedValidatorsCache
+	PayloadDecisionCache   *cache.PayloadDecisionCache
 	HeadFetcher            blockchain.HeadFetcher
 	ForkFetcher            blockchain.ForkFetcher
 	ForkchoiceFetcher      blockchain.ForkchoiceFetcher
@@ -79,6 +80,7 @@ type Server struct {
 	BLSChangesPool         blstoexec.PoolManager
 	ClockWaiter            startup.ClockWaiter
 	CoreService            *core.Service
+	localBlockPolicy       localBlockPolicy
 }
 
 // WaitForActivation checks if a validator public key exists in the active validator registry of the current
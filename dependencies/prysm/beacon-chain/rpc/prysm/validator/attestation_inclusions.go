@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"go.opencensus.io/trace"
+)
+
+type AttestationInclusionsResponse struct {
+	Data          []*AttestationInclusion `json:"data"`
+	NextPageToken string                  `json:"next_page_token"`
+}
+
+type AttestationInclusion struct {
+	Slot      string `json:"slot"`
+	BlockRoot string `json:"block_root"`
+}
+
+// GetAttestationInclusions pages through the blocks including aggregated attestations the validator took part in,
+// with a slot between the start_slot and end_slot query parameters, both inclusive, in ascending order of slot.
+// Only canonical blocks are returned, and the blocks of the last epoch are not indexed yet. Only the requested
+// page is read from the database, the next_page_token pointing at the first inclusion of the next page. It
+// requires the node to run with the --index-attestation-inclusions flag.
+//
+// Example usage:
+//
+//	GET /prysm/v1/validators/123/attestation_inclusions?start_slot=1000&page_size=100
+func (s *Server) GetAttestationInclusions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetAttestationInclusions")
+	defer span.End()
+
+	if !features.Get().IndexAttestationInclusions {
+		httputil.HandleError(w, "Attestation inclusions are not indexed by this node", http.StatusNotFound)
+		return
+	}
+	_, index, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return
+	}
+	_, startSlot, ok := shared.UintFromQuery(w, r, "start_slot", false)
+	if !ok {
+		return
+	}
+	rawEndSlot, endSlot, ok := shared.UintFromQuery(w, r, "end_slot", false)
+	if !ok {
+		return
+	}
+	if rawEndSlot == "" {
+		endSlot = uint64(params.BeaconConfig().FarFutureSlot)
+	}
+	if startSlot > endSlot {
+		httputil.HandleError(w, fmt.Sprintf("start_slot %d is after end_slot %d", startSlot, endSlot), http.StatusBadRequest)
+		return
+	}
+	pageSize, ok := shared.PageSizeFromQuery(w, r)
+	if !ok {
+		return
+	}
+	from := &dbval.AttestationInclusion{Slot: startSlot}
+	if pageToken := r.URL.Query().Get("page_token"); pageToken != "" {
+		var err error
+		from, err = decodeAttestationInclusionsPageToken(pageToken)
+		if err != nil || from.Slot < startSlot {
+			httputil.HandleError(w, "Invalid page_token: "+pageToken, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// One more inclusion than the page size is read to find the start of the next page.
+	inclusions, err := s.BeaconDB.AttestationInclusions(
+		ctx, primitives.ValidatorIndex(index), from, primitives.Slot(endSlot), pageSize+1,
+	)
+	if err != nil {
+		httputil.HandleError(w, "Could not get attestation inclusions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var nextPageToken string
+	if len(inclusions) > pageSize {
+		nextPageToken = encodeAttestationInclusionsPageToken(inclusions[pageSize])
+		inclusions = inclusions[:pageSize]
+	}
+	data := make([]*AttestationInclusion, 0, len(inclusions))
+	for _, inclusion := range inclusions {
+		data = append(data, &AttestationInclusion{
+			Slot:      strconv.FormatUint(inclusion.Slot, 10),
+			BlockRoot: hexutil.Encode(inclusion.BlockRoot),
+		})
+	}
+	httputil.WriteJson(w, &AttestationInclusionsResponse{
+		Data:          data,
+		NextPageToken: nextPageToken,
+	})
+}
+
+// encodeAttestationInclusionsPageToken encodes the slot and block root of the first inclusion of a page.
+func encodeAttestationInclusionsPageToken(inclusion *dbval.AttestationInclusion) string {
+	return hexutil.Encode(append(bytesutil.Uint64ToBytesBigEndian(inclusion.Slot), inclusion.BlockRoot...))
+}
+
+func decodeAttestationInclusionsPageToken(token string) (*dbval.AttestationInclusion, error) {
+	b, err := hexutil.Decode(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 8+fieldparams.RootLength {
+		return nil, fmt.Errorf("page token has length %d", len(b))
+	}
+	return &dbval.AttestationInclusion{Slot: bytesutil.BytesToUint64BigEndian(b[:8]), BlockRoot: b[8:]}, nil
+}
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGetAttestationInclusions(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+	inclusions := make([]*db.BlockAttestationInclusions, 0)
+	for slot := primitives.Slot(1); slot <= 5; slot++ {
+		inclusions = append(inclusions, &db.BlockAttestationInclusions{Slot: slot, BlockRoot: [32]byte{byte(slot)}, Indices: []primitives.ValidatorIndex{1, 2}})
+	}
+	require.NoError(t, beaconDB.SaveAttestationInclusions(ctx, inclusions))
+
+	get := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/1/attestation_inclusions"+query, nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetAttestationInclusions(writer, request)
+		return writer
+	}
+
+	t.Run("not indexed", func(t *testing.T) {
+		writer := get(t, "")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	resetCfg := features.InitWithReset(&features.Flags{IndexAttestationInclusions: true})
+	defer resetCfg()
+
+	t.Run("pages", func(t *testing.T) {
+		writer := get(t, "?start_slot=2&page_size=2")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationInclusionsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		require.DeepEqual(t, &AttestationInclusion{Slot: "2", BlockRoot: hexutil.Encode([]byte{2, 31: 0})}, resp.Data[0])
+		require.Equal(t, "3", resp.Data[1].Slot)
+		require.NotEqual(t, "", resp.NextPageToken)
+
+		writer = get(t, "?start_slot=2&page_size=2&page_token="+resp.NextPageToken)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &AttestationInclusionsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, "", resp.NextPageToken)
+		require.Equal(t, 2, len(resp.Data))
+		require.Equal(t, "4", resp.Data[0].Slot)
+		require.Equal(t, "5", resp.Data[1].Slot)
+	})
+	t.Run("end slot", func(t *testing.T) {
+		writer := get(t, "?end_slot=1")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationInclusionsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, "1", resp.Data[0].Slot)
+		require.Equal(t, "", resp.NextPageToken)
+	})
+	t.Run("invalid page token", func(t *testing.T) {
+		writer := get(t, "?page_token=1")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("start after end", func(t *testing.T) {
+		writer := get(t, "?start_slot=3&end_slot=2")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
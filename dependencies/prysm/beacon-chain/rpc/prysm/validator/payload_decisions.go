@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+type PayloadDecisionsResponse struct {
+	Data []*PayloadDecision `json:"data"`
+}
+
+type PayloadDecision struct {
+	Slot               string `json:"slot"`
+	ProposerIndex      string `json:"proposer_index"`
+	Source             string `json:"source"`
+	Reason             string `json:"reason"`
+	LocalValueGwei     string `json:"local_value_gwei"`
+	BuilderValueGwei   string `json:"builder_value_gwei"`
+	BuilderBoostFactor string `json:"builder_boost_factor"`
+	MinBidGwei         string `json:"min_bid_gwei"`
+}
+
+// GetPayloadDecisions is a debug endpoint returning, for the most recent blocks proposed through this node, whether
+// the local execution payload or the builder bid was used and why, the oldest block first.
+func (s *Server) GetPayloadDecisions(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.GetPayloadDecisions")
+	defer span.End()
+
+	decisions := s.PayloadDecisionCache.Decisions()
+	data := make([]*PayloadDecision, len(decisions))
+	for i, d := range decisions {
+		source := "local"
+		if d.Builder {
+			source = "builder"
+		}
+		data[i] = &PayloadDecision{
+			Slot:               strconv.FormatUint(uint64(d.Slot), 10),
+			ProposerIndex:      strconv.FormatUint(uint64(d.ProposerIndex), 10),
+			Source:             source,
+			Reason:             d.Reason,
+			LocalValueGwei:     strconv.FormatUint(d.LocalValueGwei, 10),
+			BuilderValueGwei:   strconv.FormatUint(d.BuilderValueGwei, 10),
+			BuilderBoostFactor: strconv.FormatUint(d.BuilderBoostFactor, 10),
+			MinBidGwei:         strconv.FormatUint(d.MinBidGwei, 10),
+		}
+	}
+	httputil.WriteJson(w, &PayloadDecisionsResponse{Data: data})
+}
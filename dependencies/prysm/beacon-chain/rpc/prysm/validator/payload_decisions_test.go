@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGetPayloadDecisions(t *testing.T) {
+	c := cache.NewPayloadDecisionCache()
+	c.Add(cache.PayloadDecision{Slot: 1, ProposerIndex: 2, Reason: "below_min_bid", LocalValueGwei: 3, BuilderValueGwei: 4, BuilderBoostFactor: 100, MinBidGwei: 5})
+	c.Add(cache.PayloadDecision{Slot: 6, ProposerIndex: 7, Builder: true, Reason: "builder_higher_value", BuilderBoostFactor: 100})
+	s := &Server{PayloadDecisionCache: c}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/payload_decisions", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetPayloadDecisions(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &PayloadDecisionsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	require.DeepEqual(t, &PayloadDecision{
+		Slot:               "1",
+		ProposerIndex:      "2",
+		Source:             "local",
+		Reason:             "below_min_bid",
+		LocalValueGwei:     "3",
+		BuilderValueGwei:   "4",
+		BuilderBoostFactor: "100",
+		MinBidGwei:         "5",
+	}, resp.Data[0])
+	require.Equal(t, "builder", resp.Data[1].Source)
+	require.Equal(t, "builder_higher_value", resp.Data[1].Reason)
+}
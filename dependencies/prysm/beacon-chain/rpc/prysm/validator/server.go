@@ -2,6 +2,7 @@ package validator
 
 import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
@@ -20,4 +21,5 @@ type Server struct {
 	ChainInfoFetcher      blockchain.ChainInfoFetcher
 	BeaconDB              db.ReadOnlyDatabase
 	FinalizationFetcher   blockchain.FinalizationFetcher
+	PayloadDecisionCache  *cache.PayloadDecisionCache
 }
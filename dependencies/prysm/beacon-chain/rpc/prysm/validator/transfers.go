@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+type ValidatorDepositsResponse struct {
+	Data []*ValidatorDeposit `json:"data"`
+}
+
+type ValidatorDeposit struct {
+	Slot            string `json:"slot"`
+	DepositIndex    string `json:"deposit_index"`
+	Amount          string `json:"amount"`
+	Eth1BlockNumber string `json:"eth1_block_number,omitempty"`
+	LogIndex        string `json:"log_index,omitempty"`
+}
+
+type ValidatorWithdrawalsResponse struct {
+	Data []*ValidatorWithdrawal `json:"data"`
+}
+
+type ValidatorWithdrawal struct {
+	Slot                 string `json:"slot"`
+	WithdrawalIndex      string `json:"withdrawal_index"`
+	Address              string `json:"address"`
+	Amount               string `json:"amount"`
+	ExecutionBlockNumber string `json:"execution_block_number"`
+}
+
+// GetValidatorDeposits returns the deposits processed for the validator in finalized blocks, in ascending order
+// of deposit index, along with the eth1 block and the index of the log which emitted them, which are omitted when
+// the node does not know the deposit log, as with deposits pruned from its deposit cache. The validator is
+// identified by its index or its hex encoded public key. It requires the node to run with the
+// --index-deposits-withdrawals flag.
+//
+// Example usage:
+//
+//	GET /prysm/v1/validators/123/deposits
+func (s *Server) GetValidatorDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorDeposits")
+	defer span.End()
+
+	if !s.transfersIndexed(w, r) {
+		return
+	}
+	idx, ok := s.validatorIndexFromRoute(w, r)
+	if !ok {
+		return
+	}
+	deposits, err := s.BeaconDB.ValidatorDeposits(ctx, idx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator deposits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*ValidatorDeposit, len(deposits))
+	for i, d := range deposits {
+		data[i] = &ValidatorDeposit{
+			Slot:         strconv.FormatUint(d.Slot, 10),
+			DepositIndex: strconv.FormatUint(d.DepositIndex, 10),
+			Amount:       strconv.FormatUint(d.Amount, 10),
+		}
+		// The execution genesis block holds no deposit log, so a zero block number means the log is unknown.
+		if d.Eth1BlockNumber != 0 {
+			data[i].Eth1BlockNumber = strconv.FormatUint(d.Eth1BlockNumber, 10)
+			data[i].LogIndex = strconv.FormatUint(d.LogIndex, 10)
+		}
+	}
+	httputil.WriteJson(w, &ValidatorDepositsResponse{Data: data})
+}
+
+// GetValidatorWithdrawals returns the withdrawals paid to the validator by the execution payloads of finalized
+// blocks, in ascending order of withdrawal index. The validator is identified by its index or its hex encoded
+// public key. It requires the node to run with the --index-deposits-withdrawals flag.
+//
+// Example usage:
+//
+//	GET /prysm/v1/validators/123/withdrawals
+func (s *Server) GetValidatorWithdrawals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorWithdrawals")
+	defer span.End()
+
+	if !s.transfersIndexed(w, r) {
+		return
+	}
+	idx, ok := s.validatorIndexFromRoute(w, r)
+	if !ok {
+		return
+	}
+	withdrawals, err := s.BeaconDB.ValidatorWithdrawals(ctx, idx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator withdrawals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*ValidatorWithdrawal, len(withdrawals))
+	for i, wd := range withdrawals {
+		data[i] = &ValidatorWithdrawal{
+			Slot:                 strconv.FormatUint(wd.Slot, 10),
+			WithdrawalIndex:      strconv.FormatUint(wd.WithdrawalIndex, 10),
+			Address:              hexutil.Encode(wd.Address),
+			Amount:               strconv.FormatUint(wd.Amount, 10),
+			ExecutionBlockNumber: strconv.FormatUint(wd.ExecutionBlockNumber, 10),
+		}
+	}
+	httputil.WriteJson(w, &ValidatorWithdrawalsResponse{Data: data})
+}
+
+// transfersIndexed writes a not found error if the deposits and withdrawals index was never initialized.
+func (s *Server) transfersIndexed(w http.ResponseWriter, r *http.Request) bool {
+	_, err := s.BeaconDB.TransfersProgress(r.Context())
+	if errors.Is(err, db.ErrNotFound) {
+		httputil.HandleError(w, "Deposits and withdrawals are not indexed by this node", http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not get deposits and withdrawals index progress: "+err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// validatorIndexFromRoute parses the validator_id route variable, which is either a validator index or a hex
+// encoded public key resolved against the head state.
+func (s *Server) validatorIndexFromRoute(w http.ResponseWriter, r *http.Request) (primitives.ValidatorIndex, bool) {
+	rawId := mux.Vars(r)["validator_id"]
+	if rawId == "" {
+		httputil.HandleError(w, "validator_id is required in URL params", http.StatusBadRequest)
+		return 0, false
+	}
+	pubkey, err := hexutil.Decode(rawId)
+	if err == nil {
+		if len(pubkey) != fieldparams.BLSPubkeyLength {
+			httputil.HandleError(w, fmt.Sprintf("Pubkey length is %d instead of %d", len(pubkey), fieldparams.BLSPubkeyLength), http.StatusBadRequest)
+			return 0, false
+		}
+		idx, ok := s.HeadFetcher.HeadPublicKeyToValidatorIndex(bytesutil.ToBytes48(pubkey))
+		if !ok {
+			httputil.HandleError(w, fmt.Sprintf("Unknown validator: %s", hexutil.Encode(pubkey)), http.StatusNotFound)
+			return 0, false
+		}
+		return idx, true
+	}
+	index, err := strconv.ParseUint(rawId, 10, 64)
+	if err != nil {
+		httputil.HandleError(w, fmt.Sprintf("Invalid validator index %s", rawId), http.StatusBadRequest)
+		return 0, false
+	}
+	return primitives.ValidatorIndex(index), true
+}
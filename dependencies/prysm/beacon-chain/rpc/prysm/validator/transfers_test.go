@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGetValidatorTransfers(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB, HeadFetcher: &mock.ChainService{}}
+
+	get := func(t *testing.T, handler http.HandlerFunc, path, id string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/"+id+"/"+path, nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_id": id})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		handler(writer, request)
+		return writer
+	}
+
+	t.Run("not indexed", func(t *testing.T) {
+		writer := get(t, s.GetValidatorDeposits, "deposits", "0")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		writer = get(t, s.GetValidatorWithdrawals, "withdrawals", "0")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	require.NoError(t, beaconDB.SaveTransfers(ctx, &dbval.TransfersProgress{Slot: 64, NextDepositIndex: 3},
+		[]*dbval.ValidatorDeposit{
+			{ValidatorIndex: 0, Slot: 3, DepositIndex: 1, Amount: 32, Eth1BlockNumber: 100, LogIndex: 4},
+			// The deposit log of this deposit is not known by the node.
+			{ValidatorIndex: 0, Slot: 5, DepositIndex: 2, Amount: 1},
+		},
+		[]*dbval.ValidatorWithdrawal{{ValidatorIndex: 0, Slot: 40, WithdrawalIndex: 7, Address: []byte{0xAA}, Amount: 5, ExecutionBlockNumber: 200}},
+	))
+
+	t.Run("deposits", func(t *testing.T) {
+		writer := get(t, s.GetValidatorDeposits, "deposits", "0")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		require.DeepEqual(t, &ValidatorDeposit{
+			Slot:            "3",
+			DepositIndex:    "1",
+			Amount:          "32",
+			Eth1BlockNumber: "100",
+			LogIndex:        "4",
+		}, resp.Data[0])
+
+		raw := &struct {
+			Data []map[string]interface{} `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), raw))
+		require.Equal(t, 2, len(raw.Data))
+		require.DeepEqual(t, map[string]interface{}{"slot": "5", "deposit_index": "2", "amount": "1"}, raw.Data[1])
+	})
+	t.Run("withdrawals by pubkey", func(t *testing.T) {
+		// The mock head fetcher resolves every public key to validator 0.
+		writer := get(t, s.GetValidatorWithdrawals, "withdrawals", hexutil.Encode(make([]byte, 48)))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		require.DeepEqual(t, &ValidatorWithdrawal{
+			Slot:                 "40",
+			WithdrawalIndex:      "7",
+			Address:              "0xaa",
+			Amount:               "5",
+			ExecutionBlockNumber: "200",
+		}, resp.Data[0])
+	})
+	t.Run("no transfers", func(t *testing.T) {
+		writer := get(t, s.GetValidatorDeposits, "deposits", "1")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		writer := get(t, s.GetValidatorDeposits, "deposits", "foo")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		writer = get(t, s.GetValidatorWithdrawals, "withdrawals", "0x1234")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+type ValidatorHistoryResponse struct {
+	Data []*ValidatorHistory `json:"data"`
+}
+
+type ValidatorHistory struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Epoch             string `json:"epoch"`
+	Balance           string `json:"balance"`
+	EffectiveBalance  string `json:"effective_balance"`
+	AttestationSource string `json:"attestation_source"`
+	AttestationTarget string `json:"attestation_target"`
+	AttestationHead   string `json:"attestation_head"`
+	Inactivity        string `json:"inactivity"`
+	SyncCommittee     string `json:"sync_committee"`
+	Proposer          string `json:"proposer"`
+}
+
+// GetValidatorHistory returns the balances and the rewards, in Gwei, of the requested validators over a range of
+// epochs, as indexed by the validator history service. Only the validators tracked by the service have a history.
+// The range is inclusive and defaults to all epochs indexed so far. Rewards are negative when they are penalties.
+//
+// Example usage:
+//
+//	GET /prysm/v1/validators/history?index=1,2&start_epoch=100&end_epoch=200
+func (s *Server) GetValidatorHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorHistory")
+	defer span.End()
+
+	rawIndices := r.URL.Query()["index"]
+	if len(rawIndices) == 0 {
+		httputil.HandleError(w, "index is required", http.StatusBadRequest)
+		return
+	}
+	indices := make([]primitives.ValidatorIndex, len(rawIndices))
+	for i, raw := range rawIndices {
+		idx, valid := shared.ValidateUint(w, "index", raw)
+		if !valid {
+			return
+		}
+		indices[i] = primitives.ValidatorIndex(idx)
+	}
+
+	last, err := s.BeaconDB.LastValidatorHistoryEpoch(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		httputil.WriteJson(w, &ValidatorHistoryResponse{Data: make([]*ValidatorHistory, 0)})
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not get last indexed epoch: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, start, valid := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !valid {
+		return
+	}
+	rawEnd, end, valid := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !valid {
+		return
+	}
+	if rawEnd == "" {
+		end = uint64(last)
+	}
+	if start > end {
+		httputil.HandleError(w, fmt.Sprintf("start_epoch %d is after end_epoch %d", start, end), http.StatusBadRequest)
+		return
+	}
+
+	data := make([]*ValidatorHistory, 0)
+	for _, idx := range indices {
+		history, err := s.BeaconDB.ValidatorHistory(ctx, idx, primitives.Epoch(start), primitives.Epoch(end))
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not get history of validator %d: %v", idx, err), http.StatusInternalServerError)
+			return
+		}
+		for _, h := range history {
+			data = append(data, &ValidatorHistory{
+				ValidatorIndex:    strconv.FormatUint(uint64(idx), 10),
+				Epoch:             strconv.FormatUint(h.Epoch, 10),
+				Balance:           strconv.FormatUint(h.Balance, 10),
+				EffectiveBalance:  strconv.FormatUint(h.EffectiveBalance, 10),
+				AttestationSource: strconv.FormatInt(h.AttestationSource, 10),
+				AttestationTarget: strconv.FormatInt(h.AttestationTarget, 10),
+				AttestationHead:   strconv.FormatInt(h.AttestationHead, 10),
+				Inactivity:        strconv.FormatInt(h.Inactivity, 10),
+				SyncCommittee:     strconv.FormatInt(h.SyncCommittee, 10),
+				Proposer:          strconv.FormatUint(h.Proposer, 10),
+			})
+		}
+	}
+	httputil.WriteJson(w, &ValidatorHistoryResponse{Data: data})
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGetValidatorHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("nothing indexed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/history?index=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 0, len(resp.Data))
+	})
+
+	for epoch := primitives.Epoch(1); epoch <= 3; epoch++ {
+		require.NoError(t, beaconDB.SaveValidatorHistory(ctx, epoch, map[primitives.ValidatorIndex]*dbval.ValidatorHistory{
+			1: {Epoch: uint64(epoch), Balance: 32, EffectiveBalance: 31, AttestationSource: -2, Proposer: 5},
+			2: {Epoch: uint64(epoch), Balance: 16},
+		}))
+	}
+
+	t.Run("range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/history?index=1&index=2&start_epoch=2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+		require.DeepEqual(t, &ValidatorHistory{
+			ValidatorIndex:    "1",
+			Epoch:             "2",
+			Balance:           "32",
+			EffectiveBalance:  "31",
+			AttestationSource: "-2",
+			AttestationTarget: "0",
+			AttestationHead:   "0",
+			Inactivity:        "0",
+			SyncCommittee:     "0",
+			Proposer:          "5",
+		}, resp.Data[0])
+		require.Equal(t, "3", resp.Data[1].Epoch)
+		require.Equal(t, "2", resp.Data[2].ValidatorIndex)
+		require.Equal(t, "16", resp.Data[2].Balance)
+	})
+	t.Run("no index", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/history", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorHistory(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("start after end", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/history?index=1&start_epoch=3&end_epoch=2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorHistory(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
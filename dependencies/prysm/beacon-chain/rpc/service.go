@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/events"
 	beaconprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/beacon"
 	nodeprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/slasher"
 	validatorprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/validator"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -225,6 +226,8 @@ func (s *Service) initializeValidatorServerRoutes(validatorServer *validator.Ser
 	s.cfg.Router.HandleFunc("/eth/v1/validator/duties/sync/{epoch}", validatorServer.GetSyncCommitteeDuties).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/prepare_beacon_proposer", validatorServer.PrepareBeaconProposer).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/liveness/{epoch}", validatorServer.GetLiveness).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/validator/beacon_committee_selections", validatorServer.BeaconCommitteeSelections).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/validator/sync_committee_selections", validatorServer.SyncCommitteeSelections).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v2/validator/blocks/{slot}", validatorServer.ProduceBlockV2).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/blinded_blocks/{slot}", validatorServer.ProduceBlindedBlock).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v3/validator/blocks/{slot}", validatorServer.ProduceBlockV3).Methods(http.MethodGet)
@@ -269,6 +272,7 @@ func (s *Service) initializeBeaconServerRoutes(beaconServer *beacon.Server) {
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/headers", beaconServer.GetBlockHeaders).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/headers/{block_id}", beaconServer.GetBlockHeader).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/genesis", beaconServer.GetGenesis).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/deposit_snapshot", beaconServer.GetDepositSnapshot).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/finality_checkpoints", beaconServer.GetFinalityCheckpoints).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators", beaconServer.GetValidators).Methods(http.MethodGet, http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators/{validator_id}", beaconServer.GetValidator).Methods(http.MethodGet)
@@ -302,6 +306,8 @@ func (s *Service) initializeDebugServerRoutes(debugServer *debug.Server) {
 // prysm internal routes
 func (s *Service) initializePrysmBeaconServerRoutes(beaconServerPrysm *beaconprysm.Server) {
 	s.cfg.Router.HandleFunc("/prysm/v1/beacon/weak_subjectivity", beaconServerPrysm.GetWeakSubjectivity).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/debug/states/{slot}/rebuild_cost", beaconServerPrysm.GetStateRebuildCost).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/debug/archive", beaconServerPrysm.ExportArchive).Methods(http.MethodGet)
 }
 
 func (s *Service) initializePrysmNodeServerRoutes(nodeServerPrysm *nodeprysm.Server) {
@@ -314,6 +320,20 @@ func (s *Service) initializePrysmValidatorServerRoutes(validatorServerPrysm *val
 	s.cfg.Router.HandleFunc("/prysm/validators/performance", validatorServerPrysm.GetValidatorPerformance).Methods(http.MethodPost)
 	// /eth/v1/beacon/states/{state_id}/validator_count is not a beacon API, it's a custom endpoint
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validator_count", validatorServerPrysm.GetValidatorCount).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/debug/payload_decisions", validatorServerPrysm.GetPayloadDecisions).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/validators/history", validatorServerPrysm.GetValidatorHistory).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/validators/{validator_index}/attestation_inclusions", validatorServerPrysm.GetAttestationInclusions).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/validators/{validator_id}/deposits", validatorServerPrysm.GetValidatorDeposits).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/validators/{validator_id}/withdrawals", validatorServerPrysm.GetValidatorWithdrawals).Methods(http.MethodGet)
+}
+
+func (s *Service) initializePrysmSlasherServerRoutes(slasherServerPrysm *slasherprysm.Server) {
+	s.cfg.Router.HandleFunc("/prysm/v1/slasher/highest_attestations", slasherServerPrysm.GetHighestAttestations).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/v1/slasher/is_slashable/block", slasherServerPrysm.IsSlashableBlock).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/v1/slasher/is_slashable/attestation", slasherServerPrysm.IsSlashableAttestation).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/v1/slasher/slashings/attester", slasherServerPrysm.GetAttesterSlashings).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/slasher/slashings/proposer", slasherServerPrysm.GetProposerSlashings).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/slasher/spans/{validator_index}", slasherServerPrysm.GetValidatorSpans).Methods(http.MethodGet)
 }
 
 // Start the gRPC server.
@@ -372,8 +392,10 @@ func (s *Service) Start() {
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 	}
 
+	payloadDecisionCache := cache.NewPayloadDecisionCache()
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
+		PayloadDecisionCache:   payloadDecisionCache,
 		AttPool:                s.cfg.AttestationsPool,
 		ExitPool:               s.cfg.ExitPool,
 		HeadFetcher:            s.cfg.HeadFetcher,
@@ -522,6 +544,7 @@ func (s *Service) Start() {
 		Blocker:     blocker,
 		Stater:      stater,
 		HeadFetcher: s.cfg.HeadFetcher,
+		BeaconDB:    s.cfg.BeaconDB,
 	})
 
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
@@ -563,6 +586,7 @@ func (s *Service) Start() {
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		CanonicalHistory:      ch,
 		BeaconDB:              s.cfg.BeaconDB,
+		BlobStorage:           s.cfg.BlobStorage,
 	})
 
 	s.initializePrysmNodeServerRoutes(&nodeprysm.Server{
@@ -587,8 +611,16 @@ func (s *Service) Start() {
 		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
 		BeaconDB:              s.cfg.BeaconDB,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		PayloadDecisionCache:  payloadDecisionCache,
 	})
 
+	if features.Get().EnableSlasher {
+		s.initializePrysmSlasherServerRoutes(&slasherprysm.Server{
+			SlashingChecker:    s.cfg.SlashingChecker,
+			GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
+		})
+	}
+
 	go func() {
 		if s.listener != nil {
 			if err := s.grpcServer.Serve(s.listener); err != nil {
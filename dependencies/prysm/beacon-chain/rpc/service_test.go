@@ -22,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
 	beaconprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/beacon"
 	nodeprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/slasher"
 	validatorprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/validator"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
@@ -69,6 +70,7 @@ func TestServer_InitializeRoutes(t *testing.T) {
 	s.initializePrysmBeaconServerRoutes(&beaconprysm.Server{})
 	s.initializePrysmNodeServerRoutes(&nodeprysm.Server{})
 	s.initializePrysmValidatorServerRoutes(&validatorprysm.Server{})
+	s.initializePrysmSlasherServerRoutes(&slasherprysm.Server{})
 
 	beaconRoutes := map[string][]string{
 		"/eth/v1/beacon/genesis":                                     {http.MethodGet},
@@ -93,20 +95,20 @@ func TestServer_InitializeRoutes(t *testing.T) {
 		"/eth/v1/beacon/blocks/{block_id}/attestations":              {http.MethodGet},
 		"/eth/v1/beacon/blob_sidecars/{block_id}":                    {http.MethodGet},
 		"/eth/v1/beacon/rewards/sync_committee/{block_id}":           {http.MethodPost},
-		//"/eth/v1/beacon/deposit_snapshot": {http.MethodGet}, not implemented
-		"/eth/v1/beacon/rewards/blocks/{block_id}":           {http.MethodGet},
-		"/eth/v1/beacon/rewards/attestations/{epoch}":        {http.MethodPost},
-		"/eth/v1/beacon/blinded_blocks/{block_id}":           {http.MethodGet},
-		"/eth/v1/beacon/light_client/bootstrap/{block_root}": {http.MethodGet},
-		"/eth/v1/beacon/light_client/updates":                {http.MethodGet},
-		"/eth/v1/beacon/light_client/finality_update":        {http.MethodGet},
-		"/eth/v1/beacon/light_client/optimistic_update":      {http.MethodGet},
-		"/eth/v1/beacon/pool/attestations":                   {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/attester_slashings":             {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/proposer_slashings":             {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/sync_committees":                {http.MethodPost},
-		"/eth/v1/beacon/pool/voluntary_exits":                {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/bls_to_execution_changes":       {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/deposit_snapshot":                            {http.MethodGet},
+		"/eth/v1/beacon/rewards/blocks/{block_id}":                   {http.MethodGet},
+		"/eth/v1/beacon/rewards/attestations/{epoch}":                {http.MethodPost},
+		"/eth/v1/beacon/blinded_blocks/{block_id}":                   {http.MethodGet},
+		"/eth/v1/beacon/light_client/bootstrap/{block_root}":         {http.MethodGet},
+		"/eth/v1/beacon/light_client/updates":                        {http.MethodGet},
+		"/eth/v1/beacon/light_client/finality_update":                {http.MethodGet},
+		"/eth/v1/beacon/light_client/optimistic_update":              {http.MethodGet},
+		"/eth/v1/beacon/pool/attestations":                           {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/attester_slashings":                     {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/proposer_slashings":                     {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/sync_committees":                        {http.MethodPost},
+		"/eth/v1/beacon/pool/voluntary_exits":                        {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/bls_to_execution_changes":               {http.MethodGet, http.MethodPost},
 	}
 
 	builderRoutes := map[string][]string{
@@ -152,21 +154,34 @@ func TestServer_InitializeRoutes(t *testing.T) {
 		"/eth/v1/validator/aggregate_and_proofs":           {http.MethodPost},
 		"/eth/v1/validator/beacon_committee_subscriptions": {http.MethodPost},
 		"/eth/v1/validator/sync_committee_subscriptions":   {http.MethodPost},
-		//"/eth/v1/validator/beacon_committee_selections":   {http.MethodPost}, // not implemented
-		"/eth/v1/validator/sync_committee_contribution": {http.MethodGet},
-		//"/eth/v1/validator/sync_committee_selections":  {http.MethodPost}, // not implemented
-		"/eth/v1/validator/contribution_and_proofs": {http.MethodPost},
-		"/eth/v1/validator/prepare_beacon_proposer": {http.MethodPost},
-		"/eth/v1/validator/register_validator":      {http.MethodPost},
-		"/eth/v1/validator/liveness/{epoch}":        {http.MethodPost},
+		"/eth/v1/validator/beacon_committee_selections":    {http.MethodPost},
+		"/eth/v1/validator/sync_committee_contribution":    {http.MethodGet},
+		"/eth/v1/validator/sync_committee_selections":      {http.MethodPost},
+		"/eth/v1/validator/contribution_and_proofs":        {http.MethodPost},
+		"/eth/v1/validator/prepare_beacon_proposer":        {http.MethodPost},
+		"/eth/v1/validator/register_validator":             {http.MethodPost},
+		"/eth/v1/validator/liveness/{epoch}":               {http.MethodPost},
 	}
 
 	prysmCustomRoutes := map[string][]string{
-		"/prysm/v1/beacon/weak_subjectivity":               {http.MethodGet},
-		"/prysm/node/trusted_peers":                        {http.MethodGet, http.MethodPost},
-		"/prysm/node/trusted_peers/{peer_id}":              {http.MethodDelete},
-		"/prysm/validators/performance":                    {http.MethodPost},
-		"/eth/v1/beacon/states/{state_id}/validator_count": {http.MethodGet},
+		"/prysm/v1/beacon/weak_subjectivity":                            {http.MethodGet},
+		"/prysm/node/trusted_peers":                                     {http.MethodGet, http.MethodPost},
+		"/prysm/node/trusted_peers/{peer_id}":                           {http.MethodDelete},
+		"/prysm/validators/performance":                                 {http.MethodPost},
+		"/eth/v1/beacon/states/{state_id}/validator_count":              {http.MethodGet},
+		"/prysm/v1/debug/payload_decisions":                             {http.MethodGet},
+		"/prysm/v1/validators/history":                                  {http.MethodGet},
+		"/prysm/v1/validators/{validator_index}/attestation_inclusions": {http.MethodGet},
+		"/prysm/v1/validators/{validator_id}/deposits":                  {http.MethodGet},
+		"/prysm/v1/validators/{validator_id}/withdrawals":               {http.MethodGet},
+		"/prysm/v1/debug/states/{slot}/rebuild_cost":                    {http.MethodGet},
+		"/prysm/v1/debug/archive":                                       {http.MethodGet},
+		"/prysm/v1/slasher/highest_attestations":                        {http.MethodPost},
+		"/prysm/v1/slasher/is_slashable/block":                          {http.MethodPost},
+		"/prysm/v1/slasher/is_slashable/attestation":                    {http.MethodPost},
+		"/prysm/v1/slasher/slashings/attester":                          {http.MethodGet},
+		"/prysm/v1/slasher/slashings/proposer":                          {http.MethodGet},
+		"/prysm/v1/slasher/spans/{validator_index}":                     {http.MethodGet},
 	}
 
 	wantRouteList := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, prysmCustomRoutes)
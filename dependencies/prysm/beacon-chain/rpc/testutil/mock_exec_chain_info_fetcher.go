@@ -22,6 +22,10 @@ func (m *MockExecutionChainInfoFetcher) ExecutionClientEndpoint() string {
 	return m.CurrEndpoint
 }
 
+func (m *MockExecutionChainInfoFetcher) ExecutionClientEndpoints() []string {
+	return []string{m.CurrEndpoint}
+}
+
 func (m *MockExecutionChainInfoFetcher) ExecutionClientConnectionErr() error {
 	return m.CurrError
 }
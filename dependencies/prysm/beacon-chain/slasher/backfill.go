@@ -0,0 +1,195 @@
+package slasher
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBackfillBatchSize is the default number of epochs replayed at once when backfilling the slasher.
+const DefaultBackfillBatchSize = 8
+
+// BackfillConfig defines the range of epochs of the beacon DB replayed through slashing detection
+// when the slasher starts, so that offences included on chain before it was enabled are detected.
+type BackfillConfig struct {
+	StartEpoch primitives.Epoch
+	// EndEpoch is capped to the finalized epoch, 0 meaning the finalized epoch.
+	EndEpoch primitives.Epoch
+	// BatchSize is the number of epochs replayed at once.
+	BatchSize uint64
+}
+
+// Replays the finalized blocks in the backfill range, and the attestations they include, through
+// slashing detection in batches of epochs. Blocks which are not part of the canonical chain are
+// replayed as well, as they may be double proposals.
+func (s *Service) backfill(ctx context.Context) {
+	defer s.wg.Done()
+
+	cfg := s.serviceCfg.Backfill
+	finalized, err := s.serviceCfg.BeaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get finalized checkpoint, slasher backfill aborted")
+		return
+	}
+	endEpoch := cfg.EndEpoch
+	if endEpoch == 0 || endEpoch > finalized.Epoch {
+		endEpoch = finalized.Epoch
+	}
+	// Attestations with a source epoch older than the history length are not checked by slasher.
+	currentEpoch := slots.EpochsSinceGenesis(s.genesisTime)
+	startEpoch := cfg.StartEpoch
+	if currentEpoch >= s.params.historyLength && startEpoch <= currentEpoch-s.params.historyLength {
+		startEpoch = currentEpoch - s.params.historyLength + 1
+		log.WithFields(logrus.Fields{
+			"requestedStartEpoch": cfg.StartEpoch,
+			"startEpoch":          startEpoch,
+		}).Warn("Slasher backfill start epoch is older than the slasher history, starting at the oldest epoch kept")
+	}
+	if startEpoch > endEpoch {
+		log.WithFields(logrus.Fields{
+			"startEpoch": startEpoch,
+			"endEpoch":   endEpoch,
+		}).Warn("Nothing to backfill, the slasher backfill start epoch is after the finalized epoch")
+		return
+	}
+	batchSize := cfg.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	totalEpochs := uint64(endEpoch-startEpoch) + 1
+	log.WithFields(logrus.Fields{
+		"startEpoch": startEpoch,
+		"endEpoch":   endEpoch,
+		"batchSize":  batchSize,
+	}).Info("Starting slasher backfill")
+	start := time.Now()
+	for batchStart := startEpoch; batchStart <= endEpoch; {
+		if ctx.Err() != nil {
+			return
+		}
+		batchEnd := batchStart.Add(batchSize - 1)
+		if batchEnd > endEpoch || batchEnd < batchStart {
+			batchEnd = endEpoch
+		}
+		batchStartTime := time.Now()
+		numBlocks, numAtts, err := s.backfillBatch(ctx, batchStart, batchEnd)
+		if err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"startEpoch": batchStart,
+				"endEpoch":   batchEnd,
+			}).Error("Could not backfill slasher, backfill aborted")
+			return
+		}
+		done := uint64(batchEnd-startEpoch) + 1
+		backfilledEpoch.Set(float64(batchEnd))
+		log.WithFields(logrus.Fields{
+			"startEpoch": batchStart,
+			"endEpoch":   batchEnd,
+			"numBlocks":  numBlocks,
+			"numAtts":    numAtts,
+			"elapsed":    time.Since(batchStartTime),
+			"progress":   float64(done) * 100 / float64(totalEpochs),
+		}).Info("Backfilled slasher")
+		if batchEnd == endEpoch {
+			break
+		}
+		batchStart = batchEnd + 1
+	}
+	log.WithField("elapsed", time.Since(start)).Info("Completed slasher backfill")
+}
+
+// Replays the blocks of the beacon DB between the start and end epochs, both inclusive, and the attestations
+// they include through slashing detection, returning the number of blocks and attestations replayed.
+func (s *Service) backfillBatch(ctx context.Context, startEpoch, endEpoch primitives.Epoch) (int, int, error) {
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	endSlot, err := slots.EpochEnd(endEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	blks, _, err := s.serviceCfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get blocks")
+	}
+
+	proposals := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blks))
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	for _, blk := range blks {
+		header, err := blk.Header()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "could not get block header")
+		}
+		signingRoot, err := header.Header.HashTreeRoot()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "could not get block header hash tree root")
+		}
+		proposals = append(proposals, &slashertypes.SignedBlockHeaderWrapper{
+			SignedBeaconBlockHeader: header,
+			SigningRoot:             signingRoot,
+		})
+
+		for _, att := range blk.Block().Body().Attestations() {
+			targetState, err := s.serviceCfg.AttestationStateFetcher.AttestationTargetState(ctx, att.Data.Target)
+			if err != nil {
+				// The target state of attestations included in a non canonical block may not be available.
+				log.WithError(err).WithField("slot", att.Data.Slot).Debug("Could not get attestation target state, skipping attestation")
+				continue
+			}
+			committee, err := helpers.BeaconCommitteeFromState(ctx, targetState, att.Data.Slot, att.Data.CommitteeIndex)
+			if err != nil {
+				return 0, 0, errors.Wrap(err, "could not get attestation committee")
+			}
+			indexedAtt, err := attestation.ConvertToIndexed(ctx, att, committee)
+			if err != nil {
+				return 0, 0, errors.Wrap(err, "could not convert to indexed attestation")
+			}
+			if !validateAttestationIntegrity(indexedAtt) {
+				continue
+			}
+			signingRoot, err := indexedAtt.Data.HashTreeRoot()
+			if err != nil {
+				return 0, 0, errors.Wrap(err, "could not get attestation hash tree root")
+			}
+			atts = append(atts, &slashertypes.IndexedAttestationWrapper{
+				IndexedAttestation: indexedAtt,
+				SigningRoot:        signingRoot,
+			})
+		}
+	}
+
+	proposerSlashings, err := s.detectProposerSlashings(ctx, proposals)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not detect proposer slashings")
+	}
+	if err := s.processProposerSlashings(ctx, proposerSlashings); err != nil {
+		return 0, 0, errors.Wrap(err, "could not process proposer slashings")
+	}
+
+	// The attestations are processed as if received in the current epoch, as done for late attestations.
+	s.attsProcessingLock.Lock()
+	defer s.attsProcessingLock.Unlock()
+	currentEpoch := slots.EpochsSinceGenesis(s.genesisTime)
+	validAtts, _, _ := s.filterAttestations(atts, currentEpoch)
+	if err := s.serviceCfg.Database.SaveAttestationRecordsForValidators(ctx, validAtts); err != nil {
+		return 0, 0, errors.Wrap(err, "could not save attestation records")
+	}
+	attesterSlashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not check slashable attestations")
+	}
+	if err := s.processAttesterSlashings(ctx, attesterSlashings); err != nil {
+		return 0, 0, errors.Wrap(err, "could not process attester slashings")
+	}
+	return len(blks), len(validAtts), nil
+}
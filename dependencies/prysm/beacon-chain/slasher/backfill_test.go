@@ -0,0 +1,96 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	slashingsmock "github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings/mock"
+	mockstategen "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_backfill(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	beaconDB := dbtest.SetupDB(t)
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	mockChain := &mock.ChainService{State: beaconState}
+
+	parentRoot := bytesutil.PadTo([]byte("parent"), 32)
+	gen := mockstategen.NewService()
+	gen.StatesByRoot[bytesutil.ToBytes32(parentRoot)] = beaconState
+
+	// Two attestations from the same committee member voting for different blocks with the same target.
+	committee, err := helpers.BeaconCommitteeFromState(ctx, beaconState, 1, 0)
+	require.NoError(t, err)
+	atts := make([]*ethpb.Attestation, 2)
+	for i := range atts {
+		att := util.HydrateAttestation(&ethpb.Attestation{
+			AggregationBits: bitfield.NewBitlist(uint64(len(committee))),
+			Data: &ethpb.AttestationData{
+				Slot:            1,
+				BeaconBlockRoot: bytesutil.PadTo([]byte{byte(i)}, 32),
+			},
+		})
+		att.AggregationBits.SetBitAt(0, true)
+		att.Signature, err = signing.ComputeDomainAndSign(
+			beaconState, 0, att.Data, params.BeaconConfig().DomainBeaconAttester, privKeys[committee[0]],
+		)
+		require.NoError(t, err)
+		atts[i] = att
+	}
+
+	// Two blocks proposed by the same validator at the same slot, each including one of the attestations.
+	for i, att := range atts {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = 2
+		blk.Block.ParentRoot = parentRoot
+		blk.Block.Body.Attestations = []*ethpb.Attestation{att}
+		blk.Signature, err = signing.ComputeDomainAndSign(
+			beaconState, 0, blk.Block, params.BeaconConfig().DomainBeaconProposer, privKeys[0],
+		)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb), "block %d", i)
+	}
+
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Database:                slasherDB,
+			BeaconDB:                beaconDB,
+			AttestationStateFetcher: mockChain,
+			HeadStateFetcher:        mockChain,
+			StateGen:                gen,
+			SlashingPoolInserter:    &slashingsmock.PoolMock{},
+			Backfill:                &BackfillConfig{},
+		},
+		params:                         DefaultParams(),
+		genesisTime:                    time.Now(),
+		latestEpochWrittenForValidator: make(map[primitives.ValidatorIndex]primitives.Epoch),
+	}
+	s.wg.Add(1)
+	s.backfill(ctx)
+
+	proposerSlashings, err := slasherDB.ProposerSlashings(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(proposerSlashings))
+	require.Equal(t, primitives.Slot(2), proposerSlashings[0].Header_1.Header.Slot)
+
+	attesterSlashings, err := slasherDB.AttesterSlashings(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(attesterSlashings))
+	require.DeepEqual(t, []uint64{uint64(committee[0])}, attesterSlashings[0].Attestation_1.AttestingIndices)
+}
@@ -20,6 +20,7 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 			return err
 		}
 	}
+	verified := make([]*ethpb.AttesterSlashing, 0, len(slashings))
 	for _, sl := range slashings {
 		if err := s.verifyAttSignature(ctx, sl.Attestation_1); err != nil {
 			log.WithError(err).WithField("a", sl.Attestation_1).Warn(
@@ -41,6 +42,13 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 		); err != nil {
 			log.WithError(err).Error("Could not insert attester slashing into operations pool")
 		}
+		verified = append(verified, sl)
+	}
+	// Keep the detected slashings, to be queried through the slasher API.
+	if len(verified) > 0 {
+		if err := s.serviceCfg.Database.SaveAttesterSlashings(ctx, verified); err != nil {
+			log.WithError(err).Error("Could not save attester slashings")
+		}
 	}
 	return nil
 }
@@ -56,6 +64,7 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 			return err
 		}
 	}
+	verified := make([]*ethpb.ProposerSlashing, 0, len(slashings))
 	for _, sl := range slashings {
 		if err := s.verifyBlockSignature(ctx, sl.Header_1); err != nil {
 			log.WithError(err).WithField("a", sl.Header_1).Warn(
@@ -74,6 +83,13 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, sl); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
+		verified = append(verified, sl)
+	}
+	// Keep the detected slashings, to be queried through the slasher API.
+	if len(verified) > 0 {
+		if err := s.serviceCfg.Database.SaveProposerSlashings(ctx, verified); err != nil {
+			log.WithError(err).Error("Could not save proposer slashings")
+		}
 	}
 	return nil
 }
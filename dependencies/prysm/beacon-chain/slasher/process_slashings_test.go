@@ -123,6 +123,11 @@ func TestService_processAttesterSlashings(t *testing.T) {
 		err = s.processAttesterSlashings(ctx, slashings)
 		require.NoError(tt, err)
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
+
+		// Only the slashing with valid signatures is saved.
+		saved, err := slasherDB.AttesterSlashings(ctx, 0, params.BeaconConfig().FarFutureEpoch)
+		require.NoError(tt, err)
+		require.DeepSSZEqual(tt, slashings, saved)
 	})
 }
 
@@ -249,5 +254,10 @@ func TestService_processProposerSlashings(t *testing.T) {
 		err = s.processProposerSlashings(ctx, slashings)
 		require.NoError(tt, err)
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
+
+		// Only the slashing with valid signatures is saved.
+		saved, err := slasherDB.ProposerSlashings(ctx, 0, params.BeaconConfig().FarFutureEpoch)
+		require.NoError(tt, err)
+		require.DeepSSZEqual(tt, slashings, saved)
 	})
 }
@@ -92,55 +92,62 @@ func (s *Service) processQueuedAttestations(ctx context.Context, slotTicker <-ch
 	for {
 		select {
 		case currentSlot := <-slotTicker:
-			attestations := s.attsQueue.dequeue()
-			currentEpoch := slots.ToEpoch(currentSlot)
-			// We take all the attestations in the queue and filter out
-			// those which are valid now and valid in the future.
-			validAtts, validInFuture, numDropped := s.filterAttestations(attestations, currentEpoch)
+			s.processAttestations(ctx, currentSlot)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-			deferredAttestationsTotal.Add(float64(len(validInFuture)))
-			droppedAttestationsTotal.Add(float64(numDropped))
+func (s *Service) processAttestations(ctx context.Context, currentSlot primitives.Slot) {
+	s.attsProcessingLock.Lock()
+	defer s.attsProcessingLock.Unlock()
 
-			// We add back those attestations that are valid in the future to the queue.
-			s.attsQueue.extend(validInFuture)
+	attestations := s.attsQueue.dequeue()
+	currentEpoch := slots.ToEpoch(currentSlot)
+	// We take all the attestations in the queue and filter out
+	// those which are valid now and valid in the future.
+	validAtts, validInFuture, numDropped := s.filterAttestations(attestations, currentEpoch)
 
-			log.WithFields(logrus.Fields{
-				"currentSlot":     currentSlot,
-				"currentEpoch":    currentEpoch,
-				"numValidAtts":    len(validAtts),
-				"numDeferredAtts": len(validInFuture),
-				"numDroppedAtts":  numDropped,
-			}).Info("Processing queued attestations for slashing detection")
-
-			// Save the attestation records to our database.
-			// If multiple attestations are provided for the same validator index + target epoch combination,
-			// then last (validator index + target epoch) => signing root) link is kept into the database.
-			if err := s.serviceCfg.Database.SaveAttestationRecordsForValidators(
-				ctx, validAtts,
-			); err != nil {
-				log.WithError(err).Error(couldNotSaveAttRecord)
-				continue
-			}
+	deferredAttestationsTotal.Add(float64(len(validInFuture)))
+	droppedAttestationsTotal.Add(float64(numDropped))
 
-			// Check for slashings.
-			slashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
-			if err != nil {
-				log.WithError(err).Error(couldNotCheckSlashableAtt)
-				continue
-			}
+	// We add back those attestations that are valid in the future to the queue.
+	s.attsQueue.extend(validInFuture)
 
-			// Process attester slashings by verifying their signatures, submitting
-			// to the beacon node's operations pool, and logging them.
-			if err := s.processAttesterSlashings(ctx, slashings); err != nil {
-				log.WithError(err).Error(couldNotProcessAttesterSlashings)
-				continue
-			}
+	log.WithFields(logrus.Fields{
+		"currentSlot":     currentSlot,
+		"currentEpoch":    currentEpoch,
+		"numValidAtts":    len(validAtts),
+		"numDeferredAtts": len(validInFuture),
+		"numDroppedAtts":  numDropped,
+	}).Info("Processing queued attestations for slashing detection")
+
+	// Save the attestation records to our database.
+	// If multiple attestations are provided for the same validator index + target epoch combination,
+	// then last (validator index + target epoch) => signing root) link is kept into the database.
+	if err := s.serviceCfg.Database.SaveAttestationRecordsForValidators(
+		ctx, validAtts,
+	); err != nil {
+		log.WithError(err).Error(couldNotSaveAttRecord)
+		return
+	}
 
-			processedAttestationsTotal.Add(float64(len(validAtts)))
-		case <-ctx.Done():
-			return
-		}
+	// Check for slashings.
+	slashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
+	if err != nil {
+		log.WithError(err).Error(couldNotCheckSlashableAtt)
+		return
 	}
+
+	// Process attester slashings by verifying their signatures, submitting
+	// to the beacon node's operations pool, and logging them.
+	if err := s.processAttesterSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error(couldNotProcessAttesterSlashings)
+		return
+	}
+
+	processedAttestationsTotal.Add(float64(len(validAtts)))
 }
 
 // Process queued blocks every time an epoch ticker fires. We retrieve
@@ -2,6 +2,7 @@ package slasher
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
@@ -77,3 +78,80 @@ func (s *Service) IsSlashableAttestation(
 	}
 	return attesterSlashings, nil
 }
+
+// AttesterSlashings detected by the slasher with a target epoch between the
+// start and end epochs, both inclusive.
+func (s *Service) AttesterSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*ethpb.AttesterSlashing, error) {
+	slashings, err := s.serviceCfg.Database.AttesterSlashings(ctx, startEpoch, endEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attester slashings from database")
+	}
+	return slashings, nil
+}
+
+// ProposerSlashings detected by the slasher with an epoch between the
+// start and end epochs, both inclusive.
+func (s *Service) ProposerSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*ethpb.ProposerSlashing, error) {
+	slashings, err := s.serviceCfg.Database.ProposerSlashings(ctx, startEpoch, endEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get proposer slashings from database")
+	}
+	return slashings, nil
+}
+
+// ValidatorSpans retrieves the min and max spans of a validator for the epochs between
+// the start and end epochs, both inclusive, grouped by chunk. As chunks are indexed by
+// epoch modulo the history length, the range cannot be longer than the history length.
+func (s *Service) ValidatorSpans(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.ValidatorSpans, error) {
+	if startEpoch > endEpoch {
+		return nil, fmt.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+	numEpochs := uint64(endEpoch-startEpoch) + 1
+	if numEpochs > uint64(s.params.historyLength) {
+		return nil, fmt.Errorf("cannot get spans for more than %d epochs", s.params.historyLength)
+	}
+
+	chunkIndices := make([]uint64, 0)
+	for i := uint64(0); i < numEpochs; i++ {
+		chunkIdx := s.params.chunkIndex(startEpoch.Add(i))
+		if len(chunkIndices) == 0 || chunkIndices[len(chunkIndices)-1] != chunkIdx {
+			chunkIndices = append(chunkIndices, chunkIdx)
+		}
+	}
+	args := &chunkUpdateArgs{
+		kind:                slashertypes.MinSpan,
+		validatorChunkIndex: s.params.validatorChunkIndex(validatorIdx),
+	}
+	minChunks, err := s.loadChunks(ctx, args, chunkIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load min span chunks")
+	}
+	args.kind = slashertypes.MaxSpan
+	maxChunks, err := s.loadChunks(ctx, args, chunkIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load max span chunks")
+	}
+
+	spans := make([]*slashertypes.ValidatorSpans, 0, len(chunkIndices))
+	for i := uint64(0); i < numEpochs; i++ {
+		epoch := startEpoch.Add(i)
+		chunkIdx := s.params.chunkIndex(epoch)
+		if len(spans) == 0 || spans[len(spans)-1].ChunkIndex != chunkIdx {
+			spans = append(spans, &slashertypes.ValidatorSpans{
+				ChunkIndex: chunkIdx,
+				StartEpoch: epoch,
+			})
+		}
+		span := spans[len(spans)-1]
+		cellIdx := s.params.cellIndex(validatorIdx, epoch)
+		span.MinSpans = append(span.MinSpans, minChunks[chunkIdx].Chunk()[cellIdx])
+		span.MaxSpans = append(span.MaxSpans, maxChunks[chunkIdx].Chunk()[cellIdx])
+	}
+	return spans, nil
+}
@@ -2,6 +2,7 @@ package slasher
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
@@ -198,3 +199,43 @@ func TestService_HighestAttestations(t *testing.T) {
 		require.DeepEqual(t, &ethpb.HighestAttestation{ValidatorIndex: 1, HighestSourceEpoch: 0, HighestTargetEpoch: 1}, atts[0])
 	})
 }
+
+func TestService_ValidatorSpans(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	params := &Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 2,
+		historyLength:      6,
+	}
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Database: slasherDB,
+		},
+		params: params,
+	}
+	// Chunk index 0 of validators 0 and 1, the spans of validator 1 being the last two elements.
+	key := params.flatSliceID(0, 0)
+	require.NoError(t, slasherDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, [][]byte{key}, [][]uint16{{1, 2, 3, 4}}))
+	require.NoError(t, slasherDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, [][]byte{key}, [][]uint16{{5, 6, 7, 8}}))
+
+	spans, err := s.ValidatorSpans(ctx, 1, 0, 2)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.ValidatorSpans{
+		{ChunkIndex: 0, StartEpoch: 0, MinSpans: []uint16{3, 4}, MaxSpans: []uint16{7, 8}},
+		// Chunks missing from the database are filled with the neutral elements.
+		{ChunkIndex: 1, StartEpoch: 2, MinSpans: []uint16{math.MaxUint16}, MaxSpans: []uint16{0}},
+	}, spans)
+
+	// Epochs wrap around the history length.
+	spans, err = s.ValidatorSpans(ctx, 1, 7, 7)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.ValidatorSpans{
+		{ChunkIndex: 0, StartEpoch: 7, MinSpans: []uint16{4}, MaxSpans: []uint16{8}},
+	}, spans)
+
+	_, err = s.ValidatorSpans(ctx, 1, 2, 1)
+	require.ErrorContains(t, "start epoch 2 is greater than end epoch 1", err)
+	_, err = s.ValidatorSpans(ctx, 1, 0, 6)
+	require.ErrorContains(t, "cannot get spans for more than 6 epochs", err)
+}
@@ -14,6 +14,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	beaconChainSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
@@ -41,6 +42,8 @@ type ServiceConfig struct {
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             beaconChainSync.Checker
 	ClockWaiter             startup.ClockWaiter
+	BeaconDB                db.ReadOnlyDatabase
+	Backfill                *BackfillConfig
 }
 
 // SlashingChecker is an interface for defining services that the beacon node may interact with to provide slashing data.
@@ -50,6 +53,11 @@ type SlashingChecker interface {
 	HighestAttestations(
 		ctx context.Context, indices []primitives.ValidatorIndex,
 	) ([]*ethpb.HighestAttestation, error)
+	AttesterSlashings(ctx context.Context, startEpoch, endEpoch primitives.Epoch) ([]*ethpb.AttesterSlashing, error)
+	ProposerSlashings(ctx context.Context, startEpoch, endEpoch primitives.Epoch) ([]*ethpb.ProposerSlashing, error)
+	ValidatorSpans(
+		ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.ValidatorSpans, error)
 }
 
 // Service defining a slasher implementation as part of
@@ -68,6 +76,7 @@ type Service struct {
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochWrittenForValidator map[primitives.ValidatorIndex]primitives.Epoch
+	attsProcessingLock             sync.Mutex // Serializes the processing of received and backfilled attestations.
 	wg                             sync.WaitGroup
 }
 
@@ -148,6 +157,11 @@ func (s *Service) run() {
 
 	s.wg.Add(1)
 	go s.pruneSlasherData(s.ctx, s.pruningSlotTicker.C())
+
+	if s.serviceCfg.Backfill != nil {
+		s.wg.Add(1)
+		go s.backfill(s.ctx)
+	}
 }
 
 // Stop the slasher service.
@@ -51,3 +51,13 @@ type AttestedEpochForValidator struct {
 	ValidatorIndex primitives.ValidatorIndex
 	Epoch          primitives.Epoch
 }
+
+// ValidatorSpans contains the min and max spans of a validator for the consecutive
+// epochs of a chunk, starting at StartEpoch. Spans are the raw distances stored
+// in the chunks, the neutral element of a chunk meaning no attestation was recorded.
+type ValidatorSpans struct {
+	ChunkIndex uint64
+	StartEpoch primitives.Epoch
+	MinSpans   []uint16
+	MaxSpans   []uint16
+}
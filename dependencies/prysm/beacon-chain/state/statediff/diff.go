@@ -0,0 +1,406 @@
+// Package statediff computes compact differences between beacon states, so that a state can be
+// stored as its difference with an older state, and rebuilt from it without replaying blocks.
+package statediff
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Kinds of diff.
+const (
+	// The diff holds the whole target state, when the states are of different forks.
+	kindFull byte = iota
+	// The diff holds the fields of the target state which changed.
+	kindFields
+)
+
+// Operations applied on a field of the base state to get the field of the target state.
+const (
+	// The field is replaced by the one of the target state.
+	opReplace byte = iota
+	// The bytes of the field are xor-ed with the ones of the target state.
+	opXorBytes
+	// The elements of the uint64 list are xor-ed with the ones of the target state.
+	opXorUints
+	// The elements of the list which changed are replaced by the ones of the target state.
+	opElements
+)
+
+var marshalOpts = proto.MarshalOptions{Deterministic: true}
+
+// Diff computes the difference of the target state with the base state, which Apply reverts.
+// Lists are diffed element-wise, which keeps the diff small for the large lists of the state
+// (validator registry, balances, participation, roots...) as few of their elements change from an
+// epoch to another. The diff between states of different forks is the whole target state.
+func Diff(base, target state.ReadOnlyBeaconState) ([]byte, error) {
+	if base == nil || base.IsNil() || target == nil || target.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	targetPb, ok := target.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return nil, errors.New("target state is not a proto message")
+	}
+	if target.Version() != base.Version() {
+		enc, err := marshalOpts.Marshal(targetPb)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal target state")
+		}
+		return append([]byte{byte(target.Version()), kindFull}, enc...), nil
+	}
+	basePb, ok := base.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return nil, errors.New("base state is not a proto message")
+	}
+
+	bm, tm := basePb.ProtoReflect(), targetPb.ProtoReflect()
+	diff := []byte{byte(target.Version()), kindFields}
+	fields := tm.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		var err error
+		diff, err = appendFieldDiff(diff, fd, bm, tm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not diff field %s", fd.Name())
+		}
+	}
+	return diff, nil
+}
+
+// Apply rebuilds the state a diff was computed for, from the base state the diff was computed against.
+func Apply(base state.ReadOnlyBeaconState, diff []byte) (state.BeaconState, error) {
+	if len(diff) < 2 {
+		return nil, errors.New("diff is too short")
+	}
+	v := int(diff[0])
+	switch diff[1] {
+	case kindFull:
+		m, err := newStateProto(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(diff[2:], m); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal target state")
+		}
+		return initializeFromProto(v, m)
+	case kindFields:
+		if base == nil || base.IsNil() {
+			return nil, errors.New("nil base state")
+		}
+		if base.Version() != v {
+			return nil, errors.Errorf("diff of a %s state applied on a %s state", version.String(v), version.String(base.Version()))
+		}
+		m, ok := base.ToProto().(proto.Message)
+		if !ok {
+			return nil, errors.New("base state is not a proto message")
+		}
+		if err := applyFieldDiffs(m.ProtoReflect(), bytes.NewReader(diff[2:])); err != nil {
+			return nil, err
+		}
+		return initializeFromProto(v, m)
+	default:
+		return nil, errors.Errorf("unknown diff kind %d", diff[1])
+	}
+}
+
+func appendFieldDiff(diff []byte, fd protoreflect.FieldDescriptor, bm, tm protoreflect.Message) ([]byte, error) {
+	switch {
+	case fd.IsList() && fd.Kind() == protoreflect.Uint64Kind:
+		bl, tl := bm.Get(fd).List(), tm.Get(fd).List()
+		if equalLists(bl, tl) {
+			return diff, nil
+		}
+		diff = appendOp(diff, fd, opXorUints)
+		diff = binary.AppendUvarint(diff, uint64(tl.Len()))
+		for i := 0; i < tl.Len(); i++ {
+			u := tl.Get(i).Uint()
+			if i < bl.Len() {
+				u ^= bl.Get(i).Uint()
+			}
+			diff = binary.AppendUvarint(diff, u)
+		}
+		return diff, nil
+	case fd.IsList():
+		bl, tl := bm.Get(fd).List(), tm.Get(fd).List()
+		changed := make([]int, 0)
+		encs := make([][]byte, 0)
+		for i := 0; i < tl.Len(); i++ {
+			enc, err := elementBytes(fd, tl.Get(i))
+			if err != nil {
+				return nil, err
+			}
+			if i < bl.Len() {
+				baseEnc, err := elementBytes(fd, bl.Get(i))
+				if err != nil {
+					return nil, err
+				}
+				if bytes.Equal(enc, baseEnc) {
+					continue
+				}
+			}
+			changed = append(changed, i)
+			encs = append(encs, enc)
+		}
+		if len(changed) == 0 && tl.Len() == bl.Len() {
+			return diff, nil
+		}
+		diff = appendOp(diff, fd, opElements)
+		diff = binary.AppendUvarint(diff, uint64(tl.Len()))
+		diff = binary.AppendUvarint(diff, uint64(len(changed)))
+		for i, idx := range changed {
+			diff = binary.AppendUvarint(diff, uint64(idx))
+			diff = appendBytes(diff, encs[i])
+		}
+		return diff, nil
+	case fd.Kind() == protoreflect.BytesKind:
+		b, t := bm.Get(fd).Bytes(), tm.Get(fd).Bytes()
+		if bytes.Equal(b, t) {
+			return diff, nil
+		}
+		diff = appendOp(diff, fd, opXorBytes)
+		return appendBytes(diff, xorBytes(t, b)), nil
+	default:
+		b, err := fieldBytes(bm, fd)
+		if err != nil {
+			return nil, err
+		}
+		t, err := fieldBytes(tm, fd)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(b, t) {
+			return diff, nil
+		}
+		diff = appendOp(diff, fd, opReplace)
+		return appendBytes(diff, t), nil
+	}
+}
+
+func applyFieldDiffs(m protoreflect.Message, r *bytes.Reader) error {
+	fields := m.Descriptor().Fields()
+	for r.Len() > 0 {
+		num, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		fd := fields.ByNumber(protoreflect.FieldNumber(num))
+		if fd == nil {
+			return errors.Errorf("unknown field number %d", num)
+		}
+		op, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if err := applyFieldDiff(m, fd, op, r); err != nil {
+			return errors.Wrapf(err, "could not apply diff of field %s", fd.Name())
+		}
+	}
+	return nil
+}
+
+func applyFieldDiff(m protoreflect.Message, fd protoreflect.FieldDescriptor, op byte, r *bytes.Reader) error {
+	switch op {
+	case opXorUints:
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		bl := m.Get(fd).List()
+		l := m.NewField(fd).List()
+		for i := 0; i < int(n); i++ {
+			u, err := binary.ReadUvarint(r)
+			if err != nil {
+				return err
+			}
+			if i < bl.Len() {
+				u ^= bl.Get(i).Uint()
+			}
+			l.Append(protoreflect.ValueOfUint64(u))
+		}
+		m.Set(fd, protoreflect.ValueOfList(l))
+	case opElements:
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		numChanged, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		bl := m.Get(fd).List()
+		l := m.NewField(fd).List()
+		for i := 0; i < int(n) && i < bl.Len(); i++ {
+			l.Append(bl.Get(i))
+		}
+		for i := 0; i < int(numChanged); i++ {
+			idx, err := binary.ReadUvarint(r)
+			if err != nil {
+				return err
+			}
+			enc, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			if idx >= n || idx > uint64(l.Len()) {
+				return errors.Errorf("element index %d out of range", idx)
+			}
+			v, err := elementValue(fd, l, enc)
+			if err != nil {
+				return err
+			}
+			if idx == uint64(l.Len()) {
+				l.Append(v)
+			} else {
+				l.Set(int(idx), v)
+			}
+		}
+		if uint64(l.Len()) != n {
+			return errors.Errorf("list has %d elements, expected %d", l.Len(), n)
+		}
+		m.Set(fd, protoreflect.ValueOfList(l))
+	case opXorBytes:
+		enc, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, protoreflect.ValueOfBytes(xorBytes(enc, m.Get(fd).Bytes())))
+	case opReplace:
+		enc, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+		single := m.New()
+		if err := proto.Unmarshal(enc, single.Interface()); err != nil {
+			return err
+		}
+		m.Set(fd, single.Get(fd))
+	default:
+		return errors.Errorf("unknown operation %d", op)
+	}
+	return nil
+}
+
+func appendOp(diff []byte, fd protoreflect.FieldDescriptor, op byte) []byte {
+	diff = binary.AppendUvarint(diff, uint64(fd.Number()))
+	return append(diff, op)
+}
+
+func appendBytes(diff, b []byte) []byte {
+	diff = binary.AppendUvarint(diff, uint64(len(b)))
+	return append(diff, b...)
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(r.Len()) {
+		return nil, errors.Errorf("length %d exceeds the %d remaining bytes", n, r.Len())
+	}
+	b := make([]byte, n)
+	if _, err := r.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Xors the bytes of a with the ones of b at the same position, with the result having the length of a.
+func xorBytes(a, b []byte) []byte {
+	x := make([]byte, len(a))
+	copy(x, a)
+	for i := 0; i < len(x) && i < len(b); i++ {
+		x[i] ^= b[i]
+	}
+	return x
+}
+
+func equalLists(a, b protoreflect.List) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.Get(i).Uint() != b.Get(i).Uint() {
+			return false
+		}
+	}
+	return true
+}
+
+// Encodes an element of a list of bytes or messages.
+func elementBytes(fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		return v.Bytes(), nil
+	case protoreflect.MessageKind:
+		return marshalOpts.Marshal(v.Message().Interface())
+	default:
+		return nil, errors.Errorf("unsupported list of %s", fd.Kind())
+	}
+}
+
+func elementValue(fd protoreflect.FieldDescriptor, l protoreflect.List, enc []byte) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(enc), nil
+	case protoreflect.MessageKind:
+		v := l.NewElement()
+		if err := proto.Unmarshal(enc, v.Message().Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return v, nil
+	default:
+		return protoreflect.Value{}, errors.Errorf("unsupported list of %s", fd.Kind())
+	}
+}
+
+// Encodes a single field of a message, as a message with only this field set.
+func fieldBytes(m protoreflect.Message, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	single := m.New()
+	if m.Has(fd) {
+		single.Set(fd, m.Get(fd))
+	}
+	return marshalOpts.Marshal(single.Interface())
+}
+
+func newStateProto(v int) (proto.Message, error) {
+	switch v {
+	case version.Phase0:
+		return &ethpb.BeaconState{}, nil
+	case version.Altair:
+		return &ethpb.BeaconStateAltair{}, nil
+	case version.Bellatrix:
+		return &ethpb.BeaconStateBellatrix{}, nil
+	case version.Capella:
+		return &ethpb.BeaconStateCapella{}, nil
+	case version.Deneb:
+		return &ethpb.BeaconStateDeneb{}, nil
+	default:
+		return nil, errors.Errorf("unsupported state version %d", v)
+	}
+}
+
+func initializeFromProto(v int, m proto.Message) (state.BeaconState, error) {
+	switch v {
+	case version.Phase0:
+		return statenative.InitializeFromProtoUnsafePhase0(m.(*ethpb.BeaconState))
+	case version.Altair:
+		return statenative.InitializeFromProtoUnsafeAltair(m.(*ethpb.BeaconStateAltair))
+	case version.Bellatrix:
+		return statenative.InitializeFromProtoUnsafeBellatrix(m.(*ethpb.BeaconStateBellatrix))
+	case version.Capella:
+		return statenative.InitializeFromProtoUnsafeCapella(m.(*ethpb.BeaconStateCapella))
+	case version.Deneb:
+		return statenative.InitializeFromProtoUnsafeDeneb(m.(*ethpb.BeaconStateDeneb))
+	default:
+		return nil, errors.Errorf("unsupported state version %d", v)
+	}
+}
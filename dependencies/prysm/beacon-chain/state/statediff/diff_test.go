@@ -0,0 +1,95 @@
+package statediff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDiffApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		genesis func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey)
+	}{
+		{name: "phase0", genesis: util.DeterministicGenesisState},
+		{name: "altair", genesis: util.DeterministicGenesisStateAltair},
+		{name: "bellatrix", genesis: util.DeterministicGenesisStateBellatrix},
+		{name: "capella", genesis: util.DeterministicGenesisStateCapella},
+		{name: "deneb", genesis: util.DeterministicGenesisStateDeneb},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, _ := tt.genesis(t, 64)
+			target, err := transition.ProcessSlots(context.Background(), base.Copy(), params.BeaconConfig().SlotsPerEpoch*2+1)
+			require.NoError(t, err)
+			require.NoError(t, target.UpdateBalancesAtIndex(3, 1))
+			require.NoError(t, target.AppendValidator(&ethpb.Validator{
+				PublicKey:             make([]byte, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+			}))
+			require.NoError(t, target.AppendBalance(params.BeaconConfig().MaxEffectiveBalance))
+			if target.Version() >= version.Altair {
+				require.NoError(t, target.AppendCurrentParticipationBits(1))
+				require.NoError(t, target.AppendPreviousParticipationBits(2))
+				require.NoError(t, target.AppendInactivityScore(3))
+			}
+
+			diff, err := Diff(base, target)
+			require.NoError(t, err)
+			full, err := proto.Marshal(target.ToProtoUnsafe().(proto.Message))
+			require.NoError(t, err)
+			assert.Equal(t, true, len(diff) < len(full)/2, "diff of %d bytes is not smaller than the state of %d bytes", len(diff), len(full))
+
+			rebuilt, err := Apply(base, diff)
+			require.NoError(t, err)
+			wanted, err := target.HashTreeRoot(context.Background())
+			require.NoError(t, err)
+			got, err := rebuilt.HashTreeRoot(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, wanted, got)
+		})
+	}
+}
+
+func TestDiffApply_DifferentForks(t *testing.T) {
+	base, _ := util.DeterministicGenesisState(t, 16)
+	target, _ := util.DeterministicGenesisStateAltair(t, 16)
+
+	diff, err := Diff(base, target)
+	require.NoError(t, err)
+	rebuilt, err := Apply(base, diff)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, rebuilt.Version())
+	wanted, err := target.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	got, err := rebuilt.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, wanted, got)
+}
+
+func TestApply_Invalid(t *testing.T) {
+	base, _ := util.DeterministicGenesisState(t, 16)
+	altair, _ := util.DeterministicGenesisStateAltair(t, 16)
+	target := altair.Copy()
+	require.NoError(t, target.SetSlot(1))
+	diff, err := Diff(altair, target)
+	require.NoError(t, err)
+
+	_, err = Apply(base, diff)
+	require.ErrorContains(t, "diff of a altair state applied on a phase0 state", err)
+	_, err = Apply(altair, diff[:len(diff)-1])
+	require.ErrorContains(t, "could not apply diff of field slot", err)
+	_, err = Apply(altair, []byte{1})
+	require.ErrorContains(t, "diff is too short", err)
+}
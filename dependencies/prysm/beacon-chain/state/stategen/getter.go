@@ -206,8 +206,8 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 		return cachedInfo.state, nil
 	}
 
-	// Short circuit if the state is already in the DB.
-	if s.beaconDB.HasState(ctx, blockRoot) {
+	// Short circuit if the state is already in the DB, in full or as a state diff.
+	if s.beaconDB.HasState(ctx, blockRoot) || s.beaconDB.HasStateDiff(ctx, blockRoot) {
 		return s.beaconDB.State(ctx, blockRoot)
 	}
 
@@ -248,7 +248,7 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 // There's three ways to derive block parent state:
 // 1) block parent state is the last finalized state
 // 2) block parent state is the epoch boundary state and exists in epoch boundary cache
-// 3) block parent state is in DB
+// 3) block parent state is in DB, in full or as a state diff
 func (s *State) latestAncestor(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "stateGen.latestAncestor")
 	defer span.End()
@@ -304,8 +304,8 @@ func (s *State) latestAncestor(ctx context.Context, blockRoot [32]byte) (state.B
 			return cachedInfo.state, nil
 		}
 
-		// Does the state exists in DB.
-		if s.beaconDB.HasState(ctx, parentRoot) {
+		// Does the state exists in DB, in full or as a state diff.
+		if s.beaconDB.HasState(ctx, parentRoot) || s.beaconDB.HasStateDiff(ctx, parentRoot) {
 			s, err := s.beaconDB.State(ctx, parentRoot)
 			return s, errors.Wrap(err, "failed to retrieve state from db")
 		}
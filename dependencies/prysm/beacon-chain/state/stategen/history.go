@@ -185,6 +185,89 @@ func (c *CanonicalHistory) ancestorChain(ctx context.Context, tail interfaces.Re
 	}
 }
 
+// RebuildCost describes the work needed to rebuild the canonical state of a slot.
+type RebuildCost struct {
+	// BlockRoot is the root of the highest canonical block at or below the slot.
+	BlockRoot [32]byte
+	// BaseRoot is the root of the block whose state, cached or saved in the DB, the state is rebuilt from.
+	BaseRoot [32]byte
+	BaseSlot primitives.Slot
+	// StateDiffs is the number of state diffs applied to a state saved in full to get the base state.
+	StateDiffs int
+	// Blocks is the number of blocks replayed on the base state.
+	Blocks int
+	// Slots is the number of slots processed on the base state.
+	Slots primitives.Slot
+}
+
+// RebuildCost estimates the work needed to rebuild the canonical state of a slot, without rebuilding it.
+// It follows the same lineage as the replayer, looking backwards from the highest canonical block at or
+// below the slot for a cached or saved state.
+func (c *CanonicalHistory) RebuildCost(ctx context.Context, target primitives.Slot) (*RebuildCost, error) {
+	ctx, span := trace.StartSpan(ctx, "canonicalChainer.RebuildCost")
+	defer span.End()
+	r, err := c.BlockRootForSlot(ctx, target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "no canonical block root found below slot=%d", target)
+	}
+	cost := &RebuildCost{BlockRoot: r}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		b, err := c.h.Block(ctx, r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to retrieve block by root=%#x", r)
+		}
+		if blocks.BeaconBlockIsNil(b) != nil {
+			return nil, errors.Wrap(db.ErrNotFound, fmt.Sprintf("unable to retrieve block by root=%#x", r))
+		}
+		found, diffs, err := c.savedStateDepth(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			cost.BaseRoot = r
+			cost.BaseSlot = b.Block().Slot()
+			cost.StateDiffs = diffs
+			cost.Slots = target - cost.BaseSlot
+			return cost, nil
+		}
+		cost.Blocks++
+		r = b.Block().ParentRoot()
+	}
+}
+
+// savedStateDepth returns whether the state of a block is cached or saved in the DB, along with the
+// number of state diffs to apply to a state saved in full to get it.
+func (c *CanonicalHistory) savedStateDepth(ctx context.Context, blockRoot [32]byte) (bool, int, error) {
+	if c.cache != nil {
+		_, err := c.cache.ByBlockRoot(blockRoot)
+		if err == nil {
+			return true, 0, nil
+		}
+		if !errors.Is(err, ErrNotInCache) {
+			return false, 0, errors.Wrap(err, "error reading from state cache")
+		}
+	}
+	diffs := 0
+	for !c.h.HasState(ctx, blockRoot) {
+		base, err := c.h.StateDiffBase(ctx, blockRoot)
+		if errors.Is(err, db.ErrNotFoundStateDiff) {
+			if diffs > 0 {
+				return false, 0, errors.Wrapf(db.ErrNotFoundState, "no base state with blockroot=%#x", blockRoot)
+			}
+			return false, 0, nil
+		}
+		if err != nil {
+			return false, 0, errors.Wrapf(err, "could not get state diff base of blockroot=%#x", blockRoot)
+		}
+		blockRoot = base
+		diffs++
+	}
+	return true, diffs, nil
+}
+
 func reverseChain(c []interfaces.ReadOnlySignedBeaconBlock) {
 	last := len(c) - 1
 	swaps := (last + 1) / 2
@@ -446,6 +446,62 @@ func TestChainForSlot(t *testing.T) {
 	}
 }
 
+func TestRebuildCost(t *testing.T) {
+	ctx := context.Background()
+	var zero, one, two, three primitives.Slot = 50, 51, 150, 151
+	specs := []mockHistorySpec{
+		{slot: zero, canonicalBlock: true, savedState: true},
+		{slot: one, canonicalBlock: true},
+		{slot: two},
+		{slot: three, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, three+10)
+	hist.stateDiffs = map[[32]byte][32]byte{hist.slotMap[one]: hist.slotMap[zero]}
+	ch := &CanonicalHistory{h: hist, cc: hist, cs: hist}
+
+	cases := []struct {
+		name       string
+		slot       primitives.Slot
+		blockRoot  [32]byte
+		baseRoot   [32]byte
+		stateDiffs int
+		blocks     int
+	}{
+		{
+			name:       "blocks replayed on a state diff",
+			slot:       three + 1,
+			blockRoot:  hist.slotMap[three],
+			baseRoot:   hist.slotMap[one],
+			stateDiffs: 1,
+			blocks:     2,
+		},
+		{
+			name:       "slots processed on a state diff",
+			slot:       one + 10,
+			blockRoot:  hist.slotMap[one],
+			baseRoot:   hist.slotMap[one],
+			stateDiffs: 1,
+		},
+		{
+			name:      "slot at saved state",
+			slot:      zero,
+			blockRoot: hist.slotMap[zero],
+			baseRoot:  hist.slotMap[zero],
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cost, err := ch.RebuildCost(ctx, c.slot)
+			require.NoError(t, err)
+			require.Equal(t, c.blockRoot, cost.BlockRoot)
+			require.Equal(t, c.baseRoot, cost.BaseRoot)
+			require.Equal(t, c.stateDiffs, cost.StateDiffs)
+			require.Equal(t, c.blocks, cost.Blocks)
+			require.Equal(t, c.slot-cost.BaseSlot, cost.Slots)
+		})
+	}
+}
+
 func TestAncestorChainOrdering(t *testing.T) {
 	ctx := context.Background()
 	var zero, one, two, three, four, five primitives.Slot = 50, 51, 150, 151, 152, 200
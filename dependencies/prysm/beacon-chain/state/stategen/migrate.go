@@ -6,11 +6,17 @@ import (
 	"fmt"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
+// The number of epochs between the state diffs of each layer of the state diff hierarchy, from the
+// coarsest to the finest. Each layer divides the previous one.
+var stateDiffLayers = []primitives.Epoch{16, 4, 1}
+
 // MigrateToCold advances the finalized info in between the cold and hot state sections.
 // It moves the recent finalized states from the hot section to the cold section and
 // only preserves the ones that are on archived point.
@@ -44,41 +50,17 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 			return ctx.Err()
 		}
 
-		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
-			cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
-			if err != nil {
-				return fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+		if s.saveStateDiffs && slot%params.BeaconConfig().SlotsPerEpoch == 0 && slot%s.slotsPerArchivedPoint != 0 {
+			if err := s.saveStateDiff(ctx, slot); err != nil {
+				return err
 			}
+			continue
+		}
 
-			var aRoot [32]byte
-			var aState state.BeaconState
-
-			// When the epoch boundary state is not in cache due to skip slot scenario,
-			// we have to regenerate the state which will represent epoch boundary.
-			// By finding the highest available block below epoch boundary slot, we
-			// generate the state for that block root.
-			if exists {
-				aRoot = cached.root
-				aState = cached.state
-			} else {
-				_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot)
-				if err != nil {
-					return err
-				}
-				// Given the block has been finalized, the db should not have more than one block in a given slot.
-				// We should error out when this happens.
-				if len(roots) != 1 {
-					return errUnknownBlock
-				}
-				aRoot = roots[0]
-				// There's no need to generate the state if the state already exists in the DB.
-				// We can skip saving the state.
-				if !s.beaconDB.HasState(ctx, aRoot) {
-					aState, err = s.StateByRoot(ctx, aRoot)
-					if err != nil {
-						return err
-					}
-				}
+		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
+			aRoot, aState, err := s.boundaryState(ctx, slot)
+			if err != nil {
+				return err
 			}
 
 			if s.beaconDB.HasState(ctx, aRoot) {
@@ -120,3 +102,126 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// boundaryState returns the block root and the state of an epoch boundary slot, which is the state of
+// the highest block below the slot. The state is nil when it already exists in the DB, as there is then
+// no need to generate it.
+func (s *State) boundaryState(ctx context.Context, slot primitives.Slot) ([32]byte, state.BeaconState, error) {
+	cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+	if err != nil {
+		return [32]byte{}, nil, fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+	}
+	if exists {
+		return cached.root, cached.state, nil
+	}
+
+	// When the epoch boundary state is not in cache due to skip slot scenario,
+	// we have to regenerate the state which will represent epoch boundary.
+	// By finding the highest available block below epoch boundary slot, we
+	// generate the state for that block root.
+	_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	// Given the block has been finalized, the db should not have more than one block in a given slot.
+	// We should error out when this happens.
+	if len(roots) != 1 {
+		return [32]byte{}, nil, errUnknownBlock
+	}
+	root := roots[0]
+	// There's no need to generate the state if the state already exists in the DB.
+	if s.beaconDB.HasState(ctx, root) {
+		return root, nil, nil
+	}
+	st, err := s.StateByRoot(ctx, root)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return root, st, nil
+}
+
+// saveStateDiff saves the state of an epoch boundary slot between archived points as a state diff
+// against the state of its base slot in the state diff hierarchy. The diff is saved even if the state
+// is also saved in full, e.g. as a hot state during a long period of non-finality, as such states are
+// deleted later on and must not be the base of other diffs.
+func (s *State) saveStateDiff(ctx context.Context, slot primitives.Slot) error {
+	root, st, err := s.boundaryState(ctx, slot)
+	if err != nil {
+		return err
+	}
+	if s.beaconDB.HasStateDiff(ctx, root) {
+		return nil
+	}
+	baseSlot := s.stateDiffBaseSlot(slot)
+	_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, baseSlot)
+	if err != nil {
+		return err
+	}
+	if len(roots) != 1 {
+		return errUnknownBlock
+	}
+	baseRoot := roots[0]
+	if baseRoot == root {
+		return nil
+	}
+	// Diffs only apply on the state of an archived point or on another diff, which are never deleted.
+	// The base state was not saved, for example because the node started from a checkpoint after it.
+	// There is nothing to apply a diff on, so states up to the next archived point are not saved.
+	if !s.isStateDiffBase(ctx, baseSlot, baseRoot) {
+		log.WithFields(logrus.Fields{
+			"slot":     slot,
+			"baseSlot": baseSlot,
+		}).Debug("Base state of the state diff is not in DB, skipping")
+		return nil
+	}
+	if st == nil {
+		// The state is saved in full, so it was not generated.
+		st, err = s.beaconDB.State(ctx, root)
+		if err != nil {
+			return err
+		}
+	}
+	base, err := s.StateByRoot(ctx, baseRoot)
+	if err != nil {
+		return err
+	}
+	if err := s.beaconDB.SaveStateDiff(ctx, root, st, baseRoot, base); err != nil {
+		return err
+	}
+	log.WithFields(
+		logrus.Fields{
+			"slot":     st.Slot(),
+			"root":     hex.EncodeToString(bytesutil.Trunc(root[:])),
+			"baseSlot": base.Slot(),
+		}).Debug("Saved state diff in DB")
+	return nil
+}
+
+// isStateDiffBase returns whether the state of the block at the base slot of a state diff can be the
+// base of the diff, which is the case for the state saved at an archived point and for states saved
+// as a diff themselves.
+func (s *State) isStateDiffBase(ctx context.Context, baseSlot primitives.Slot, baseRoot [32]byte) bool {
+	if s.beaconDB.HasStateDiff(ctx, baseRoot) {
+		return true
+	}
+	return baseSlot%s.slotsPerArchivedPoint == 0 && s.beaconDB.HasState(ctx, baseRoot)
+}
+
+// stateDiffBaseSlot returns the slot of the state the state diff of an epoch boundary slot is computed
+// against. The layers of the state diff hierarchy each save a diff every given number of epochs, against
+// the closest slot of the previous layer, the first layer being against the archived point. States are
+// then rebuilt by applying at most one diff per layer on the state of the archived point.
+func (s *State) stateDiffBaseSlot(slot primitives.Slot) primitives.Slot {
+	base := slot - slot%s.slotsPerArchivedPoint
+	for _, epochs := range stateDiffLayers {
+		layerSlots := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epochs))
+		layerSlot := slot - slot%layerSlots
+		if layerSlot == slot {
+			break
+		}
+		if layerSlot > base {
+			base = layerSlot
+		}
+	}
+	return base
+}
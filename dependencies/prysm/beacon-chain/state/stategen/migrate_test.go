@@ -7,6 +7,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
 	testDB "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -225,3 +227,153 @@ func TestMigrateToCold_ParallelCalls(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{r7}, service.saveHotStateDB.blockRootsOfSavedStates, "Did not remove all saved hot state roots")
 	require.LogsContain(t, hook, "Saved state in DB")
 }
+
+func TestMigrateToCold_SavesStateDiffs(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New(), WithStateDiffs())
+	service.slotsPerArchivedPoint = 256
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	roots := make(map[primitives.Slot][32]byte)
+	signed := make(map[primitives.Slot]*ethpb.SignedBeaconBlock)
+	for _, slot := range []primitives.Slot{1, 33, 65, 97, 129, 161, 162} {
+		b, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		roots[slot] = r
+		signed[slot] = b
+	}
+	service.finalizedInfo = &finalizedInfo{
+		slot:  0,
+		root:  genesisStateRoot,
+		state: beaconState,
+	}
+
+	require.NoError(t, service.MigrateToCold(ctx, roots[162]))
+
+	// Epoch boundary slots 32 to 128 are saved against the archived point, and slot 160 against slot 128.
+	wantedBases := map[primitives.Slot][32]byte{
+		1:   gRoot,
+		33:  gRoot,
+		65:  gRoot,
+		97:  gRoot,
+		129: roots[97],
+	}
+	for slot, wanted := range wantedBases {
+		assert.Equal(t, false, beaconDB.HasState(ctx, roots[slot]))
+		base, err := beaconDB.StateDiffBase(ctx, roots[slot])
+		require.NoError(t, err)
+		assert.Equal(t, wanted, base, "wrong base for the state diff of slot %d", slot)
+	}
+	assert.Equal(t, false, beaconDB.HasStateDiff(ctx, roots[161]))
+
+	wsb, err := consensusblocks.NewSignedBeaconBlock(signed[129])
+	require.NoError(t, err)
+	wanted, err := executeStateTransitionStateGen(ctx, beaconState.Copy(), wsb)
+	require.NoError(t, err)
+	wantedRoot, err := wanted.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	got, err := beaconDB.State(ctx, roots[129])
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantedRoot, gotRoot)
+}
+
+func TestMigrateToCold_StateDiffsSurviveHotStateDeletion(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New(), WithStateDiffs())
+	service.slotsPerArchivedPoint = 256
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	roots := make(map[primitives.Slot][32]byte)
+	states := make(map[primitives.Slot]state.BeaconState)
+	for _, slot := range []primitives.Slot{97, 129, 162} {
+		b, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err := executeStateTransitionStateGen(ctx, beaconState.Copy(), wsb)
+		require.NoError(t, err)
+		roots[slot] = r
+		states[slot] = st
+	}
+	service.finalizedInfo = &finalizedInfo{
+		slot:  0,
+		root:  genesisStateRoot,
+		state: beaconState,
+	}
+
+	// The state of slot 97, which is the base of the state diff of slot 129, is saved as a hot state
+	// during non-finality.
+	require.NoError(t, beaconDB.SaveState(ctx, states[97], roots[97]))
+	service.saveHotStateDB.enabled = true
+	service.saveHotStateDB.blockRootsOfSavedStates = [][32]byte{roots[97]}
+
+	require.NoError(t, service.MigrateToCold(ctx, roots[162]))
+	base, err := beaconDB.StateDiffBase(ctx, roots[97])
+	require.NoError(t, err)
+	assert.Equal(t, gRoot, base)
+	base, err = beaconDB.StateDiffBase(ctx, roots[129])
+	require.NoError(t, err)
+	assert.Equal(t, roots[97], base)
+
+	// The state of slot 129 is still rebuilt once the hot state is deleted.
+	require.NoError(t, service.DisableSaveHotStateToDB(ctx))
+	assert.Equal(t, false, beaconDB.HasState(ctx, roots[97]))
+	wantedRoot, err := states[129].HashTreeRoot(ctx)
+	require.NoError(t, err)
+	got, err := beaconDB.State(ctx, roots[129])
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantedRoot, gotRoot)
+}
+
+func TestStateDiffBaseSlot(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	service := &State{slotsPerArchivedPoint: slotsPerEpoch * 64}
+	tests := []struct {
+		epoch primitives.Epoch
+		base  primitives.Epoch
+	}{
+		{epoch: 1, base: 0},
+		{epoch: 16, base: 0},
+		{epoch: 20, base: 16},
+		{epoch: 21, base: 20},
+		{epoch: 65, base: 64},
+		{epoch: 80, base: 64},
+		{epoch: 87, base: 84},
+	}
+	for _, tt := range tests {
+		got := service.stateDiffBaseSlot(slotsPerEpoch.Mul(uint64(tt.epoch)))
+		assert.Equal(t, slotsPerEpoch.Mul(uint64(tt.base)), got, "wrong base for epoch %d", tt.epoch)
+	}
+}
@@ -88,6 +88,7 @@ type mockHistory struct {
 	canonical                      map[[32]byte]bool
 	states                         map[[32]byte]state.BeaconState
 	hiddenStates                   map[[32]byte]state.BeaconState
+	stateDiffs                     map[[32]byte][32]byte
 	current                        primitives.Slot
 	overrideHighestSlotBlocksBelow func(context.Context, primitives.Slot) (primitives.Slot, [][32]byte, error)
 }
@@ -153,6 +154,18 @@ func (m *mockHistory) StateOrError(_ context.Context, blockRoot [32]byte) (state
 	return nil, db.ErrNotFoundState
 }
 
+func (m *mockHistory) HasState(_ context.Context, blockRoot [32]byte) bool {
+	_, ok := m.states[blockRoot]
+	return ok
+}
+
+func (m *mockHistory) StateDiffBase(_ context.Context, blockRoot [32]byte) ([32]byte, error) {
+	if base, ok := m.stateDiffs[blockRoot]; ok {
+		return base, nil
+	}
+	return [32]byte{}, db.ErrNotFoundStateDiff
+}
+
 func (m *mockHistory) IsCanonical(_ context.Context, blockRoot [32]byte) (bool, error) {
 	canon, ok := m.canonical[blockRoot]
 	return ok && canon, nil
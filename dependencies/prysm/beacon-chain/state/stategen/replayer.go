@@ -30,6 +30,8 @@ type HistoryAccessor interface {
 	GenesisBlockRoot(ctx context.Context) ([32]byte, error)
 	Block(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	HasState(ctx context.Context, blockRoot [32]byte) bool
+	StateDiffBase(ctx context.Context, blockRoot [32]byte) ([32]byte, error)
 }
 
 // CanonicalChecker determines whether the given block root is canonical.
@@ -54,6 +54,7 @@ type State struct {
 	avb                     coverage.AvailableBlocker
 	migrationLock           *sync.Mutex
 	fc                      forkchoice.ForkChoicer
+	saveStateDiffs          bool
 }
 
 // This tracks the config in the event of long non-finality,
@@ -86,6 +87,15 @@ func WithAvailableBlocker(avb coverage.AvailableBlocker) Option {
 	}
 }
 
+// WithStateDiffs makes stategen save the state of every epoch boundary slot between archived points
+// as a state diff when migrating finalized states to the cold section, so that historical states are
+// rebuilt by applying a few diffs instead of replaying blocks.
+func WithStateDiffs() Option {
+	return func(sg *State) {
+		sg.saveStateDiffs = true
+	}
+}
+
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase, fc forkchoice.ForkChoicer, opts ...Option) *State {
 	s := &State{
@@ -5,25 +5,45 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	log "github.com/sirupsen/logrus"
 )
 
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
 // from the remote beacon node api.
 type APIInitializer struct {
-	c *beacon.Client
+	c               *beacon.Client
+	depositSnapshot bool
+}
+
+// APIInitializerOption is a functional option for configuring an APIInitializer.
+type APIInitializerOption func(*APIInitializer)
+
+// WithDepositSnapshot configures the APIInitializer to also download the EIP-4881 deposit snapshot
+// from the remote beacon node, so that deposit contract logs preceding it do not need to be processed.
+func WithDepositSnapshot() APIInitializerOption {
+	return func(dl *APIInitializer) {
+		dl.depositSnapshot = true
+	}
 }
 
 // NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
 // using the provided host string.
-func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
+func NewAPIInitializer(beaconNodeHost string, opts ...APIInitializerOption) (*APIInitializer, error) {
 	c, err := beacon.NewClient(beaconNodeHost)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
 	}
-	return &APIInitializer{c: c}, nil
+	dl := &APIInitializer{c: c}
+	for _, o := range opts {
+		o(dl)
+	}
+	return dl, nil
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -42,5 +62,58 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
-	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
+	if err := d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes()); err != nil {
+		return err
+	}
+	if dl.depositSnapshot {
+		return dl.initializeDepositSnapshot(ctx, d, od.State())
+	}
+	return nil
+}
+
+// initializeDepositSnapshot downloads the deposit snapshot from the remote beacon node and saves it as
+// the execution chain data of the node, which then resumes processing deposit logs from the execution
+// block the snapshot was finalized at.
+func (dl *APIInitializer) initializeDepositSnapshot(ctx context.Context, d db.Database, st state.BeaconState) error {
+	if !features.Get().EnableEIP4881 {
+		return errors.Errorf("deposit snapshots require the EIP-4881 deposit tree. "+
+			"Remove the --%s flag to initialize the node from a deposit snapshot.", features.DisableEIP4881.Name)
+	}
+	existing, err := d.ExecutionChainData(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve execution chain data")
+	}
+	if existing != nil {
+		log.Warn("Execution chain data found in db, ignoring deposit snapshot")
+		return nil
+	}
+	snapshot, err := dl.c.GetDepositSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error retrieving deposit snapshot")
+	}
+	// Rebuilding the tree verifies the finalized branches of the snapshot against its deposit root.
+	if _, err := depositsnapshot.DepositTreeFromSnapshotProto(snapshot); err != nil {
+		return errors.Wrap(err, "invalid deposit snapshot")
+	}
+	eth1Data := &ethpb.ETH1ChainData{
+		CurrentEth1Data: &ethpb.LatestETH1Data{
+			BlockHeight:        snapshot.ExecutionDepth,
+			BlockHash:          snapshot.ExecutionHash,
+			LastRequestedBlock: snapshot.ExecutionDepth,
+		},
+		ChainstartData: &ethpb.ChainStartData{
+			Chainstarted:       true,
+			GenesisTime:        st.GenesisTime(),
+			Eth1Data:           &ethpb.Eth1Data{},
+			ChainstartDeposits: make([]*ethpb.Deposit, 0),
+		},
+		DepositSnapshot: snapshot,
+	}
+	if err := d.SaveExecutionChainData(ctx, eth1Data); err != nil {
+		return errors.Wrap(err, "could not save deposit snapshot")
+	}
+	log.WithField("depositCount", snapshot.DepositCount).
+		WithField("executionBlockHeight", snapshot.ExecutionDepth).
+		Info("Initialized deposit tree from deposit snapshot")
+	return nil
 }
@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdatesRoutine keeps track of the latest light client finality and optimistic
+// updates computed by the blockchain service, so that they can be served over req/resp,
+// and publishes them on their respective gossip topics.
+func (s *Service) lightClientUpdatesRoutine() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case ev := <-stateChannel:
+			switch ev.Type {
+			case statefeed.LightClientFinalityUpdate:
+				data, ok := ev.Data.(*ethpbv2.LightClientFinalityUpdateWithVersion)
+				if !ok || data.Data == nil {
+					log.Error("Event feed data is not of type *ethpbv2.LightClientFinalityUpdateWithVersion")
+					continue
+				}
+				s.setLightClientFinalityUpdate(data.Data)
+				go s.broadcastLightClientUpdate(s.ctx, data.Data.SignatureSlot, data.Data)
+			case statefeed.LightClientOptimisticUpdate:
+				data, ok := ev.Data.(*ethpbv2.LightClientOptimisticUpdateWithVersion)
+				if !ok || data.Data == nil {
+					log.Error("Event feed data is not of type *ethpbv2.LightClientOptimisticUpdateWithVersion")
+					continue
+				}
+				s.setLightClientOptimisticUpdate(data.Data)
+				go s.broadcastLightClientUpdate(s.ctx, data.Data.SignatureSlot, data.Data)
+			}
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		}
+	}
+}
+
+// broadcastLightClientUpdate publishes the light client update once one third of its signature slot
+// has transpired, as peers ignore updates received before the signing block had time to propagate.
+func (s *Service) broadcastLightClientUpdate(ctx context.Context, signatureSlot primitives.Slot, update proto.Message) {
+	cfg := params.BeaconConfig()
+	publishTime := slots.BeginsAt(signatureSlot, s.cfg.clock.GenesisTime()).
+		Add(time.Duration(cfg.SecondsPerSlot/cfg.IntervalsPerSlot) * time.Second)
+	timer := time.NewTimer(time.Until(publishTime))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return
+	}
+	// Do not publish updates which have been superseded in the meantime.
+	switch u := update.(type) {
+	case *ethpbv2.LightClientFinalityUpdate:
+		if !proto.Equal(u, s.latestLightClientFinalityUpdate()) || !s.markLightClientFinalityUpdateForwarded(u) {
+			return
+		}
+	case *ethpbv2.LightClientOptimisticUpdate:
+		if !proto.Equal(u, s.latestLightClientOptimisticUpdate()) || !s.markLightClientOptimisticUpdateForwarded(u) {
+			return
+		}
+	}
+	if err := s.cfg.p2p.Broadcast(ctx, update); err != nil {
+		log.WithError(err).Error("Could not broadcast light client update")
+	}
+}
+
+func (s *Service) setLightClientFinalityUpdate(update *ethpbv2.LightClientFinalityUpdate) {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	s.lightClientFinalityUpdate = update
+}
+
+func (s *Service) latestLightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientFinalityUpdate
+}
+
+func (s *Service) setLightClientOptimisticUpdate(update *ethpbv2.LightClientOptimisticUpdate) {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	s.lightClientOptimisticUpdate = update
+}
+
+func (s *Service) latestLightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientOptimisticUpdate
+}
+
+// markLightClientFinalityUpdateForwarded records the update as forwarded, returning false
+// if an update with an equal or higher finalized header slot has already been forwarded.
+func (s *Service) markLightClientFinalityUpdateForwarded(update *ethpbv2.LightClientFinalityUpdate) bool {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	if update.FinalizedHeader.Slot <= s.lastForwardedFinalizedSlot {
+		return false
+	}
+	s.lastForwardedFinalizedSlot = update.FinalizedHeader.Slot
+	return true
+}
+
+// markLightClientOptimisticUpdateForwarded records the update as forwarded, returning false
+// if an update with an equal or higher attested header slot has already been forwarded.
+func (s *Service) markLightClientOptimisticUpdateForwarded(update *ethpbv2.LightClientOptimisticUpdate) bool {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	if update.AttestedHeader.Slot <= s.lastForwardedAttestedSlot {
+		return false
+	}
+	s.lastForwardedAttestedSlot = update.AttestedHeader.Slot
+	return true
+}
@@ -0,0 +1,255 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	db "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+func setupLightClientRPCService(t *testing.T, topic protocol.ID) (*Service, *p2ptest.TestP2P, *p2ptest.TestP2P) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+
+	r := &Service{
+		cfg: &config{
+			beaconDB: db.SetupDB(t),
+			p2p:      p1,
+			chain: &mock.ChainService{
+				ValidatorsRoot: [32]byte{'A'},
+			},
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	r.rateLimiter.limiterMap[string(topic)] = leakybucket.NewCollector(1, 1, time.Second, false)
+	return r, p1, p2
+}
+
+func testLightClientUpdate(attestedSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	finality := testLightClientFinalityUpdate(attestedSlot, 0)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	branch := make([][]byte, fieldparams.NextSyncCommitteeBranchDepth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader: finality.AttestedHeader,
+		NextSyncCommittee: &ethpbv2.SyncCommittee{
+			Pubkeys:         pubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		},
+		NextSyncCommitteeBranch: branch,
+		FinalizedHeader:         finality.FinalizedHeader,
+		FinalityBranch:          finality.FinalityBranch,
+		SyncAggregate:           finality.SyncAggregate,
+		SignatureSlot:           finality.SignatureSlot,
+	}
+}
+
+func TestLightClientOptimisticUpdateRPCHandler_ServesLatestUpdate(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientOptimisticUpdateTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+	update := testLightClientOptimisticUpdate(10)
+	r.setLightClientOptimisticUpdate(update)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		ctxBytes, err := readContextFromStream(stream)
+		require.NoError(t, err)
+		valRoot := r.cfg.chain.GenesisValidatorsRoot()
+		digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(update.AttestedHeader.Slot), valRoot[:])
+		require.NoError(t, err)
+		assert.DeepEqual(t, digest[:], ctxBytes)
+		out := &ethpbv2.LightClientOptimisticUpdate{}
+		require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, out))
+		assert.DeepEqual(t, update, out)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientOptimisticUpdateRPCHandler(context.Background(), new(interface{}), stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientFinalityUpdateRPCHandler_NoUpdate(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientFinalityUpdateTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.lightClientFinalityUpdateRPCHandler(context.Background(), new(interface{}), stream), p2ptypes.ErrResourceUnavailable)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientBootstrapRPCHandler_UnknownBlock(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientBootstrapTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := p2ptypes.LightClientBootstrapReq{'a'}
+	require.ErrorIs(t, r.lightClientBootstrapRPCHandler(context.Background(), &req, stream), p2ptypes.ErrResourceUnavailable)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientBootstrapRPCHandler_ServesStoredBootstrap(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientBootstrapTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+	update := testLightClientUpdate(10)
+	bootstrap := &ethpbv2.LightClientBootstrap{
+		Header:                     update.AttestedHeader,
+		CurrentSyncCommittee:       update.NextSyncCommittee,
+		CurrentSyncCommitteeBranch: update.NextSyncCommitteeBranch,
+	}
+	root := [32]byte{'a'}
+	require.NoError(t, r.cfg.beaconDB.SaveLightClientBootstrap(context.Background(), root, bootstrap))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		_, err := readContextFromStream(stream)
+		require.NoError(t, err)
+		out := &ethpbv2.LightClientBootstrap{}
+		require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, out))
+		assert.DeepEqual(t, bootstrap, out)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := p2ptypes.LightClientBootstrapReq(root)
+	require.NoError(t, r.lightClientBootstrapRPCHandler(context.Background(), &req, stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler_ServesStoredUpdates(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+	cfg := params.BeaconConfig()
+	slotsPerPeriod := primitives.Slot(uint64(cfg.EpochsPerSyncCommitteePeriod) * uint64(cfg.SlotsPerEpoch))
+	// Period 3 is missing, so the response stops after period 2.
+	var want []*ethpbv2.LightClientUpdate
+	for _, period := range []uint64{1, 2, 4} {
+		update := testLightClientUpdate(primitives.Slot(period) * slotsPerPeriod)
+		require.NoError(t, r.cfg.beaconDB.SaveLightClientUpdate(context.Background(), period, update))
+		if period < 3 {
+			want = append(want, update)
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for _, update := range want {
+			expectSuccess(t, stream)
+			_, err := readContextFromStream(stream)
+			require.NoError(t, err)
+			out := &ethpbv2.LightClientUpdate{}
+			require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, out))
+			assert.DeepEqual(t, update, out)
+		}
+		_, _, err := ReadStatusCode(stream, r.cfg.p2p.Encoding())
+		require.ErrorContains(t, "EOF", err)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &pb.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 4}
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(context.Background(), req, stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler_ZeroCount(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeInvalidRequest, p2ptypes.ErrInvalidRequest.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &pb.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 0}
+	require.ErrorIs(t, r.lightClientUpdatesByRangeRPCHandler(context.Background(), req, stream), p2ptypes.ErrInvalidRequest)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler_NoUpdates(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1)
+	r, p1, p2 := setupLightClientRPCService(t, pcl)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		// The stream is closed without any chunk when no update is available.
+		_, _, err := ReadStatusCode(stream, r.cfg.p2p.Encoding())
+		require.ErrorContains(t, "EOF", err)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &pb.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 2}
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(context.Background(), req, stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
@@ -35,10 +35,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/verification"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
@@ -155,6 +158,11 @@ type Service struct {
 	initialSyncComplete              chan struct{}
 	verifierWaiter                   *verification.InitializerWaiter
 	newBlobVerifier                  verification.NewBlobVerifier
+	lightClientLock                  sync.RWMutex
+	lightClientFinalityUpdate        *ethpbv2.LightClientFinalityUpdate
+	lightClientOptimisticUpdate      *ethpbv2.LightClientOptimisticUpdate
+	lastForwardedFinalizedSlot       primitives.Slot
+	lastForwardedAttestedSlot        primitives.Slot
 }
 
 // NewService initializes new regular sync service.
@@ -222,6 +230,9 @@ func (s *Service) Start() {
 
 	go s.verifierRoutine()
 	go s.registerHandlers()
+	if features.Get().EnableLightClient {
+		go s.lightClientUpdatesRoutine()
+	}
 
 	s.cfg.p2p.AddConnectionHandler(s.reValidatePeer, s.sendGoodbye)
 	s.cfg.p2p.AddDisconnectionHandler(func(_ context.Context, _ peer.ID) error {
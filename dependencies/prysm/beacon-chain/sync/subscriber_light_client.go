@@ -0,0 +1,20 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdateSubscriber handles light client updates received over gossip. Validation only
+// accepts updates matching the locally computed ones, so there is nothing left to store.
+func (s *Service) lightClientUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	switch msg.(type) {
+	case *ethpbv2.LightClientFinalityUpdate, *ethpbv2.LightClientOptimisticUpdate:
+		return nil
+	default:
+		return errors.Errorf("incorrect type of message received, wanted a light client update but got %T", msg)
+	}
+}
@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate validates a light client finality update received over gossip.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_finality_update
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The node cannot compute the latest update while syncing.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	ctx, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
+	update, ok := m.(*ethpbv2.LightClientFinalityUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if update.AttestedHeader == nil || update.FinalizedHeader == nil || update.SyncAggregate == nil {
+		return pubsub.ValidationReject, errNilMessage
+	}
+
+	// [IGNORE] The finality_update is received after the block at signature_slot was given enough time
+	// to propagate through the network.
+	if !lightClientUpdatePropagated(update.SignatureSlot, s.cfg.clock.GenesisTime()) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	// [IGNORE] The received finality_update matches the locally computed one exactly.
+	if !proto.Equal(update, s.latestLightClientFinalityUpdate()) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	// [IGNORE] The finalized_header.slot is greater than that of all previously forwarded finality_updates.
+	if !s.markLightClientFinalityUpdateForwarded(update) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	msg.ValidatorData = update // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate validates a light client optimistic update received over gossip.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_optimistic_update
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The node cannot compute the latest update while syncing.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	ctx, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
+	update, ok := m.(*ethpbv2.LightClientOptimisticUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if update.AttestedHeader == nil || update.SyncAggregate == nil {
+		return pubsub.ValidationReject, errNilMessage
+	}
+
+	// [IGNORE] The optimistic_update is received after the block at signature_slot was given enough time
+	// to propagate through the network.
+	if !lightClientUpdatePropagated(update.SignatureSlot, s.cfg.clock.GenesisTime()) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	// [IGNORE] The received optimistic_update matches the locally computed one exactly.
+	if !proto.Equal(update, s.latestLightClientOptimisticUpdate()) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	// [IGNORE] The attested_header.slot is greater than that of all previously forwarded optimistic_updates.
+	if !s.markLightClientOptimisticUpdateForwarded(update) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	msg.ValidatorData = update // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientUpdatePropagated returns true once one third of the signature slot has transpired,
+// allowing for the maximum gossip clock disparity.
+func lightClientUpdatePropagated(signatureSlot primitives.Slot, genesisTime time.Time) bool {
+	cfg := params.BeaconConfig()
+	propagationTime := slots.BeginsAt(signatureSlot, genesisTime).
+		Add(time.Duration(cfg.SecondsPerSlot/cfg.IntervalsPerSlot) * time.Second).
+		Add(-cfg.MaximumGossipClockDisparityDuration())
+	return !prysmTime.Now().Before(propagationTime)
+}
@@ -0,0 +1,200 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/encoder"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testLightClientHeader(slot primitives.Slot) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: 1,
+		ParentRoot:    make([]byte, 32),
+		StateRoot:     make([]byte, 32),
+		BodyRoot:      make([]byte, 32),
+	}
+}
+
+func testLightClientOptimisticUpdate(attestedSlot primitives.Slot) *ethpbv2.LightClientOptimisticUpdate {
+	return &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: testLightClientHeader(attestedSlot),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, 96),
+		},
+		SignatureSlot: attestedSlot + 1,
+	}
+}
+
+func testLightClientFinalityUpdate(attestedSlot, finalizedSlot primitives.Slot) *ethpbv2.LightClientFinalityUpdate {
+	optimistic := testLightClientOptimisticUpdate(attestedSlot)
+	branch := make([][]byte, 6)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  optimistic.AttestedHeader,
+		FinalizedHeader: testLightClientHeader(finalizedSlot),
+		FinalityBranch:  branch,
+		SyncAggregate:   optimistic.SyncAggregate,
+		SignatureSlot:   optimistic.SignatureSlot,
+	}
+}
+
+func setupLightClientValidationService(t *testing.T, syncing bool) *Service {
+	genesis := time.Now().Add(-time.Duration(100*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	s := &Service{
+		cfg: &config{
+			p2p:         p2ptest.NewTestP2P(t),
+			initialSync: &mockSync.Sync{IsSyncing: syncing},
+			clock:       startup.NewClock(genesis, [32]byte{'A'}),
+		},
+	}
+	return s
+}
+
+func lightClientPubsubMessage(t *testing.T, s *Service, topicFormat string, msg interface{ MarshalSSZ() ([]byte, error) }) *pubsub.Message {
+	digest, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic := fmt.Sprintf(topicFormat, digest) + "/" + encoder.ProtocolSuffixSSZSnappy
+	buf, err := msg.MarshalSSZ()
+	require.NoError(t, err)
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:  snappy.Encode(nil, buf),
+			Topic: &topic,
+		},
+	}
+}
+
+func TestValidateLightClientOptimisticUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		syncing bool
+		local   *ethpbv2.LightClientOptimisticUpdate
+		msg     *ethpbv2.LightClientOptimisticUpdate
+		want    pubsub.ValidationResult
+	}{
+		{
+			name:    "syncing",
+			syncing: true,
+			local:   testLightClientOptimisticUpdate(10),
+			msg:     testLightClientOptimisticUpdate(10),
+			want:    pubsub.ValidationIgnore,
+		},
+		{
+			name: "no local update",
+			msg:  testLightClientOptimisticUpdate(10),
+			want: pubsub.ValidationIgnore,
+		},
+		{
+			name:  "does not match local update",
+			local: testLightClientOptimisticUpdate(10),
+			msg:   testLightClientOptimisticUpdate(11),
+			want:  pubsub.ValidationIgnore,
+		},
+		{
+			name:  "signature slot too recent",
+			local: testLightClientOptimisticUpdate(1000),
+			msg:   testLightClientOptimisticUpdate(1000),
+			want:  pubsub.ValidationIgnore,
+		},
+		{
+			name:  "matches local update",
+			local: testLightClientOptimisticUpdate(10),
+			msg:   testLightClientOptimisticUpdate(10),
+			want:  pubsub.ValidationAccept,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupLightClientValidationService(t, tt.syncing)
+			s.setLightClientOptimisticUpdate(tt.local)
+			msg := lightClientPubsubMessage(t, s, p2p.LightClientOptimisticUpdateTopicFormat, tt.msg)
+			got, _ := s.validateLightClientOptimisticUpdate(context.Background(), "peer", msg)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateLightClientOptimisticUpdate_IgnoresAlreadyForwarded(t *testing.T) {
+	s := setupLightClientValidationService(t, false)
+	s.setLightClientOptimisticUpdate(testLightClientOptimisticUpdate(10))
+
+	msg := lightClientPubsubMessage(t, s, p2p.LightClientOptimisticUpdateTopicFormat, testLightClientOptimisticUpdate(10))
+	got, err := s.validateLightClientOptimisticUpdate(context.Background(), "peer", msg)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, got)
+
+	msg = lightClientPubsubMessage(t, s, p2p.LightClientOptimisticUpdateTopicFormat, testLightClientOptimisticUpdate(10))
+	got, err = s.validateLightClientOptimisticUpdate(context.Background(), "peer", msg)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, got)
+}
+
+func TestValidateLightClientFinalityUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		local     *ethpbv2.LightClientFinalityUpdate
+		msg       *ethpbv2.LightClientFinalityUpdate
+		forwarded primitives.Slot
+		want      pubsub.ValidationResult
+	}{
+		{
+			name: "no local update",
+			msg:  testLightClientFinalityUpdate(10, 4),
+			want: pubsub.ValidationIgnore,
+		},
+		{
+			name:  "does not match local update",
+			local: testLightClientFinalityUpdate(10, 4),
+			msg:   testLightClientFinalityUpdate(10, 5),
+			want:  pubsub.ValidationIgnore,
+		},
+		{
+			name:      "finalized slot already forwarded",
+			local:     testLightClientFinalityUpdate(10, 4),
+			msg:       testLightClientFinalityUpdate(10, 4),
+			forwarded: 4,
+			want:      pubsub.ValidationIgnore,
+		},
+		{
+			name:      "matches local update",
+			local:     testLightClientFinalityUpdate(10, 4),
+			msg:       testLightClientFinalityUpdate(10, 4),
+			forwarded: 3,
+			want:      pubsub.ValidationAccept,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupLightClientValidationService(t, false)
+			s.setLightClientFinalityUpdate(tt.local)
+			s.lastForwardedFinalizedSlot = tt.forwarded
+			msg := lightClientPubsubMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, tt.msg)
+			got, _ := s.validateLightClientFinalityUpdate(context.Background(), "peer", msg)
+			assert.Equal(t, tt.want, got)
+			if got == pubsub.ValidationAccept {
+				assert.Equal(t, tt.msg.FinalizedHeader.Slot, s.lastForwardedFinalizedSlot)
+			}
+		})
+	}
+}
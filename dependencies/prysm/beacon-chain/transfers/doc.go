@@ -0,0 +1,3 @@
+// Package transfers defines a service indexing the deposits processed for validators and the withdrawals
+// paid to them, as blocks get finalized.
+package transfers
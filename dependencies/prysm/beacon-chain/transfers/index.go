@@ -0,0 +1,220 @@
+package transfers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// Number of epochs of finalized blocks saved at once.
+const batchEpochs = 8
+
+// indexer indexes finalized blocks, looking up the eth1 log of the processed deposits in the deposit cache.
+type indexer struct {
+	cfg        *Config
+	containers []*ethpb.DepositContainer
+}
+
+// indexOrigin initializes the index from the state the node started from. When syncing from genesis, the
+// deposits included in the genesis state are indexed as well.
+func (s *Service) indexOrigin(ctx context.Context) (*dbval.TransfersProgress, error) {
+	st, err := s.originState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress := &dbval.TransfersProgress{
+		Slot:             uint64(st.Slot()),
+		NextDepositIndex: st.Eth1DepositIndex(),
+	}
+	var deposits []*dbval.ValidatorDeposit
+	if st.Slot() == 0 {
+		ix := &indexer{cfg: s.cfg}
+		for i := uint64(0); i < st.Eth1DepositIndex(); i++ {
+			c := ix.container(ctx, i)
+			if c == nil {
+				continue
+			}
+			if d := ix.validatorDeposit(c.Deposit, 0, i, c); d != nil {
+				deposits = append(deposits, d)
+			}
+		}
+	}
+	if err := s.cfg.BeaconDB.SaveTransfers(ctx, progress, deposits, nil); err != nil {
+		return nil, errors.Wrap(err, "could not save transfers")
+	}
+	return progress, nil
+}
+
+func (s *Service) originState(ctx context.Context) (state.BeaconState, error) {
+	root, err := s.cfg.BeaconDB.OriginCheckpointBlockRoot(ctx)
+	if err == nil {
+		st, err := s.cfg.BeaconDB.State(ctx, root)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get origin checkpoint state")
+		}
+		if st != nil && !st.IsNil() {
+			return st, nil
+		}
+	}
+	st, err := s.cfg.BeaconDB.GenesisState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis state")
+	}
+	if st == nil || st.IsNil() {
+		return nil, errors.New("genesis state not found")
+	}
+	return st, nil
+}
+
+// indexBlocks indexes the finalized blocks after the progress slot, up to the end slot included.
+func (ix *indexer) indexBlocks(
+	ctx context.Context,
+	progress *dbval.TransfersProgress,
+	end primitives.Slot,
+) (*dbval.TransfersProgress, error) {
+	f := filters.NewFilter().SetStartSlot(primitives.Slot(progress.Slot + 1)).SetEndSlot(end)
+	blks, roots, err := ix.cfg.BeaconDB.Blocks(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blocks")
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, b := range blks {
+		if ix.cfg.BeaconDB.IsFinalizedBlock(ctx, roots[i]) {
+			canonical = append(canonical, b)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+	if err := ix.reconstructPayloads(ctx, canonical); err != nil {
+		return nil, err
+	}
+
+	next := &dbval.TransfersProgress{Slot: uint64(end), NextDepositIndex: progress.NextDepositIndex}
+	var deposits []*dbval.ValidatorDeposit
+	var withdrawals []*dbval.ValidatorWithdrawal
+	for _, b := range canonical {
+		blk := b.Block()
+		for _, d := range blk.Body().Deposits() {
+			depositIndex := next.NextDepositIndex
+			next.NextDepositIndex++
+			if vd := ix.validatorDeposit(d, blk.Slot(), depositIndex, ix.container(ctx, depositIndex)); vd != nil {
+				deposits = append(deposits, vd)
+			}
+		}
+		if blk.Version() < version.Capella {
+			continue
+		}
+		payload, err := blk.Body().Execution()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get execution payload of block at slot %d", blk.Slot())
+		}
+		ws, err := payload.Withdrawals()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get withdrawals of block at slot %d", blk.Slot())
+		}
+		for _, w := range ws {
+			withdrawals = append(withdrawals, &dbval.ValidatorWithdrawal{
+				ValidatorIndex:       uint64(w.ValidatorIndex),
+				Slot:                 uint64(blk.Slot()),
+				WithdrawalIndex:      w.Index,
+				Address:              bytesutil.SafeCopyBytes(w.Address),
+				Amount:               w.Amount,
+				ExecutionBlockNumber: payload.BlockNumber(),
+			})
+		}
+	}
+	if err := ix.cfg.BeaconDB.SaveTransfers(ctx, next, deposits, withdrawals); err != nil {
+		return nil, errors.Wrap(err, "could not save transfers")
+	}
+	return next, nil
+}
+
+// reconstructPayloads replaces the blinded blocks since Capella with their full version, which carries the
+// withdrawals of the execution payload.
+func (ix *indexer) reconstructPayloads(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock) error {
+	var blinded []interfaces.ReadOnlySignedBeaconBlock
+	var positions []int
+	for i, b := range blks {
+		if b.Version() >= version.Capella && b.IsBlinded() {
+			blinded = append(blinded, b)
+			positions = append(positions, i)
+		}
+	}
+	if len(blinded) == 0 {
+		return nil
+	}
+	if ix.cfg.ExecutionPayloadReconstructor == nil {
+		return errors.New("no execution payload reconstructor for blinded blocks")
+	}
+	full, err := ix.cfg.ExecutionPayloadReconstructor.ReconstructFullBellatrixBlockBatch(ctx, blinded)
+	if err != nil {
+		return errors.Wrap(err, "could not reconstruct full blocks")
+	}
+	if len(full) != len(blinded) {
+		return errors.Errorf("reconstructed %d full blocks out of %d blinded blocks", len(full), len(blinded))
+	}
+	for i, b := range full {
+		blks[positions[i]] = b
+	}
+	return nil
+}
+
+// container returns the deposit container of the deposit index, or nil if it is not in the deposit cache. The
+// containers are only fetched once per indexer, as the deposits of finalized blocks are known beforehand.
+func (ix *indexer) container(ctx context.Context, depositIndex uint64) *ethpb.DepositContainer {
+	if ix.cfg.DepositFetcher == nil {
+		return nil
+	}
+	if ix.containers == nil {
+		ix.containers = ix.cfg.DepositFetcher.AllDepositContainers(ctx)
+		sort.Slice(ix.containers, func(i, j int) bool {
+			return ix.containers[i].Index < ix.containers[j].Index
+		})
+	}
+	i := sort.Search(len(ix.containers), func(i int) bool {
+		return ix.containers[i].Index >= int64(depositIndex)
+	})
+	if i == len(ix.containers) || ix.containers[i].Index != int64(depositIndex) {
+		return nil
+	}
+	return ix.containers[i]
+}
+
+// validatorDeposit returns the index entry of a deposit, or nil if the deposit did not create a validator
+// known to the head state, as with deposits having an invalid signature. The eth1 block number and log index are
+// left zero, meaning unknown, when the deposit container is missing.
+func (ix *indexer) validatorDeposit(
+	d *ethpb.Deposit,
+	slot primitives.Slot,
+	depositIndex uint64,
+	c *ethpb.DepositContainer,
+) *dbval.ValidatorDeposit {
+	if d == nil || d.Data == nil {
+		return nil
+	}
+	idx, ok := ix.cfg.HeadFetcher.HeadPublicKeyToValidatorIndex(bytesutil.ToBytes48(d.Data.PublicKey))
+	if !ok {
+		return nil
+	}
+	vd := &dbval.ValidatorDeposit{
+		ValidatorIndex: uint64(idx),
+		Slot:           uint64(slot),
+		DepositIndex:   depositIndex,
+		Amount:         d.Data.Amount,
+	}
+	if c != nil {
+		vd.Eth1BlockNumber = c.Eth1BlockHeight
+		vd.LogIndex = c.LogIndex
+	}
+	return vd
+}
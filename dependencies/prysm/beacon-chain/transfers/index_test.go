@@ -0,0 +1,149 @@
+package transfers
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// headFetcher resolves public keys with the validator registry of a given head state.
+type headFetcher struct {
+	mock.ChainService
+	head state.BeaconState
+}
+
+func (h *headFetcher) HeadPublicKeyToValidatorIndex(pubKey [fieldparams.BLSPubkeyLength]byte) (primitives.ValidatorIndex, bool) {
+	return h.head.ValidatorIndexByPubkey(pubKey)
+}
+
+func TestService_indexFinalized(t *testing.T) {
+	// The genesis state of the database is not the embedded mainnet genesis state.
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ConfigName = "transfers-test"
+	params.OverrideBeaconConfig(cfg)
+	// Blocks are saved with their full execution payload, so that no execution engine is needed to read withdrawals.
+	resetCfg := features.InitWithReset(&features.Flags{SaveFullExecutionPayloads: true})
+	defer resetCfg()
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+
+	genesis, _ := util.DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, genesis.SetEth1DepositIndex(64))
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genesis))
+	genesisRoot, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	// The deposit cache knows the eth1 logs of the genesis deposits and of a top-up included at slot 1.
+	depositCache, err := depositsnapshot.New()
+	require.NoError(t, err)
+	for i := 0; i < 64; i++ {
+		pubkey := genesis.PubkeyAtIndex(primitives.ValidatorIndex(i))
+		d := &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: pubkey[:], Amount: 32}}
+		require.NoError(t, depositCache.InsertDeposit(ctx, d, 10, uint64(i), int64(i), [32]byte{}))
+	}
+	pubkey := genesis.PubkeyAtIndex(5)
+	topUp := &ethpb.Deposit{
+		Proof: make([][]byte, params.BeaconConfig().DepositContractTreeDepth+1),
+		Data: &ethpb.Deposit_Data{
+			PublicKey:             pubkey[:],
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                5,
+			Signature:             make([]byte, fieldparams.BLSSignatureLength),
+		},
+	}
+	for i := range topUp.Proof {
+		topUp.Proof[i] = make([]byte, 32)
+	}
+	require.NoError(t, depositCache.InsertDeposit(ctx, topUp, 20, 3, 64, [32]byte{}))
+
+	b1 := util.NewBeaconBlockCapella()
+	b1.Block.Slot = 1
+	b1.Block.ParentRoot = genesisRoot[:]
+	b1.Block.Body.Deposits = []*ethpb.Deposit{topUp}
+	b1.Block.Body.ExecutionPayload.BlockNumber = 100
+	b1.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{
+		{Index: 0, ValidatorIndex: 3, Address: bytesutil.PadTo([]byte{'A'}, 20), Amount: 7},
+	}
+	util.SaveBlock(t, ctx, beaconDB, b1)
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// A block which is not part of the finalized chain is not indexed.
+	orphan := util.NewBeaconBlockCapella()
+	orphan.Block.Slot = 2
+	orphan.Block.ParentRoot = genesisRoot[:]
+	orphan.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{
+		{Index: 1, ValidatorIndex: 3, Address: bytesutil.PadTo([]byte{'B'}, 20), Amount: 8},
+	}
+	util.SaveBlock(t, ctx, beaconDB, orphan)
+
+	b2 := util.NewBeaconBlockCapella()
+	b2.Block.Slot = 40
+	b2.Block.ParentRoot = r1[:]
+	b2.Block.Body.ExecutionPayload.BlockNumber = 140
+	b2.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{
+		{Index: 1, ValidatorIndex: 3, Address: bytesutil.PadTo([]byte{'A'}, 20), Amount: 9},
+	}
+	util.SaveBlock(t, ctx, beaconDB, b2)
+	r2, err := b2.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 40, Root: r2[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: r2[:]}))
+
+	s := NewService(ctx, &Config{
+		BeaconDB:       beaconDB,
+		HeadFetcher:    &headFetcher{head: genesis},
+		DepositFetcher: depositCache,
+	})
+	require.NoError(t, s.indexFinalized(ctx, 2))
+
+	progress, err := beaconDB.TransfersProgress(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(64), progress.Slot)
+	assert.Equal(t, uint64(65), progress.NextDepositIndex)
+
+	deposits, err := beaconDB.ValidatorDeposits(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deposits))
+	assert.Equal(t, uint64(5), deposits[0].DepositIndex)
+	assert.Equal(t, uint64(0), deposits[0].Slot)
+	assert.Equal(t, uint64(32), deposits[0].Amount)
+	assert.Equal(t, uint64(10), deposits[0].Eth1BlockNumber)
+	assert.Equal(t, uint64(5), deposits[0].LogIndex)
+	assert.Equal(t, uint64(64), deposits[1].DepositIndex)
+	assert.Equal(t, uint64(1), deposits[1].Slot)
+	assert.Equal(t, uint64(5), deposits[1].Amount)
+	assert.Equal(t, uint64(20), deposits[1].Eth1BlockNumber)
+	assert.Equal(t, uint64(3), deposits[1].LogIndex)
+
+	withdrawals, err := beaconDB.ValidatorWithdrawals(ctx, primitives.ValidatorIndex(3))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(withdrawals))
+	assert.Equal(t, uint64(1), withdrawals[0].Slot)
+	assert.Equal(t, uint64(7), withdrawals[0].Amount)
+	assert.Equal(t, uint64(100), withdrawals[0].ExecutionBlockNumber)
+	assert.Equal(t, uint64(40), withdrawals[1].Slot)
+	assert.Equal(t, uint64(9), withdrawals[1].Amount)
+	assert.Equal(t, uint64(140), withdrawals[1].ExecutionBlockNumber)
+
+	// Indexing again the same finalized epoch is a no-op.
+	require.NoError(t, s.indexFinalized(ctx, 2))
+	deposits, err = beaconDB.ValidatorDeposits(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(deposits))
+}
@@ -0,0 +1,16 @@
+package transfers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	log = logrus.WithField("prefix", "transfers")
+
+	indexedSlotGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "transfers_indexed_slot",
+		Help: "The slot of the last finalized block indexed by the deposits and withdrawals index",
+	})
+)
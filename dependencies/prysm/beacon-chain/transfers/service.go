@@ -0,0 +1,124 @@
+package transfers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// Config for the deposits and withdrawals index service.
+type Config struct {
+	BeaconDB                      db.NoHeadAccessDatabase
+	StateNotifier                 statefeed.Notifier
+	HeadFetcher                   blockchain.HeadFetcher
+	DepositFetcher                cache.DepositFetcher
+	ExecutionPayloadReconstructor execution.PayloadReconstructor
+}
+
+// Service indexes the deposits processed for validators and the withdrawals paid to them, as blocks get finalized.
+type Service struct {
+	cfg     *Config
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running bool
+}
+
+// NewService sets up a new deposits and withdrawals index service instance.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the deposits and withdrawals index service.
+func (s *Service) Start() {
+	s.running = true
+	go s.run()
+}
+
+// Stop the deposits and withdrawals index service.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	s.running = false
+	return nil
+}
+
+// Status of the deposits and withdrawals index service.
+func (s *Service) Status() error {
+	if s.running {
+		return nil
+	}
+	return errors.New("not running")
+}
+
+// Indexes the blocks finalized by every new finalized checkpoint.
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case e := <-stateChannel:
+			if e.Type != statefeed.FinalizedCheckpoint {
+				continue
+			}
+			data, ok := e.Data.(*ethpbv1.EventFinalizedCheckpoint)
+			if !ok {
+				log.Error("Event feed data is not of type *ethpbv1.EventFinalizedCheckpoint")
+				continue
+			}
+			if err := s.indexFinalized(s.ctx, data.Epoch); err != nil {
+				log.WithError(err).Error("Could not index deposits and withdrawals")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		}
+	}
+}
+
+// indexFinalized indexes the finalized blocks after the last indexed one, up to the start of the finalized epoch.
+func (s *Service) indexFinalized(ctx context.Context, finalized primitives.Epoch) error {
+	end, err := slots.EpochStart(finalized)
+	if err != nil {
+		return err
+	}
+	progress, err := s.cfg.BeaconDB.TransfersProgress(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		progress, err = s.indexOrigin(ctx)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get transfers progress")
+	}
+	ix := &indexer{cfg: s.cfg}
+	for primitives.Slot(progress.Slot) < end {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		batchEnd := primitives.Slot(progress.Slot) + params.BeaconConfig().SlotsPerEpoch*batchEpochs
+		if batchEnd > end {
+			batchEnd = end
+		}
+		if progress, err = ix.indexBlocks(ctx, progress, batchEnd); err != nil {
+			return errors.Wrapf(err, "could not index blocks up to slot %d", batchEnd)
+		}
+		indexedSlotGauge.Set(float64(progress.Slot))
+	}
+	return nil
+}
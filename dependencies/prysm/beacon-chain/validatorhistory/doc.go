@@ -0,0 +1,6 @@
+/*
+Package validatorhistory defines a runtime service which indexes the balance and the rewards of tracked
+validators at every finalized epoch, so that their history is served from the database instead of
+replaying states for every query.
+*/
+package validatorhistory
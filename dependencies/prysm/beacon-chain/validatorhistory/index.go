@@ -0,0 +1,223 @@
+package validatorhistory
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// The state at the last slot of an epoch, before the epoch processing.
+type epochState struct {
+	epoch primitives.Epoch
+	state state.BeaconState
+}
+
+// indexEpoch computes and saves the balance and the rewards of the tracked validators over an epoch. It
+// returns the state at the end of the next epoch, from which the attestation rewards are computed.
+func (s *Service) indexEpoch(ctx context.Context, epoch primitives.Epoch, prev *epochState) (*epochState, error) {
+	var st state.BeaconState
+	var err error
+	if prev != nil && prev.epoch == epoch {
+		st = prev.state
+	} else if st, err = s.stateAtEpochEnd(ctx, epoch); err != nil {
+		return nil, err
+	}
+	next, err := s.stateAtEpochEnd(ctx, epoch+1)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[primitives.ValidatorIndex]*dbval.ValidatorHistory, len(s.tracked))
+	indices := make([]primitives.ValidatorIndex, 0, len(s.tracked))
+	for _, idx := range s.tracked {
+		// Validators which are not deposited yet have no history.
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			continue
+		}
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		v, err := st.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, err
+		}
+		history[idx] = &dbval.ValidatorHistory{
+			Epoch:            uint64(epoch),
+			Balance:          balance,
+			EffectiveBalance: v.EffectiveBalance(),
+		}
+		indices = append(indices, idx)
+	}
+
+	// Rewards are computed with the Altair accounting, which is not defined for Phase 0.
+	if len(indices) > 0 && epoch >= params.BeaconConfig().AltairForkEpoch {
+		if err := addAttestationRewards(ctx, next, indices, history); err != nil {
+			return nil, err
+		}
+		if err := s.addBlockRewards(ctx, epoch, st, next, history); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cfg.BeaconDB.SaveValidatorHistory(ctx, epoch, history); err != nil {
+		return nil, errors.Wrap(err, "could not save validator history")
+	}
+	return &epochState{epoch: epoch + 1, state: next}, nil
+}
+
+func (s *Service) stateAtEpochEnd(ctx context.Context, epoch primitives.Epoch) (state.BeaconState, error) {
+	slot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.cfg.ReplayerBuilder.ReplayerForSlot(slot).ReplayBlocks(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not replay state at slot %d", slot)
+	}
+	return st, nil
+}
+
+// addAttestationRewards adds the rewards of the attestations of the previous epoch of the state.
+func addAttestationRewards(
+	ctx context.Context,
+	st state.BeaconState,
+	indices []primitives.ValidatorIndex,
+	history map[primitives.ValidatorIndex]*dbval.ValidatorHistory,
+) error {
+	deltas, err := rewards.AttestationDeltas(ctx, st, indices)
+	if err != nil {
+		return err
+	}
+	for i, d := range deltas {
+		h := history[indices[i]]
+		h.AttestationSource = int64(d.SourceReward) - int64(d.SourcePenalty)
+		h.AttestationTarget = int64(d.TargetReward) - int64(d.TargetPenalty)
+		h.AttestationHead = int64(d.HeadReward)
+		h.Inactivity = -int64(d.InactivityPenalty)
+	}
+	return nil
+}
+
+// addBlockRewards adds the sync committee rewards and the proposer rewards of the canonical blocks of the
+// epoch, which are read from the block roots of the state at the end of the next epoch.
+func (s *Service) addBlockRewards(
+	ctx context.Context,
+	epoch primitives.Epoch,
+	st, next state.BeaconState,
+	history map[primitives.ValidatorIndex]*dbval.ValidatorHistory,
+) error {
+	committee, err := syncCommitteeIndices(st)
+	if err != nil {
+		return err
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return errors.Wrap(err, "could not get total active balance")
+	}
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return errors.Wrap(err, "could not get sync committee rewards")
+	}
+
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return err
+	}
+	end, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return err
+	}
+	// There is nothing to reward in the genesis block.
+	if start == 0 {
+		start = 1
+	}
+	prevRoot, err := helpers.BlockRootAtSlot(next, start-1)
+	if err != nil {
+		return err
+	}
+	for slot := start; slot <= end; slot++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		root, err := helpers.BlockRootAtSlot(next, slot)
+		if err != nil {
+			return err
+		}
+		// The block root of a skipped slot is the one of the previous slot.
+		if bytes.Equal(root, prevRoot) {
+			continue
+		}
+		prevRoot = root
+		blk, err := s.cfg.BeaconDB.Block(ctx, bytesutil.ToBytes32(root))
+		if err != nil {
+			return err
+		}
+		if err := blocks.BeaconBlockIsNil(blk); err != nil {
+			return errors.Wrapf(err, "could not get block at slot %d", slot)
+		}
+		if blk.Version() == version.Phase0 {
+			continue
+		}
+
+		if h, ok := history[blk.Block().ProposerIndex()]; ok {
+			blockRewards, httpErr := s.cfg.BlockRewardFetcher.GetBlockRewardsData(ctx, blk.Block())
+			if httpErr != nil {
+				return errors.Errorf("could not get rewards of block at slot %d: %s", slot, httpErr.Message)
+			}
+			total, err := strconv.ParseUint(blockRewards.Total, 10, 64)
+			if err != nil {
+				return err
+			}
+			h.Proposer += total
+		}
+
+		sa, err := blk.Block().Body().SyncAggregate()
+		if err != nil {
+			return err
+		}
+		for i, idx := range committee {
+			h, ok := history[idx]
+			if !ok {
+				continue
+			}
+			if sa.SyncCommitteeBits.BitAt(uint64(i)) {
+				h.SyncCommittee += int64(participantReward)
+			} else {
+				h.SyncCommittee -= int64(participantReward)
+			}
+		}
+	}
+	return nil
+}
+
+// syncCommitteeIndices returns the validator indices of the members of the current sync committee of the state,
+// in committee order.
+func syncCommitteeIndices(st state.BeaconState) ([]primitives.ValidatorIndex, error) {
+	sc, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	indices := make([]primitives.ValidatorIndex, len(sc.Pubkeys))
+	for i, pk := range sc.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			return nil, errors.Errorf("no validator index found for pubkey %#x", pk)
+		}
+		indices[i] = idx
+	}
+	return indices, nil
+}
@@ -0,0 +1,137 @@
+package validatorhistory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+type mockBlockRewards struct {
+	total string
+}
+
+func (m *mockBlockRewards) GetBlockRewardsData(context.Context, interfaces.ReadOnlyBeaconBlock) (*rewards.BlockRewards, *httputil.DefaultJsonError) {
+	return &rewards.BlockRewards{Total: m.total}, nil
+}
+
+func (*mockBlockRewards) GetStateForRewards(context.Context, interfaces.ReadOnlyBeaconBlock) (state.BeaconState, *httputil.DefaultJsonError) {
+	return nil, nil
+}
+
+func TestService_indexFinalized(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+
+	genesis, _ := util.DeterministicGenesisStateAltair(t, 64)
+	sc, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(sc))
+	tracked, ok := genesis.ValidatorIndexByPubkey(bytesutil.ToBytes48(sc.Pubkeys[0]))
+	require.Equal(t, true, ok)
+
+	// Index epoch 1, from the states at the end of epochs 1 and 2.
+	epoch := primitives.Epoch(1)
+	endSlot, err := slots.EpochEnd(epoch)
+	require.NoError(t, err)
+	st, err := transition.ProcessSlots(ctx, genesis.Copy(), endSlot)
+	require.NoError(t, err)
+	nextEndSlot, err := slots.EpochEnd(epoch + 1)
+	require.NoError(t, err)
+	next, err := transition.ProcessSlots(ctx, st.Copy(), nextEndSlot)
+	require.NoError(t, err)
+
+	// The tracked validator attested timely in epoch 1.
+	participation, err := next.PreviousEpochParticipation()
+	require.NoError(t, err)
+	participation[tracked] = 0b111
+	require.NoError(t, next.SetPreviousParticipationBits(participation))
+
+	// The tracked validator proposed a block with a full sync aggregate in epoch 1.
+	blockSlot := endSlot - 10
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = blockSlot
+	b.Block.ProposerIndex = tracked
+	for i := range b.Block.Body.SyncAggregate.SyncCommitteeBits {
+		b.Block.Body.SyncAggregate.SyncCommitteeBits[i] = 0xFF
+	}
+	util.SaveBlock(t, ctx, beaconDB, b)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	for slot := blockSlot; slot <= endSlot; slot++ {
+		require.NoError(t, next.UpdateBlockRootAtIndex(uint64(slot%params.BeaconConfig().SlotsPerHistoricalRoot), root))
+	}
+
+	replayer := mock.NewReplayerBuilder()
+	replayer.SetMockStateForSlot(st, endSlot)
+	replayer.SetMockStateForSlot(next, nextEndSlot)
+	s, err := NewService(ctx, &Config{
+		BeaconDB:           beaconDB,
+		ReplayerBuilder:    replayer,
+		BlockRewardFetcher: &mockBlockRewards{total: "123"},
+		TrackedValidators:  []primitives.ValidatorIndex{tracked, 1000},
+	})
+	require.NoError(t, err)
+
+	// Nothing was indexed yet, so only the last epoch finalized by the checkpoint is indexed.
+	require.NoError(t, s.indexFinalized(ctx, epoch+2))
+	last, err := beaconDB.LastValidatorHistoryEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, last)
+	history, err := beaconDB.ValidatorHistory(ctx, tracked, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	h := history[0]
+	assert.Equal(t, uint64(epoch), h.Epoch)
+	balance, err := st.BalanceAtIndex(tracked)
+	require.NoError(t, err)
+	assert.Equal(t, balance, h.Balance)
+	assert.Equal(t, params.BeaconConfig().MaxEffectiveBalance, h.EffectiveBalance)
+	assert.Equal(t, true, h.AttestationSource > 0)
+	assert.Equal(t, true, h.AttestationTarget > 0)
+	assert.Equal(t, true, h.AttestationHead > 0)
+	assert.Equal(t, int64(0), h.Inactivity)
+	assert.Equal(t, uint64(123), h.Proposer)
+
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	require.NoError(t, err)
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+	seats := 0
+	for _, pk := range sc.Pubkeys {
+		if idx, _ := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk)); idx == tracked {
+			seats++
+		}
+	}
+	assert.Equal(t, int64(participantReward)*int64(seats), h.SyncCommittee)
+
+	// Validators which do not exist yet have no history.
+	history, err = beaconDB.ValidatorHistory(ctx, 1000, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+
+	// Epochs already indexed are not indexed again.
+	require.NoError(t, s.indexFinalized(ctx, epoch+2))
+	history, err = beaconDB.ValidatorHistory(ctx, tracked, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(history))
+}
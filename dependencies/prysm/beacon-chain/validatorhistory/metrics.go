@@ -0,0 +1,16 @@
+package validatorhistory
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	log = logrus.WithField("prefix", "validator-history")
+
+	indexedEpochGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "validator_history_indexed_epoch",
+		Help: "The last epoch indexed by the validator history service",
+	})
+)
@@ -0,0 +1,145 @@
+package validatorhistory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Config for the validator history service.
+type Config struct {
+	BeaconDB           db.NoHeadAccessDatabase
+	StateNotifier      statefeed.Notifier
+	ReplayerBuilder    stategen.ReplayerBuilder
+	BlockRewardFetcher rewards.BlockRewardsFetcher
+	// TrackedValidators are the indices of the validators whose history is indexed.
+	TrackedValidators []primitives.ValidatorIndex
+}
+
+// Service indexes the balance and the rewards of the tracked validators at every epoch, as epochs
+// get finalized.
+type Service struct {
+	cfg     *Config
+	ctx     context.Context
+	cancel  context.CancelFunc
+	tracked []primitives.ValidatorIndex
+	running bool
+}
+
+// NewService sets up a new validator history service instance.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if len(cfg.TrackedValidators) == 0 {
+		return nil, errors.New("no validators to track")
+	}
+	tracked := make([]primitives.ValidatorIndex, len(cfg.TrackedValidators))
+	copy(tracked, cfg.TrackedValidators)
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:     cfg,
+		ctx:     ctx,
+		cancel:  cancel,
+		tracked: tracked,
+	}, nil
+}
+
+// Start the validator history service.
+func (s *Service) Start() {
+	log.WithField("validatorIndices", s.tracked).Info("Starting service")
+	s.running = true
+	go s.run()
+}
+
+// Stop the validator history service.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	s.running = false
+	return nil
+}
+
+// Status of the validator history service.
+func (s *Service) Status() error {
+	if s.running {
+		return nil
+	}
+	return errors.New("not running")
+}
+
+// Indexes the epochs finalized by every new finalized checkpoint.
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case e := <-stateChannel:
+			if e.Type != statefeed.FinalizedCheckpoint {
+				continue
+			}
+			data, ok := e.Data.(*ethpbv1.EventFinalizedCheckpoint)
+			if !ok {
+				log.Error("Event feed data is not of type *ethpbv1.EventFinalizedCheckpoint")
+				continue
+			}
+			if err := s.indexFinalized(s.ctx, data.Epoch); err != nil {
+				log.WithError(err).Error("Could not index validator history")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		}
+	}
+}
+
+// indexFinalized indexes the epochs after the last indexed one which are fully finalized by the finalized
+// checkpoint. The attestation rewards of an epoch are only known at the end of the next epoch, so the last
+// epoch indexed is two epochs before the finalized checkpoint. When nothing was indexed yet, indexing
+// starts at that epoch.
+func (s *Service) indexFinalized(ctx context.Context, finalized primitives.Epoch) error {
+	if finalized < 2 {
+		return nil
+	}
+	end := finalized - 2
+	start := end
+	last, err := s.cfg.BeaconDB.LastValidatorHistoryEpoch(ctx)
+	switch {
+	case err == nil:
+		if last >= end {
+			return nil
+		}
+		start = last + 1
+	case !errors.Is(err, db.ErrNotFound):
+		return errors.Wrap(err, "could not get last indexed epoch")
+	}
+
+	var st *epochState
+	for epoch := start; epoch <= end; epoch++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The state at the end of an epoch is reused as the state at the end of the previous epoch
+		// of the next one.
+		st, err = s.indexEpoch(ctx, epoch, st)
+		if err != nil {
+			return errors.Wrapf(err, "could not index epoch %d", epoch)
+		}
+		indexedEpochGauge.Set(float64(epoch))
+		log.WithFields(logrus.Fields{
+			"epoch":      epoch,
+			"validators": len(s.tracked),
+		}).Debug("Indexed validator history")
+	}
+	return nil
+}
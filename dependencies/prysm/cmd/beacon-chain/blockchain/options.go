@@ -5,6 +5,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,5 +22,16 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
 	}
+	// Defaults are resolved from the beacon config, which is only loaded at runtime.
+	lightClientRetention := params.BeaconConfig().MinEpochsForBlockRequests / uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod)
+	if c.IsSet(flags.LightClientRetentionPeriods.Name) {
+		lightClientRetention = c.Uint64(flags.LightClientRetentionPeriods.Name)
+	}
+	opts = append(opts, blockchain.WithLightClientRetentionPeriods(lightClientRetention))
+	inclusionsRetention := primitives.Epoch(params.BeaconConfig().MinEpochsForBlockRequests)
+	if c.IsSet(flags.AttestationInclusionsRetentionEpochs.Name) {
+		inclusionsRetention = primitives.Epoch(c.Uint64(flags.AttestationInclusionsRetentionEpochs.Name))
+	}
+	opts = append(opts, blockchain.WithAttestationInclusionsRetentionEpochs(inclusionsRetention))
 	return opts, nil
 }
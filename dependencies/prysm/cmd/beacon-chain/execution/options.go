@@ -7,7 +7,9 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	cmdflags "github.com/prysmaticlabs/prysm/v4/cmd/flags"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -19,10 +21,18 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	if err != nil {
 		return nil, err
 	}
-	jwtSecret, err := parseJWTSecretFromFile(c)
+	jwtSecret, err := parseJWTSecretFromFile(c.String(flags.ExecutionJWTSecretFlag.Name))
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read JWT secret file for authenticating execution API")
 	}
+	backupEndpoints, err := parseBackupExecutionChainEndpoints(c, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	backupJWTSecrets, err := parseBackupJWTSecrets(c, len(backupEndpoints), jwtSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read JWT secret file for authenticating backup execution API")
+	}
 	headers := strings.Split(c.String(flags.ExecutionEngineHeaders.Name), ",")
 	opts := []execution.Option{
 		execution.WithHttpEndpoint(endpoint),
@@ -32,9 +42,41 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	if len(jwtSecret) > 0 {
 		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
 	}
+	for i, backup := range backupEndpoints {
+		opts = append(opts, execution.WithBackupHttpEndpoint(backup, backupJWTSecrets[i]))
+	}
 	return opts, nil
 }
 
+// Parses the JWT secrets of the given number of backup execution endpoints. Either no secret, in which
+// case the secret of the primary endpoint is used, a single secret shared by all the backup endpoints,
+// or one secret per backup endpoint can be provided.
+func parseBackupJWTSecrets(c *cli.Context, endpoints int, primarySecret []byte) ([][]byte, error) {
+	files := c.StringSlice(flags.ExecutionJWTSecretBackupFlag.Name)
+	if len(files) > 1 && len(files) != endpoints {
+		return nil, fmt.Errorf("%d JWT secrets provided for %d backup execution endpoints, "+
+			"provide a single secret or one secret per backup endpoint", len(files), endpoints)
+	}
+	secrets := make([][]byte, endpoints)
+	for i := range secrets {
+		secrets[i] = primarySecret
+	}
+	for i, f := range files {
+		secret, err := parseJWTSecretFromFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 1 {
+			for j := range secrets {
+				secrets[j] = secret
+			}
+			break
+		}
+		secrets[i] = secret
+	}
+	return secrets, nil
+}
+
 // Parses a JWT secret from a file path. This secret is required when connecting to execution nodes
 // over HTTP, and must be the same one used in Prysm and the execution node server Prysm is connecting to.
 // The engine API specification here https://github.com/ethereum/execution-apis/blob/main/src/engine/authentication.md
@@ -43,8 +85,7 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 // The secret must be stored as a hex-encoded string within a file in the filesystem.
 // If the --jwt-secret flag is provided to Prysm, but the file cannot be read, or does not contain a hex-encoded
 // key of at least 256 bits, the client should treat this as an error and abort the startup.
-func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
-	jwtSecretFile := c.String(flags.ExecutionJWTSecretFlag.Name)
+func parseJWTSecretFromFile(jwtSecretFile string) ([]byte, error) {
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
@@ -79,3 +120,25 @@ func parseExecutionChainEndpoint(c *cli.Context) (string, error) {
 	}
 	return c.String(flags.ExecutionEngineEndpoint.Name), nil
 }
+
+// Parses the backup execution endpoints, in order of preference, leaving out duplicates and the
+// primary endpoint. Backup endpoints are not split on commas, as they can contain an auth header.
+func parseBackupExecutionChainEndpoints(c *cli.Context, primary string) ([]string, error) {
+	var endpoints []string
+	seen := map[string]bool{primary: true}
+	for _, e := range cmdflags.UnsplitStringSliceFromContext(c, flags.ExecutionEngineBackupEndpoint.Name) {
+		if e == "" {
+			continue
+		}
+		e, err := cmd.ExpandEndpointIfFile(e)
+		if err != nil {
+			return nil, err
+		}
+		if seen[e] {
+			continue
+		}
+		seen[e] = true
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, nil
+}
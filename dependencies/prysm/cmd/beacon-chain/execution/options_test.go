@@ -7,8 +7,11 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	cmdflags "github.com/prysmaticlabs/prysm/v4/cmd/flags"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/network/authorization"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/urfave/cli/v2"
@@ -25,67 +28,73 @@ func TestExecutionchainCmd(t *testing.T) {
 	assert.Equal(t, "primary", endpoints)
 }
 
+func Test_parseBackupExecutionChainEndpoints(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	require.NoError(t, flags.ExecutionEngineEndpoint.Apply(set))
+	set.Var(&cmdflags.UnsplitStringSlice{}, flags.ExecutionEngineBackupEndpoint.Name, "")
+	// Endpoints can contain an auth header after a comma, which must not be split into another endpoint.
+	require.NoError(t, set.Parse([]string{
+		"--" + flags.ExecutionEngineEndpoint.Name, "http://primary:8551,Bearer primary-token",
+		"--" + flags.ExecutionEngineBackupEndpoint.Name, "http://backup:8551,Bearer backup-token",
+		"--" + flags.ExecutionEngineBackupEndpoint.Name, "http://primary:8551,Bearer primary-token",
+		"--" + flags.ExecutionEngineBackupEndpoint.Name, "http://other:8551",
+	}))
+	ctx := cli.NewContext(&app, set, nil)
+
+	primary, err := parseExecutionChainEndpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "http://primary:8551,Bearer primary-token", primary)
+	primaryEndpoint := network.HttpEndpoint(primary)
+	assert.Equal(t, "http://primary:8551", primaryEndpoint.Url)
+	assert.Equal(t, authorization.Bearer, primaryEndpoint.Auth.Method)
+	assert.Equal(t, "primary-token", primaryEndpoint.Auth.Value)
+
+	backups, err := parseBackupExecutionChainEndpoints(ctx, primary)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"http://backup:8551,Bearer backup-token", "http://other:8551"}, backups)
+	backupEndpoint := network.HttpEndpoint(backups[0])
+	assert.Equal(t, "http://backup:8551", backupEndpoint.Url)
+	assert.Equal(t, "backup-token", backupEndpoint.Auth.Value)
+}
+
 func Test_parseJWTSecretFromFile(t *testing.T) {
 	t.Run("no flag value specified leads to nil secret", func(t *testing.T) {
-		app := cli.App{}
-		set := flag.NewFlagSet("test", 0)
-		set.String(flags.ExecutionJWTSecretFlag.Name, "", "")
-		ctx := cli.NewContext(&app, set, nil)
-		secret, err := parseJWTSecretFromFile(ctx)
+		secret, err := parseJWTSecretFromFile("")
 		require.NoError(t, err)
 		require.Equal(t, true, secret == nil)
 	})
 	t.Run("flag specified but no file found", func(t *testing.T) {
-		app := cli.App{}
-		set := flag.NewFlagSet("test", 0)
-		set.String(flags.ExecutionJWTSecretFlag.Name, "/tmp/askdjkajsd", "")
-		ctx := cli.NewContext(&app, set, nil)
-		_, err := parseJWTSecretFromFile(ctx)
+		_, err := parseJWTSecretFromFile("/tmp/askdjkajsd")
 		require.ErrorContains(t, "no such file", err)
 	})
 	t.Run("empty string in file", func(t *testing.T) {
-		app := cli.App{}
-		set := flag.NewFlagSet("test", 0)
 		fullPath := filepath.Join(t.TempDir(), "foohex")
 		require.NoError(t, file.WriteFile(fullPath, []byte{}))
-		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
-		ctx := cli.NewContext(&app, set, nil)
-		_, err := parseJWTSecretFromFile(ctx)
+		_, err := parseJWTSecretFromFile(fullPath)
 		require.ErrorContains(t, "cannot be empty", err)
 	})
 	t.Run("less than 32 bytes", func(t *testing.T) {
-		app := cli.App{}
-		set := flag.NewFlagSet("test", 0)
 		fullPath := filepath.Join(t.TempDir(), "foohex")
 		secret := bytesutil.PadTo([]byte("foo"), 31)
 		hexData := fmt.Sprintf("%#x", secret)
 		require.NoError(t, file.WriteFile(fullPath, []byte(hexData)))
-		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
-		ctx := cli.NewContext(&app, set, nil)
-		_, err := parseJWTSecretFromFile(ctx)
+		_, err := parseJWTSecretFromFile(fullPath)
 		require.ErrorContains(t, "should be a hex string of at least 32 bytes", err)
 	})
 	t.Run("bad data", func(t *testing.T) {
-		app := cli.App{}
-		set := flag.NewFlagSet("test", 0)
 		fullPath := filepath.Join(t.TempDir(), "foohex")
 		secret := []byte("foo")
 		require.NoError(t, file.WriteFile(fullPath, secret))
-		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
-		ctx := cli.NewContext(&app, set, nil)
-		_, err := parseJWTSecretFromFile(ctx)
+		_, err := parseJWTSecretFromFile(fullPath)
 		require.ErrorContains(t, "invalid byte", err)
 	})
 	t.Run("correct format", func(t *testing.T) {
-		app := cli.App{}
-		set := flag.NewFlagSet("test", 0)
 		fullPath := filepath.Join(t.TempDir(), "foohex")
 		secret := bytesutil.ToBytes32([]byte("foo"))
 		secretHex := fmt.Sprintf("%#x", secret)
 		require.NoError(t, file.WriteFile(fullPath, []byte(secretHex)))
-		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
-		ctx := cli.NewContext(&app, set, nil)
-		got, err := parseJWTSecretFromFile(ctx)
+		got, err := parseJWTSecretFromFile(fullPath)
 		require.NoError(t, err)
 		require.DeepEqual(t, secret[:], got)
 	})
@@ -99,3 +108,43 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	_, err := parseExecutionChainEndpoint(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
+
+func Test_parseBackupJWTSecrets(t *testing.T) {
+	writeSecret := func(t *testing.T, b byte) string {
+		fullPath := filepath.Join(t.TempDir(), "secret")
+		secret := bytesutil.ToBytes32([]byte{b})
+		require.NoError(t, file.WriteFile(fullPath, []byte(fmt.Sprintf("%#x", secret))))
+		return fullPath
+	}
+	secretsCtx := func(paths ...string) *cli.Context {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.Var(cli.NewStringSlice(paths...), flags.ExecutionJWTSecretBackupFlag.Name, "")
+		return cli.NewContext(&app, set, nil)
+	}
+	first, second := writeSecret(t, 1), writeSecret(t, 2)
+	primary := []byte{3}
+
+	t.Run("primary secret", func(t *testing.T) {
+		secrets, err := parseBackupJWTSecrets(secretsCtx(), 2, primary)
+		require.NoError(t, err)
+		assert.DeepEqual(t, [][]byte{primary, primary}, secrets)
+	})
+	t.Run("shared secret", func(t *testing.T) {
+		secrets, err := parseBackupJWTSecrets(secretsCtx(first), 2, primary)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(secrets))
+		assert.Equal(t, byte(1), secrets[0][0])
+		assert.Equal(t, byte(1), secrets[1][0])
+	})
+	t.Run("secret per endpoint", func(t *testing.T) {
+		secrets, err := parseBackupJWTSecrets(secretsCtx(first, second), 2, primary)
+		require.NoError(t, err)
+		assert.Equal(t, byte(1), secrets[0][0])
+		assert.Equal(t, byte(2), secrets[1][0])
+	})
+	t.Run("secret count mismatch", func(t *testing.T) {
+		_, err := parseBackupJWTSecrets(secretsCtx(first, second), 3, primary)
+		require.ErrorContains(t, "2 JWT secrets provided for 3 backup execution endpoints", err)
+	})
+}
@@ -3,7 +3,10 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v4/cmd"
+	cmdflags "github.com/prysmaticlabs/prysm/v4/cmd/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/urfave/cli/v2"
 )
@@ -15,6 +18,19 @@ var (
 		Usage: "A MEV builder relay string http endpoint, this will be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
 		Value: "",
 	}
+	// MevRelayExtraEndpoint provides HTTP access endpoints to additional MEV builder relays, queried along with the
+	// --http-mev-relay one.
+	MevRelayExtraEndpoint = &cli.StringSliceFlag{
+		Name: "http-mev-relay-extra",
+		Usage: "An additional MEV builder relay string http endpoint, queried along with the --http-mev-relay one, in which case the highest bid is used. " +
+			"Can be used multiple times to add several relays",
+	}
+	// MevRelayTimeout sets the time allowed to each relay to respond with a bid.
+	MevRelayTimeout = &cli.DurationFlag{
+		Name:  "mev-relay-timeout",
+		Usage: "Time allowed to each MEV builder relay to respond with a bid, after which its bid is not considered",
+		Value: 900 * time.Millisecond,
+	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
 		Usage: "Number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction",
@@ -30,12 +46,27 @@ var (
 		Usage: "A percentage boost for local block construction as a Uint64. This is used to prioritize local block construction over relay/builder block construction" +
 			"Boost is an additional percentage to multiple local block value. Use builder block if: builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100)",
 	}
+	// LocalBlockEpochsAfterStall forces local block construction for a number of epochs after the chain liveness degraded.
+	LocalBlockEpochsAfterStall = &cli.Uint64Flag{
+		Name: "local-block-epochs-after-stall",
+		Usage: "Number of epochs for which local block construction is forced over relay/builder block construction after finality has stalled, " +
+			"or after the circuit breaker was activated by skipped slots. 0 disables this policy",
+	}
 	// ExecutionEngineEndpoint provides an HTTP access endpoint to connect to an execution client on the execution layer
 	ExecutionEngineEndpoint = &cli.StringFlag{
 		Name:  "execution-endpoint",
 		Usage: "An execution client http endpoint. Can contain auth header as well in the format",
 		Value: "http://localhost:8551",
 	}
+	// ExecutionEngineBackupEndpoint provides HTTP access endpoints to backup execution clients, which are kept in sync
+	// and failed over to when the primary one is down or syncing.
+	ExecutionEngineBackupEndpoint = &cli.GenericFlag{
+		Name: "execution-endpoint-backup",
+		Usage: "A backup execution client http endpoint, which is kept in sync and failed over to when the " +
+			"--execution-endpoint is down or syncing. Can contain auth header as well in the format. " +
+			"Can be used multiple times to add several backup endpoints, in order of preference",
+		Value: &cmdflags.UnsplitStringSlice{},
+	}
 	// ExecutionEngineHeaders defines a list of HTTP headers to send with all execution client requests.
 	ExecutionEngineHeaders = &cli.StringFlag{
 		Name: "execution-headers",
@@ -54,6 +85,13 @@ var (
 			"This is not required if using an IPC connection.",
 		Value: "",
 	}
+	// ExecutionJWTSecretBackupFlag provides paths to files containing the JWT secrets of the backup execution endpoints.
+	ExecutionJWTSecretBackupFlag = &cli.StringSliceFlag{
+		Name: "jwt-secret-backup",
+		Usage: "Paths to files containing the JWT secrets of the --execution-endpoint-backup endpoints. Either a single " +
+			"secret shared by all backup endpoints or one secret per backup endpoint, in the same order, can be set. " +
+			"If not set, the backup endpoints use the --jwt-secret one",
+	}
 	// JwtId is the id field of the JWT claims. The consensus layer client MAY use this to communicate a unique identifier for the individual consensus layer client
 	JwtId = &cli.StringFlag{
 		Name:  "jwt-id",
@@ -155,6 +193,24 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// ArchiveStateDiffs saves the states of the epoch boundaries between archived points as diffs in the cold section of beaconDB.
+	ArchiveStateDiffs = &cli.BoolFlag{
+		Name: "archive-state-diffs",
+		Usage: "Saves the state of every epoch boundary between archived points as a diff against a previous state, " +
+			"so that historical states are rebuilt from a few diffs instead of replaying blocks.",
+	}
+	// ValidatorHistoryIndices specifies the validators whose balance and rewards history is indexed.
+	ValidatorHistoryIndices = &cli.IntSliceFlag{
+		Name: "validator-history-indices",
+		Usage: "List of validator indices whose balance and rewards are indexed at every finalized epoch, " +
+			"and served by the /prysm/v1/validators/history endpoint.",
+	}
+	// IndexDepositsWithdrawals enables the index of the deposits and withdrawals of validators.
+	IndexDepositsWithdrawals = &cli.BoolFlag{
+		Name: "index-deposits-withdrawals",
+		Usage: "Indexes the deposits processed for validators and the withdrawals paid to them as blocks get finalized, " +
+			"and serves them by the /prysm/v1/validators/{validator_id}/deposits and /withdrawals endpoints.",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -226,6 +282,18 @@ var (
 			"If such a sync is not possible, the node will treat it as a critical and irrecoverable failure",
 		Value: "",
 	}
+	// LightClientRetentionPeriods defines the number of sync committee periods for which light client data is kept in the database.
+	LightClientRetentionPeriods = &cli.Uint64Flag{
+		Name: "light-client-retention-periods",
+		Usage: "Sets the number of sync committee periods for which light client updates and bootstraps are kept in the database. " +
+			"Defaults to the periods covered by MIN_EPOCHS_FOR_BLOCK_REQUESTS. A value of 0 keeps them indefinitely.",
+	}
+	// AttestationInclusionsRetentionEpochs defines the number of epochs for which attestation inclusions are kept in the database.
+	AttestationInclusionsRetentionEpochs = &cli.Uint64Flag{
+		Name: "attestation-inclusions-retention-epochs",
+		Usage: "Sets the number of finalized epochs for which the attestation inclusions indexed with --index-attestation-inclusions " +
+			"are kept in the database. Defaults to MIN_EPOCHS_FOR_BLOCK_REQUESTS. A value of 0 keeps them indefinitely.",
+	}
 	// MinPeersPerSubnet defines a flag to set the minimum number of peers that a node will attempt to peer with for a subnet.
 	MinPeersPerSubnet = &cli.Uint64Flag{
 		Name:  "minimum-peers-per-subnet",
@@ -265,4 +333,21 @@ var (
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// SlasherBackfillStartEpoch defines the first epoch of the beacon DB replayed by the slasher on startup.
+	SlasherBackfillStartEpoch = &cli.Uint64Flag{
+		Name: "slasher-backfill-start-epoch",
+		Usage: "Replays the finalized blocks from this epoch, and the attestations they include, through slashing detection " +
+			"when the slasher starts, so that offences already on chain are detected",
+	}
+	// SlasherBackfillEndEpoch defines the last epoch of the beacon DB replayed by the slasher on startup.
+	SlasherBackfillEndEpoch = &cli.Uint64Flag{
+		Name:  "slasher-backfill-end-epoch",
+		Usage: "The last epoch replayed by the slasher backfill, defaults to the finalized epoch",
+	}
+	// SlasherBackfillBatchSize defines the number of epochs replayed at once by the slasher backfill.
+	SlasherBackfillBatchSize = &cli.Uint64Flag{
+		Name:  "slasher-backfill-batch-size",
+		Usage: "The number of epochs replayed at once by the slasher backfill",
+		Value: 8,
+	}
 )
@@ -28,6 +28,13 @@ var (
 			"As an additional safety measure, it is strongly recommended to only use this option in conjunction with " +
 			"--weak-subjectivity-checkpoint flag",
 	}
+	// DepositSnapshot is used with RemoteURL to also initialize the deposit tree from the EIP-4881 deposit snapshot
+	// served by the remote beacon node.
+	DepositSnapshot = &cli.BoolFlag{
+		Name: "checkpoint-sync-deposit-snapshot",
+		Usage: "Used with --checkpoint-sync-url. Initializes the deposit tree from the EIP-4881 deposit snapshot of the " +
+			"trusted beacon node, rather than processing all deposit contract logs from the execution client.",
+	}
 )
 
 // BeaconNodeOptions is responsible for determining if the checkpoint sync options have been used, and if so,
@@ -37,10 +44,17 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	blockPath := c.Path(BlockPath.Name)
 	statePath := c.Path(StatePath.Name)
 	remoteURL := c.String(RemoteURL.Name)
+	if c.Bool(DepositSnapshot.Name) && remoteURL == "" {
+		return nil, fmt.Errorf("--%s specified, but not --%s. both are required", DepositSnapshot.Name, RemoteURL.Name)
+	}
 	if remoteURL != "" {
+		var apiOpts []checkpoint.APIInitializerOption
+		if c.Bool(DepositSnapshot.Name) {
+			apiOpts = append(apiOpts, checkpoint.WithDepositSnapshot())
+		}
 		opt := func(node *node.BeaconNode) error {
 			var err error
-			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL)
+			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL, apiOpts...)
 			if err != nil {
 				return errors.Wrap(err, "error while constructing beacon node api client for checkpoint sync")
 			}
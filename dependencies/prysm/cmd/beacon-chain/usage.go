@@ -107,10 +107,15 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
 			flags.ExecutionEngineEndpoint,
+			flags.ExecutionEngineBackupEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
+			flags.ExecutionJWTSecretBackupFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.ArchiveStateDiffs,
+			flags.ValidatorHistoryIndices,
+			flags.IndexDepositsWithdrawals,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.BlobBatchLimit,
@@ -121,18 +126,27 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpoint,
+			flags.LightClientRetentionPeriods,
+			flags.AttestationInclusionsRetentionEpochs,
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
 			flags.MevRelayEndpoint,
+			flags.MevRelayExtraEndpoint,
+			flags.MevRelayTimeout,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
+			flags.SlasherBackfillStartEpoch,
+			flags.SlasherBackfillEndEpoch,
+			flags.SlasherBackfillBatchSize,
 			flags.LocalBlockValueBoost,
+			flags.LocalBlockEpochsAfterStall,
 			flags.JwtId,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
+			checkpoint.DepositSnapshot,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
@@ -0,0 +1,39 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// UnsplitStringSlice is a flag value which can be set several times. Unlike cli.StringSlice, its
+// values are not split on commas, so that they can contain some, such as endpoints followed by an
+// authorization header.
+type UnsplitStringSlice struct {
+	values []string
+}
+
+// Set appends a value.
+func (s *UnsplitStringSlice) Set(value string) error {
+	s.values = append(s.values, value)
+	return nil
+}
+
+// String returns the values separated by spaces.
+func (s *UnsplitStringSlice) String() string {
+	return strings.Join(s.values, " ")
+}
+
+// Value returns the values in the order they were set.
+func (s *UnsplitStringSlice) Value() []string {
+	return s.values
+}
+
+// UnsplitStringSliceFromContext returns the values of an UnsplitStringSlice flag.
+func UnsplitStringSliceFromContext(c *cli.Context, name string) []string {
+	s, ok := c.Generic(name).(*UnsplitStringSlice)
+	if !ok || s == nil {
+		return nil
+	}
+	return s.Value()
+}
@@ -80,25 +80,34 @@ func ExpandSingleEndpointIfFile(ctx *cli.Context, flag *cli.StringFlag) error {
 	if !ctx.IsSet(flag.Name) {
 		return nil
 	}
+	web3endpoint, err := ExpandEndpointIfFile(ctx.String(flag.Name))
+	if err != nil {
+		return err
+	}
+	if err := ctx.Set(flag.Name, web3endpoint); err != nil {
+		return errors.Wrapf(err, "could not set %s to %s", flag.Name, web3endpoint)
+	}
+	return nil
+}
+
+// ExpandEndpointIfFile expands the path of an endpoint if specified as a file, such as an IPC endpoint.
+func ExpandEndpointIfFile(endpoint string) (string, error) {
 	// Return early for non-unix operating systems, as there is
 	// no shell path expansion for ipc endpoints on windows.
 	if runtime.GOOS == "windows" {
-		return nil
+		return endpoint, nil
 	}
-	web3endpoint := ctx.String(flag.Name)
 	switch {
-	case strings.HasPrefix(web3endpoint, "http://"):
-	case strings.HasPrefix(web3endpoint, "https://"):
-	case strings.HasPrefix(web3endpoint, "ws://"):
-	case strings.HasPrefix(web3endpoint, "wss://"):
+	case strings.HasPrefix(endpoint, "http://"):
+	case strings.HasPrefix(endpoint, "https://"):
+	case strings.HasPrefix(endpoint, "ws://"):
+	case strings.HasPrefix(endpoint, "wss://"):
 	default:
-		web3endpoint, err := file.ExpandPath(ctx.String(flag.Name))
+		expanded, err := file.ExpandPath(endpoint)
 		if err != nil {
-			return errors.Wrapf(err, "could not expand path for %s", ctx.String(flag.Name))
-		}
-		if err := ctx.Set(flag.Name, web3endpoint); err != nil {
-			return errors.Wrapf(err, "could not set %s to %s", flag.Name, web3endpoint)
+			return "", errors.Wrapf(err, "could not expand path for %s", endpoint)
 		}
+		return expanded, nil
 	}
-	return nil
+	return endpoint, nil
 }
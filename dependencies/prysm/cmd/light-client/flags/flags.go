@@ -0,0 +1,43 @@
+// Package flags contains all configuration runtime flags for
+// the light client.
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// BeaconNodeHostFlag defines a flag for the beacon node REST API serving the light client endpoints.
+	BeaconNodeHostFlag = &cli.StringFlag{
+		Name:  "beacon-node-host",
+		Usage: "URL of the beacon node REST API serving light client data. eg http://localhost:3500",
+		Value: "http://localhost:3500",
+	}
+	// TrustedBlockRootFlag defines a flag for the trusted block root the light client bootstraps from.
+	TrustedBlockRootFlag = &cli.StringFlag{
+		Name: "trusted-block-root",
+		Usage: "Hex-encoded root of a trusted block, typically a recent finalized checkpoint root, " +
+			"from which the light client bootstraps.",
+		Required: true,
+	}
+	// PollIntervalFlag defines a flag for the frequency of light client update requests.
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:  "poll-interval",
+		Usage: "Frequency of light client update requests expressed as a duration, eg 12s or 1m.",
+		Value: 12 * time.Second,
+	}
+	// HTTPHostFlag defines a flag for the host on which the verified head is served.
+	HTTPHostFlag = &cli.StringFlag{
+		Name:  "http-host",
+		Usage: "Host on which the light client serves the verified head.",
+		Value: "127.0.0.1",
+	}
+	// HTTPPortFlag defines a flag for the port on which the verified head is served.
+	HTTPPortFlag = &cli.IntFlag{
+		Name:  "http-port",
+		Usage: "Port on which the light client serves the verified head.",
+		Value: 3600,
+	}
+)
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	runtimeDebug "runtime/debug"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	joonix "github.com/joonix/log"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/cmd/light-client/flags"
+	"github.com/prysmaticlabs/prysm/v4/cmd/light-client/node"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/logs"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/journald"
+	prefixed "github.com/prysmaticlabs/prysm/v4/runtime/logging/logrus-prefixed-formatter"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var appFlags = []cli.Flag{
+	cmd.VerbosityFlag,
+	cmd.LogFormat,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	flags.BeaconNodeHostFlag,
+	flags.TrustedBlockRootFlag,
+	flags.PollIntervalFlag,
+	flags.HTTPHostFlag,
+	flags.HTTPPortFlag,
+}
+
+func init() {
+	appFlags = cmd.WrapFlags(appFlags)
+}
+
+func main() {
+	app := cli.App{}
+	app.Name = "light-client"
+	app.Usage = "light client following the chain from a trusted block root through the light client endpoints of a beacon node"
+	app.Action = run
+	app.Version = version.Version()
+
+	app.Flags = appFlags
+
+	// logging/config setup cargo-culted from beaconchain
+	app.Before = func(ctx *cli.Context) error {
+		// Load flags from config file, if specified.
+		if err := cmd.LoadFlagsFromConfig(ctx, app.Flags); err != nil {
+			return err
+		}
+
+		verbosity := ctx.String(cmd.VerbosityFlag.Name)
+		level, err := logrus.ParseLevel(verbosity)
+		if err != nil {
+			return err
+		}
+		logrus.SetLevel(level)
+
+		format := ctx.String(cmd.LogFormat.Name)
+		switch format {
+		case "text":
+			formatter := new(prefixed.TextFormatter)
+			formatter.TimestampFormat = "2006-01-02 15:04:05"
+			formatter.FullTimestamp = true
+			// If persistent log files are written - we disable the log messages coloring because
+			// the colors are ANSI codes and seen as gibberish in the log files.
+			formatter.DisableColors = ctx.String(cmd.LogFileName.Name) != ""
+			logrus.SetFormatter(formatter)
+		case "fluentd":
+			f := joonix.NewFormatter()
+			if err := joonix.DisableTimestampFormat(f); err != nil {
+				panic(err)
+			}
+			logrus.SetFormatter(f)
+		case "json":
+			logrus.SetFormatter(&logrus.JSONFormatter{})
+		case "journald":
+			if err := journald.Enable(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown log format %s", format)
+		}
+
+		logFileName := ctx.String(cmd.LogFileName.Name)
+		if logFileName != "" {
+			if err := logs.ConfigurePersistentLogging(logFileName); err != nil {
+				log.WithError(err).Error("Failed to configuring logging to disk.")
+			}
+		}
+		return cmd.ValidateNoArgs(ctx)
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
+			panic(x)
+		}
+	}()
+
+	if err := app.Run(os.Args); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func run(ctx *cli.Context) error {
+	if ctx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(ctx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return err
+		}
+	}
+	trustedRoot, err := hexutil.Decode(ctx.String(flags.TrustedBlockRootFlag.Name))
+	if err != nil || len(trustedRoot) != 32 {
+		return errors.Errorf("invalid --%s, expected a hex-encoded 32 byte root", flags.TrustedBlockRootFlag.Name)
+	}
+
+	n, err := node.New(ctx.Context, &node.Config{
+		BeaconNodeHost:   ctx.String(flags.BeaconNodeHostFlag.Name),
+		TrustedBlockRoot: bytesutil.ToBytes32(trustedRoot),
+		PollInterval:     ctx.Duration(flags.PollIntervalFlag.Name),
+		HTTPHost:         ctx.String(flags.HTTPHostFlag.Name),
+		HTTPPort:         ctx.Int(flags.HTTPPortFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigc)
+		<-sigc
+		log.Info("Got interrupt, shutting down...")
+		if err := n.Stop(); err != nil {
+			log.WithError(err).Error("Could not stop light client")
+		}
+	}()
+	n.Start()
+	return nil
+}
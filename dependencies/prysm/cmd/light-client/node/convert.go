@@ -0,0 +1,106 @@
+package node
+
+import (
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+func bootstrapFromJSON(b *lightclient.LightClientBootstrap) (*Bootstrap, error) {
+	if b.Header == nil || b.CurrentSyncCommittee == nil {
+		return nil, errors.New("incomplete light client bootstrap")
+	}
+	header, err := b.Header.ToConsensus()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid header")
+	}
+	committee, err := b.CurrentSyncCommittee.ToConsensus()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid current sync committee")
+	}
+	branch, err := branchFromJSON(b.CurrentSyncCommitteeBranch)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid current sync committee branch")
+	}
+	return &Bootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+func updateFromJSON(u *lightclient.LightClientUpdate) (*Update, error) {
+	if u.AttestedHeader == nil || u.SyncAggregate == nil {
+		return nil, errors.New("incomplete light client update")
+	}
+	update := &Update{}
+	var err error
+	if update.AttestedHeader, err = u.AttestedHeader.ToConsensus(); err != nil {
+		return nil, errors.Wrap(err, "invalid attested header")
+	}
+	if u.NextSyncCommittee != nil {
+		if update.NextSyncCommittee, err = u.NextSyncCommittee.ToConsensus(); err != nil {
+			return nil, errors.Wrap(err, "invalid next sync committee")
+		}
+	}
+	if update.NextSyncCommitteeBranch, err = branchFromJSON(u.NextSyncCommitteeBranch); err != nil {
+		return nil, errors.Wrap(err, "invalid next sync committee branch")
+	}
+	if u.FinalizedHeader != nil {
+		if update.FinalizedHeader, err = u.FinalizedHeader.ToConsensus(); err != nil {
+			return nil, errors.Wrap(err, "invalid finalized header")
+		}
+	}
+	if update.FinalityBranch, err = branchFromJSON(u.FinalityBranch); err != nil {
+		return nil, errors.Wrap(err, "invalid finality branch")
+	}
+	if update.SyncAggregate, err = syncAggregateFromJSON(u.SyncAggregate); err != nil {
+		return nil, errors.Wrap(err, "invalid sync aggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(u.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature slot")
+	}
+	update.SignatureSlot = primitives.Slot(signatureSlot)
+	return update, nil
+}
+
+func syncAggregateFromJSON(a *shared.SyncAggregate) (*ethpb.SyncAggregate, error) {
+	bits, err := bytesutil.DecodeHexWithLength(a.SyncCommitteeBits, fieldparams.SyncAggregateSyncCommitteeBytesLength)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := bytesutil.DecodeHexWithLength(a.SyncCommitteeSignature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: sig,
+	}, nil
+}
+
+func branchFromJSON(branch []string) ([][]byte, error) {
+	if len(branch) == 0 {
+		return nil, nil
+	}
+	decoded := make([][]byte, len(branch))
+	for i, node := range branch {
+		b, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != fieldparams.RootLength {
+			return nil, errors.Errorf("branch node %d has length %d", i, len(b))
+		}
+		decoded[i] = b
+	}
+	return decoded, nil
+}
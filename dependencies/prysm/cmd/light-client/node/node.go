@@ -0,0 +1,278 @@
+// Package node defines a light client that follows the chain through the light client
+// REST endpoints of a beacon node, and serves the headers it verified to local tooling.
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// Config for the light client node.
+type Config struct {
+	BeaconNodeHost   string
+	TrustedBlockRoot [32]byte
+	PollInterval     time.Duration
+	HTTPHost         string
+	HTTPPort         int
+}
+
+// verifiedHead is the block of the latest verified header, along with its execution payload header.
+type verifiedHead struct {
+	root      [32]byte
+	header    *ethpb.SignedBeaconBlockHeader
+	finalized bool
+	execution interfaces.ExecutionData
+}
+
+// Node follows the chain from a trusted block root, verifying light client updates
+// served by a beacon node, and serves the verified head over HTTP.
+type Node struct {
+	cfg         *Config
+	ctx         context.Context
+	cancel      context.CancelFunc
+	client      *beacon.Client
+	store       *Store
+	genesisTime uint64
+	server      *http.Server
+	headLock    sync.RWMutex
+	head        *verifiedHead
+}
+
+// New bootstraps a light client node from the trusted block root of the config.
+func New(ctx context.Context, cfg *Config) (*Node, error) {
+	c, err := beacon.NewClient(cfg.BeaconNodeHost)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create beacon node client")
+	}
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	genesisTime, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis time")
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis validators root")
+	}
+
+	resp, err := c.GetLightClientBootstrap(ctx, cfg.TrustedBlockRoot)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := bootstrapFromJSON(resp.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode light client bootstrap")
+	}
+	store, err := NewStore(cfg.TrustedBlockRoot, bytesutil.ToBytes32(genesisValidatorsRoot), bootstrap)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize light client store")
+	}
+	log.WithField("slot", bootstrap.Header.Slot).Info("Bootstrapped light client from trusted block root")
+
+	ctx, cancel := context.WithCancel(ctx)
+	n := &Node{
+		cfg:         cfg,
+		ctx:         ctx,
+		cancel:      cancel,
+		client:      c,
+		store:       store,
+		genesisTime: genesisTime,
+	}
+	router := mux.NewRouter()
+	n.registerRoutes(router)
+	n.server = &http.Server{
+		Addr:              cfg.HTTPHost + ":" + strconv.Itoa(cfg.HTTPPort),
+		Handler:           router,
+		ReadHeaderTimeout: time.Second,
+	}
+	return n, nil
+}
+
+// Start serves the verified head over HTTP and follows the chain until the node is stopped.
+func (n *Node) Start() {
+	go func() {
+		log.WithField("address", n.server.Addr).Info("Starting light client HTTP server")
+		if err := n.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Light client HTTP server failed")
+		}
+	}()
+
+	ticker := time.NewTicker(n.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := n.sync(n.ctx); err != nil {
+			log.WithError(err).Error("Could not sync light client")
+		}
+		select {
+		case <-ticker.C:
+		case <-n.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop the light client node.
+func (n *Node) Stop() error {
+	n.cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return n.server.Shutdown(ctx)
+}
+
+// sync fetches the updates needed to reach the current sync committee period,
+// then the latest finality and optimistic updates, and refreshes the verified head.
+func (n *Node) sync(ctx context.Context) error {
+	currentSlot := slots.CurrentSlot(n.genesisTime)
+	storePeriod := syncCommitteePeriod(n.store.FinalizedHeader().Slot)
+	currentPeriod := syncCommitteePeriod(currentSlot)
+	if storePeriod < currentPeriod || !n.store.HasNextSyncCommittee() {
+		count := currentPeriod - storePeriod + 1
+		if count > params.BeaconConfig().MaxRequestLightClientUpdates {
+			count = params.BeaconConfig().MaxRequestLightClientUpdates
+		}
+		updates, err := n.client.GetLightClientUpdatesByRange(ctx, storePeriod, count)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return err
+		}
+		for _, u := range updates {
+			if u.Data == nil {
+				continue
+			}
+			n.processUpdate(u.Data, currentSlot, "sync committee")
+		}
+	}
+
+	finality, err := n.client.GetLightClientFinalityUpdate(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not get light client finality update")
+	} else {
+		n.processUpdate(finality.Data, currentSlot, "finality")
+	}
+	optimistic, err := n.client.GetLightClientOptimisticUpdate(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not get light client optimistic update")
+	} else {
+		n.processUpdate(optimistic.Data, currentSlot, "optimistic")
+	}
+	return n.updateHead(ctx)
+}
+
+func (n *Node) processUpdate(data *lightclient.LightClientUpdate, currentSlot primitives.Slot, kind string) {
+	update, err := updateFromJSON(data)
+	if err != nil {
+		log.WithError(err).WithField("kind", kind).Warn("Could not decode light client update")
+		return
+	}
+	if err := n.store.ProcessUpdate(update, currentSlot); err != nil {
+		if !errors.Is(err, errIrrelevantUpdate) {
+			log.WithError(err).WithField("kind", kind).Warn("Rejected light client update")
+		}
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"kind":           kind,
+		"attestedSlot":   update.AttestedHeader.Slot,
+		"finalizedSlot":  n.store.FinalizedHeader().Slot,
+		"optimisticSlot": n.store.OptimisticHeader().Slot,
+	}).Debug("Processed light client update")
+}
+
+// updateHead retrieves the block of the latest optimistic header, if it changed, and checks that it matches
+// the verified header before exposing its execution payload header.
+func (n *Node) updateHead(ctx context.Context) error {
+	header := n.store.OptimisticHeader()
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute optimistic header root")
+	}
+	finalized := n.store.FinalizedHeader()
+	finalizedRoot, err := finalized.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute finalized header root")
+	}
+
+	if current := n.verifiedHead(); current != nil && current.root == root {
+		if current.finalized != (root == finalizedRoot) {
+			head := *current
+			head.finalized = root == finalizedRoot
+			n.setVerifiedHead(&head)
+		}
+		return nil
+	}
+
+	b, err := n.client.GetBlock(ctx, beacon.IdFromRoot(root))
+	if err != nil {
+		return err
+	}
+	vu, err := detect.FromBlock(b)
+	if err != nil {
+		return errors.Wrap(err, "could not detect block fork")
+	}
+	blk, err := vu.UnmarshalBeaconBlock(b)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal block")
+	}
+	blkRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	if blkRoot != root {
+		return errors.Errorf("block root %#x does not match the verified header root %#x", blkRoot, root)
+	}
+	signedHeader, err := blk.Header()
+	if err != nil {
+		return errors.Wrap(err, "could not get block header")
+	}
+	head := &verifiedHead{
+		root:      root,
+		header:    signedHeader,
+		finalized: root == finalizedRoot,
+	}
+	if blk.Version() >= version.Bellatrix {
+		head.execution, err = blk.Block().Body().Execution()
+		if err != nil {
+			return errors.Wrap(err, "could not get execution payload")
+		}
+	}
+	n.setVerifiedHead(head)
+	log.WithFields(logrus.Fields{
+		"slot":      header.Slot,
+		"root":      fmt.Sprintf("%#x", bytesutil.Trunc(root[:])),
+		"finalized": head.finalized,
+	}).Info("Verified new head")
+	return nil
+}
+
+func (n *Node) verifiedHead() *verifiedHead {
+	n.headLock.RLock()
+	defer n.headLock.RUnlock()
+	return n.head
+}
+
+func (n *Node) setVerifiedHead(head *verifiedHead) {
+	n.headLock.Lock()
+	defer n.headLock.Unlock()
+	n.head = head
+}
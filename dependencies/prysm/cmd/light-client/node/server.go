@@ -0,0 +1,68 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+)
+
+type GetExecutionStateRootResponse struct {
+	Data *ExecutionStateRoot `json:"data"`
+}
+
+type ExecutionStateRoot struct {
+	Slot        string `json:"slot"`
+	BlockRoot   string `json:"block_root"`
+	BlockNumber string `json:"block_number"`
+	BlockHash   string `json:"block_hash"`
+	StateRoot   string `json:"state_root"`
+}
+
+func (n *Node) registerRoutes(router *mux.Router) {
+	router.HandleFunc("/eth/v1/beacon/headers/head", n.GetHeadHeader).Methods(http.MethodGet)
+	router.HandleFunc("/prysm/v1/light_client/execution_state_root", n.GetExecutionStateRoot).Methods(http.MethodGet)
+}
+
+// GetHeadHeader returns the header of the latest block verified by the light client.
+func (n *Node) GetHeadHeader(w http.ResponseWriter, _ *http.Request) {
+	head := n.verifiedHead()
+	if head == nil {
+		httputil.HandleError(w, "No verified head yet", http.StatusServiceUnavailable)
+		return
+	}
+	httputil.WriteJson(w, &beacon.GetBlockHeaderResponse{
+		Finalized: head.finalized,
+		Data: &shared.SignedBeaconBlockHeaderContainer{
+			Header:    shared.SignedBeaconBlockHeaderFromConsensus(head.header),
+			Root:      hexutil.Encode(head.root[:]),
+			Canonical: true,
+		},
+	})
+}
+
+// GetExecutionStateRoot returns the execution state root of the latest block verified by the light client.
+func (n *Node) GetExecutionStateRoot(w http.ResponseWriter, _ *http.Request) {
+	head := n.verifiedHead()
+	if head == nil {
+		httputil.HandleError(w, "No verified head yet", http.StatusServiceUnavailable)
+		return
+	}
+	if head.execution == nil {
+		httputil.HandleError(w, "Verified head has no execution payload", http.StatusNotFound)
+		return
+	}
+	httputil.WriteJson(w, &GetExecutionStateRootResponse{
+		Data: &ExecutionStateRoot{
+			Slot:        fmt.Sprintf("%d", head.header.Header.Slot),
+			BlockRoot:   hexutil.Encode(head.root[:]),
+			BlockNumber: fmt.Sprintf("%d", head.execution.BlockNumber()),
+			BlockHash:   hexutil.Encode(head.execution.BlockHash()),
+			StateRoot:   hexutil.Encode(head.execution.StateRoot()),
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func testNodeWithHead(t *testing.T) (*Node, *verifiedHead) {
+	b := util.NewBeaconBlockBellatrix()
+	b.Block.Slot = 123
+	b.Block.Body.ExecutionPayload.BlockNumber = 456
+	b.Block.Body.ExecutionPayload.StateRoot = bytesutil.PadTo([]byte{'s'}, fieldparams.RootLength)
+	b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{'h'}, fieldparams.RootLength)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	header, err := blk.Header()
+	require.NoError(t, err)
+	execution, err := blk.Block().Body().Execution()
+	require.NoError(t, err)
+	head := &verifiedHead{root: root, header: header, finalized: true, execution: execution}
+	n := &Node{}
+	n.setVerifiedHead(head)
+	return n, head
+}
+
+func TestGetHeadHeader(t *testing.T) {
+	t.Run("no head", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		(&Node{}).GetHeadHeader(writer, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+	t.Run("ok", func(t *testing.T) {
+		n, head := testNodeWithHead(t)
+		writer := httptest.NewRecorder()
+		n.GetHeadHeader(writer, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &beacon.GetBlockHeaderResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Finalized)
+		assert.Equal(t, hexutil.Encode(head.root[:]), resp.Data.Root)
+		assert.Equal(t, "123", resp.Data.Header.Message.Slot)
+		assert.Equal(t, hexutil.Encode(head.header.Signature), resp.Data.Header.Signature)
+	})
+}
+
+func TestGetExecutionStateRoot(t *testing.T) {
+	t.Run("no head", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		(&Node{}).GetExecutionStateRoot(writer, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+	t.Run("ok", func(t *testing.T) {
+		n, head := testNodeWithHead(t)
+		writer := httptest.NewRecorder()
+		n.GetExecutionStateRoot(writer, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetExecutionStateRootResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "123", resp.Data.Slot)
+		assert.Equal(t, hexutil.Encode(head.root[:]), resp.Data.BlockRoot)
+		assert.Equal(t, "456", resp.Data.BlockNumber)
+		assert.Equal(t, hexutil.Encode(bytesutil.PadTo([]byte{'s'}, fieldparams.RootLength)), resp.Data.StateRoot)
+		assert.Equal(t, hexutil.Encode(bytesutil.PadTo([]byte{'h'}, fieldparams.RootLength)), resp.Data.BlockHash)
+	})
+}
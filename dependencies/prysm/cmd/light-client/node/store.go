@@ -0,0 +1,334 @@
+package node
+
+import (
+	"bytes"
+	"math/bits"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// Generalized indices of the light client proofs within the beacon state.
+const (
+	finalizedRootGeneralizedIndex        = 105
+	currentSyncCommitteeGeneralizedIndex = 54
+	nextSyncCommitteeGeneralizedIndex    = 55
+)
+
+var (
+	errTrustedRootMismatch  = errors.New("bootstrap header does not match the trusted block root")
+	errInvalidBranch        = errors.New("invalid merkle branch")
+	errNotEnoughSignatures  = errors.New("not enough sync committee participants")
+	errInvalidUpdateSlot    = errors.New("invalid update slot")
+	errIrrelevantUpdate     = errors.New("update does not advance the light client store")
+	errUnknownSyncCommittee = errors.New("sync committee of the signature period is unknown")
+)
+
+// Bootstrap is the light client bootstrap of a trusted block, expressed with consensus containers.
+type Bootstrap struct {
+	Header                     *ethpb.BeaconBlockHeader
+	CurrentSyncCommittee       *ethpb.SyncCommittee
+	CurrentSyncCommitteeBranch [][]byte
+}
+
+// Update is a light client update, expressed with consensus containers. Finality and optimistic
+// updates are updates without a next sync committee and, for the latter, without a finalized header.
+type Update struct {
+	AttestedHeader          *ethpb.BeaconBlockHeader
+	NextSyncCommittee       *ethpb.SyncCommittee
+	NextSyncCommitteeBranch [][]byte
+	FinalizedHeader         *ethpb.BeaconBlockHeader
+	FinalityBranch          [][]byte
+	SyncAggregate           *ethpb.SyncAggregate
+	SignatureSlot           primitives.Slot
+}
+
+func (u *Update) isSyncCommitteeUpdate() bool {
+	return u.NextSyncCommittee != nil && !isEmptyBranch(u.NextSyncCommitteeBranch)
+}
+
+func (u *Update) isFinalityUpdate() bool {
+	return u.FinalizedHeader != nil && !isEmptyBranch(u.FinalityBranch)
+}
+
+// Store keeps the headers and sync committees verified by the light client.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#lightclientstore
+type Store struct {
+	lock                  sync.RWMutex
+	genesisValidatorsRoot [32]byte
+	finalizedHeader       *ethpb.BeaconBlockHeader
+	optimisticHeader      *ethpb.BeaconBlockHeader
+	currentSyncCommittee  *ethpb.SyncCommittee
+	nextSyncCommittee     *ethpb.SyncCommittee
+}
+
+// NewStore initializes a light client store from the bootstrap of the trusted block root.
+//
+// Spec code:
+// def initialize_light_client_store(trusted_block_root: Root,
+//
+//	                                 bootstrap: LightClientBootstrap) -> LightClientStore:
+//	assert is_valid_light_client_header(bootstrap.header)
+//	assert hash_tree_root(bootstrap.header.beacon) == trusted_block_root
+//
+//	assert is_valid_merkle_branch(
+//	    leaf=hash_tree_root(bootstrap.current_sync_committee),
+//	    branch=bootstrap.current_sync_committee_branch,
+//	    depth=floorlog2(CURRENT_SYNC_COMMITTEE_GINDEX),
+//	    index=get_subtree_index(CURRENT_SYNC_COMMITTEE_GINDEX),
+//	    root=bootstrap.header.beacon.state_root,
+//	)
+func NewStore(trustedBlockRoot, genesisValidatorsRoot [32]byte, bootstrap *Bootstrap) (*Store, error) {
+	if bootstrap == nil || bootstrap.Header == nil || bootstrap.CurrentSyncCommittee == nil {
+		return nil, errors.New("incomplete light client bootstrap")
+	}
+	root, err := bootstrap.Header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute bootstrap header root")
+	}
+	if root != trustedBlockRoot {
+		return nil, errors.Wrapf(errTrustedRootMismatch, "got %#x, wanted %#x", root, trustedBlockRoot)
+	}
+	committeeRoot, err := bootstrap.CurrentSyncCommittee.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute current sync committee root")
+	}
+	if !isValidMerkleBranch(committeeRoot, bootstrap.CurrentSyncCommitteeBranch, currentSyncCommitteeGeneralizedIndex, bootstrap.Header.StateRoot) {
+		return nil, errors.Wrap(errInvalidBranch, "current sync committee")
+	}
+	return &Store{
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		finalizedHeader:       bootstrap.Header,
+		optimisticHeader:      bootstrap.Header,
+		currentSyncCommittee:  bootstrap.CurrentSyncCommittee,
+	}, nil
+}
+
+// FinalizedHeader returns the latest finalized header verified by the light client.
+func (s *Store) FinalizedHeader() *ethpb.BeaconBlockHeader {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.finalizedHeader
+}
+
+// OptimisticHeader returns the latest header attested by a sufficient part of the sync committee.
+func (s *Store) OptimisticHeader() *ethpb.BeaconBlockHeader {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.optimisticHeader
+}
+
+// HasNextSyncCommittee returns true if the sync committee of the period following
+// the finalized header is known.
+func (s *Store) HasNextSyncCommittee() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.nextSyncCommittee != nil
+}
+
+// ProcessUpdate validates the update against the store and, if valid, advances the optimistic
+// header and, with a supermajority of the sync committee, the finalized header and sync committees.
+// Unlike the spec, updates without a supermajority are never force-applied.
+func (s *Store) ProcessUpdate(update *Update, currentSlot primitives.Slot) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.validateUpdate(update, currentSlot); err != nil {
+		return err
+	}
+
+	participants := update.SyncAggregate.SyncCommitteeBits.Count()
+	if update.AttestedHeader.Slot > s.optimisticHeader.Slot {
+		s.optimisticHeader = update.AttestedHeader
+	}
+
+	supermajority := participants*3 >= update.SyncAggregate.SyncCommitteeBits.Len()*2
+	if !supermajority || !update.isFinalityUpdate() {
+		return nil
+	}
+	updateFinalizedPeriod := syncCommitteePeriod(update.FinalizedHeader.Slot)
+	hasFinalizedNextSyncCommittee := s.nextSyncCommittee == nil && update.isSyncCommitteeUpdate() &&
+		updateFinalizedPeriod == syncCommitteePeriod(update.AttestedHeader.Slot)
+	if update.FinalizedHeader.Slot > s.finalizedHeader.Slot || hasFinalizedNextSyncCommittee {
+		s.applyUpdate(update)
+	}
+	return nil
+}
+
+// applyUpdate follows the spec's apply_light_client_update.
+func (s *Store) applyUpdate(update *Update) {
+	storePeriod := syncCommitteePeriod(s.finalizedHeader.Slot)
+	updateFinalizedPeriod := syncCommitteePeriod(update.FinalizedHeader.Slot)
+	if s.nextSyncCommittee == nil {
+		if updateFinalizedPeriod != storePeriod {
+			return
+		}
+		if update.isSyncCommitteeUpdate() {
+			s.nextSyncCommittee = update.NextSyncCommittee
+		}
+	} else if updateFinalizedPeriod == storePeriod+1 {
+		s.currentSyncCommittee = s.nextSyncCommittee
+		s.nextSyncCommittee = nil
+		if update.isSyncCommitteeUpdate() {
+			s.nextSyncCommittee = update.NextSyncCommittee
+		}
+	}
+	if update.FinalizedHeader.Slot > s.finalizedHeader.Slot {
+		s.finalizedHeader = update.FinalizedHeader
+		if s.finalizedHeader.Slot > s.optimisticHeader.Slot {
+			s.optimisticHeader = s.finalizedHeader
+		}
+	}
+}
+
+// validateUpdate follows the spec's validate_light_client_update.
+func (s *Store) validateUpdate(update *Update, currentSlot primitives.Slot) error {
+	if update == nil || update.AttestedHeader == nil || update.SyncAggregate == nil {
+		return errors.New("incomplete light client update")
+	}
+	if update.SyncAggregate.SyncCommitteeBits.Len() != fieldparams.SyncCommitteeLength {
+		return errors.Errorf("sync committee bits have length %d", update.SyncAggregate.SyncCommitteeBits.Len())
+	}
+	if update.SyncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return errNotEnoughSignatures
+	}
+
+	// Verify update does not skip a sync committee period.
+	if currentSlot < update.SignatureSlot || update.SignatureSlot <= update.AttestedHeader.Slot {
+		return errors.Wrapf(errInvalidUpdateSlot, "signature slot %d, attested slot %d", update.SignatureSlot, update.AttestedHeader.Slot)
+	}
+	if update.isFinalityUpdate() && update.AttestedHeader.Slot < update.FinalizedHeader.Slot {
+		return errors.Wrapf(errInvalidUpdateSlot, "attested slot %d, finalized slot %d", update.AttestedHeader.Slot, update.FinalizedHeader.Slot)
+	}
+	storePeriod := syncCommitteePeriod(s.finalizedHeader.Slot)
+	signaturePeriod := syncCommitteePeriod(update.SignatureSlot)
+	if signaturePeriod != storePeriod && (s.nextSyncCommittee == nil || signaturePeriod != storePeriod+1) {
+		return errors.Wrapf(errUnknownSyncCommittee, "signature period %d, store period %d", signaturePeriod, storePeriod)
+	}
+
+	// Verify update is relevant.
+	attestedPeriod := syncCommitteePeriod(update.AttestedHeader.Slot)
+	hasNextSyncCommittee := s.nextSyncCommittee == nil && update.isSyncCommitteeUpdate() && attestedPeriod == storePeriod
+	if update.AttestedHeader.Slot <= s.finalizedHeader.Slot && !hasNextSyncCommittee {
+		return errIrrelevantUpdate
+	}
+
+	// Verify that the finality branch, if present, confirms the finalized header
+	// to match the finalized checkpoint root saved in the state of the attested header.
+	if update.isFinalityUpdate() {
+		var finalizedRoot [32]byte
+		if update.FinalizedHeader.Slot != params.BeaconConfig().GenesisSlot {
+			r, err := update.FinalizedHeader.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not compute finalized header root")
+			}
+			finalizedRoot = r
+		}
+		if !isValidMerkleBranch(finalizedRoot, update.FinalityBranch, finalizedRootGeneralizedIndex, update.AttestedHeader.StateRoot) {
+			return errors.Wrap(errInvalidBranch, "finality")
+		}
+	}
+
+	// Verify that the next sync committee, if present, actually is the next sync committee
+	// saved in the state of the attested header.
+	if update.isSyncCommitteeUpdate() {
+		if attestedPeriod == storePeriod && s.nextSyncCommittee != nil {
+			if !syncCommitteesEqual(update.NextSyncCommittee, s.nextSyncCommittee) {
+				return errors.New("next sync committee does not match the one in the store")
+			}
+		}
+		committeeRoot, err := update.NextSyncCommittee.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute next sync committee root")
+		}
+		if !isValidMerkleBranch(committeeRoot, update.NextSyncCommitteeBranch, nextSyncCommitteeGeneralizedIndex, update.AttestedHeader.StateRoot) {
+			return errors.Wrap(errInvalidBranch, "next sync committee")
+		}
+	}
+
+	// Verify sync committee aggregate signature.
+	committee := s.currentSyncCommittee
+	if signaturePeriod != storePeriod {
+		committee = s.nextSyncCommittee
+	}
+	return s.verifySyncAggregate(update, committee)
+}
+
+func (s *Store) verifySyncAggregate(update *Update, committee *ethpb.SyncCommittee) error {
+	bits := update.SyncAggregate.SyncCommitteeBits
+	if uint64(len(committee.Pubkeys)) != bits.Len() {
+		return errors.Errorf("sync committee has %d members, wanted %d", len(committee.Pubkeys), bits.Len())
+	}
+	pubkeys := make([][]byte, 0, bits.Count())
+	for i, pubkey := range committee.Pubkeys {
+		if bits.BitAt(uint64(i)) {
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+	aggregate, err := bls.AggregatePublicKeys(pubkeys)
+	if err != nil {
+		return errors.Wrap(err, "could not aggregate sync committee public keys")
+	}
+
+	cfg := params.BeaconConfig()
+	previousSlot := update.SignatureSlot
+	if previousSlot > 0 {
+		previousSlot--
+	}
+	forkVersion, err := forks.NewOrderedSchedule(cfg).VersionForEpoch(slots.ToEpoch(previousSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not determine fork version")
+	}
+	domain, err := signing.ComputeDomain(cfg.DomainSyncCommittee, forkVersion[:], s.genesisValidatorsRoot[:])
+	if err != nil {
+		return errors.Wrap(err, "could not compute sync committee domain")
+	}
+	if err := signing.VerifySigningRoot(update.AttestedHeader, aggregate.Marshal(), update.SyncAggregate.SyncCommitteeSignature, domain); err != nil {
+		return errors.Wrap(err, "invalid sync committee signature")
+	}
+	return nil
+}
+
+// isValidMerkleBranch verifies the branch of the leaf at the given generalized index against the root.
+func isValidMerkleBranch(leaf [32]byte, branch [][]byte, generalizedIndex uint64, root []byte) bool {
+	depth := bits.Len64(generalizedIndex) - 1
+	if len(branch) != depth {
+		return false
+	}
+	subtreeIndex := generalizedIndex % (1 << depth)
+	return trie.VerifyMerkleProof(root, leaf[:], subtreeIndex, branch)
+}
+
+func isEmptyBranch(branch [][]byte) bool {
+	for _, node := range branch {
+		if !bytes.Equal(node, make([]byte, len(node))) {
+			return false
+		}
+	}
+	return true
+}
+
+func syncCommitteesEqual(a, b *ethpb.SyncCommittee) bool {
+	if len(a.Pubkeys) != len(b.Pubkeys) || !bytes.Equal(a.AggregatePubkey, b.AggregatePubkey) {
+		return false
+	}
+	for i := range a.Pubkeys {
+		if !bytes.Equal(a.Pubkeys[i], b.Pubkeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func syncCommitteePeriod(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
@@ -0,0 +1,207 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+var testGenesisValidatorsRoot = [32]byte{'g', 'v', 'r'}
+
+type testChain struct {
+	keys      []bls.SecretKey
+	committee *ethpb.SyncCommittee
+	bootstrap *Bootstrap
+	root      [32]byte
+}
+
+// syncCommitteeKeys returns the secret keys of a sync committee made of a few validators
+// occupying all the seats in turn.
+func syncCommitteeKeys(t *testing.T) ([]bls.SecretKey, *ethpb.SyncCommittee) {
+	distinct := make([]bls.SecretKey, 8)
+	for i := range distinct {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		distinct[i] = k
+	}
+	keys := make([]bls.SecretKey, fieldparams.SyncCommitteeLength)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range keys {
+		keys[i] = distinct[i%len(distinct)]
+		pubkeys[i] = keys[i].PublicKey().Marshal()
+	}
+	aggregate, err := bls.AggregatePublicKeys(pubkeys)
+	require.NoError(t, err)
+	return keys, &ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: aggregate.Marshal()}
+}
+
+func testState(t *testing.T, committee *ethpb.SyncCommittee) state.BeaconState {
+	st, _ := util.DeterministicGenesisStateAltair(t, 16)
+	require.NoError(t, st.SetCurrentSyncCommittee(committee))
+	require.NoError(t, st.SetNextSyncCommittee(committee))
+	return st
+}
+
+func testHeader(t *testing.T, slot primitives.Slot, st state.BeaconState) *ethpb.BeaconBlockHeader {
+	stateRoot := make([]byte, fieldparams.RootLength)
+	if st != nil {
+		r, err := st.HashTreeRoot(context.Background())
+		require.NoError(t, err)
+		stateRoot = r[:]
+	}
+	return &ethpb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: bytesutil.PadTo([]byte{byte(slot)}, fieldparams.RootLength),
+		StateRoot:  stateRoot,
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+func newTestChain(t *testing.T) *testChain {
+	keys, committee := syncCommitteeKeys(t)
+	st := testState(t, committee)
+	header := testHeader(t, 8, st)
+	branch, err := st.CurrentSyncCommitteeProof(context.Background())
+	require.NoError(t, err)
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	return &testChain{
+		keys:      keys,
+		committee: committee,
+		bootstrap: &Bootstrap{
+			Header:                     header,
+			CurrentSyncCommittee:       committee,
+			CurrentSyncCommitteeBranch: branch,
+		},
+		root: root,
+	}
+}
+
+// signedUpdate returns an update of the attested header signed by the given number of sync committee members.
+func (c *testChain) signedUpdate(t *testing.T, attested *ethpb.BeaconBlockHeader, participants int) *Update {
+	// Test slots all precede the Altair fork epoch of the mainnet config.
+	cfg := params.BeaconConfig()
+	domain, err := signing.ComputeDomain(cfg.DomainSyncCommittee, cfg.GenesisForkVersion, testGenesisValidatorsRoot[:])
+	require.NoError(t, err)
+	signingRoot, err := signing.ComputeSigningRoot(attested, domain)
+	require.NoError(t, err)
+
+	bits := bitfield.NewBitvector512()
+	sigs := make([]bls.Signature, 0, participants)
+	for i := 0; i < participants; i++ {
+		bits.SetBitAt(uint64(i), true)
+		sigs = append(sigs, c.keys[i].Sign(signingRoot[:]))
+	}
+	sig := make([]byte, fieldparams.BLSSignatureLength)
+	if len(sigs) > 0 {
+		sig = bls.AggregateSignatures(sigs).Marshal()
+	}
+	return &Update{
+		AttestedHeader: attested,
+		SyncAggregate: &ethpb.SyncAggregate{
+			SyncCommitteeBits:      bits,
+			SyncCommitteeSignature: sig,
+		},
+		SignatureSlot: attested.Slot + 1,
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	c := newTestChain(t)
+
+	s, err := NewStore(c.root, testGenesisValidatorsRoot, c.bootstrap)
+	require.NoError(t, err)
+	assert.DeepEqual(t, c.bootstrap.Header, s.FinalizedHeader())
+	assert.DeepEqual(t, c.bootstrap.Header, s.OptimisticHeader())
+	assert.Equal(t, false, s.HasNextSyncCommittee())
+
+	_, err = NewStore([32]byte{'a'}, testGenesisValidatorsRoot, c.bootstrap)
+	require.ErrorIs(t, err, errTrustedRootMismatch)
+
+	tampered := &Bootstrap{
+		Header:                     c.bootstrap.Header,
+		CurrentSyncCommittee:       c.bootstrap.CurrentSyncCommittee,
+		CurrentSyncCommitteeBranch: bytesutil.SafeCopy2dBytes(c.bootstrap.CurrentSyncCommitteeBranch),
+	}
+	tampered.CurrentSyncCommitteeBranch[0][0] ^= 1
+	_, err = NewStore(c.root, testGenesisValidatorsRoot, tampered)
+	require.ErrorIs(t, err, errInvalidBranch)
+}
+
+func TestStore_ProcessUpdate_Optimistic(t *testing.T) {
+	c := newTestChain(t)
+	s, err := NewStore(c.root, testGenesisValidatorsRoot, c.bootstrap)
+	require.NoError(t, err)
+	minParticipants := int(params.BeaconConfig().MinSyncCommitteeParticipants)
+
+	attested := testHeader(t, 20, nil)
+	update := c.signedUpdate(t, attested, minParticipants-1)
+	require.ErrorIs(t, s.ProcessUpdate(update, 30), errNotEnoughSignatures)
+
+	update = c.signedUpdate(t, attested, minParticipants)
+	require.ErrorIs(t, s.ProcessUpdate(update, update.SignatureSlot-1), errInvalidUpdateSlot)
+
+	forged := c.signedUpdate(t, testHeader(t, 21, nil), minParticipants)
+	forged.AttestedHeader = attested
+	require.ErrorContains(t, "invalid sync committee signature", s.ProcessUpdate(forged, 30))
+
+	require.NoError(t, s.ProcessUpdate(update, 30))
+	assert.DeepEqual(t, attested, s.OptimisticHeader())
+	assert.DeepEqual(t, c.bootstrap.Header, s.FinalizedHeader())
+
+	// Updates attesting headers older than the finalized one are ignored.
+	require.ErrorIs(t, s.ProcessUpdate(c.signedUpdate(t, testHeader(t, 4, nil), minParticipants), 30), errIrrelevantUpdate)
+}
+
+func TestStore_ProcessUpdate_Finality(t *testing.T) {
+	ctx := context.Background()
+	c := newTestChain(t)
+	s, err := NewStore(c.root, testGenesisValidatorsRoot, c.bootstrap)
+	require.NoError(t, err)
+
+	finalized := testHeader(t, 32, nil)
+	finalizedRoot, err := finalized.HashTreeRoot()
+	require.NoError(t, err)
+	attestedState := testState(t, c.committee)
+	require.NoError(t, attestedState.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}))
+	finalityBranch, err := attestedState.FinalizedRootProof(ctx)
+	require.NoError(t, err)
+	nextCommitteeBranch, err := attestedState.NextSyncCommitteeProof(ctx)
+	require.NoError(t, err)
+	attested := testHeader(t, 64, attestedState)
+
+	// Without a supermajority, only the optimistic header advances.
+	update := c.signedUpdate(t, attested, fieldparams.SyncCommitteeLength/2)
+	update.FinalizedHeader = finalized
+	update.FinalityBranch = finalityBranch
+	require.NoError(t, s.ProcessUpdate(update, 100))
+	assert.DeepEqual(t, attested, s.OptimisticHeader())
+	assert.DeepEqual(t, c.bootstrap.Header, s.FinalizedHeader())
+
+	tampered := c.signedUpdate(t, attested, fieldparams.SyncCommitteeLength)
+	tampered.FinalizedHeader = testHeader(t, 33, nil)
+	tampered.FinalityBranch = finalityBranch
+	require.ErrorIs(t, s.ProcessUpdate(tampered, 100), errInvalidBranch)
+
+	update = c.signedUpdate(t, attested, fieldparams.SyncCommitteeLength)
+	update.FinalizedHeader = finalized
+	update.FinalityBranch = finalityBranch
+	update.NextSyncCommittee = c.committee
+	update.NextSyncCommitteeBranch = nextCommitteeBranch
+	require.NoError(t, s.ProcessUpdate(update, 100))
+	assert.DeepEqual(t, finalized, s.FinalizedHeader())
+	assert.DeepEqual(t, attested, s.OptimisticHeader())
+	assert.Equal(t, true, s.HasNextSyncCommittee())
+}
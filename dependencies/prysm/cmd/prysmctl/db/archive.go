@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// archiveOpts are the flags shared by the archive export and import commands.
+type archiveOpts struct {
+	Path            string
+	BlobPath        string
+	ConfigName      string
+	ChainConfigFile string
+}
+
+// archiveFlags returns the flags shared by the archive export and import commands. The db path is only optional for
+// an export from a running beacon node.
+func archiveFlags(opts *archiveOpts, pathRequired bool) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &opts.Path,
+			Required:    pathRequired,
+		},
+		&cli.StringFlag{
+			Name:        "blob-path",
+			Usage:       "path to the blob storage directory of the beacon node; blob sidecars are skipped if unset",
+			Destination: &opts.BlobPath,
+		},
+		&cli.StringFlag{
+			Name:        "config-name",
+			Usage:       "name of the config of the chain of the database, eg mainnet, holesky",
+			Destination: &opts.ConfigName,
+			Value:       params.MainnetName,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to a YAML file with the chain config values, for chains without a named config",
+			Destination: &opts.ChainConfigFile,
+		},
+	}
+}
+
+// setActiveConfig sets the config of the chain of the database, which determines its embedded genesis state.
+func (o *archiveOpts) setActiveConfig() error {
+	if o.ChainConfigFile != "" {
+		log.Infof("Specified a chain config file: %s", o.ChainConfigFile)
+		return params.LoadChainConfigFile(o.ChainConfigFile, nil)
+	}
+	cfg, err := params.ByName(o.ConfigName)
+	if err != nil {
+		return fmt.Errorf("unable to find config using name %s: %v", o.ConfigName, err)
+	}
+	return params.SetActive(cfg.Copy())
+}
+
+// open opens the database to import into, creating it if needed, along with the blob storage.
+func (o *archiveOpts) open(ctx context.Context) (*kv.Store, *filesystem.BlobStorage, error) {
+	if err := o.setActiveConfig(); err != nil {
+		return nil, nil, err
+	}
+	db, err := kv.NewKVStore(ctx, o.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open database at %s: %v", o.Path, err)
+	}
+	blobs, err := o.blobStorage()
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, blobs, nil
+}
+
+// openReadOnly opens the existing database to export for reading only, waiting up to timeout for the lock held by
+// a running beacon node, along with the blob storage.
+func (o *archiveOpts) openReadOnly(ctx context.Context, timeout time.Duration) (*kv.Store, *filesystem.BlobStorage, error) {
+	if err := o.setActiveConfig(); err != nil {
+		return nil, nil, err
+	}
+	db, err := kv.NewReadOnlyKVStore(ctx, o.Path, timeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open database at %s: %v", o.Path, err)
+	}
+	blobs, err := o.blobStorage()
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, blobs, nil
+}
+
+func (o *archiveOpts) blobStorage() (*filesystem.BlobStorage, error) {
+	if o.BlobPath == "" {
+		return nil, nil
+	}
+	return filesystem.NewBlobStorage(o.BlobPath)
+}
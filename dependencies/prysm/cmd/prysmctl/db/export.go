@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/archive"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+const exportArchivePath = "/prysm/v1/debug/archive"
+
+var exportFlags = struct {
+	archiveOpts
+	Output         string
+	StartSlot      uint64
+	EndSlot        uint64
+	LockTimeout    time.Duration
+	BeaconNodeHost string
+}{}
+
+var exportCmd = &cli.Command{
+	Name: "export",
+	Usage: "export the finalized blocks, blob sidecars and states of a slot range to a portable archive. " +
+		"With --path, the db is opened for reading only, and cannot be opened while a beacon node is running on it. " +
+		"To export the db of a running beacon node, use --beacon-node-host instead",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export db")
+		}
+		return nil
+	},
+	Flags: append(archiveFlags(&exportFlags.archiveOpts, false),
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the archive file to write",
+			Destination: &exportFlags.Output,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-slot",
+			Usage:       "lowest slot of the exported range",
+			Destination: &exportFlags.StartSlot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "highest slot of the exported range, defaulting to the finalized slot",
+			Destination: &exportFlags.EndSlot,
+			Value:       math.MaxUint64,
+			DefaultText: "finalized slot",
+		},
+		&cli.DurationFlag{
+			Name:        "lock-timeout",
+			Usage:       "how long to wait for the db lock, which a running beacon node holds, before giving up",
+			Destination: &exportFlags.LockTimeout,
+			Value:       5 * time.Second,
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port of the http api of a running beacon node to export the db of, instead of opening the db at --path",
+			Destination: &exportFlags.BeaconNodeHost,
+		},
+	),
+}
+
+func exportAction(cliCtx *cli.Context) error {
+	f := exportFlags
+	if (f.Path == "") == (f.BeaconNodeHost == "") {
+		return errors.New("exactly one of --path and --beacon-node-host must be set")
+	}
+	out, err := os.Create(f.Output) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not create %s", f.Output)
+	}
+	if f.BeaconNodeHost != "" {
+		err = exportFromNode(cliCtx.Context, f.BeaconNodeHost, out, f.StartSlot, f.EndSlot)
+	} else {
+		err = exportFromDB(cliCtx.Context, out)
+	}
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// exportFromDB writes the archive exported from the db at --path to w.
+func exportFromDB(ctx context.Context, w io.Writer) (err error) {
+	f := exportFlags
+	db, blobs, err := f.openReadOnly(ctx, f.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := db.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	_, err = archive.Export(ctx, db, blobs, w, primitives.Slot(f.StartSlot), primitives.Slot(f.EndSlot))
+	return err
+}
+
+// exportFromNode writes the archive streamed by the beacon node at host to w.
+func exportFromNode(ctx context.Context, host string, w io.Writer, start, end uint64) error {
+	c, err := client.NewClient(host)
+	if err != nil {
+		return err
+	}
+	q := url.Values{}
+	q.Set("start_slot", strconv.FormatUint(start, 10))
+	if end != math.MaxUint64 {
+		q.Set("end_slot", strconv.FormatUint(end, 10))
+	}
+	u := c.BaseURL().ResolveReference(&url.URL{Path: exportArchivePath, RawQuery: q.Encode()})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not request archive from %s", c.NodeURL())
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return client.Non200Err(resp)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "could not read archive from beacon node")
+	}
+	return nil
+}
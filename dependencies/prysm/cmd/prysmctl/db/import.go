@@ -0,0 +1,58 @@
+package db
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/archive"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var importFlags = struct {
+	archiveOpts
+	Input string
+}{}
+
+var importCmd = &cli.Command{
+	Name:  "import",
+	Usage: "import the blocks, blob sidecars and states of an archive written by export, initializing an empty db from it. The archive is verified before anything is written",
+	Action: func(cliCtx *cli.Context) error {
+		if err := importAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import db archive")
+		}
+		return nil
+	},
+	Flags: append(archiveFlags(&importFlags.archiveOpts, true),
+		&cli.StringFlag{
+			Name:        "input",
+			Usage:       "path of the archive file to read",
+			Destination: &importFlags.Input,
+			Required:    true,
+		},
+	),
+}
+
+func importAction(cliCtx *cli.Context) (err error) {
+	f := importFlags
+	in, err := os.Open(f.Input) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", f.Input)
+	}
+	defer func() {
+		if cerr := in.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	db, blobs, err := f.open(cliCtx.Context)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := db.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	_, err = archive.Import(cliCtx.Context, db, blobs, in)
+	return err
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var slasherCompactFlags = struct {
+	Path          string
+	HistoryLength uint64
+}{}
+
+var slasherCompactCmd = &cli.Command{
+	Name:  "slasher-compact",
+	Usage: "rewrite the slasher db into a fresh file, keeping only the attestations and proposals of the slasher history",
+	Action: func(cliCtx *cli.Context) error {
+		if err := slasherCompactAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not compact slasher db")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing slasher.db",
+			Destination: &slasherCompactFlags.Path,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "history-length",
+			Usage:       "number of epochs of attestations and proposals kept, up to the highest epoch attested",
+			Destination: &slasherCompactFlags.HistoryLength,
+			Value:       4096,
+		},
+	},
+}
+
+func slasherCompactAction(cliCtx *cli.Context) (err error) {
+	f := slasherCompactFlags
+	if f.HistoryLength == 0 {
+		return errors.New("history length must be greater than 0")
+	}
+	dbPath := filepath.Join(f.Path, slasherkv.DatabaseFileName)
+	if !file.Exists(dbPath) {
+		return errors.Errorf("no slasher db at %s", dbPath)
+	}
+	src, err := slasherkv.NewKVStore(cliCtx.Context, f.Path)
+	if err != nil {
+		return errors.Wrap(err, "could not open slasher db")
+	}
+	defer func() {
+		if src != nil {
+			if cerr := src.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+	}()
+	stats, err := src.Stats(cliCtx.Context)
+	if err != nil {
+		return errors.Wrap(err, "could not compute slasher db stats")
+	}
+	log.Info("Slasher db before compaction")
+	logSlasherStats(stats)
+
+	// Keep the same window as the slasher pruning, relative to the highest epoch attested.
+	var minEpoch primitives.Epoch
+	if stats.HighestAttestedEpoch >= primitives.Epoch(f.HistoryLength) {
+		minEpoch = stats.HighestAttestedEpoch - primitives.Epoch(f.HistoryLength) + 1
+	}
+	tmpDir, err := os.MkdirTemp(f.Path, "slasher-compact-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rerr := os.RemoveAll(tmpDir); rerr != nil && err == nil {
+			err = rerr
+		}
+	}()
+	dst, err := slasherkv.NewKVStore(cliCtx.Context, tmpDir)
+	if err != nil {
+		return errors.Wrap(err, "could not create compacted slasher db")
+	}
+	log.WithField("minEpoch", minEpoch).Info("Compacting slasher db")
+	if err := src.CompactTo(cliCtx.Context, dst, minEpoch); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	compactedStats, err := dst.Stats(cliCtx.Context)
+	if err != nil {
+		_ = dst.Close()
+		return errors.Wrap(err, "could not compute compacted slasher db stats")
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := src.Close(); err != nil {
+		return err
+	}
+	src = nil
+	if err := os.Rename(filepath.Join(tmpDir, slasherkv.DatabaseFileName), dbPath); err != nil {
+		return errors.Wrap(err, "could not replace slasher db with the compacted one")
+	}
+	log.Info("Slasher db after compaction")
+	logSlasherStats(compactedStats)
+	if stats.FileSize > compactedStats.FileSize {
+		log.Infof("Reclaimed %s", humanize.Bytes(uint64(stats.FileSize-compactedStats.FileSize)))
+	}
+	return nil
+}
+
+func logSlasherStats(stats *slasherkv.DatabaseStats) {
+	log.Infof("FileSize          =  %s", humanize.Bytes(uint64(stats.FileSize)))
+	log.Infof("ReclaimableBytes  =  %s", humanize.Bytes(uint64(stats.ReclaimableBytes)))
+	for _, b := range stats.Buckets {
+		log.Infof("Bucket %-28s  keys = %-10d  size = %s", b.Name, b.NumKeys, humanize.Bytes(uint64(b.Size)))
+	}
+	log.Infof("MinSpanChunks     =  %d", stats.MinSpanChunks)
+	log.Infof("MaxSpanChunks     =  %d", stats.MaxSpanChunks)
+	log.Infof("HighestAttested   =  %d", stats.HighestAttestedEpoch)
+}
@@ -0,0 +1,617 @@
+package testnet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	devnetKeystorePassword = "devnet-password"
+	devnetDefaultChainID   = 32382
+)
+
+var (
+	devnetFlags = struct {
+		Manifest  string
+		OutputDir string
+	}{}
+	devnetCmd = &cli.Command{
+		Name:  "devnet",
+		Usage: "Generate the data directories, keys, genesis and start scripts of a multi-node devnet from a YAML manifest",
+		Action: func(cliCtx *cli.Context) error {
+			if err := cliActionDevnet(cliCtx); err != nil {
+				log.WithError(err).Fatal("Could not generate devnet")
+			}
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "manifest",
+				Usage:       "Path to the YAML manifest describing the devnet",
+				Destination: &devnetFlags.Manifest,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "output-dir",
+				Usage:       "Directory in which the devnet files are written. It must not exist or be empty",
+				Destination: &devnetFlags.OutputDir,
+				Value:       "./network",
+			},
+		},
+	}
+)
+
+// devnetManifest describes a devnet. Each port is the port of the first node, and is incremented
+// by one for every following node so that all the nodes can run on the same machine.
+type devnetManifest struct {
+	ChainConfigFile  string         `json:"chain_config_file"`
+	ConfigName       string         `json:"config_name"`
+	GethGenesisJSON  string         `json:"geth_genesis_json"`
+	Fork             string         `json:"fork"`
+	Forks            devnetForks    `json:"forks"`
+	ChainID          uint64         `json:"chain_id"`
+	GenesisTimeDelay uint64         `json:"genesis_time_delay"`
+	NumNodes         uint64         `json:"num_nodes"`
+	NumValidators    uint64         `json:"num_validators"`
+	ValidatorSplit   []uint64       `json:"validator_split"`
+	HostIP           string         `json:"host_ip"`
+	FeeRecipient     string         `json:"fee_recipient"`
+	Ports            devnetPorts    `json:"ports"`
+	Binaries         devnetBinaries `json:"binaries"`
+
+	// Keys and genesis overrides of the genesis validators, the interop keys being used when neither
+	// a mnemonic file nor a keystores directory is set. See the flags of generate-genesis.
+	MnemonicFile            string `json:"mnemonic_file"`
+	KeystoresDir            string `json:"keystores_dir"`
+	KeystoresPasswordFile   string `json:"keystores_password_file"`
+	WithdrawalAddress       string `json:"withdrawal_address"`
+	WithdrawalAddressesFile string `json:"withdrawal_addresses_file"`
+	GenesisOverridesFile    string `json:"genesis_overrides_file"`
+}
+
+// devnetForks overrides the fork epochs of the chain config.
+type devnetForks struct {
+	AltairEpoch    *uint64 `json:"altair_epoch"`
+	BellatrixEpoch *uint64 `json:"bellatrix_epoch"`
+	CapellaEpoch   *uint64 `json:"capella_epoch"`
+	DenebEpoch     *uint64 `json:"deneb_epoch"`
+}
+
+type devnetPorts struct {
+	GethHTTP          int `json:"geth_http"`
+	GethWS            int `json:"geth_ws"`
+	GethAuthRPC       int `json:"geth_auth_rpc"`
+	GethP2P           int `json:"geth_p2p"`
+	BeaconRPC         int `json:"beacon_rpc"`
+	BeaconGRPCGateway int `json:"beacon_grpc_gateway"`
+	BeaconP2PTCP      int `json:"beacon_p2p_tcp"`
+	BeaconP2PUDP      int `json:"beacon_p2p_udp"`
+	BeaconMonitoring  int `json:"beacon_monitoring"`
+	ValidatorMonitor  int `json:"validator_monitoring"`
+}
+
+type devnetBinaries struct {
+	Geth        string `json:"geth"`
+	BeaconChain string `json:"beacon_chain"`
+	Validator   string `json:"validator"`
+}
+
+// devnetNode holds the values rendered in the start script of a node.
+type devnetNode struct {
+	Index            int
+	Dir              string
+	HostIP           string
+	ChainID          uint64
+	FeeRecipient     string
+	Ports            devnetPorts
+	Binaries         devnetBinaries
+	ValidatorCount   uint64
+	GethBootnode     string
+	BeaconBootnode   string
+	gethNodeKey      *ecdsa.PrivateKey
+	beaconNodeKey    *ecdsa.PrivateKey
+	validatorOffset  uint64
+	validatorKeys    []bls.SecretKey
+	keystorePassword string
+}
+
+func defaultDevnetManifest() *devnetManifest {
+	return &devnetManifest{
+		ConfigName: params.InteropName,
+		Fork:       "capella",
+		ChainID:    devnetDefaultChainID,
+		HostIP:     "127.0.0.1",
+		Ports: devnetPorts{
+			GethHTTP:          8545,
+			GethWS:            8645,
+			GethAuthRPC:       8551,
+			GethP2P:           30303,
+			BeaconRPC:         4000,
+			BeaconGRPCGateway: 4100,
+			BeaconP2PTCP:      4200,
+			BeaconP2PUDP:      4300,
+			BeaconMonitoring:  4400,
+			ValidatorMonitor:  7200,
+		},
+		Binaries: devnetBinaries{
+			Geth:        "geth",
+			BeaconChain: "beacon-chain",
+			Validator:   "validator",
+		},
+	}
+}
+
+func loadDevnetManifest(path string) (*devnetManifest, error) {
+	b, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read manifest %s", path)
+	}
+	m := defaultDevnetManifest()
+	if err := yaml.Unmarshal(b, m); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal manifest")
+	}
+	if m.NumNodes == 0 {
+		return nil, errors.New("num_nodes must be greater than 0")
+	}
+	if net.ParseIP(m.HostIP).To4() == nil {
+		return nil, fmt.Errorf("invalid ipv4 host_ip %q", m.HostIP)
+	}
+	// The number of validators defaults to the number of keystores.
+	if m.KeystoresDir != "" {
+		return m, nil
+	}
+	if m.NumValidators == 0 {
+		return nil, errors.New("num_validators must be greater than 0")
+	}
+	if _, err := validatorSplit(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// validatorSplit returns the number of validators run by each node. Unless the manifest
+// specifies a split, validators are spread evenly with the first nodes running the remainder.
+func validatorSplit(m *devnetManifest) ([]uint64, error) {
+	if len(m.ValidatorSplit) > 0 {
+		if uint64(len(m.ValidatorSplit)) != m.NumNodes {
+			return nil, fmt.Errorf("validator_split has %d entries, expected one per node (%d)", len(m.ValidatorSplit), m.NumNodes)
+		}
+		var total uint64
+		for _, n := range m.ValidatorSplit {
+			total += n
+		}
+		if total != m.NumValidators {
+			return nil, fmt.Errorf("validator_split adds up to %d validators, expected num_validators (%d)", total, m.NumValidators)
+		}
+		return m.ValidatorSplit, nil
+	}
+	split := make([]uint64, m.NumNodes)
+	for i := range split {
+		split[i] = m.NumValidators / m.NumNodes
+		if uint64(i) < m.NumValidators%m.NumNodes {
+			split[i]++
+		}
+	}
+	return split, nil
+}
+
+// chainConfigYaml returns the chain config of the devnet: the base config of the manifest
+// with the fork epochs and chain id of the manifest applied.
+func chainConfigYaml(m *devnetManifest) ([]byte, error) {
+	var base []byte
+	if m.ChainConfigFile != "" {
+		b, err := os.ReadFile(m.ChainConfigFile) // #nosec G304
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read chain config file %s", m.ChainConfigFile)
+		}
+		base = b
+	} else {
+		cfg, err := params.ByName(m.ConfigName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find config using name %s: %v", m.ConfigName, err)
+		}
+		base = params.ConfigToYaml(cfg)
+	}
+	overrides := map[string]string{
+		"DEPOSIT_CHAIN_ID":   fmt.Sprintf("%d", m.ChainID),
+		"DEPOSIT_NETWORK_ID": fmt.Sprintf("%d", m.ChainID),
+	}
+	forks := map[string]*uint64{
+		"ALTAIR_FORK_EPOCH":    m.Forks.AltairEpoch,
+		"BELLATRIX_FORK_EPOCH": m.Forks.BellatrixEpoch,
+		"CAPELLA_FORK_EPOCH":   m.Forks.CapellaEpoch,
+		"DENEB_FORK_EPOCH":     m.Forks.DenebEpoch,
+	}
+	for k, v := range forks {
+		if v != nil {
+			overrides[k] = fmt.Sprintf("%d", *v)
+		}
+	}
+	return overrideConfigValues(base, overrides), nil
+}
+
+// overrideConfigValues replaces the values of the given keys in a chain config yaml file, appending
+// the keys missing from the file. The file is edited line by line to preserve the hex encoding of values.
+func overrideConfigValues(yamlFile []byte, overrides map[string]string) []byte {
+	lines := strings.Split(strings.TrimRight(string(yamlFile), "\n"), "\n")
+	seen := make(map[string]bool, len(overrides))
+	for i, line := range lines {
+		key, _, found := strings.Cut(line, ":")
+		if !found || strings.HasPrefix(key, "#") {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if v, ok := overrides[key]; ok {
+			lines[i] = fmt.Sprintf("%s: %s", key, v)
+			seen[key] = true
+		}
+	}
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %s", k, overrides[k]))
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func cliActionDevnet(cliCtx *cli.Context) error {
+	m, err := loadDevnetManifest(devnetFlags.Manifest)
+	if err != nil {
+		return err
+	}
+	outputDir, err := file.ExpandPath(devnetFlags.OutputDir)
+	if err != nil {
+		return err
+	}
+	if entries, err := os.ReadDir(outputDir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("output directory %s is not empty", outputDir)
+	}
+	if err := file.MkdirAll(outputDir); err != nil {
+		return err
+	}
+
+	configYaml, err := chainConfigYaml(m)
+	if err != nil {
+		return err
+	}
+	cfg, err := params.UnmarshalConfig(configYaml, nil)
+	if err != nil {
+		return err
+	}
+	if err := params.SetActive(cfg); err != nil {
+		return err
+	}
+
+	opts := &generateGenesisOpts{
+		NumValidators:           m.NumValidators,
+		GenesisTime:             uint64(time.Now().Unix()) + m.GenesisTimeDelay,
+		ForkName:                m.Fork,
+		GethGenesisJsonIn:       m.GethGenesisJSON,
+		MnemonicFile:            m.MnemonicFile,
+		KeystoresDir:            m.KeystoresDir,
+		KeystoresPasswordFile:   m.KeystoresPasswordFile,
+		WithdrawalAddress:       m.WithdrawalAddress,
+		WithdrawalAddressesFile: m.WithdrawalAddressesFile,
+		GenesisOverridesFile:    m.GenesisOverridesFile,
+	}
+	opts.keys, err = loadGenesisKeys(opts)
+	if err != nil {
+		return errors.Wrap(err, "could not load genesis validator keys")
+	}
+	var validatorKeys []bls.SecretKey
+	password := devnetKeystorePassword
+	if opts.keys != nil {
+		validatorKeys = opts.keys.secretKeys
+		m.NumValidators = uint64(len(validatorKeys))
+	} else {
+		validatorKeys, _, err = interop.DeterministicallyGenerateKeys(0, m.NumValidators)
+		if err != nil {
+			return errors.Wrap(err, "could not generate validator keys")
+		}
+	}
+	if m.KeystoresPasswordFile != "" {
+		if password, err = readPasswordFile(m.KeystoresPasswordFile); err != nil {
+			return err
+		}
+	}
+
+	gen, err := gethGenesis(opts)
+	if err != nil {
+		return err
+	}
+	gen.Config.ChainID = new(big.Int).SetUint64(m.ChainID)
+	st, err := genesisStateFromGeth(cliCtx.Context, opts, gen)
+	if err != nil {
+		return errors.Wrap(err, "could not generate genesis state")
+	}
+	genesisSSZ, err := st.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := devnetNodes(m, outputDir)
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		n.validatorKeys = validatorKeys[n.validatorOffset : n.validatorOffset+n.ValidatorCount]
+		n.keystorePassword = password
+		if err := writeDevnetNode(n, configYaml, genesisSSZ, func(fPath string) error {
+			return writeGethGenesis(fPath, gen)
+		}); err != nil {
+			return errors.Wrapf(err, "could not write node %d", n.Index)
+		}
+	}
+	if err := writeScript(filepath.Join(outputDir, "start-all.sh"), startAllTemplate, nodes); err != nil {
+		return err
+	}
+	log.WithField("nodes", len(nodes)).Infof("Devnet written to %s, start it with %s", outputDir, filepath.Join(outputDir, "start-all.sh"))
+	return nil
+}
+
+// devnetNodes generates the networking keys of every node. The first node is the bootnode of the others.
+func devnetNodes(m *devnetManifest, outputDir string) ([]*devnetNode, error) {
+	split, err := validatorSplit(m)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(m.HostIP)
+	nodes := make([]*devnetNode, m.NumNodes)
+	var offset uint64
+	for i := range nodes {
+		gethKey, err := gethcrypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		beaconKey, err := gethcrypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		ports := m.Ports
+		ports.GethHTTP += i
+		ports.GethWS += i
+		ports.GethAuthRPC += i
+		ports.GethP2P += i
+		ports.BeaconRPC += i
+		ports.BeaconGRPCGateway += i
+		ports.BeaconP2PTCP += i
+		ports.BeaconP2PUDP += i
+		ports.BeaconMonitoring += i
+		ports.ValidatorMonitor += i
+		nodes[i] = &devnetNode{
+			Index:           i,
+			Dir:             filepath.Join(outputDir, fmt.Sprintf("node%d", i)),
+			HostIP:          m.HostIP,
+			ChainID:         m.ChainID,
+			FeeRecipient:    m.FeeRecipient,
+			Ports:           ports,
+			Binaries:        m.Binaries,
+			ValidatorCount:  split[i],
+			gethNodeKey:     gethKey,
+			beaconNodeKey:   beaconKey,
+			validatorOffset: offset,
+		}
+		offset += split[i]
+	}
+
+	boot := nodes[0]
+	gethBootnode := enode.NewV4(&boot.gethNodeKey.PublicKey, ip, boot.Ports.GethP2P, boot.Ports.GethP2P).URLv4()
+	beaconBootnode, err := beaconENR(boot.beaconNodeKey, ip, boot.Ports.BeaconP2PTCP, boot.Ports.BeaconP2PUDP)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes[1:] {
+		n.GethBootnode = gethBootnode
+		n.BeaconBootnode = beaconBootnode
+	}
+	return nodes, nil
+}
+
+func beaconENR(key *ecdsa.PrivateKey, ip net.IP, tcpPort, udpPort int) (string, error) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		return "", errors.Wrap(err, "could not open node's peer database")
+	}
+	defer db.Close()
+	localNode := enode.NewLocalNode(db, key)
+	localNode.Set(enr.IP(ip))
+	localNode.Set(enr.TCP(tcpPort))
+	localNode.Set(enr.UDP(udpPort))
+	return localNode.Node().String(), nil
+}
+
+// writeDevnetNode writes the data directory of a node:
+//
+//	node<i>/
+//	  start.sh
+//	  execution/  genesis.json, jwtsecret, geth_password.txt, geth/nodekey, keystore/
+//	  consensus/  config.yml, genesis.ssz, p2p-key, keystores/, keystore_password.txt
+func writeDevnetNode(n *devnetNode, configYaml, genesisSSZ []byte, writeGethGenesisFn func(string) error) error {
+	executionDir := filepath.Join(n.Dir, "execution")
+	consensusDir := filepath.Join(n.Dir, "consensus")
+	for _, dir := range []string{filepath.Join(executionDir, "geth"), filepath.Join(consensusDir, "keystores"), filepath.Join(n.Dir, "logs")} {
+		if err := file.MkdirAll(dir); err != nil {
+			return err
+		}
+	}
+
+	// Execution client.
+	if err := writeGethGenesisFn(filepath.Join(executionDir, "genesis.json")); err != nil {
+		return err
+	}
+	if err := gethcrypto.SaveECDSA(filepath.Join(executionDir, "geth", "nodekey"), n.gethNodeKey); err != nil {
+		return errors.Wrap(err, "could not write geth node key")
+	}
+	// Geth accounts are created with an empty password. Do not do this in production.
+	if err := file.WriteFile(filepath.Join(executionDir, "geth_password.txt"), []byte("\n")); err != nil {
+		return err
+	}
+	ks := keystore.NewKeyStore(filepath.Join(executionDir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
+	if _, err := ks.NewAccount(""); err != nil {
+		return errors.Wrap(err, "could not create geth account")
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	if err := file.WriteFile(filepath.Join(executionDir, "jwtsecret"), []byte(hexutil.Encode(secret))); err != nil {
+		return err
+	}
+
+	// Consensus client.
+	if err := file.WriteFile(filepath.Join(consensusDir, "config.yml"), configYaml); err != nil {
+		return err
+	}
+	if err := file.WriteFile(filepath.Join(consensusDir, "genesis.ssz"), genesisSSZ); err != nil {
+		return err
+	}
+	if err := gethcrypto.SaveECDSA(filepath.Join(consensusDir, "p2p-key"), n.beaconNodeKey); err != nil {
+		return errors.Wrap(err, "could not write beacon node p2p key")
+	}
+	if err := writeKeystores(filepath.Join(consensusDir, "keystores"), n.validatorKeys, n.validatorOffset, n.keystorePassword); err != nil {
+		return errors.Wrap(err, "could not write validator keystores")
+	}
+	if err := file.WriteFile(filepath.Join(consensusDir, "keystore_password.txt"), []byte(n.keystorePassword)); err != nil {
+		return err
+	}
+	return writeScript(filepath.Join(n.Dir, "start.sh"), startNodeTemplate, n)
+}
+
+func writeScript(fPath string, tmpl *template.Template, data interface{}) error {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return errors.Wrapf(err, "could not render %s", fPath)
+	}
+	if err := file.WriteFile(fPath, b.Bytes()); err != nil {
+		return err
+	}
+	return os.Chmod(fPath, 0700)
+}
+
+var startNodeTemplate = template.Must(template.New("start.sh").Parse(`#!/bin/bash
+# Generated by prysmctl testnet devnet. Starts the execution client, beacon node
+# and validator client of node {{.Index}}.
+
+set -eu
+set -o pipefail
+
+NODE_DIR=$(cd "$(dirname "$0")" && pwd)
+GETH_BINARY=${GETH_BINARY:-{{.Binaries.Geth}}}
+PRYSM_BEACON_BINARY=${PRYSM_BEACON_BINARY:-{{.Binaries.BeaconChain}}}
+PRYSM_VALIDATOR_BINARY=${PRYSM_VALIDATOR_BINARY:-{{.Binaries.Validator}}}
+
+trap 'kill $(jobs -p) 2>/dev/null' EXIT
+
+if [ ! -d "$NODE_DIR/execution/geth/chaindata" ]; then
+  $GETH_BINARY init --datadir="$NODE_DIR/execution" "$NODE_DIR/execution/genesis.json"
+fi
+{{- if .ValidatorCount}}
+if [ ! -d "$NODE_DIR/consensus/validatordata/wallet" ]; then
+  cp "$NODE_DIR/consensus/keystore_password.txt" "$NODE_DIR/consensus/wallet_password.txt"
+  $PRYSM_VALIDATOR_BINARY accounts import \
+    --accept-terms-of-use \
+    --keys-dir="$NODE_DIR/consensus/keystores" \
+    --wallet-dir="$NODE_DIR/consensus/validatordata/wallet" \
+    --wallet-password-file="$NODE_DIR/consensus/wallet_password.txt" \
+    --account-password-file="$NODE_DIR/consensus/keystore_password.txt"
+fi
+{{- end}}
+
+$GETH_BINARY \
+  --networkid={{.ChainID}} \
+  --datadir="$NODE_DIR/execution" \
+  --password="$NODE_DIR/execution/geth_password.txt" \
+  --http --http.addr=0.0.0.0 --http.port={{.Ports.GethHTTP}} --http.api=eth,net,web3,txpool,debug --http.corsdomain="*" \
+  --ws --ws.addr=0.0.0.0 --ws.port={{.Ports.GethWS}} --ws.api=eth,net,web3,txpool,debug --ws.origins="*" \
+  --authrpc.addr=127.0.0.1 --authrpc.port={{.Ports.GethAuthRPC}} --authrpc.vhosts="*" \
+  --authrpc.jwtsecret="$NODE_DIR/execution/jwtsecret" \
+  --port={{.Ports.GethP2P}} \
+{{- if .GethBootnode}}
+  --bootnodes={{.GethBootnode}} \
+{{- end}}
+  --nat=extip:{{.HostIP}} \
+  --syncmode=full > "$NODE_DIR/logs/geth.log" 2>&1 &
+
+$PRYSM_BEACON_BINARY \
+  --accept-terms-of-use \
+  --datadir="$NODE_DIR/consensus/beacondata" \
+  --chain-config-file="$NODE_DIR/consensus/config.yml" \
+  --genesis-state="$NODE_DIR/consensus/genesis.ssz" \
+  --chain-id={{.ChainID}} \
+  --contract-deployment-block=0 \
+  --interop-eth1data-votes \
+  --min-sync-peers=0 \
+  --minimum-peers-per-subnet=0 \
+  --execution-endpoint=http://127.0.0.1:{{.Ports.GethAuthRPC}} \
+  --jwt-secret="$NODE_DIR/execution/jwtsecret" \
+  --rpc-host=0.0.0.0 --rpc-port={{.Ports.BeaconRPC}} \
+  --grpc-gateway-host=0.0.0.0 --grpc-gateway-port={{.Ports.BeaconGRPCGateway}} \
+  --p2p-host-ip={{.HostIP}} --p2p-tcp-port={{.Ports.BeaconP2PTCP}} --p2p-udp-port={{.Ports.BeaconP2PUDP}} \
+  --p2p-priv-key="$NODE_DIR/consensus/p2p-key" \
+{{- if .BeaconBootnode}}
+  --bootstrap-node={{.BeaconBootnode}} \
+{{- end}}
+{{- if .FeeRecipient}}
+  --suggested-fee-recipient={{.FeeRecipient}} \
+{{- end}}
+  --monitoring-port={{.Ports.BeaconMonitoring}} > "$NODE_DIR/logs/beacon.log" 2>&1 &
+{{- if .ValidatorCount}}
+
+$PRYSM_VALIDATOR_BINARY \
+  --accept-terms-of-use \
+  --datadir="$NODE_DIR/consensus/validatordata" \
+  --wallet-dir="$NODE_DIR/consensus/validatordata/wallet" \
+  --wallet-password-file="$NODE_DIR/consensus/wallet_password.txt" \
+  --chain-config-file="$NODE_DIR/consensus/config.yml" \
+  --beacon-rpc-provider=127.0.0.1:{{.Ports.BeaconRPC}} \
+{{- if .FeeRecipient}}
+  --suggested-fee-recipient={{.FeeRecipient}} \
+{{- end}}
+  --monitoring-port={{.Ports.ValidatorMonitor}} > "$NODE_DIR/logs/validator.log" 2>&1 &
+{{- end}}
+
+wait
+`))
+
+var startAllTemplate = template.Must(template.New("start-all.sh").Parse(`#!/bin/bash
+# Generated by prysmctl testnet devnet. Starts every node of the devnet.
+
+set -eu
+
+NETWORK_DIR=$(cd "$(dirname "$0")" && pwd)
+
+trap 'kill $(jobs -p) 2>/dev/null' EXIT
+{{range .}}
+"$NETWORK_DIR/node{{.Index}}/start.sh" &
+{{- end}}
+
+wait
+`))
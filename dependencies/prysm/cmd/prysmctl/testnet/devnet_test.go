@@ -0,0 +1,86 @@
+package testnet
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func Test_validatorSplit(t *testing.T) {
+	m := &devnetManifest{NumNodes: 3, NumValidators: 64}
+	split, err := validatorSplit(m)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{22, 21, 21}, split)
+
+	m.ValidatorSplit = []uint64{32, 32, 0}
+	split, err = validatorSplit(m)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{32, 32, 0}, split)
+
+	m.ValidatorSplit = []uint64{32, 32}
+	_, err = validatorSplit(m)
+	require.ErrorContains(t, "expected one per node", err)
+
+	m.ValidatorSplit = []uint64{32, 16, 8}
+	_, err = validatorSplit(m)
+	require.ErrorContains(t, "expected num_validators", err)
+}
+
+func Test_chainConfigYaml(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	capella, deneb := uint64(0), uint64(10)
+	m := defaultDevnetManifest()
+	m.ConfigName = params.MinimalName
+	m.Forks.CapellaEpoch = &capella
+	m.Forks.DenebEpoch = &deneb
+
+	b, err := chainConfigYaml(m)
+	require.NoError(t, err)
+	cfg, err := params.UnmarshalConfig(b, nil)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(0), cfg.CapellaForkEpoch)
+	assert.Equal(t, primitives.Epoch(10), cfg.DenebForkEpoch)
+	assert.Equal(t, uint64(devnetDefaultChainID), cfg.DepositChainID)
+	assert.Equal(t, uint64(devnetDefaultChainID), cfg.DepositNetworkID)
+	assert.DeepEqual(t, params.MinimalSpecConfig().GenesisForkVersion, cfg.GenesisForkVersion)
+}
+
+func Test_overrideConfigValues(t *testing.T) {
+	in := []byte("# comment: value\nPRESET_BASE: 'minimal'\nALTAIR_FORK_EPOCH: 5\n")
+	out := overrideConfigValues(in, map[string]string{"ALTAIR_FORK_EPOCH": "0", "DENEB_FORK_EPOCH": "2", "BELLATRIX_FORK_EPOCH": "1"})
+	want := "# comment: value\nPRESET_BASE: 'minimal'\nALTAIR_FORK_EPOCH: 0\nBELLATRIX_FORK_EPOCH: 1\nDENEB_FORK_EPOCH: 2\n"
+	assert.Equal(t, want, string(out))
+}
+
+func Test_devnetNodes(t *testing.T) {
+	m := defaultDevnetManifest()
+	m.NumNodes = 3
+	m.NumValidators = 8
+	nodes, err := devnetNodes(m, "network")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(nodes))
+
+	assert.Equal(t, "", nodes[0].GethBootnode)
+	assert.Equal(t, "", nodes[0].BeaconBootnode)
+	var offset uint64
+	for i, n := range nodes {
+		assert.Equal(t, filepath.Join("network", fmt.Sprintf("node%d", i)), n.Dir)
+		assert.Equal(t, m.Ports.BeaconP2PTCP+i, n.Ports.BeaconP2PTCP)
+		assert.Equal(t, m.Ports.GethAuthRPC+i, n.Ports.GethAuthRPC)
+		assert.Equal(t, offset, n.validatorOffset)
+		offset += n.ValidatorCount
+		if i > 0 {
+			assert.Equal(t, nodes[1].GethBootnode, n.GethBootnode)
+			assert.Equal(t, nodes[1].BeaconBootnode, n.BeaconBootnode)
+		}
+	}
+	assert.Equal(t, m.NumValidators, offset)
+	assert.Equal(t, true, strings.HasPrefix(nodes[1].GethBootnode, "enode://"))
+	assert.Equal(t, true, strings.HasPrefix(nodes[1].BeaconBootnode, "enr:"))
+}
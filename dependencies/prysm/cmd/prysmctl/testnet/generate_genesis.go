@@ -27,25 +27,37 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+type generateGenesisOpts struct {
+	DepositJsonFile    string
+	ChainConfigFile    string
+	ConfigName         string
+	NumValidators      uint64
+	GenesisTime        uint64
+	GenesisTimeDelay   uint64
+	OutputSSZ          string
+	OutputJSON         string
+	OutputYaml         string
+	ForkName           string
+	OverrideEth1Data   bool
+	ExecutionEndpoint  string
+	GethGenesisJsonIn  string
+	GethGenesisJsonOut string
+
+	MnemonicFile            string
+	KeystoresDir            string
+	KeystoresPasswordFile   string
+	WithdrawalAddress       string
+	WithdrawalAddressesFile string
+	OutputKeystoresDir      string
+	GenesisOverridesFile    string
+
+	keys *genesisKeys
+}
+
 var (
-	generateGenesisStateFlags = struct {
-		DepositJsonFile    string
-		ChainConfigFile    string
-		ConfigName         string
-		NumValidators      uint64
-		GenesisTime        uint64
-		GenesisTimeDelay   uint64
-		OutputSSZ          string
-		OutputJSON         string
-		OutputYaml         string
-		ForkName           string
-		OverrideEth1Data   bool
-		ExecutionEndpoint  string
-		GethGenesisJsonIn  string
-		GethGenesisJsonOut string
-	}{}
-	log           = logrus.WithField("prefix", "genesis")
-	outputSSZFlag = &cli.StringFlag{
+	generateGenesisStateFlags = &generateGenesisOpts{}
+	log                       = logrus.WithField("prefix", "genesis")
+	outputSSZFlag             = &cli.StringFlag{
 		Name:        "output-ssz",
 		Destination: &generateGenesisStateFlags.OutputSSZ,
 		Usage:       "Output filename of the SSZ marshaling of the generated genesis state",
@@ -91,9 +103,43 @@ var (
 			},
 			&cli.Uint64Flag{
 				Name:        "num-validators",
-				Usage:       "Number of validators to deterministically generate in the genesis state",
+				Usage:       "Number of validators in the genesis state, deterministically generated unless --mnemonic-file or --keystores-dir is provided",
 				Destination: &generateGenesisStateFlags.NumValidators,
-				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "mnemonic-file",
+				Destination: &generateGenesisStateFlags.MnemonicFile,
+				Usage:       "Path to a file containing an EIP-2333 mnemonic from which the keys of --num-validators validators are derived",
+			},
+			&cli.StringFlag{
+				Name:        "keystores-dir",
+				Destination: &generateGenesisStateFlags.KeystoresDir,
+				Usage:       "Path to a directory of EIP-2335 keystores of the genesis validators, ordered by file name",
+			},
+			&cli.StringFlag{
+				Name:        "keystores-password-file",
+				Destination: &generateGenesisStateFlags.KeystoresPasswordFile,
+				Usage:       "Path to a file containing the password of the keystores read from --keystores-dir or written to --output-keystores-dir",
+			},
+			&cli.StringFlag{
+				Name:        "withdrawal-address",
+				Destination: &generateGenesisStateFlags.WithdrawalAddress,
+				Usage:       "Execution address used in the 0x01 withdrawal credentials of every validator. If unset, validators get 0x00 BLS withdrawal credentials",
+			},
+			&cli.StringFlag{
+				Name:        "withdrawal-addresses-file",
+				Destination: &generateGenesisStateFlags.WithdrawalAddressesFile,
+				Usage:       "Path to a file with one execution address per line, used in the 0x01 withdrawal credentials of the validator of the same index. Validators with an empty line get 0x00 BLS withdrawal credentials",
+			},
+			&cli.StringFlag{
+				Name:        "genesis-overrides-file",
+				Destination: &generateGenesisStateFlags.GenesisOverridesFile,
+				Usage:       "Path to a YAML file patching the balances, exit status and withdrawal credentials of genesis validators",
+			},
+			&cli.StringFlag{
+				Name:        "output-keystores-dir",
+				Destination: &generateGenesisStateFlags.OutputKeystoresDir,
+				Usage:       "Directory in which the EIP-2335 keystores of the validators derived from --mnemonic-file or read from --keystores-dir are written, encrypted with the password of --keystores-password-file",
 			},
 			&cli.Uint64Flag{
 				Name:        "genesis-time",
@@ -176,7 +222,7 @@ func cliActionGenerateGenesisState(cliCtx *cli.Context) error {
 	if err := setGlobalParams(); err != nil {
 		return fmt.Errorf("could not set config params: %v", err)
 	}
-	st, err := generateGenesis(cliCtx.Context)
+	st, err := generateGenesis(cliCtx.Context, generateGenesisStateFlags)
 	if err != nil {
 		return fmt.Errorf("could not generate genesis state: %v", err)
 	}
@@ -223,8 +269,7 @@ func setGlobalParams() error {
 	return params.SetActive(cfg.Copy())
 }
 
-func generateGenesis(ctx context.Context) (state.BeaconState, error) {
-	f := &generateGenesisStateFlags
+func generateGenesis(ctx context.Context, f *generateGenesisOpts) (state.BeaconState, error) {
 	if f.GenesisTime == 0 {
 		f.GenesisTime = uint64(time.Now().Unix())
 		log.Info("No genesis time specified, defaulting to now()")
@@ -233,6 +278,88 @@ func generateGenesis(ctx context.Context) (state.BeaconState, error) {
 	f.GenesisTime += f.GenesisTimeDelay
 	log.Infof("Genesis is now %v", f.GenesisTime)
 
+	keys, err := loadGenesisKeys(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load genesis validator keys")
+	}
+	f.keys = keys
+	if f.OutputKeystoresDir != "" {
+		if keys == nil {
+			return nil, errors.New("keystores can only be written for validators derived from a mnemonic or read from keystores")
+		}
+		password, err := readPasswordFile(f.KeystoresPasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeKeystores(f.OutputKeystoresDir, keys.secretKeys, 0, password); err != nil {
+			return nil, errors.Wrap(err, "could not write validator keystores")
+		}
+	}
+
+	gen, err := gethGenesis(f)
+	if err != nil {
+		return nil, err
+	}
+	if f.GethGenesisJsonOut != "" {
+		if err := writeGethGenesis(f.GethGenesisJsonOut, gen); err != nil {
+			return nil, err
+		}
+	}
+	return genesisStateFromGeth(ctx, f, gen)
+}
+
+// gethGenesis returns the execution genesis matching the genesis time and fork of the options,
+// read from the geth genesis json file of the options if any.
+func gethGenesis(f *generateGenesisOpts) (*core.Genesis, error) {
+	v, err := version.FromString(f.ForkName)
+	if err != nil {
+		return nil, err
+	}
+	if f.GethGenesisJsonIn == "" {
+		return interop.GethTestnetGenesis(f.GenesisTime, params.BeaconConfig()), nil
+	}
+	gen := &core.Genesis{}
+	gbytes, err := os.ReadFile(f.GethGenesisJsonIn) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", f.GethGenesisJsonIn)
+	}
+	if err := json.Unmarshal(gbytes, gen); err != nil {
+		return nil, err
+	}
+	// set timestamps for genesis and shanghai fork
+	gen.Timestamp = f.GenesisTime
+	gen.Config.ShanghaiTime = interop.GethShanghaiTime(f.GenesisTime, params.BeaconConfig())
+	gen.Config.CancunTime = interop.GethCancunTime(f.GenesisTime, params.BeaconConfig())
+
+	fields := logrus.Fields{}
+	if gen.Config.ShanghaiTime != nil {
+		fields["shanghai"] = fmt.Sprintf("%d", *gen.Config.ShanghaiTime)
+	}
+	if gen.Config.CancunTime != nil {
+		fields["cancun"] = fmt.Sprintf("%d", *gen.Config.CancunTime)
+	}
+	log.WithFields(fields).Info("Setting fork geth times")
+	if v > version.Altair {
+		// set ttd to zero so EL goes post-merge immediately
+		gen.Config.TerminalTotalDifficulty = big.NewInt(0)
+		gen.Config.TerminalTotalDifficultyPassed = true
+	}
+	return gen, nil
+}
+
+func writeGethGenesis(fPath string, gen *core.Genesis) error {
+	gbytes, err := json.MarshalIndent(gen, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(fPath, gbytes, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to write %s", fPath)
+	}
+	return nil
+}
+
+// genesisStateFromGeth generates the beacon chain genesis state on top of the given execution genesis.
+func genesisStateFromGeth(ctx context.Context, f *generateGenesisOpts, gen *core.Genesis) (state.BeaconState, error) {
 	v, err := version.FromString(f.ForkName)
 	if err != nil {
 		return nil, err
@@ -254,51 +381,25 @@ func generateGenesis(ctx context.Context) (state.BeaconState, error) {
 			return nil, err
 		}
 		opts = append(opts, interop.WithDepositData(dds, roots))
+	} else if f.keys != nil {
+		dds, roots, err := f.keys.depositData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not generate deposit data of genesis validators")
+		}
+		opts = append(opts, interop.WithDepositData(dds, roots))
+		nv = uint64(len(dds))
 	} else if nv == 0 {
 		return nil, fmt.Errorf(
-			"expected --num-validators > 0 or --deposit-json-file to have been provided",
+			"expected --num-validators > 0, --keystores-dir or --deposit-json-file to have been provided",
 		)
 	}
 
-	gen := &core.Genesis{}
-	if f.GethGenesisJsonIn != "" {
-		gbytes, err := os.ReadFile(f.GethGenesisJsonIn) // #nosec G304
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to read %s", f.GethGenesisJsonIn)
-		}
-		if err := json.Unmarshal(gbytes, gen); err != nil {
-			return nil, err
-		}
-		// set timestamps for genesis and shanghai fork
-		gen.Timestamp = f.GenesisTime
-		gen.Config.ShanghaiTime = interop.GethShanghaiTime(f.GenesisTime, params.BeaconConfig())
-		gen.Config.CancunTime = interop.GethCancunTime(f.GenesisTime, params.BeaconConfig())
-
-		fields := logrus.Fields{}
-		if gen.Config.ShanghaiTime != nil {
-			fields["shanghai"] = fmt.Sprintf("%d", *gen.Config.ShanghaiTime)
-		}
-		if gen.Config.CancunTime != nil {
-			fields["cancun"] = fmt.Sprintf("%d", *gen.Config.CancunTime)
-		}
-		log.WithFields(fields).Info("Setting fork geth times")
-		if v > version.Altair {
-			// set ttd to zero so EL goes post-merge immediately
-			gen.Config.TerminalTotalDifficulty = big.NewInt(0)
-			gen.Config.TerminalTotalDifficultyPassed = true
-		}
-	} else {
-		gen = interop.GethTestnetGenesis(f.GenesisTime, params.BeaconConfig())
-	}
-
-	if f.GethGenesisJsonOut != "" {
-		gbytes, err := json.MarshalIndent(gen, "", "\t")
+	if f.GenesisOverridesFile != "" {
+		overrides, err := loadGenesisOverrides(f.GenesisOverridesFile)
 		if err != nil {
 			return nil, err
 		}
-		if err := os.WriteFile(f.GethGenesisJsonOut, gbytes, os.ModePerm); err != nil {
-			return nil, errors.Wrapf(err, "failed to write %s", f.GethGenesisJsonOut)
-		}
+		opts = append(opts, interop.WithGenesisOverrides(overrides))
 	}
 
 	gb := gen.ToBlock()
@@ -311,12 +412,12 @@ func generateGenesis(ctx context.Context) (state.BeaconState, error) {
 
 	if f.OverrideEth1Data {
 		log.Print("Overriding Eth1Data with data from execution client")
-		conn, err := rpc.Dial(generateGenesisStateFlags.ExecutionEndpoint)
+		conn, err := rpc.Dial(f.ExecutionEndpoint)
 		if err != nil {
 			return nil, errors.Wrapf(
 				err,
 				"could not dial %s please make sure you are running your execution client",
-				generateGenesisStateFlags.ExecutionEndpoint)
+				f.ExecutionEndpoint)
 		}
 		client := ethclient.NewClient(conn)
 		header, err := client.HeaderByNumber(ctx, big.NewInt(0))
@@ -347,6 +448,22 @@ func generateGenesis(ctx context.Context) (state.BeaconState, error) {
 	return genesisState, err
 }
 
+func loadGenesisOverrides(fPath string) (*interop.GenesisOverrides, error) {
+	expanded, err := file.ExpandPath(fPath)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(expanded) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read genesis overrides file %s", expanded)
+	}
+	overrides := &interop.GenesisOverrides{}
+	if err := yaml.Unmarshal(b, overrides); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal genesis overrides")
+	}
+	return overrides, nil
+}
+
 func depositEntriesFromJSON(enc []byte) ([][]byte, []*ethpb.Deposit_Data, error) {
 	var depositJSON []*depositDataJSON
 	if err := json.Unmarshal(enc, &depositJSON); err != nil {
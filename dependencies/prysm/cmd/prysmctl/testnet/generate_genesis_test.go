@@ -3,8 +3,11 @@ package testnet
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -48,3 +51,26 @@ func createGenesisDepositData(t *testing.T, numKeys int) []*depositDataJSON {
 	}
 	return jsonData
 }
+
+func Test_loadGenesisOverrides(t *testing.T) {
+	fPath := filepath.Join(t.TempDir(), "overrides.yaml")
+	content := `
+validators:
+  - index: 0
+    count: 4
+    balance: 16000000000
+  - index: 4
+    slashed: true
+    exit_epoch: 5
+    withdrawal_address: "0x123463a4b065722e99115d6c222f267d9cabb524"
+`
+	require.NoError(t, os.WriteFile(fPath, []byte(content), 0600))
+	overrides, err := loadGenesisOverrides(fPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(overrides.Validators))
+	assert.Equal(t, uint64(4), overrides.Validators[0].Count)
+	assert.Equal(t, uint64(16000000000), *overrides.Validators[0].Balance)
+	assert.Equal(t, true, overrides.Validators[1].Slashed)
+	assert.Equal(t, primitives.Epoch(5), *overrides.Validators[1].ExitEpoch)
+	assert.Equal(t, "0x123463a4b065722e99115d6c222f267d9cabb524", overrides.Validators[1].WithdrawalAddress)
+}
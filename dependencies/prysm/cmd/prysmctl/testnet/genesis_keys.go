@@ -0,0 +1,280 @@
+package testnet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
+	"github.com/tyler-smith/go-bip39"
+	util "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+const (
+	// EIP-2334 paths of the keys of a validator.
+	validatingKeyPathTemplate = "m/12381/3600/%d/0/0"
+	withdrawalKeyPathTemplate = "m/12381/3600/%d/0"
+)
+
+// genesisKeys are the keys of the genesis validators, with their withdrawal credentials.
+type genesisKeys struct {
+	secretKeys            []bls.SecretKey
+	withdrawalCredentials [][]byte
+}
+
+// loadGenesisKeys returns the keys of the genesis validators, derived from the EIP-2333 mnemonic
+// or decrypted from the EIP-2335 keystores of the options. It returns nil when neither is set,
+// in which case the well-known interop keys are used.
+func loadGenesisKeys(f *generateGenesisOpts) (*genesisKeys, error) {
+	if f.MnemonicFile != "" && f.KeystoresDir != "" {
+		return nil, errors.New("only one of a mnemonic file or a keystores directory can be provided")
+	}
+	if f.WithdrawalAddress != "" && f.WithdrawalAddressesFile != "" {
+		return nil, errors.New("only one of a withdrawal address or a withdrawal addresses file can be provided")
+	}
+	var (
+		secretKeys []bls.SecretKey
+		// withdrawalKeys are the BLS keys behind 0x00 withdrawal credentials.
+		withdrawalKeys []bls.PublicKey
+		err            error
+	)
+	switch {
+	case f.MnemonicFile != "":
+		if f.NumValidators == 0 {
+			return nil, errors.New("the number of validators to derive from the mnemonic must be greater than 0")
+		}
+		secretKeys, withdrawalKeys, err = keysFromMnemonic(f.MnemonicFile, f.NumValidators)
+	case f.KeystoresDir != "":
+		secretKeys, err = keysFromKeystores(f.KeystoresDir, f.KeystoresPasswordFile)
+		if err == nil && f.NumValidators != 0 && f.NumValidators != uint64(len(secretKeys)) {
+			err = fmt.Errorf("found %d keystores in %s, expected %d validators", len(secretKeys), f.KeystoresDir, f.NumValidators)
+		}
+		// Without a mnemonic, 0x00 withdrawal credentials commit to the validating keys.
+		withdrawalKeys = make([]bls.PublicKey, len(secretKeys))
+		for i, sk := range secretKeys {
+			withdrawalKeys[i] = sk.PublicKey()
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	creds, err := withdrawalCredentials(f, withdrawalKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &genesisKeys{secretKeys: secretKeys, withdrawalCredentials: creds}, nil
+}
+
+// depositData returns the deposit data of the genesis validators.
+func (k *genesisKeys) depositData() ([]*ethpb.Deposit_Data, [][]byte, error) {
+	pubKeys := make([]bls.PublicKey, len(k.secretKeys))
+	for i, sk := range k.secretKeys {
+		pubKeys[i] = sk.PublicKey()
+	}
+	return interop.DepositDataFromKeysWithWithdrawalCredentials(k.secretKeys, pubKeys, k.withdrawalCredentials)
+}
+
+func keysFromMnemonic(mnemonicFile string, numKeys uint64) ([]bls.SecretKey, []bls.PublicKey, error) {
+	expanded, err := file.ExpandPath(mnemonicFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := os.ReadFile(expanded) // #nosec G304
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read mnemonic file %s", expanded)
+	}
+	mnemonic := strings.Join(strings.Fields(string(b)), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, nil, bip39.ErrInvalidMnemonic
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+	secretKeys := make([]bls.SecretKey, numKeys)
+	withdrawalKeys := make([]bls.PublicKey, numKeys)
+	for i := uint64(0); i < numKeys; i++ {
+		sk, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf(validatingKeyPathTemplate, i))
+		if err != nil {
+			return nil, nil, err
+		}
+		secretKeys[i], err = bls.SecretKeyFromBytes(sk.Marshal())
+		if err != nil {
+			return nil, nil, err
+		}
+		wk, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf(withdrawalKeyPathTemplate, i))
+		if err != nil {
+			return nil, nil, err
+		}
+		withdrawalKeys[i], err = bls.PublicKeyFromBytes(wk.PublicKey().Marshal())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return secretKeys, withdrawalKeys, nil
+}
+
+// keysFromKeystores decrypts the EIP-2335 keystores of a directory, in the lexical order of their file names.
+func keysFromKeystores(dir, passwordFile string) ([]bls.SecretKey, error) {
+	password, err := readPasswordFile(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+	expanded, err := file.ExpandPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(expanded)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read keystores directory %s", expanded)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no keystore found in %s", expanded)
+	}
+	decryptor := keystorev4.New()
+	secretKeys := make([]bls.SecretKey, len(names))
+	for i, name := range names {
+		b, err := os.ReadFile(filepath.Join(expanded, name)) // #nosec G304
+		if err != nil {
+			return nil, err
+		}
+		ks := &keymanager.Keystore{}
+		if err := json.Unmarshal(b, ks); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal keystore %s", name)
+		}
+		secret, err := decryptor.Decrypt(ks.Crypto, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt keystore %s", name)
+		}
+		secretKeys[i], err = bls.SecretKeyFromBytes(secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid secret key in keystore %s", name)
+		}
+	}
+	return secretKeys, nil
+}
+
+// withdrawalCredentials returns 0x01 credentials for the validators with a withdrawal address,
+// and 0x00 credentials committing to their withdrawal key for the others.
+func withdrawalCredentials(f *generateGenesisOpts, withdrawalKeys []bls.PublicKey) ([][]byte, error) {
+	addresses := make([]string, len(withdrawalKeys))
+	if f.WithdrawalAddress != "" {
+		for i := range addresses {
+			addresses[i] = f.WithdrawalAddress
+		}
+	}
+	if f.WithdrawalAddressesFile != "" {
+		lines, err := readLines(f.WithdrawalAddressesFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(lines) > len(addresses) {
+			return nil, fmt.Errorf("%s has %d withdrawal addresses for %d validators", f.WithdrawalAddressesFile, len(lines), len(addresses))
+		}
+		copy(addresses, lines)
+	}
+	cfg := params.BeaconConfig()
+	creds := make([][]byte, len(withdrawalKeys))
+	for i, a := range addresses {
+		if a == "" {
+			h := hash.Hash(withdrawalKeys[i].Marshal())
+			creds[i] = append([]byte{cfg.BLSWithdrawalPrefixByte}, h[1:]...)
+			continue
+		}
+		if !common.IsHexAddress(a) {
+			return nil, fmt.Errorf("invalid withdrawal address %q for validator %d", a, i)
+		}
+		c := make([]byte, 12, 32)
+		c[0] = cfg.ETH1AddressWithdrawalPrefixByte
+		creds[i] = append(c, common.HexToAddress(a).Bytes()...)
+	}
+	return creds, nil
+}
+
+// writeKeystores writes the EIP-2335 keystores of the given validator keys, the first of which is the key of validator offset.
+func writeKeystores(dir string, secretKeys []bls.SecretKey, offset uint64, password string) error {
+	if err := file.MkdirAll(dir); err != nil {
+		return err
+	}
+	encryptor := keystorev4.New()
+	for i, sk := range secretKeys {
+		cryptoFields, err := encryptor.Encrypt(sk.Marshal(), password)
+		if err != nil {
+			return errors.Wrap(err, "could not encrypt validator key")
+		}
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return err
+		}
+		ks := &keymanager.Keystore{
+			Crypto:      cryptoFields,
+			ID:          id.String(),
+			Pubkey:      fmt.Sprintf("%x", sk.PublicKey().Marshal()),
+			Version:     encryptor.Version(),
+			Description: encryptor.Name(),
+		}
+		enc, err := json.MarshalIndent(ks, "", "\t")
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("keystore-%d.json", offset+uint64(i))
+		if err := file.WriteFile(filepath.Join(dir, name), enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPasswordFile(fPath string) (string, error) {
+	if fPath == "" {
+		return "", errors.New("a keystores password file is required")
+	}
+	expanded, err := file.ExpandPath(fPath)
+	if err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(expanded) // #nosec G304
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read password file %s", expanded)
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// readLines returns the lines of a file, with surrounding whitespace trimmed.
+func readLines(fPath string) ([]string, error) {
+	expanded, err := file.ExpandPath(fPath)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(expanded) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read %s", expanded)
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+	return lines, scanner.Err()
+}
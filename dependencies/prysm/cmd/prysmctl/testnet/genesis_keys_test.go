@@ -0,0 +1,86 @@
+package testnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	constant "github.com/prysmaticlabs/prysm/v4/validator/testing"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	fPath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(fPath, []byte(content), 0600))
+	return fPath
+}
+
+func Test_loadGenesisKeys_Mnemonic(t *testing.T) {
+	address := "0x123463a4b065722e99115d6c222f267d9cabb524"
+	f := &generateGenesisOpts{
+		NumValidators:           3,
+		MnemonicFile:            writeTestFile(t, "mnemonic.txt", constant.TestMnemonic+"\n"),
+		WithdrawalAddressesFile: writeTestFile(t, "addresses.txt", "\n"+address+"\n"),
+	}
+	keys, err := loadGenesisKeys(f)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(keys.secretKeys))
+	require.Equal(t, 3, len(keys.withdrawalCredentials))
+
+	again, err := loadGenesisKeys(f)
+	require.NoError(t, err)
+	cfg := params.BeaconConfig()
+	for i := range keys.secretKeys {
+		assert.DeepEqual(t, keys.secretKeys[i].Marshal(), again.secretKeys[i].Marshal())
+		assert.Equal(t, 32, len(keys.withdrawalCredentials[i]))
+	}
+	assert.Equal(t, cfg.BLSWithdrawalPrefixByte, keys.withdrawalCredentials[0][0])
+	assert.Equal(t, cfg.ETH1AddressWithdrawalPrefixByte, keys.withdrawalCredentials[1][0])
+	assert.Equal(t, address, fmt.Sprintf("%#x", keys.withdrawalCredentials[1][12:]))
+	assert.Equal(t, cfg.BLSWithdrawalPrefixByte, keys.withdrawalCredentials[2][0])
+	// 0x00 credentials commit to the withdrawal key, not to the validating key.
+	h := hash.Hash(keys.secretKeys[0].PublicKey().Marshal())
+	assert.NotEqual(t, fmt.Sprintf("%#x", h[1:]), fmt.Sprintf("%#x", keys.withdrawalCredentials[0][1:]))
+
+	_, _, err = keys.depositData()
+	require.NoError(t, err)
+
+	f.MnemonicFile = writeTestFile(t, "mnemonic.txt", "not a mnemonic")
+	_, err = loadGenesisKeys(f)
+	require.ErrorIs(t, err, bip39.ErrInvalidMnemonic)
+}
+
+func Test_loadGenesisKeys_Keystores(t *testing.T) {
+	keys, _, err := interop.DeterministicallyGenerateKeys(0, 2)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, writeKeystores(dir, keys, 0, "password"))
+
+	f := &generateGenesisOpts{
+		KeystoresDir:          dir,
+		KeystoresPasswordFile: writeTestFile(t, "password.txt", "password\n"),
+		WithdrawalAddress:     "0x123463a4b065722e99115d6c222f267d9cabb524",
+	}
+	loaded, err := loadGenesisKeys(f)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(loaded.secretKeys))
+	for i := range keys {
+		assert.DeepEqual(t, keys[i].Marshal(), loaded.secretKeys[i].Marshal())
+		assert.Equal(t, params.BeaconConfig().ETH1AddressWithdrawalPrefixByte, loaded.withdrawalCredentials[i][0])
+	}
+
+	f.NumValidators = 3
+	_, err = loadGenesisKeys(f)
+	require.ErrorContains(t, "expected 3 validators", err)
+
+	f.NumValidators = 0
+	f.KeystoresPasswordFile = writeTestFile(t, "password.txt", "wrong")
+	_, err = loadGenesisKeys(f)
+	require.ErrorContains(t, "could not decrypt keystore", err)
+}
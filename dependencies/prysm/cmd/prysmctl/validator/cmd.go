@@ -70,6 +70,23 @@ var (
 		Aliases: []string{"t"},
 		Usage:   "keymanager API bearer token, note: currently required but may be removed in the future, this is the same token as the web ui token.",
 	}
+
+	SlashingProtectionFilesFlag = &cli.StringSliceFlag{
+		Name:    "slashing-protection-files",
+		Aliases: []string{"spf"},
+		Usage:   "comma-separated paths to EIP-3076 slashing protection JSON files",
+	}
+
+	SlashingProtectionOutputFlag = &cli.StringFlag{
+		Name:    "output-slashing-protection-path",
+		Aliases: []string{"spo"},
+		Usage:   "path to write the resulting EIP-3076 slashing protection JSON file to",
+	}
+
+	GenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "genesis-validators-root",
+		Usage: "hex genesis validators root of the chain to verify slashing protection JSON against, instead of querying the beacon node",
+	}
 )
 
 var Commands = []*cli.Command{
@@ -188,6 +205,68 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			{
+				Name:    "slashing-protection",
+				Aliases: []string{"sp"},
+				Usage:   "Merge, verify and minify EIP-3076 slashing protection JSON files",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "merge",
+						Usage: "Merges several slashing protection JSON files into one, warning about slashable history across them",
+						Flags: []cli.Flag{
+							SlashingProtectionFilesFlag,
+							SlashingProtectionOutputFlag,
+							cmd.ConfigFileFlag,
+						},
+						Before: func(cliCtx *cli.Context) error {
+							return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+						},
+						Action: func(cliCtx *cli.Context) error {
+							if err := mergeSlashingProtection(cliCtx); err != nil {
+								log.WithError(err).Fatal("Could not merge slashing protection JSON files")
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "verify",
+						Usage: "Verifies slashing protection JSON files have no slashable history and are on the chain of the beacon node",
+						Flags: []cli.Flag{
+							SlashingProtectionFilesFlag,
+							BeaconHostFlag,
+							GenesisValidatorsRootFlag,
+							cmd.ConfigFileFlag,
+						},
+						Before: func(cliCtx *cli.Context) error {
+							return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+						},
+						Action: func(cliCtx *cli.Context) error {
+							if err := verifySlashingProtection(cliCtx); err != nil {
+								log.WithError(err).Fatal("Could not verify slashing protection JSON files")
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "minify",
+						Usage: "Reduces slashing protection JSON files to the highest signed block and attestation of each public key",
+						Flags: []cli.Flag{
+							SlashingProtectionFilesFlag,
+							SlashingProtectionOutputFlag,
+							cmd.ConfigFileFlag,
+						},
+						Before: func(cliCtx *cli.Context) error {
+							return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+						},
+						Action: func(cliCtx *cli.Context) error {
+							if err := minifySlashingProtection(cliCtx); err != nil {
+								log.WithError(err).Fatal("Could not minify slashing protection JSON files")
+							}
+							return nil
+						},
+					},
+				},
+			},
 		},
 	},
 }
@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	history "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"go.opencensus.io/trace"
+)
+
+// mergeSlashingProtection merges the slashing protection JSON files into a single one, warning
+// about slashable history found across them.
+func mergeSlashingProtection(c *cli.Context) error {
+	interchanges, err := readSlashingProtectionFiles(c)
+	if err != nil {
+		return err
+	}
+	merged, err := history.MergeStandardProtectionJSON(interchanges...)
+	if err != nil {
+		return errors.Wrap(err, "could not merge slashing protection JSON files")
+	}
+	slashable, err := history.VerifyStandardProtectionJSON(merged, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not verify merged slashing protection JSON")
+	}
+	logSlashableHistory(slashable)
+	log.Infof("Merged slashing protection history of %d public keys", len(merged.Data))
+	return writeSlashingProtectionFile(c, merged)
+}
+
+// verifySlashingProtection checks the slashing protection JSON files for slashable history
+// across them, and that they are on the chain of the beacon node or genesis validators root.
+func verifySlashingProtection(c *cli.Context) error {
+	ctx, span := trace.StartSpan(c.Context, "validator.verifySlashingProtection")
+	defer span.End()
+	interchanges, err := readSlashingProtectionFiles(c)
+	if err != nil {
+		return err
+	}
+	merged, err := history.MergeStandardProtectionJSON(interchanges...)
+	if err != nil {
+		return errors.Wrap(err, "could not merge slashing protection JSON files")
+	}
+
+	var gvr []byte
+	if c.IsSet(GenesisValidatorsRootFlag.Name) {
+		root, err := history.RootFromHex(c.String(GenesisValidatorsRootFlag.Name))
+		if err != nil {
+			return errors.Wrapf(err, "invalid --%s flag value", GenesisValidatorsRootFlag.Name)
+		}
+		gvr = root[:]
+	} else {
+		client, err := beacon.NewClient(c.String(BeaconHostFlag.Name))
+		if err != nil {
+			return err
+		}
+		genesis, err := client.GetGenesis(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve genesis validators root from beacon node")
+		}
+		gvr, err = bytesutil.DecodeHexWithLength(genesis.GenesisValidatorsRoot, 32)
+		if err != nil {
+			return errors.Wrap(err, "beacon node returned an invalid genesis validators root")
+		}
+	}
+
+	slashable, err := history.VerifyStandardProtectionJSON(merged, gvr)
+	if err != nil {
+		return errors.Wrap(err, "could not verify slashing protection JSON")
+	}
+	if len(slashable) > 0 {
+		logSlashableHistory(slashable)
+		return fmt.Errorf("found %d slashable histories in slashing protection JSON", len(slashable))
+	}
+	log.Infof("Slashing protection history of %d public keys is not slashable and on chain %#x", len(merged.Data), gvr)
+	return nil
+}
+
+// minifySlashingProtection reduces the slashing protection JSON files to the highest signed
+// block and attestation of each public key.
+func minifySlashingProtection(c *cli.Context) error {
+	interchanges, err := readSlashingProtectionFiles(c)
+	if err != nil {
+		return err
+	}
+	merged, err := history.MergeStandardProtectionJSON(interchanges...)
+	if err != nil {
+		return errors.Wrap(err, "could not merge slashing protection JSON files")
+	}
+	slashable, err := history.VerifyStandardProtectionJSON(merged, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not verify slashing protection JSON")
+	}
+	logSlashableHistory(slashable)
+	minified, err := history.MinifyStandardProtectionJSON(merged)
+	if err != nil {
+		return errors.Wrap(err, "could not minify slashing protection JSON")
+	}
+	log.Infof("Minified slashing protection history of %d public keys", len(minified.Data))
+	return writeSlashingProtectionFile(c, minified)
+}
+
+func readSlashingProtectionFiles(c *cli.Context) ([]*format.EIPSlashingProtectionFormat, error) {
+	paths := c.StringSlice(SlashingProtectionFilesFlag.Name)
+	if len(paths) == 0 {
+		return nil, errNoFlag(SlashingProtectionFilesFlag.Name)
+	}
+	interchanges := make([]*format.EIPSlashingProtectionFormat, 0, len(paths))
+	for _, path := range paths {
+		enc, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read slashing protection JSON file %s", path)
+		}
+		interchangeJSON := &format.EIPSlashingProtectionFormat{}
+		if err := json.Unmarshal(enc, interchangeJSON); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal slashing protection JSON file %s", path)
+		}
+		interchanges = append(interchanges, interchangeJSON)
+	}
+	return interchanges, nil
+}
+
+func writeSlashingProtectionFile(c *cli.Context, interchangeJSON *format.EIPSlashingProtectionFormat) error {
+	if !c.IsSet(SlashingProtectionOutputFlag.Name) {
+		return errNoFlag(SlashingProtectionOutputFlag.Name)
+	}
+	enc, err := json.MarshalIndent(interchangeJSON, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal slashing protection JSON")
+	}
+	outputPath := c.String(SlashingProtectionOutputFlag.Name)
+	if err := file.WriteFile(outputPath, enc); err != nil {
+		return errors.Wrapf(err, "could not write slashing protection JSON file %s", outputPath)
+	}
+	log.Infof("Wrote slashing protection JSON file %s", outputPath)
+	return nil
+}
+
+func logSlashableHistory(slashable []*history.SlashableHistory) {
+	for _, s := range slashable {
+		log.WithField("pubkey", fmt.Sprintf("%#x", s.PubKey)).Warn("Slashable history: " + s.Reason)
+	}
+}
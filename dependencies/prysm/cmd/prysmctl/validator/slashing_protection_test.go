@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	testGenesisValidatorsRoot = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	testPubKey                = "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+)
+
+func writeTestSlashingProtectionFile(t *testing.T, data *format.ProtectionData) string {
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: []*format.ProtectionData{data}}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = testGenesisValidatorsRoot
+	enc, err := json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "slashing_protection.json")
+	require.NoError(t, os.WriteFile(path, enc, 0600))
+	return path
+}
+
+func slashingProtectionCliContext(t *testing.T, files []string, output, beaconHost, gvr string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	require.NoError(t, SlashingProtectionFilesFlag.Apply(set))
+	for _, f := range files {
+		require.NoError(t, set.Set(SlashingProtectionFilesFlag.Name, f))
+	}
+	set.String(SlashingProtectionOutputFlag.Name, "", "")
+	set.String(BeaconHostFlag.Name, "", "")
+	set.String(GenesisValidatorsRootFlag.Name, "", "")
+	if output != "" {
+		require.NoError(t, set.Set(SlashingProtectionOutputFlag.Name, output))
+	}
+	require.NoError(t, set.Set(BeaconHostFlag.Name, beaconHost))
+	if gvr != "" {
+		require.NoError(t, set.Set(GenesisValidatorsRootFlag.Name, gvr))
+	}
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestMergeAndMinifySlashingProtection(t *testing.T) {
+	hook := logtest.NewGlobal()
+	first := writeTestSlashingProtectionFile(t, &format.ProtectionData{
+		Pubkey:             testPubKey,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "1"}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+	})
+	second := writeTestSlashingProtectionFile(t, &format.ProtectionData{
+		Pubkey:             testPubKey,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "4"}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "0", TargetEpoch: "3"}},
+	})
+
+	output := filepath.Join(t.TempDir(), "merged.json")
+	require.NoError(t, mergeSlashingProtection(slashingProtectionCliContext(t, []string{first, second}, output, "", "")))
+	enc, err := os.ReadFile(output)
+	require.NoError(t, err)
+	merged := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, merged))
+	require.Equal(t, 1, len(merged.Data))
+	assert.Equal(t, 2, len(merged.Data[0].SignedBlocks))
+	assert.Equal(t, 2, len(merged.Data[0].SignedAttestations))
+	// The attestations of both files surround each other.
+	assert.LogsContain(t, hook, "Slashable history: surround vote")
+
+	output = filepath.Join(t.TempDir(), "minified.json")
+	require.NoError(t, minifySlashingProtection(slashingProtectionCliContext(t, []string{first, second}, output, "", "")))
+	enc, err = os.ReadFile(output)
+	require.NoError(t, err)
+	minified := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, minified))
+	require.DeepEqual(t, []*format.ProtectionData{{
+		Pubkey:             testPubKey,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "4"}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "3"}},
+	}}, minified.Data)
+
+	err = mergeSlashingProtection(slashingProtectionCliContext(t, []string{first, second}, "", "", ""))
+	require.ErrorContains(t, "no --output-slashing-protection-path flag value was provided", err)
+	err = mergeSlashingProtection(slashingProtectionCliContext(t, nil, output, "", ""))
+	require.ErrorContains(t, "no --slashing-protection-files flag value was provided", err)
+}
+
+func TestVerifySlashingProtection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI == "/eth/v1/beacon/genesis" {
+			require.NoError(t, json.NewEncoder(w).Encode(&beacon.GetGenesisResponse{
+				Data: &beacon.Genesis{
+					GenesisTime:           "1606824023",
+					GenesisValidatorsRoot: testGenesisValidatorsRoot,
+					GenesisForkVersion:    "0x00000000",
+				},
+			}))
+		}
+	}))
+	defer srv.Close()
+
+	hook := logtest.NewGlobal()
+	path := writeTestSlashingProtectionFile(t, &format.ProtectionData{
+		Pubkey:             testPubKey,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "1"}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+	})
+	require.NoError(t, verifySlashingProtection(slashingProtectionCliContext(t, []string{path}, "", srv.URL, "")))
+	assert.LogsContain(t, hook, "is not slashable")
+
+	otherRoot := fmt.Sprintf("%#x", [32]byte{1})
+	err := verifySlashingProtection(slashingProtectionCliContext(t, []string{path}, "", srv.URL, otherRoot))
+	require.ErrorContains(t, "does not match", err)
+
+	slashable := writeTestSlashingProtectionFile(t, &format.ProtectionData{
+		Pubkey:       testPubKey,
+		SignedBlocks: []*format.SignedBlock{{Slot: "1", SigningRoot: otherRoot}},
+	})
+	err = verifySlashingProtection(slashingProtectionCliContext(t, []string{path, slashable}, "", "", testGenesisValidatorsRoot))
+	require.ErrorContains(t, "found 1 slashable histories", err)
+	assert.LogsContain(t, hook, "Slashable history: double proposal at slot 1")
+}
@@ -83,6 +83,13 @@ var appFlags = []cli.Flag{
 	flags.EnableBuilderFlag,
 	flags.BuilderGasLimitFlag,
 	flags.ValidatorsRegistrationBatchSizeFlag,
+	flags.EnableDistributed,
+	flags.PostgresDatabaseURLFlag,
+	flags.LeaseFileFlag,
+	flags.LeaseEtcdEndpointFlag,
+	flags.LeaseEtcdKeyFlag,
+	flags.LeaseIDFlag,
+	flags.LeaseTTLFlag,
 	////////////////////
 	cmd.DisableMonitoringFlag,
 	cmd.MonitoringHostFlag,
@@ -122,6 +122,13 @@ var appHelpFlagGroups = []flagGroup{
 			flags.EnableBuilderFlag,
 			flags.BuilderGasLimitFlag,
 			flags.ValidatorsRegistrationBatchSizeFlag,
+			flags.EnableDistributed,
+			flags.PostgresDatabaseURLFlag,
+			flags.LeaseFileFlag,
+			flags.LeaseEtcdEndpointFlag,
+			flags.LeaseEtcdKeyFlag,
+			flags.LeaseIDFlag,
+			flags.LeaseTTLFlag,
 		},
 	},
 	{
@@ -58,9 +58,11 @@ type Flags struct {
 
 	EnableSlasher                   bool // Enable slasher in the beacon node runtime.
 	EnableSlashingProtectionPruning bool // EnableSlashingProtectionPruning for the validator client.
+	EnableMinimalSlashingProtection bool // EnableMinimalSlashingProtection keeps only the slashing protection watermarks in the validator client.
 
-	SaveFullExecutionPayloads bool // Save full beacon blocks with execution payloads in the database.
-	EnableStartOptimistic     bool // EnableStartOptimistic treats every block as optimistic at startup.
+	SaveFullExecutionPayloads  bool // Save full beacon blocks with execution payloads in the database.
+	IndexAttestationInclusions bool // IndexAttestationInclusions indexes the blocks including the attestations of every validator.
+	EnableStartOptimistic      bool // EnableStartOptimistic treats every block as optimistic at startup.
 
 	DisableResourceManager     bool // Disables running the node with libp2p's resource manager.
 	DisableStakinContractCheck bool // Disables check for deposit contract when proposing blocks
@@ -215,6 +217,10 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(SaveFullExecutionPayloads)
 		cfg.SaveFullExecutionPayloads = true
 	}
+	if ctx.Bool(indexAttestationInclusions.Name) {
+		logEnabled(indexAttestationInclusions)
+		cfg.IndexAttestationInclusions = true
+	}
 	if ctx.Bool(enableStartupOptimistic.Name) {
 		logEnabled(enableStartupOptimistic)
 		cfg.EnableStartOptimistic = true
@@ -270,6 +276,10 @@ func ConfigureValidator(ctx *cli.Context) error {
 		logEnabled(enableSlashingProtectionPruning)
 		cfg.EnableSlashingProtectionPruning = true
 	}
+	if ctx.Bool(EnableMinimalSlashingProtection.Name) {
+		logEnabled(EnableMinimalSlashingProtection)
+		cfg.EnableMinimalSlashingProtection = true
+	}
 	if ctx.Bool(enableDoppelGangerProtection.Name) {
 		logEnabled(enableDoppelGangerProtection)
 		cfg.EnableDoppelGanger = true
@@ -95,6 +95,13 @@ var (
 		Name:  "enable-slashing-protection-history-pruning",
 		Usage: "Enables the pruning of the validator client's slashing protection database.",
 	}
+	// EnableMinimalSlashingProtection is exported for the slashing protection history commands.
+	EnableMinimalSlashingProtection = &cli.BoolFlag{
+		Name: "enable-minimal-slashing-protection",
+		Usage: "Keeps only the highest signed source and target epochs and proposal slot of every key " +
+			"in the validator client's slashing protection database, as described in EIP-3076. " +
+			"An existing slashing protection history is converted when the database is opened.",
+	}
 	enableDoppelGangerProtection = &cli.BoolFlag{
 		Name: "enable-doppelganger",
 		Usage: `Enables the validator to perform a doppelganger check.
@@ -125,6 +132,11 @@ var (
 		Name:  "save-full-execution-payloads",
 		Usage: "Saves beacon blocks with full execution payloads instead of execution payload headers in the database.",
 	}
+	indexAttestationInclusions = &cli.BoolFlag{
+		Name: "index-attestation-inclusions",
+		Usage: "Indexes, for every validator, the blocks including aggregated attestations it took part in, " +
+			"which are served by the /prysm/v1/validators/{validator_index}/attestation_inclusions endpoint.",
+	}
 	EnableBeaconRESTApi = &cli.BoolFlag{
 		Name:  "enable-beacon-rest-api",
 		Usage: "(Experimental): Enables of the beacon REST API when querying a beacon node.",
@@ -173,6 +185,7 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	dynamicKeyReloadDebounceInterval,
 	attestTimely,
 	enableSlashingProtectionPruning,
+	EnableMinimalSlashingProtection,
 	enableDoppelGangerProtection,
 	EnableBeaconRESTApi,
 }...)
@@ -198,6 +211,7 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	enableHistoricalSpaceRepresentation,
 	disableStakinContractCheck,
 	SaveFullExecutionPayloads,
+	indexAttestationInclusions,
 	enableStartupOptimistic,
 	enableFullSSZDataLogging,
 	disableVerboseSigVerification,
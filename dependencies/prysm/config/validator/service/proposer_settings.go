@@ -53,10 +53,12 @@ func ToSettings(ps *validatorpb.ProposerSettingsPayload) (*ProposerSettings, err
 
 // BuilderConfig is the struct representation of the JSON config file set in the validator through the CLI.
 // GasLimit is a number set to help the network decide on the maximum gas in each block.
+// MinBidGwei is the minimum value of a builder bid, in gwei, for it to be used instead of the local execution payload.
 type BuilderConfig struct {
-	Enabled  bool             `json:"enabled" yaml:"enabled"`
-	GasLimit validator.Uint64 `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
-	Relays   []string         `json:"relays,omitempty" yaml:"relays,omitempty"`
+	Enabled    bool             `json:"enabled" yaml:"enabled"`
+	GasLimit   validator.Uint64 `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
+	Relays     []string         `json:"relays,omitempty" yaml:"relays,omitempty"`
+	MinBidGwei validator.Uint64 `json:"min_bid_gwei,omitempty" yaml:"min_bid_gwei,omitempty"`
 }
 
 // ToBuilderConfig converts protobuf to a builder config used in inmemory storage
@@ -65,8 +67,9 @@ func ToBuilderConfig(from *validatorpb.BuilderConfig) *BuilderConfig {
 		return nil
 	}
 	config := &BuilderConfig{
-		Enabled:  from.Enabled,
-		GasLimit: from.GasLimit,
+		Enabled:    from.Enabled,
+		GasLimit:   from.GasLimit,
+		MinBidGwei: from.MinBidGwei,
 	}
 	if from.Relays != nil {
 		relays := make([]string, len(from.Relays))
@@ -173,6 +176,7 @@ func (bc *BuilderConfig) Clone() *BuilderConfig {
 	config := &BuilderConfig{}
 	config.Enabled = bc.Enabled
 	config.GasLimit = bc.GasLimit
+	config.MinBidGwei = bc.MinBidGwei
 	var relays []string
 	if bc.Relays != nil {
 		relays = make([]string, len(bc.Relays))
@@ -196,6 +200,7 @@ func (bc *BuilderConfig) ToPayload() *validatorpb.BuilderConfig {
 		config.Relays = relays
 	}
 	config.GasLimit = bc.GasLimit
+	config.MinBidGwei = bc.MinBidGwei
 	return config
 }
 
@@ -23,9 +23,10 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 					FeeRecipient: common.HexToAddress("0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"),
 				},
 				BuilderConfig: &BuilderConfig{
-					Enabled:  true,
-					GasLimit: validator.Uint64(40000000),
-					Relays:   []string{"https://example-relay.com"},
+					Enabled:    true,
+					GasLimit:   validator.Uint64(40000000),
+					Relays:     []string{"https://example-relay.com"},
+					MinBidGwei: validator.Uint64(100000000),
 				},
 			},
 		},
@@ -34,9 +35,10 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 				FeeRecipient: common.HexToAddress("0x6e35733c5af9B61374A128e6F85f553aF09ff89A"),
 			},
 			BuilderConfig: &BuilderConfig{
-				Enabled:  false,
-				GasLimit: validator.Uint64(params.BeaconConfig().DefaultBuilderGasLimit),
-				Relays:   []string{"https://example-relay.com"},
+				Enabled:    false,
+				GasLimit:   validator.Uint64(params.BeaconConfig().DefaultBuilderGasLimit),
+				Relays:     []string{"https://example-relay.com"},
+				MinBidGwei: validator.Uint64(50000000),
 			},
 		},
 	}
@@ -65,6 +67,7 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 		require.DeepEqual(t, config.Relays, clone.Relays)
 		require.Equal(t, config.Enabled, clone.Enabled)
 		require.Equal(t, config.GasLimit, clone.GasLimit)
+		require.Equal(t, config.MinBidGwei, clone.MinBidGwei)
 	})
 	t.Run("To Payload and ToSettings", func(t *testing.T) {
 		payload := settings.ToPayload()
@@ -95,6 +98,7 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 		require.Equal(t, option.FeeRecipientConfig.FeeRecipient.Hex(), noption.FeeRecipientConfig.FeeRecipient.Hex())
 		require.Equal(t, option.BuilderConfig.GasLimit, option.BuilderConfig.GasLimit)
 		require.Equal(t, option.BuilderConfig.Enabled, option.BuilderConfig.Enabled)
+		require.Equal(t, option.BuilderConfig.MinBidGwei, noption.BuilderConfig.MinBidGwei)
 
 	})
 }
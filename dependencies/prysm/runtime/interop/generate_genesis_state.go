@@ -161,18 +161,47 @@ func depositDataFromKeys(privKeys []bls.SecretKey, pubKeys []bls.PublicKey, numO
 	return depositDataItems, dataRoots, nil
 }
 
+// DepositDataFromKeysWithWithdrawalCredentials generates a list of deposit data items from a set of BLS validator keys,
+// using the given withdrawal credentials for each key.
+func DepositDataFromKeysWithWithdrawalCredentials(privKeys []bls.SecretKey, pubKeys []bls.PublicKey, creds [][]byte) ([]*ethpb.Deposit_Data, [][]byte, error) {
+	if len(privKeys) != len(pubKeys) || len(privKeys) != len(creds) {
+		return nil, nil, errors.Errorf("got %d private keys, %d public keys and %d withdrawal credentials", len(privKeys), len(pubKeys), len(creds))
+	}
+	dataRoots := make([][]byte, len(privKeys))
+	depositDataItems := make([]*ethpb.Deposit_Data, len(privKeys))
+	for i := 0; i < len(privKeys); i++ {
+		data, err := signDepositData(privKeys[i], pubKeys[i], creds[i])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not create deposit data for key: %#x", pubKeys[i].Marshal())
+		}
+		h, err := data.HashTreeRoot()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not hash tree root deposit data item")
+		}
+		dataRoots[i] = h[:]
+		depositDataItems[i] = data
+	}
+	return depositDataItems, dataRoots, nil
+}
+
 // Generates a deposit data item from BLS keys and signs the hash tree root of the data.
 func createDepositData(privKey bls.SecretKey, pubKey bls.PublicKey, withExecCreds bool) (*ethpb.Deposit_Data, error) {
-	depositMessage := &ethpb.DepositMessage{
-		PublicKey:             pubKey.Marshal(),
-		WithdrawalCredentials: withdrawalCredentialsHash(pubKey.Marshal()),
-		Amount:                params.BeaconConfig().MaxEffectiveBalance,
-	}
+	creds := withdrawalCredentialsHash(pubKey.Marshal())
 	if withExecCreds {
 		newCredentials := make([]byte, 12)
 		newCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
 		execAddr := bytesutil.ToBytes20(pubKey.Marshal())
-		depositMessage.WithdrawalCredentials = append(newCredentials, execAddr[:]...)
+		creds = append(newCredentials, execAddr[:]...)
+	}
+	return signDepositData(privKey, pubKey, creds)
+}
+
+// Signs the deposit message of a full deposit with the given withdrawal credentials.
+func signDepositData(privKey bls.SecretKey, pubKey bls.PublicKey, creds []byte) (*ethpb.Deposit_Data, error) {
+	depositMessage := &ethpb.DepositMessage{
+		PublicKey:             pubKey.Marshal(),
+		WithdrawalCredentials: creds,
+		Amount:                params.BeaconConfig().MaxEffectiveBalance,
 	}
 	sr, err := depositMessage.HashTreeRoot()
 	if err != nil {
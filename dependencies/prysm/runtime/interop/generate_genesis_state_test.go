@@ -6,7 +6,9 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -34,3 +36,35 @@ func TestGenerateGenesisState(t *testing.T) {
 	assert.Equal(t, want, genesisState.NumValidators())
 	assert.Equal(t, uint64(0), genesisState.GenesisTime())
 }
+
+func TestDepositDataFromKeysWithWithdrawalCredentials(t *testing.T) {
+	privKeys, pubKeys, err := interop.DeterministicallyGenerateKeys(0 /*startIndex*/, 2)
+	require.NoError(t, err)
+	creds := [][]byte{
+		append([]byte{params.BeaconConfig().BLSWithdrawalPrefixByte}, bytesutil.PadTo([]byte{'a'}, 31)...),
+		append([]byte{params.BeaconConfig().ETH1AddressWithdrawalPrefixByte}, bytesutil.PadTo([]byte{'b'}, 31)...),
+	}
+	_, _, err = interop.DepositDataFromKeysWithWithdrawalCredentials(privKeys, pubKeys, creds[:1])
+	require.ErrorContains(t, "got 2 private keys, 2 public keys and 1 withdrawal credentials", err)
+
+	depositDataItems, depositDataRoots, err := interop.DepositDataFromKeysWithWithdrawalCredentials(privKeys, pubKeys, creds)
+	require.NoError(t, err)
+	tr, err := trie.GenerateTrieFromItems(depositDataRoots, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	deposits, err := interop.GenerateDepositsFromData(depositDataItems, tr)
+	require.NoError(t, err)
+	root, err := tr.HashTreeRoot()
+	require.NoError(t, err)
+	genesisState, err := transition.GenesisBeaconState(context.Background(), deposits, 0, &eth.Eth1Data{
+		DepositRoot:  root[:],
+		DepositCount: uint64(len(deposits)),
+	})
+	require.NoError(t, err)
+	// Validators with invalid deposit signatures would be missing from the state.
+	require.Equal(t, 2, genesisState.NumValidators())
+	for i, c := range creds {
+		v, err := genesisState.ValidatorAtIndexReadOnly(primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		assert.DeepEqual(t, c, v.WithdrawalCredentials())
+	}
+}
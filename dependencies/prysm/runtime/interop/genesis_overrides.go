@@ -0,0 +1,118 @@
+package interop
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// GenesisOverrides patches the validator registry and balances of a genesis state built from deposits.
+type GenesisOverrides struct {
+	Validators []*ValidatorOverride `json:"validators"`
+}
+
+// ValidatorOverride patches the validators with indices in [Index, Index+Count). Unset fields are left as
+// they result from the genesis deposits.
+type ValidatorOverride struct {
+	Index uint64 `json:"index"`
+	// Count of validators patched, defaulting to 1.
+	Count uint64 `json:"count"`
+	// Balance of the validators, in Gwei. Their effective balance is derived from it unless set.
+	Balance          *uint64 `json:"balance"`
+	EffectiveBalance *uint64 `json:"effective_balance"`
+	// Slashed validators are exiting and accounted for in the slashings of the genesis epoch.
+	Slashed bool `json:"slashed"`
+	// ExitEpoch defaults to the earliest exit epoch for slashed validators. Unless set, the withdrawable
+	// epoch is derived from it as if the validators had initiated their exit or been slashed at genesis.
+	ExitEpoch         *primitives.Epoch `json:"exit_epoch"`
+	WithdrawableEpoch *primitives.Epoch `json:"withdrawable_epoch"`
+	// WithdrawalAddress replaces the withdrawal credentials of the validators with 0x01 credentials.
+	WithdrawalAddress string `json:"withdrawal_address"`
+}
+
+// WithGenesisOverrides applies the given overrides to the genesis validators, before the genesis validators root,
+// sync committees and other fields derived from the registry are computed.
+func WithGenesisOverrides(o *GenesisOverrides) PremineGenesisOpt {
+	return func(cfg *PremineGenesisConfig) {
+		cfg.overrides = o
+	}
+}
+
+func (s *PremineGenesisConfig) applyOverrides(g state.BeaconState) error {
+	if s.overrides == nil {
+		return nil
+	}
+	cfg := params.BeaconConfig()
+	numVals := uint64(g.NumValidators())
+	for _, o := range s.overrides.Validators {
+		count := o.Count
+		if count == 0 {
+			count = 1
+		}
+		if o.Index+count > numVals {
+			return errors.Errorf("override of validators [%d, %d) out of the %d genesis validators", o.Index, o.Index+count, numVals)
+		}
+		if o.WithdrawalAddress != "" && !common.IsHexAddress(o.WithdrawalAddress) {
+			return errors.Errorf("invalid withdrawal address %q", o.WithdrawalAddress)
+		}
+		for i := o.Index; i < o.Index+count; i++ {
+			idx := primitives.ValidatorIndex(i)
+			v, err := g.ValidatorAtIndex(idx)
+			if err != nil {
+				return err
+			}
+			if o.Balance != nil {
+				if err := g.UpdateBalancesAtIndex(idx, *o.Balance); err != nil {
+					return err
+				}
+				v.EffectiveBalance = *o.Balance - *o.Balance%cfg.EffectiveBalanceIncrement
+				if v.EffectiveBalance > cfg.MaxEffectiveBalance {
+					v.EffectiveBalance = cfg.MaxEffectiveBalance
+				}
+			}
+			if o.EffectiveBalance != nil {
+				v.EffectiveBalance = *o.EffectiveBalance
+			}
+			if o.Slashed {
+				v.Slashed = true
+				v.ExitEpoch = helpers.ActivationExitEpoch(0)
+			}
+			if o.ExitEpoch != nil {
+				v.ExitEpoch = *o.ExitEpoch
+			}
+			if (o.Slashed || o.ExitEpoch != nil) && v.ExitEpoch != cfg.FarFutureEpoch {
+				// As in initiate_validator_exit and slash_validator at epoch 0.
+				v.WithdrawableEpoch = v.ExitEpoch + cfg.MinValidatorWithdrawabilityDelay
+				if o.Slashed && v.WithdrawableEpoch < cfg.EpochsPerSlashingsVector {
+					v.WithdrawableEpoch = cfg.EpochsPerSlashingsVector
+				}
+			}
+			if o.WithdrawableEpoch != nil {
+				v.WithdrawableEpoch = *o.WithdrawableEpoch
+			}
+			if o.WithdrawalAddress != "" {
+				creds := make([]byte, 12, 32)
+				creds[0] = cfg.ETH1AddressWithdrawalPrefixByte
+				v.WithdrawalCredentials = append(creds, common.HexToAddress(o.WithdrawalAddress).Bytes()...)
+			}
+			if err := g.UpdateValidatorAtIndex(idx, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// genesisSlashings returns the slashings vector of the genesis state, accounting for the validators slashed by the overrides.
+func genesisSlashings(g state.ReadOnlyBeaconState) []uint64 {
+	slashings := make([]uint64, params.BeaconConfig().EpochsPerSlashingsVector)
+	for _, v := range g.Validators() {
+		if v.Slashed {
+			slashings[0] += v.EffectiveBalance
+		}
+	}
+	return slashings
+}
@@ -0,0 +1,70 @@
+package interop_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestNewPreminedGenesis_Overrides(t *testing.T) {
+	cfg := params.BeaconConfig()
+	gb := interop.GethTestnetGenesis(0, cfg).ToBlock()
+	balance := uint64(17_500_000_000)
+	exitEpoch := primitives.Epoch(10)
+	address := "0x123463a4b065722e99115d6c222f267d9cabb524"
+	overrides := &interop.GenesisOverrides{
+		Validators: []*interop.ValidatorOverride{
+			{Index: 0, Count: 2, Balance: &balance},
+			{Index: 2, Slashed: true},
+			{Index: 3, ExitEpoch: &exitEpoch, WithdrawalAddress: address},
+		},
+	}
+	st, err := interop.NewPreminedGenesis(context.Background(), 0, 8, 0, version.Capella, gb, interop.WithGenesisOverrides(overrides))
+	require.NoError(t, err)
+
+	for i := primitives.ValidatorIndex(0); i < 2; i++ {
+		b, err := st.BalanceAtIndex(i)
+		require.NoError(t, err)
+		assert.Equal(t, balance, b)
+		v, err := st.ValidatorAtIndexReadOnly(i)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(17_000_000_000), v.EffectiveBalance())
+	}
+
+	slashed, err := st.ValidatorAtIndexReadOnly(2)
+	require.NoError(t, err)
+	assert.Equal(t, true, slashed.Slashed())
+	assert.Equal(t, helpers.ActivationExitEpoch(0), slashed.ExitEpoch())
+	assert.Equal(t, cfg.EpochsPerSlashingsVector, slashed.WithdrawableEpoch())
+	assert.Equal(t, cfg.MaxEffectiveBalance, st.Slashings()[0])
+
+	exiting, err := st.ValidatorAtIndexReadOnly(3)
+	require.NoError(t, err)
+	assert.Equal(t, false, exiting.Slashed())
+	assert.Equal(t, exitEpoch, exiting.ExitEpoch())
+	assert.Equal(t, exitEpoch+cfg.MinValidatorWithdrawabilityDelay, exiting.WithdrawableEpoch())
+	creds := exiting.WithdrawalCredentials()
+	assert.Equal(t, cfg.ETH1AddressWithdrawalPrefixByte, creds[0])
+	assert.DeepEqual(t, common.HexToAddress(address).Bytes(), creds[12:])
+
+	// Fields derived from the registry are computed after the overrides.
+	root, err := stateutil.ValidatorRegistryRoot(st.Validators())
+	require.NoError(t, err)
+	assert.DeepEqual(t, root[:], st.GenesisValidatorsRoot())
+	header, err := st.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	assert.DeepEqual(t, gb.Hash().Bytes(), header.BlockHash())
+
+	overrides.Validators = append(overrides.Validators, &interop.ValidatorOverride{Index: 7, Count: 2})
+	_, err = interop.NewPreminedGenesis(context.Background(), 0, 8, 0, version.Capella, gb, interop.WithGenesisOverrides(overrides))
+	require.ErrorContains(t, "override of validators [7, 9) out of the 8 genesis validators", err)
+}
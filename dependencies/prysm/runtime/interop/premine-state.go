@@ -33,6 +33,7 @@ type PremineGenesisConfig struct {
 	Version         int          // as in "github.com/prysmaticlabs/prysm/v4/runtime/version"
 	GB              *types.Block // geth genesis block
 	depositEntries  *depositEntries
+	overrides       *GenesisOverrides
 }
 
 type depositEntries struct {
@@ -80,6 +81,9 @@ func (s *PremineGenesisConfig) prepare(ctx context.Context) (state.BeaconState,
 	if err = s.processDeposits(ctx, st); err != nil {
 		return nil, err
 	}
+	if err = s.applyOverrides(st); err != nil {
+		return nil, errors.Wrap(err, "could not apply genesis overrides")
+	}
 	if err = s.populate(st); err != nil {
 		return nil, err
 	}
@@ -288,7 +292,7 @@ func (s *PremineGenesisConfig) populate(g state.BeaconState) error {
 	if err := g.SetStateRoots(nZeroRoots(uint64(params.BeaconConfig().SlotsPerHistoricalRoot))); err != nil {
 		return err
 	}
-	if err := g.SetSlashings(make([]uint64, params.BeaconConfig().EpochsPerSlashingsVector)); err != nil {
+	if err := g.SetSlashings(genesisSlashings(g)); err != nil {
 		return err
 	}
 	if err := s.setLatestBlockHeader(g); err != nil {
@@ -90,6 +90,8 @@ func (_ *Wallet) InitializeKeymanager(_ context.Context, _ iface.InitKeymanagerC
 type Validator struct {
 	Km               keymanager.IKeymanager
 	proposerSettings *validatorserviceconfig.ProposerSettings
+	graffiti         map[[48]byte][]byte
+	GraffitiFlag     []byte
 }
 
 func (_ *Validator) LogSyncCommitteeMessagesSubmitted() {}
@@ -213,6 +215,32 @@ func (m *Validator) SetProposerSettings(_ context.Context, settings *validatorse
 	return nil
 }
 
+// Graffiti for mocking
+func (m *Validator) Graffiti(_ context.Context, pubKey [48]byte) ([]byte, error) {
+	if len(m.GraffitiFlag) != 0 {
+		return m.GraffitiFlag, nil
+	}
+	return m.graffiti[pubKey], nil
+}
+
+// SetGraffiti for mocking
+func (m *Validator) SetGraffiti(_ context.Context, pubKey [48]byte, graffiti []byte) error {
+	if len(m.GraffitiFlag) != 0 {
+		return iface2.ErrGraffitiFlagSet
+	}
+	if m.graffiti == nil {
+		m.graffiti = make(map[[48]byte][]byte)
+	}
+	m.graffiti[pubKey] = graffiti
+	return nil
+}
+
+// DeleteGraffiti for mocking
+func (m *Validator) DeleteGraffiti(_ context.Context, pubKey [48]byte) error {
+	delete(m.graffiti, pubKey)
+	return nil
+}
+
 func (_ *Validator) StartEventStream(_ context.Context) error {
 	panic("implement me")
 }
@@ -224,3 +252,11 @@ func (_ *Validator) EventStreamIsRunning() bool {
 func (_ *Validator) NodeIsHealthy(ctx context.Context) bool {
 	panic("implement me")
 }
+
+func (_ *Validator) StartLeaseElection(_ context.Context) {
+	panic("implement me")
+}
+
+func (_ *Validator) SigningEnabled() bool {
+	panic("implement me")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	"github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -116,6 +117,87 @@ func TestSubmitAggregateAndProof_Ok(t *testing.T) {
 	validator.SubmitAggregateAndProof(context.Background(), 0, pubKey)
 }
 
+func TestSubmitAggregateAndProof_Distributed(t *testing.T) {
+	validatorIdx := primitives.ValidatorIndex(123)
+	slot := primitives.Slot(456)
+	ctx := context.Background()
+
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: validatorIdx,
+				AttesterSlot:   slot,
+			},
+		},
+	}
+
+	validator.distributed = true
+	validator.attSelections = make(map[attSelectionKey]iface.BeaconCommitteeSelection)
+	validator.attSelections[attSelectionKey{
+		slot:  slot,
+		index: 123,
+	}] = iface.BeaconCommitteeSelection{
+		SelectionProof: make([]byte, 96),
+		Slot:           slot,
+		ValidatorIndex: validatorIdx,
+	}
+
+	m.validatorClient.EXPECT().SubmitAggregateSelectionProof(
+		gomock.Any(), // ctx
+		&ethpb.AggregateSelectionRequest{
+			Slot:          slot,
+			PublicKey:     pubKey[:],
+			SlotSignature: make([]byte, 96),
+		},
+	).Return(&ethpb.AggregateSelectionResponse{
+		AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: 0,
+			Aggregate: util.HydrateAttestation(&ethpb.Attestation{
+				AggregationBits: make([]byte, 1),
+			}),
+			SelectionProof: make([]byte, 96),
+		},
+	}, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().SubmitSignedAggregateSelectionProof(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.SignedAggregateSubmitRequest{}),
+	).Return(&ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: make([]byte, 32)}, nil)
+
+	validator.SubmitAggregateAndProof(ctx, slot, pubKey)
+}
+
+func TestSubmitAggregateAndProof_Distributed_MissingSelection(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 123,
+				AttesterSlot:   456,
+			},
+		},
+	}
+	validator.distributed = true
+
+	validator.SubmitAggregateAndProof(context.Background(), 456, pubKey)
+	require.LogsContain(t, hook, "Could not find aggregated selection proof")
+}
+
 func TestWaitForSlotTwoThird_WaitCorrectly(t *testing.T) {
 	validator, _, _, finish := setup(t)
 	defer finish()
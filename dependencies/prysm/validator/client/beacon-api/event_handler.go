@@ -18,7 +18,7 @@ const eventByteLimit = 512
 // and dispatching received events to subscribers.
 type EventHandler struct {
 	httpClient *http.Client
-	host       string
+	source     func() (host string, switched <-chan struct{})
 	running    bool
 	subs       []eventSub
 	sync.Mutex
@@ -38,7 +38,20 @@ type event struct {
 func NewEventHandler(httpClient *http.Client, host string) *EventHandler {
 	return &EventHandler{
 		httpClient: httpClient,
-		host:       host,
+		source: func() (string, <-chan struct{}) {
+			return host, nil
+		},
+		running: false,
+		subs:    make([]eventSub, 0),
+	}
+}
+
+// NewFailoverEventHandler returns a new handler listening to the events of the beacon node the failover handler
+// currently sends requests to, which reconnects to the new node when the failover handler switches nodes.
+func NewFailoverEventHandler(httpClient *http.Client, failover *FailoverJsonRestHandler) *EventHandler {
+	return &EventHandler{
+		httpClient: httpClient,
+		source:     failover.hostSwitch,
 		running:    false,
 		subs:       make([]eventSub, 0),
 	}
@@ -63,72 +76,104 @@ func (h *EventHandler) get(ctx context.Context, topics []string) error {
 		defer func() { h.running = false }()
 
 		allTopics := strings.Join(topics, ",")
-		log.Info("Starting listening to Beacon API events on topics: " + allTopics)
-		url := h.host + "/eth/v1/events?topics=" + allTopics
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-		if err != nil {
-			log.WithError(err).Error("Failed to create HTTP request")
-			return
+		for h.listen(ctx, allTopics) {
+			log.Info("Beacon node switched, reconnecting to its event stream")
 		}
-		req.Header.Set("Accept", api.EventStreamMediaType)
-		req.Header.Set("Connection", api.KeepAlive)
-		resp, err := h.httpClient.Do(req)
-		if err != nil {
+	}()
+
+	return nil
+}
+
+// listen dispatches the events of the current beacon node until the stream stops, and returns whether it stopped
+// because requests switched to another beacon node.
+func (h *EventHandler) listen(ctx context.Context, topics string) (switched bool) {
+	host, switchedCh := h.source()
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-switchedCh:
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
+	defer func() {
+		select {
+		case <-switchedCh:
+			switched = ctx.Err() == nil
+		default:
+		}
+	}()
+
+	log.Info("Starting listening to Beacon API events on topics: " + topics)
+	url := host + "/eth/v1/events?topics=" + topics
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, url, nil)
+	if err != nil {
+		log.WithError(err).Error("Failed to create HTTP request")
+		return
+	}
+	req.Header.Set("Accept", api.EventStreamMediaType)
+	req.Header.Set("Connection", api.KeepAlive)
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		if streamCtx.Err() == nil {
 			log.WithError(err).Error("Failed to perform HTTP request")
+		}
+		return
+	}
+
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Failed to close events response body")
+		}
+	}()
+
+	// We signal an EOF error in a special way. When we get this error while reading the response body,
+	// there might still be an event received in the body that we should handle.
+	eof := false
+	for {
+		if ctx.Err() != nil {
+			log.WithError(ctx.Err()).Error("Stopping listening to Beacon API events")
 			return
 		}
 
-		defer func() {
-			if closeErr := resp.Body.Close(); closeErr != nil {
-				log.WithError(closeErr).Error("Failed to close events response body")
-			}
-		}()
-
-		// We signal an EOF error in a special way. When we get this error while reading the response body,
-		// there might still be an event received in the body that we should handle.
-		eof := false
-		for {
-			if ctx.Err() != nil {
-				log.WithError(ctx.Err()).Error("Stopping listening to Beacon API events")
+		rawData := make([]byte, eventByteLimit)
+		_, err = resp.Body.Read(rawData)
+		if err != nil {
+			if streamCtx.Err() != nil && ctx.Err() == nil {
+				// The stream was closed as requests switched to another beacon node.
 				return
 			}
-
-			rawData := make([]byte, eventByteLimit)
-			_, err = resp.Body.Read(rawData)
-			if err != nil {
-				if strings.Contains(err.Error(), "EOF") {
-					log.Error("Received EOF while reading events response body. Stopping listening to Beacon API events")
-					eof = true
-				} else {
-					log.WithError(err).Error("Stopping listening to Beacon API events")
-					return
-				}
-			}
-
-			e := strings.Split(string(rawData), "\n")
-			// We expect that the event format will contain event type and data separated with a newline
-			if len(e) < 2 {
-				// We reached EOF and there is no event to send
-				if eof {
-					return
-				}
-				continue
+			if strings.Contains(err.Error(), "EOF") {
+				log.Error("Received EOF while reading events response body. Stopping listening to Beacon API events")
+				eof = true
+			} else {
+				log.WithError(err).Error("Stopping listening to Beacon API events")
+				return
 			}
+		}
 
-			for _, sub := range h.subs {
-				select {
-				case sub.ch <- event{eventType: e[0], data: e[1]}:
-				// Event sent successfully.
-				default:
-					log.Warn("Subscriber '" + sub.name + "' not ready to receive events")
-				}
-			}
-			// We reached EOF and sent the last event
+		e := strings.Split(string(rawData), "\n")
+		// We expect that the event format will contain event type and data separated with a newline
+		if len(e) < 2 {
+			// We reached EOF and there is no event to send
 			if eof {
 				return
 			}
+			continue
 		}
-	}()
 
-	return nil
+		for _, sub := range h.subs {
+			select {
+			case sub.ch <- event{eventType: e[0], data: e[1]}:
+			// Event sent successfully.
+			default:
+				log.Warn("Subscriber '" + sub.name + "' not ready to receive events")
+			}
+		}
+		// We reached EOF and sent the last event
+		if eof {
+			return
+		}
+	}
 }
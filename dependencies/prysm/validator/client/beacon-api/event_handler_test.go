@@ -53,3 +53,26 @@ func TestEventHandler(t *testing.T) {
 
 	assert.LogsContain(t, logHook, "Subscriber 'sub3' not ready to receive events")
 }
+
+func TestEventHandler_Failover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	primary := newFakeBeaconNode(t, "100")
+	backup := newFakeBeaconNode(t, "100")
+	failover := NewFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{primary.server.URL, backup.server.URL})
+
+	handler := NewFailoverEventHandler(http.DefaultClient, failover)
+	ch := make(chan event, 1)
+	handler.subscribe(eventSub{ch: ch})
+	require.NoError(t, handler.get(ctx, []string{"head"}))
+
+	e := <-ch
+	assert.Equal(t, primary.server.URL, "http://"+e.data)
+
+	// The events of the backup node are streamed once requests switch to it.
+	primary.healthy = false
+	failover.updateCurrent(ctx)
+	require.Equal(t, backup.server.URL, failover.Host())
+	e = <-ch
+	assert.Equal(t, backup.server.URL, "http://"+e.data)
+}
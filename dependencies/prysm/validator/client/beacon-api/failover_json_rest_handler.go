@@ -0,0 +1,277 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"github.com/sirupsen/logrus"
+)
+
+// Endpoints whose requests are sent to every beacon node, so that blocks and attestations get published
+// even if some of the nodes are not well connected to the network.
+var broadcastEndpoints = []string{
+	"/eth/v1/beacon/blocks",
+	"/eth/v2/beacon/blocks",
+	"/eth/v1/beacon/blinded_blocks",
+	"/eth/v2/beacon/blinded_blocks",
+	"/eth/v1/beacon/pool/attestations",
+	"/eth/v1/validator/aggregate_and_proofs",
+}
+
+// A beacon node lagging more than this number of slots behind the best known head is not used while a node
+// closer to the head is healthy.
+const maxHeadSlotLag = 1
+
+// NewJsonRestHandler returns a handler for a comma-separated list of beacon node REST API endpoints. With
+// several endpoints, the health of the nodes is polled until the context is done.
+func NewJsonRestHandler(ctx context.Context, httpClient http.Client, endpoints string) JsonRestHandler {
+	hosts := strings.Split(endpoints, ",")
+	if len(hosts) == 1 {
+		return BeaconApiJsonRestHandler{HttpClient: httpClient, Host: hosts[0]}
+	}
+	h := NewFailoverJsonRestHandler(httpClient, hosts)
+	go h.Start(ctx)
+	return h
+}
+
+// FailoverJsonRestHandler sends requests to the healthiest of several beacon nodes, preferring the nodes in
+// the order they are configured, and sends blocks and attestations to all of them.
+type FailoverJsonRestHandler struct {
+	handlers     []BeaconApiJsonRestHandler
+	pollInterval time.Duration
+	current      int
+	// switched is closed when requests switch to another beacon node, and replaced by a new channel.
+	switched chan struct{}
+	sync.RWMutex
+}
+
+// The health of a beacon node, as seen on its last poll.
+type nodeHealth struct {
+	healthy  bool
+	headSlot uint64
+}
+
+// NewFailoverJsonRestHandler returns a handler for the given beacon node hosts, which initially sends requests
+// to the first host. Health is only polled once Start is called.
+func NewFailoverJsonRestHandler(httpClient http.Client, hosts []string) *FailoverJsonRestHandler {
+	handlers := make([]BeaconApiJsonRestHandler, len(hosts))
+	for i, host := range hosts {
+		handlers[i] = BeaconApiJsonRestHandler{HttpClient: httpClient, Host: host}
+	}
+	return &FailoverJsonRestHandler{
+		handlers: handlers,
+		// Polling three times per slot switches to another node within a slot of the current one failing.
+		pollInterval: time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3,
+		switched:     make(chan struct{}),
+	}
+}
+
+// Start polls the health of the beacon nodes until the context is done.
+func (c *FailoverJsonRestHandler) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	for {
+		c.updateCurrent(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Host returns the host of the beacon node requests are currently sent to.
+func (c *FailoverJsonRestHandler) Host() string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.handlers[c.current].Host
+}
+
+// hostSwitch returns the host of the beacon node requests are currently sent to, along with a channel which is
+// closed when requests switch to another beacon node.
+func (c *FailoverJsonRestHandler) hostSwitch() (string, <-chan struct{}) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.handlers[c.current].Host, c.switched
+}
+
+// Get sends a GET request to the current beacon node, and to the next ones in order if the current node cannot
+// be reached or fails with a server error.
+func (c *FailoverJsonRestHandler) Get(ctx context.Context, endpoint string, resp interface{}) error {
+	var err error
+	for _, h := range c.ordered() {
+		err = h.Get(ctx, endpoint, resp)
+		if !isNodeFailure(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// Post sends a POST request to the current beacon node, and to the next ones in order if the current node
+// cannot be reached or fails with a server error. Blocks and attestations are sent to every node, and the request
+// succeeds if any node accepted them, with the response of the first such node in order.
+func (c *FailoverJsonRestHandler) Post(
+	ctx context.Context,
+	apiEndpoint string,
+	headers map[string]string,
+	data *bytes.Buffer,
+	resp interface{},
+) error {
+	if data == nil {
+		return errors.New("data is nil")
+	}
+	handlers := c.ordered()
+	body := data.Bytes()
+	if !isBroadcastEndpoint(apiEndpoint) {
+		var err error
+		for _, h := range handlers {
+			err = h.Post(ctx, apiEndpoint, headers, bytes.NewBuffer(body), resp)
+			if !isNodeFailure(err) {
+				return err
+			}
+		}
+		return err
+	}
+
+	errs := make([]error, len(handlers))
+	resps := make([]interface{}, len(handlers))
+	var wg sync.WaitGroup
+	for i, h := range handlers {
+		// The response of each node is decoded separately, as any of them may be returned.
+		if resp != nil {
+			resps[i] = reflect.New(reflect.TypeOf(resp).Elem()).Interface()
+		}
+		wg.Add(1)
+		go func(i int, h BeaconApiJsonRestHandler) {
+			defer wg.Done()
+			errs[i] = h.Post(ctx, apiEndpoint, headers, bytes.NewBuffer(body), resps[i])
+		}(i, h)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err == nil {
+			if resp != nil {
+				reflect.ValueOf(resp).Elem().Set(reflect.ValueOf(resps[i]).Elem())
+			}
+			return nil
+		}
+		if i > 0 {
+			log.WithError(err).WithField("host", handlers[i].Host).Debug("Could not publish to beacon node")
+		}
+	}
+	return errs[0]
+}
+
+// ordered returns the handlers starting with the current one, followed by the others in configured order.
+func (c *FailoverJsonRestHandler) ordered() []BeaconApiJsonRestHandler {
+	c.RLock()
+	defer c.RUnlock()
+	handlers := make([]BeaconApiJsonRestHandler, 0, len(c.handlers))
+	handlers = append(handlers, c.handlers[c.current])
+	for i, h := range c.handlers {
+		if i != c.current {
+			handlers = append(handlers, h)
+		}
+	}
+	return handlers
+}
+
+// updateCurrent polls the health of every beacon node and switches to the first configured node which is
+// healthy and close to the best known head. The current node is kept if no node is healthy.
+func (c *FailoverJsonRestHandler) updateCurrent(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.pollInterval)
+	defer cancel()
+	health := make([]nodeHealth, len(c.handlers))
+	var wg sync.WaitGroup
+	for i, h := range c.handlers {
+		wg.Add(1)
+		go func(i int, h BeaconApiJsonRestHandler) {
+			defer wg.Done()
+			health[i] = pollHealth(ctx, h)
+		}(i, h)
+	}
+	wg.Wait()
+
+	var bestHeadSlot uint64
+	for _, nh := range health {
+		if nh.healthy && nh.headSlot > bestHeadSlot {
+			bestHeadSlot = nh.headSlot
+		}
+	}
+	next := -1
+	for i, nh := range health {
+		if nh.healthy && nh.headSlot+maxHeadSlotLag >= bestHeadSlot {
+			next = i
+			break
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if next == -1 {
+		log.WithField("host", c.handlers[c.current].Host).Warn("No healthy beacon node, keeping the current one")
+		return
+	}
+	if next != c.current {
+		log.WithFields(logrus.Fields{
+			"previousHost": c.handlers[c.current].Host,
+			"host":         c.handlers[next].Host,
+			"headSlot":     health[next].headSlot,
+		}).Info("Switched beacon node")
+		c.current = next
+		close(c.switched)
+		c.switched = make(chan struct{})
+	}
+}
+
+// pollHealth considers a beacon node healthy if it is synced, not optimistic, connected to its execution
+// client and reports itself as healthy.
+func pollHealth(ctx context.Context, h BeaconApiJsonRestHandler) nodeHealth {
+	syncingResp := node.SyncStatusResponse{}
+	if err := h.Get(ctx, "/eth/v1/node/syncing", &syncingResp); err != nil || syncingResp.Data == nil {
+		return nodeHealth{}
+	}
+	headSlot, err := strconv.ParseUint(syncingResp.Data.HeadSlot, 10, 64)
+	if err != nil {
+		return nodeHealth{}
+	}
+	if syncingResp.Data.IsSyncing || syncingResp.Data.IsOptimistic || syncingResp.Data.ElOffline {
+		return nodeHealth{headSlot: headSlot}
+	}
+	if err := h.Get(ctx, "/eth/v1/node/health", nil); err != nil {
+		return nodeHealth{headSlot: headSlot}
+	}
+	return nodeHealth{healthy: true, headSlot: headSlot}
+}
+
+func isBroadcastEndpoint(endpoint string) bool {
+	for _, e := range broadcastEndpoints {
+		if endpoint == e {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeFailure returns whether the error is not a response of the beacon node, or is a server error response.
+func isNodeFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	errJson := &httputil.DefaultJsonError{}
+	if !errors.As(err, &errJson) {
+		return true
+	}
+	return errJson.Code >= http.StatusInternalServerError
+}
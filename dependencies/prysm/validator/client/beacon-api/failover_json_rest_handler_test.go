@@ -0,0 +1,156 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
+	"github.com/prysmaticlabs/prysm/v4/network/httputil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type fakeBeaconNode struct {
+	server   *httptest.Server
+	syncing  node.SyncStatusResponseData
+	healthy  bool
+	failing  atomic.Bool
+	requests atomic.Int32
+	posts    atomic.Int32
+}
+
+// hostResponse is the response of the fake beacon node to requests, identifying the node.
+type hostResponse struct {
+	Host string `json:"host"`
+}
+
+func newFakeBeaconNode(t *testing.T, headSlot string) *fakeBeaconNode {
+	n := &fakeBeaconNode{syncing: node.SyncStatusResponseData{HeadSlot: headSlot}, healthy: true}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/node/syncing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		require.NoError(t, json.NewEncoder(w).Encode(&node.SyncStatusResponse{Data: &n.syncing}))
+	})
+	mux.HandleFunc("/eth/v1/node/health", func(w http.ResponseWriter, r *http.Request) {
+		if !n.healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	// A failing node answers requests with a server error.
+	respond := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		if n.failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			require.NoError(t, json.NewEncoder(w).Encode(&httputil.DefaultJsonError{Code: http.StatusInternalServerError}))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&hostResponse{Host: r.Host}))
+	}
+	mux.HandleFunc("/eth/v1/node/version", func(w http.ResponseWriter, r *http.Request) {
+		n.requests.Add(1)
+		respond(w, r)
+	})
+	mux.HandleFunc("/eth/v1/beacon/pool/attestations", func(w http.ResponseWriter, r *http.Request) {
+		n.posts.Add(1)
+		respond(w, r)
+	})
+	// A single head event is streamed, with the host of the node as data.
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, "head\n"+r.Host+"\n\n")
+		require.NoError(t, err)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	n.server = httptest.NewServer(mux)
+	t.Cleanup(n.server.Close)
+	return n
+}
+
+func TestFailoverJsonRestHandler_updateCurrent(t *testing.T) {
+	ctx := context.Background()
+	primary := newFakeBeaconNode(t, "100")
+	backup := newFakeBeaconNode(t, "100")
+	h := NewFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{primary.server.URL, backup.server.URL})
+
+	h.updateCurrent(ctx)
+	assert.Equal(t, primary.server.URL, h.Host())
+
+	// The primary node falls behind.
+	backup.syncing.HeadSlot = "102"
+	h.updateCurrent(ctx)
+	assert.Equal(t, backup.server.URL, h.Host())
+
+	// The primary node catches up to within a slot, and is preferred again.
+	primary.syncing.HeadSlot = "101"
+	h.updateCurrent(ctx)
+	assert.Equal(t, primary.server.URL, h.Host())
+
+	primary.syncing.IsOptimistic = true
+	h.updateCurrent(ctx)
+	assert.Equal(t, backup.server.URL, h.Host())
+	primary.syncing.IsOptimistic = false
+
+	primary.healthy = false
+	h.updateCurrent(ctx)
+	assert.Equal(t, backup.server.URL, h.Host())
+
+	// No node is healthy, the current one is kept.
+	backup.syncing.IsSyncing = true
+	h.updateCurrent(ctx)
+	assert.Equal(t, backup.server.URL, h.Host())
+}
+
+func TestFailoverJsonRestHandler_Get(t *testing.T) {
+	ctx := context.Background()
+	primary := newFakeBeaconNode(t, "100")
+	backup := newFakeBeaconNode(t, "100")
+	h := NewFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{primary.server.URL, backup.server.URL})
+
+	require.NoError(t, h.Get(ctx, "/eth/v1/node/version", nil))
+	assert.Equal(t, int32(1), primary.requests.Load())
+	assert.Equal(t, int32(0), backup.requests.Load())
+
+	// The request goes to the backup node when the primary node fails.
+	primary.failing.Store(true)
+	resp := &hostResponse{}
+	require.NoError(t, h.Get(ctx, "/eth/v1/node/version", resp))
+	assert.Equal(t, int32(2), primary.requests.Load())
+	assert.Equal(t, int32(1), backup.requests.Load())
+	assert.Equal(t, backup.server.URL, "http://"+resp.Host)
+
+	// The request goes to the backup node when the primary node cannot be reached.
+	primary.server.Close()
+	require.NoError(t, h.Get(ctx, "/eth/v1/node/version", nil))
+	assert.Equal(t, int32(2), backup.requests.Load())
+}
+
+func TestFailoverJsonRestHandler_Post(t *testing.T) {
+	ctx := context.Background()
+	primary := newFakeBeaconNode(t, "100")
+	backup := newFakeBeaconNode(t, "100")
+	h := NewFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{primary.server.URL, backup.server.URL})
+
+	// Attestations are published to every node.
+	require.NoError(t, h.Post(ctx, "/eth/v1/beacon/pool/attestations", nil, bytes.NewBuffer([]byte("[]")), nil))
+	assert.Equal(t, int32(1), primary.posts.Load())
+	assert.Equal(t, int32(1), backup.posts.Load())
+
+	// Publishing succeeds as long as one node accepts the attestations, with the response of that node.
+	primary.failing.Store(true)
+	resp := &hostResponse{}
+	require.NoError(t, h.Post(ctx, "/eth/v1/beacon/pool/attestations", nil, bytes.NewBuffer([]byte("[]")), resp))
+	assert.Equal(t, int32(2), backup.posts.Load())
+	assert.Equal(t, backup.server.URL, "http://"+resp.Host)
+
+	primary.server.Close()
+	require.NoError(t, h.Post(ctx, "/eth/v1/beacon/pool/attestations", nil, bytes.NewBuffer([]byte("[]")), nil))
+	assert.Equal(t, int32(3), backup.posts.Load())
+}
@@ -149,3 +149,15 @@ func (c *grpcValidatorClient) StartEventStream(context.Context) error {
 func (c *grpcValidatorClient) EventStreamIsRunning() bool {
 	panic("function not supported for gRPC client")
 }
+
+// GetAggregatedSelections is not supported, as the gRPC API has no selection endpoints. In the --distributed
+// mode, selections are sent to the beacon REST API of the DVT middleware instead.
+func (c *grpcValidatorClient) GetAggregatedSelections(context.Context, []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return nil, iface.ErrNotSupported
+}
+
+// GetAggregatedSyncSelections is not supported, as the gRPC API has no selection endpoints. In the --distributed
+// mode, selections are sent to the beacon REST API of the DVT middleware instead.
+func (c *grpcValidatorClient) GetAggregatedSyncSelections(context.Context, []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return nil, iface.ErrNotSupported
+}
@@ -17,6 +17,10 @@ import (
 // ErrConnectionIssue represents a connection problem.
 var ErrConnectionIssue = errors.New("could not connect")
 
+// ErrGraffitiFlagSet is returned when setting the graffiti of a validator while the graffiti flag, which overrides
+// it, is set.
+var ErrGraffitiFlagSet = errors.New("graffiti is set with the --graffiti flag, which overrides the graffiti of validators")
+
 // ValidatorRole defines the validator role.
 type ValidatorRole int8
 
@@ -64,9 +68,14 @@ type Validator interface {
 	SignValidatorRegistrationRequest(ctx context.Context, signer SigningFunc, newValidatorRegistration *ethpb.ValidatorRegistrationV1) (*ethpb.SignedValidatorRegistrationV1, error)
 	ProposerSettings() *validatorserviceconfig.ProposerSettings
 	SetProposerSettings(context.Context, *validatorserviceconfig.ProposerSettings) error
+	Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error)
+	SetGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error
+	DeleteGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error
 	StartEventStream(ctx context.Context) error
 	EventStreamIsRunning() bool
 	NodeIsHealthy(ctx context.Context) bool
+	StartLeaseElection(ctx context.Context)
+	SigningEnabled() bool
 }
 
 // SigningFunc interface defines a type for the a function that signs a message
@@ -0,0 +1,154 @@
+package lease
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/crypto/rand"
+	"github.com/sirupsen/logrus"
+)
+
+// State of a validator client in the election.
+type State string
+
+const (
+	// Standby clients do not hold the lease and do not sign.
+	Standby State = "standby"
+	// Pending clients hold the lease but do not sign until the takeover check passes.
+	Pending State = "pending"
+	// Active clients hold the lease and sign.
+	Active State = "active"
+)
+
+// Status of the election, as seen by a validator client.
+type Status struct {
+	ID          string
+	Nonce       string
+	State       State
+	Holder      string
+	HolderNonce string
+	Expiry      time.Time
+}
+
+// Elector renews or takes over the lease on behalf of a validator client. Before signing after
+// taking over the lease, it runs a check, e.g. doppelganger detection, to ensure the previous
+// lease holder stopped signing.
+type Elector struct {
+	store    Store
+	id       string
+	nonce    string
+	ttl      time.Duration
+	check    func(context.Context) error
+	state    State
+	record   *Record
+	deadline time.Time
+	sync.RWMutex
+}
+
+// NewElector returns an elector of the validator client with the given id, which holds the lease
+// for ttl at a time and runs check before signing after taking over the lease. The elector draws a
+// random nonce, so that it never renews a lease taken by another client using the same id.
+func NewElector(store Store, id string, ttl time.Duration, check func(context.Context) error) *Elector {
+	nonce := fmt.Sprintf("%016x", rand.NewGenerator().Uint64())
+	return &Elector{store: store, id: id, nonce: nonce, ttl: ttl, check: check, state: Standby}
+}
+
+// Start renews or takes over the lease three times per lease duration, until the context is done.
+// The lease is then released.
+func (e *Elector) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		e.update(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			e.Lock()
+			e.state = Standby
+			e.Unlock()
+			// The context is done, so release the lease with a fresh one.
+			releaseCtx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+			defer cancel()
+			if err := e.store.Release(releaseCtx, e.id, e.nonce); err != nil {
+				log.WithError(err).Error("Could not release lease")
+			}
+			return
+		}
+	}
+}
+
+// Active returns whether the validator client holds the lease and may sign. The lease is
+// considered lost once it expired without being renewed, even if the store cannot be reached.
+func (e *Elector) Active() bool {
+	e.RLock()
+	defer e.RUnlock()
+	return e.state == Active && time.Now().Before(e.deadline)
+}
+
+// Status returns the state of the election, as of the last renewal.
+func (e *Elector) Status() *Status {
+	e.RLock()
+	defer e.RUnlock()
+	status := &Status{ID: e.id, Nonce: e.nonce, State: e.state}
+	if e.state == Active && !time.Now().Before(e.deadline) {
+		status.State = Standby
+	}
+	if e.record != nil {
+		status.Holder = e.record.Holder
+		status.HolderNonce = e.record.Nonce
+		status.Expiry = e.record.Expiry
+	}
+	return status
+}
+
+func (e *Elector) update(ctx context.Context) {
+	acquireCtx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+	// The lease is only relied upon until ttl after the request was sent, which is no later than
+	// the expiry saved in the store.
+	start := time.Now()
+	record, err := e.store.Acquire(acquireCtx, e.id, e.nonce, e.ttl)
+	if err != nil {
+		log.WithError(err).Warn("Could not renew lease")
+		return
+	}
+
+	e.Lock()
+	previous := e.state
+	e.record = record
+	if !record.heldBy(e.id, e.nonce) {
+		e.state = Standby
+		e.Unlock()
+		if previous != Standby {
+			log.WithField("holder", record.Holder).Warn("Lost lease, stopped signing")
+		}
+		return
+	}
+	e.deadline = start.Add(e.ttl)
+	if previous == Active {
+		e.Unlock()
+		return
+	}
+	e.state = Pending
+	e.Unlock()
+
+	if previous == Standby {
+		log.WithField("expiry", record.Expiry).Info("Acquired lease, checking that no other client signs")
+	}
+	if err := e.check(ctx); err != nil {
+		log.WithError(err).Warn("Could not take over signing, retrying")
+		return
+	}
+	e.Lock()
+	defer e.Unlock()
+	if e.state == Pending {
+		e.state = Active
+		log.WithFields(logrus.Fields{
+			"id":     e.id,
+			"nonce":  e.nonce,
+			"expiry": record.Expiry,
+		}).Info("Holding lease, signing")
+	}
+}
@@ -0,0 +1,115 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestElector_update(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "validator.lease")
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	checkErr := errors.New("doppelganger found")
+	primary := NewElector(store, "primary", time.Minute, func(context.Context) error { return checkErr })
+	standby := NewElector(store, "standby", time.Minute, func(context.Context) error { return nil })
+	assert.Equal(t, false, primary.Active())
+	assert.Equal(t, Standby, primary.Status().State)
+
+	// The lease is taken, but signing waits for the check to pass.
+	primary.update(ctx)
+	assert.Equal(t, false, primary.Active())
+	status := primary.Status()
+	assert.Equal(t, Pending, status.State)
+	assert.Equal(t, "primary", status.Holder)
+
+	checkErr = nil
+	primary.update(ctx)
+	assert.Equal(t, true, primary.Active())
+	assert.Equal(t, Active, primary.Status().State)
+
+	standby.update(ctx)
+	assert.Equal(t, false, standby.Active())
+	status = standby.Status()
+	assert.Equal(t, Standby, status.State)
+	assert.Equal(t, "primary", status.Holder)
+
+	// The primary client loses the lease once the standby client takes it over.
+	require.NoError(t, store.Release(ctx, "primary", primary.nonce))
+	standby.update(ctx)
+	assert.Equal(t, true, standby.Active())
+	primary.update(ctx)
+	assert.Equal(t, false, primary.Active())
+	status = primary.Status()
+	assert.Equal(t, Standby, status.State)
+	assert.Equal(t, "standby", status.Holder)
+}
+
+func TestElector_update_SameID(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "validator.lease"))
+	require.NoError(t, err)
+	first := NewElector(store, "host", time.Minute, func(context.Context) error { return nil })
+	second := NewElector(store, "host", time.Minute, func(context.Context) error { return nil })
+	require.NotEqual(t, first.nonce, second.nonce)
+
+	first.update(ctx)
+	second.update(ctx)
+	assert.Equal(t, true, first.Active())
+	assert.Equal(t, false, second.Active())
+	status := second.Status()
+	assert.Equal(t, Standby, status.State)
+	assert.Equal(t, "host", status.Holder)
+	assert.Equal(t, first.nonce, status.HolderNonce)
+}
+
+func TestElector_Active_Expired(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "validator.lease"))
+	require.NoError(t, err)
+	e := NewElector(store, "primary", time.Minute, func(context.Context) error { return nil })
+	e.update(context.Background())
+	assert.Equal(t, true, e.Active())
+
+	// The lease could not be renewed before its deadline.
+	e.deadline = time.Now().Add(-time.Second)
+	assert.Equal(t, false, e.Active())
+	assert.Equal(t, Standby, e.Status().State)
+}
+
+func TestElector_Start_ReleasesLease(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "validator.lease"))
+	require.NoError(t, err)
+	e := NewElector(store, "primary", time.Minute, func(context.Context) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e.Start(ctx)
+		close(done)
+	}()
+	require.NoError(t, waitFor(e.Active))
+	cancel()
+	<-done
+	assert.Equal(t, false, e.Active())
+
+	record, err := store.Acquire(context.Background(), "standby", "standby-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", record.Holder)
+}
+
+func waitFor(cond func() bool) error {
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("timed out")
+}
@@ -0,0 +1,179 @@
+package lease
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EtcdStore saves the lease under a key of an etcd compatible key-value store, through the JSON
+// gateway of its v3 API. The lease is replaced with a transaction conditioned on the revision of
+// the key which was read, so that concurrent validator clients cannot both take it.
+type EtcdStore struct {
+	client   *http.Client
+	endpoint string
+	key      []byte
+}
+
+// NewEtcdStore returns a store saving the lease under the key, at the given etcd endpoint, e.g.
+// http://localhost:2379.
+func NewEtcdStore(client *http.Client, endpoint, key string) *EtcdStore {
+	return &EtcdStore{client: client, endpoint: strings.TrimSuffix(endpoint, "/"), key: []byte(key)}
+}
+
+type etcdKeyValue struct {
+	Key         []byte `json:"key"`
+	Value       []byte `json:"value"`
+	ModRevision string `json:"mod_revision"`
+}
+
+type etcdRangeRequest struct {
+	Key []byte `json:"key"`
+}
+
+type etcdRangeResponse struct {
+	Kvs []*etcdKeyValue `json:"kvs"`
+}
+
+type etcdCompare struct {
+	Target         string `json:"target"`
+	Result         string `json:"result"`
+	Key            []byte `json:"key"`
+	ModRevision    string `json:"mod_revision,omitempty"`
+	CreateRevision string `json:"create_revision,omitempty"`
+}
+
+type etcdRequestOp struct {
+	RequestPut         *etcdPutRequest         `json:"request_put,omitempty"`
+	RequestDeleteRange *etcdDeleteRangeRequest `json:"request_delete_range,omitempty"`
+}
+
+type etcdPutRequest struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type etcdDeleteRangeRequest struct {
+	Key []byte `json:"key"`
+}
+
+type etcdTxnRequest struct {
+	Compare []*etcdCompare   `json:"compare"`
+	Success []*etcdRequestOp `json:"success"`
+}
+
+type etcdTxnResponse struct {
+	Succeeded bool `json:"succeeded"`
+}
+
+// Acquire takes the lease for the holder if it is free, expired or already held by the holder
+// with the same nonce.
+func (s *EtcdStore) Acquire(ctx context.Context, holder, nonce string, ttl time.Duration) (*Record, error) {
+	current, revision, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record := acquire(current, holder, nonce, ttl, time.Now())
+	if record == nil {
+		return current, nil
+	}
+	enc, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	succeeded, err := s.txn(ctx, s.unchanged(revision), &etcdRequestOp{
+		RequestPut: &etcdPutRequest{Key: s.key, Value: enc},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !succeeded {
+		// Another validator client updated the lease in the meantime.
+		current, _, err = s.read(ctx)
+		return current, err
+	}
+	return record, nil
+}
+
+// Release deletes the lease key if the lease is held by the holder with the same nonce.
+func (s *EtcdStore) Release(ctx context.Context, holder, nonce string) error {
+	current, revision, err := s.read(ctx)
+	if err != nil || current == nil || !current.heldBy(holder, nonce) {
+		return err
+	}
+	_, err = s.txn(ctx, s.unchanged(revision), &etcdRequestOp{
+		RequestDeleteRange: &etcdDeleteRangeRequest{Key: s.key},
+	})
+	return err
+}
+
+// read returns the lease saved under the key along with the revision of the key, or nil and an
+// empty revision if there is none.
+func (s *EtcdStore) read(ctx context.Context) (*Record, string, error) {
+	resp := &etcdRangeResponse{}
+	if err := s.post(ctx, "/v3/kv/range", &etcdRangeRequest{Key: s.key}, resp); err != nil {
+		return nil, "", err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, "", nil
+	}
+	record := &Record{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, record); err != nil {
+		return nil, "", errors.Wrap(err, "could not decode lease")
+	}
+	return record, resp.Kvs[0].ModRevision, nil
+}
+
+// unchanged compares the key against the revision which was read, or checks that the key still
+// does not exist if the revision is empty.
+func (s *EtcdStore) unchanged(revision string) *etcdCompare {
+	if revision == "" {
+		return &etcdCompare{Target: "CREATE", Result: "EQUAL", Key: s.key, CreateRevision: "0"}
+	}
+	return &etcdCompare{Target: "MOD", Result: "EQUAL", Key: s.key, ModRevision: revision}
+}
+
+func (s *EtcdStore) txn(ctx context.Context, compare *etcdCompare, op *etcdRequestOp) (bool, error) {
+	resp := &etcdTxnResponse{}
+	req := &etcdTxnRequest{Compare: []*etcdCompare{compare}, Success: []*etcdRequestOp{op}}
+	if err := s.post(ctx, "/v3/kv/txn", req, resp); err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+func (s *EtcdStore) post(ctx context.Context, path string, req, resp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(err, "could not reach etcd")
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	if httpResp.StatusCode != http.StatusOK {
+		msg, err := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("etcd returned status %d: %s", httpResp.StatusCode, msg)
+	}
+	return json.NewDecoder(httpResp.Body).Decode(resp)
+}
@@ -0,0 +1,111 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// fakeEtcd serves the range and txn endpoints of the etcd JSON gateway for a single key.
+type fakeEtcd struct {
+	value    []byte
+	revision int
+	sync.Mutex
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		resp := &etcdRangeResponse{}
+		if f.value != nil {
+			resp.Kvs = []*etcdKeyValue{{Value: f.value, ModRevision: strconv.Itoa(f.revision)}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	case "/v3/kv/txn":
+		req := &etcdTxnRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cmp := req.Compare[0]
+		succeeded := (cmp.Target == "CREATE" && f.value == nil) ||
+			(cmp.Target == "MOD" && f.value != nil && cmp.ModRevision == strconv.Itoa(f.revision))
+		if succeeded {
+			f.revision++
+			if op := req.Success[0]; op.RequestPut != nil {
+				f.value = op.RequestPut.Value
+			} else {
+				f.value = nil
+			}
+		}
+		_ = json.NewEncoder(w).Encode(&etcdTxnResponse{Succeeded: succeeded})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestEtcdStore_Acquire(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(&fakeEtcd{})
+	defer server.Close()
+	primary := NewEtcdStore(http.DefaultClient, server.URL, "/lease")
+	standby := NewEtcdStore(http.DefaultClient, server.URL+"/", "/lease")
+
+	record, err := primary.Acquire(ctx, "primary", "primary-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+	record, err = standby.Acquire(ctx, "standby", "standby-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+
+	record, err = primary.Acquire(ctx, "primary", "primary-nonce", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+	time.Sleep(5 * time.Millisecond)
+	record, err = standby.Acquire(ctx, "standby", "standby-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", record.Holder)
+
+	require.NoError(t, primary.Release(ctx, "primary", "primary-nonce"))
+	record, err = primary.Acquire(ctx, "primary", "primary-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", record.Holder)
+	require.NoError(t, standby.Release(ctx, "standby", "standby-nonce"))
+	record, err = primary.Acquire(ctx, "primary", "primary-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+}
+
+func TestEtcdStore_Acquire_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(&fakeEtcd{})
+	defer server.Close()
+
+	const clients = 8
+	holders := make([]string, clients)
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			record, err := NewEtcdStore(http.DefaultClient, server.URL, "/lease").Acquire(ctx, strconv.Itoa(i), "nonce", time.Minute)
+			require.NoError(t, err)
+			holders[i] = record.Holder
+		}(i)
+	}
+	wg.Wait()
+	// Every client sees the same holder.
+	for _, holder := range holders {
+		assert.Equal(t, holders[0], holder)
+	}
+}
@@ -0,0 +1,106 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+)
+
+// Delay between two attempts to take the lock of the lease file.
+const fileLockRetryDelay = 50 * time.Millisecond
+
+// FileStore saves the lease in a file on storage shared by the validator clients. The file is
+// only read and written while holding an exclusive lock on a companion lock file.
+type FileStore struct {
+	path string
+	lock *flock.Flock
+}
+
+// NewFileStore returns a store saving the lease at the given path.
+func NewFileStore(path string) (*FileStore, error) {
+	if err := file.MkdirAll(filepath.Dir(path)); err != nil {
+		return nil, errors.Wrap(err, "could not create lease directory")
+	}
+	return &FileStore{path: path, lock: flock.New(path + ".lock")}, nil
+}
+
+// Acquire takes the lease for the holder if it is free, expired or already held by the holder
+// with the same nonce.
+func (s *FileStore) Acquire(ctx context.Context, holder, nonce string, ttl time.Duration) (*Record, error) {
+	var record *Record
+	err := s.withLock(ctx, func() error {
+		current, err := s.read()
+		if err != nil {
+			return err
+		}
+		record = acquire(current, holder, nonce, ttl, time.Now())
+		if record == nil {
+			record = current
+			return nil
+		}
+		return s.write(record)
+	})
+	return record, err
+}
+
+// Release removes the lease file if the lease is held by the holder with the same nonce.
+func (s *FileStore) Release(ctx context.Context, holder, nonce string) error {
+	return s.withLock(ctx, func() error {
+		current, err := s.read()
+		if err != nil || current == nil || !current.heldBy(holder, nonce) {
+			return err
+		}
+		return os.Remove(s.path)
+	})
+}
+
+func (s *FileStore) withLock(ctx context.Context, fn func() error) error {
+	locked, err := s.lock.TryLockContext(ctx, fileLockRetryDelay)
+	if err != nil {
+		return errors.Wrap(err, "could not lock lease file")
+	}
+	if !locked {
+		return errors.New("could not lock lease file")
+	}
+	defer func() {
+		if err := s.lock.Unlock(); err != nil {
+			log.WithError(err).Error("Could not unlock lease file")
+		}
+	}()
+	return fn()
+}
+
+// read returns the lease saved in the file, or nil if there is none.
+func (s *FileStore) read() (*Record, error) {
+	enc, err := os.ReadFile(s.path) // #nosec G304 -- the path is set by the operator.
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read lease file")
+	}
+	record := &Record{}
+	if err := json.Unmarshal(enc, record); err != nil {
+		return nil, errors.Wrap(err, "could not decode lease file")
+	}
+	return record, nil
+}
+
+// write replaces the lease file atomically, so that a crash never leaves a partial lease.
+func (s *FileStore) write(record *Record) error {
+	enc, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := file.WriteFile(tmp, enc); err != nil {
+		return errors.Wrap(err, "could not write lease file")
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,68 @@
+package lease
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestFileStore_Acquire(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "shared", "validator.lease")
+	primary, err := NewFileStore(path)
+	require.NoError(t, err)
+	standby, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	record, err := primary.Acquire(ctx, "primary", "primary-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+
+	// The lease is held by the primary client until it expires.
+	record, err = standby.Acquire(ctx, "standby", "standby-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+
+	// The primary client renews its lease.
+	record, err = primary.Acquire(ctx, "primary", "primary-nonce", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+
+	time.Sleep(5 * time.Millisecond)
+	record, err = standby.Acquire(ctx, "standby", "standby-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", record.Holder)
+
+	// Only the holder can release the lease.
+	require.NoError(t, primary.Release(ctx, "primary", "primary-nonce"))
+	record, err = primary.Acquire(ctx, "primary", "primary-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", record.Holder)
+	require.NoError(t, standby.Release(ctx, "standby", "standby-nonce"))
+	record, err = primary.Acquire(ctx, "primary", "primary-nonce", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", record.Holder)
+}
+
+func TestFileStore_Acquire_SameHolder(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "validator.lease"))
+	require.NoError(t, err)
+
+	// Clients sharing a hostname only renew the lease taken with their own nonce.
+	record, err := store.Acquire(ctx, "host", "first", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "first", record.Nonce)
+	record, err = store.Acquire(ctx, "host", "second", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "host", record.Holder)
+	assert.Equal(t, "first", record.Nonce)
+	require.NoError(t, store.Release(ctx, "host", "second"))
+	record, err = store.Acquire(ctx, "host", "first", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "first", record.Nonce)
+}
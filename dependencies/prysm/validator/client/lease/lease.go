@@ -0,0 +1,45 @@
+// Package lease elects which of several validator clients sharing the same keys signs, through a
+// lease held in a store shared by the clients. The lease holder renews the lease well before it
+// expires, and a standby client takes the lease over once it expired.
+//
+// Expiries are compared against the local clock of each client, which must be kept synchronized
+// with a margin much smaller than the lease duration.
+package lease
+
+import (
+	"context"
+	"time"
+)
+
+// Record of the lease, as saved in the store.
+type Record struct {
+	Holder string `json:"holder"`
+	// Nonce drawn by the holder when it started, which tells apart clients configured with the
+	// same id, e.g. containers or cloned machines sharing a hostname.
+	Nonce  string    `json:"nonce"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// Store of the lease shared by the validator clients.
+type Store interface {
+	// Acquire takes the lease for the holder with the given nonce for the given duration if it is
+	// free, expired or already held by the same holder and nonce, and returns the lease as saved in
+	// the store afterwards.
+	Acquire(ctx context.Context, holder, nonce string, ttl time.Duration) (*Record, error)
+	// Release frees the lease if it is held by the holder with the given nonce.
+	Release(ctx context.Context, holder, nonce string) error
+}
+
+// heldBy returns whether the lease is held by the holder with the given nonce.
+func (r *Record) heldBy(holder, nonce string) bool {
+	return r.Holder == holder && r.Nonce == nonce
+}
+
+// acquire returns the record which the holder saves in place of the current one, or nil if the
+// lease is held by another holder, or by another client using the same holder id.
+func acquire(current *Record, holder, nonce string, ttl time.Duration, now time.Time) *Record {
+	if current != nil && !current.heldBy(holder, nonce) && now.Before(current.Expiry) {
+		return nil
+	}
+	return &Record{Holder: holder, Nonce: nonce, Expiry: now.Add(ttl)}
+}
@@ -0,0 +1,5 @@
+package lease
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "lease")
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -74,9 +75,10 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 
 	// Request block from beacon node
 	b, err := v.validatorClient.GetBeaconBlock(ctx, &ethpb.BlockRequest{
-		Slot:         slot,
-		RandaoReveal: randaoReveal,
-		Graffiti:     g,
+		Slot:              slot,
+		RandaoReveal:      randaoReveal,
+		Graffiti:          g,
+		BuilderMinBidGwei: v.builderMinBid(pubKey),
 	})
 	if err != nil {
 		log.WithField("blockSlot", slot).WithError(err).Error("Failed to request block from beacon node")
@@ -297,7 +299,7 @@ func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BL
 	if err != nil {
 		return nil, err
 	}
-	randaoReveal, err = v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	randaoReveal, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -329,7 +331,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [fieldparams.BLSPubkey
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     blockRoot[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -382,50 +384,159 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
-// Gets the graffiti from cli or file for the validator public key.
+// Gets the minimum builder bid in gwei from the proposer settings for the validator public key, the settings
+// specific to the public key taking priority over the default ones. Zero means there is no minimum.
+func (v *validator) builderMinBid(pubKey [fieldparams.BLSPubkeyLength]byte) uint64 {
+	settings := v.ProposerSettings()
+	if settings == nil {
+		return 0
+	}
+	var config *validatorserviceconfig.BuilderConfig
+	if settings.DefaultConfig != nil {
+		config = settings.DefaultConfig.BuilderConfig
+	}
+	if option, ok := settings.ProposeConfig[pubKey]; ok && option != nil && option.BuilderConfig != nil {
+		config = option.BuilderConfig
+	}
+	if config == nil || !config.Enabled {
+		return 0
+	}
+	return uint64(config.MinBidGwei)
+}
+
+// Gets the graffiti from the cli, db or file for the validator public key, moving on to the next graffiti of the
+// ordered list in the file when it is used.
 func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
+	g, ordered, err := v.selectGraffiti(ctx, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if ordered {
+		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
+		if err := v.db.SaveGraffitiOrderedIndex(ctx, v.graffitiOrderedIndex); err != nil {
+			return nil, errors.Wrap(err, "failed to update graffiti ordered index")
+		}
+	}
+	if g == nil {
+		return []byte{}, nil
+	}
+	return bytesutil.PadTo(g, 32), nil
+}
+
+// Selects the graffiti of the validator public key, or nil if there is none. It also returns whether the graffiti
+// is the next one of the ordered list in the file, which only moves on once the graffiti is used.
+func (v *validator) selectGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, bool, error) {
 	// When specified, default graffiti from the command line takes the first priority.
 	if len(v.graffiti) != 0 {
-		return bytesutil.PadTo(v.graffiti, 32), nil
+		return v.graffiti, false, nil
+	}
+
+	// When set through the keymanager API, graffiti saved for the public key takes the second priority.
+	if v.db != nil {
+		g, exists, err := v.db.GraffitiForPubKey(ctx, pubKey)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to get graffiti for public key")
+		}
+		if exists {
+			return g, false, nil
+		}
 	}
 
 	if v.graffitiStruct == nil {
-		return nil, errors.New("graffitiStruct can't be nil")
+		return nil, false, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
+	// When specified, individual validator specified graffiti takes the third priority.
 	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	g, ok := v.graffitiStruct.Specific[idx.Index]
 	if ok {
-		return bytesutil.PadTo([]byte(g), 32), nil
+		return []byte(g), false, nil
 	}
 
-	// When specified, a graffiti from the ordered list in the file take third priority.
+	// When specified, a graffiti from the ordered list in the file take fourth priority.
 	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
-		graffiti := v.graffitiStruct.Ordered[v.graffitiOrderedIndex]
-		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
-		err := v.db.SaveGraffitiOrderedIndex(ctx, v.graffitiOrderedIndex)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to update graffiti ordered index")
-		}
-		return bytesutil.PadTo([]byte(graffiti), 32), nil
+		return []byte(v.graffitiStruct.Ordered[v.graffitiOrderedIndex]), true, nil
 	}
 
-	// When specified, a graffiti from the random list in the file take fourth priority.
+	// When specified, a graffiti from the random list in the file take fifth priority.
 	if len(v.graffitiStruct.Random) != 0 {
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
 		i := r.Uint64() % uint64(len(v.graffitiStruct.Random))
-		return bytesutil.PadTo([]byte(v.graffitiStruct.Random[i]), 32), nil
+		return []byte(v.graffitiStruct.Random[i]), false, nil
 	}
 
 	// Finally, default graffiti if specified in the file will be used.
 	if v.graffitiStruct.Default != "" {
-		return bytesutil.PadTo([]byte(v.graffitiStruct.Default), 32), nil
+		return []byte(v.graffitiStruct.Default), false, nil
+	}
+
+	return nil, false, nil
+}
+
+// Graffiti returns the graffiti the next proposal of the validator public key would use, looked up from the cli, db
+// or file without moving on to the next graffiti of the ordered list in the file. It is empty when the graffiti is
+// not known in advance, i.e. when it is picked from the random list in the file, or when it is specific to the
+// validator index in the file and the index can't be fetched from the beacon node.
+func (v *validator) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
+	if v.db == nil {
+		return nil, errors.New("db is not set")
+	}
+	if len(v.graffiti) != 0 {
+		return v.graffiti, nil
+	}
+	g, exists, err := v.db.GraffitiForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get graffiti for public key")
+	}
+	if exists {
+		return g, nil
+	}
+	if v.graffitiStruct == nil {
+		return []byte{}, nil
 	}
 
-	return []byte{}, nil
+	// The validator index is only needed, and fetched, for the graffiti specific to validator indices.
+	if len(v.graffitiStruct.Specific) != 0 {
+		idx, ok, err := v.validatorIndex(ctx, pubKey)
+		if err != nil {
+			log.WithError(err).Debugf("Could not get validator index for public key %#x", pubKey)
+			return []byte{}, nil
+		}
+		if ok {
+			if g, ok := v.graffitiStruct.Specific[idx]; ok {
+				return []byte(g), nil
+			}
+		}
+	}
+	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
+		return []byte(v.graffitiStruct.Ordered[v.graffitiOrderedIndex]), nil
+	}
+	if len(v.graffitiStruct.Random) != 0 {
+		return []byte{}, nil
+	}
+	return []byte(v.graffitiStruct.Default), nil
+}
+
+// SetGraffiti saves the graffiti for the validator public key. It is used from the next proposal onwards, unless the
+// graffiti flag is set, in which case iface.ErrGraffitiFlagSet is returned.
+func (v *validator) SetGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
+	if v.db == nil {
+		return errors.New("db is not set")
+	}
+	if len(v.graffiti) != 0 {
+		return iface.ErrGraffitiFlagSet
+	}
+	return v.db.SaveGraffitiForPubKey(ctx, pubKey, graffiti)
+}
+
+// DeleteGraffiti removes the graffiti saved for the validator public key.
+func (v *validator) DeleteGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	if v.db == nil {
+		return errors.New("db is not set")
+	}
+	return v.db.DeleteGraffitiForPubKey(ctx, pubKey)
 }
@@ -12,6 +12,7 @@ import (
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	blocktest "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks/testing"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -25,9 +26,12 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	testing2 "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/validator/graffiti"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type mocks struct {
@@ -954,6 +958,140 @@ func TestGetGraffiti_Ok(t *testing.T) {
 	}
 }
 
+func TestGetGraffiti_SavedForPubKey(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
+	}
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		AnyTimes().
+		Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+	v := &validator{
+		db:              valDB,
+		validatorClient: m.validatorClient,
+		graffiti:        []byte{'b'},
+		graffitiStruct: &graffiti.Graffiti{
+			Ordered: []string{"c", "d"},
+			Default: "e",
+		},
+	}
+	ctx := context.Background()
+
+	// The graffiti flag takes priority over the graffiti saved for the public key, which can't be set.
+	require.ErrorIs(t, v.SetGraffiti(ctx, pubKey, []byte{'f'}), iface.ErrGraffitiFlagSet)
+	got, err := v.getGraffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, bytesutil.PadTo([]byte{'b'}, 32), got)
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'b'}, got)
+
+	// The graffiti saved for the public key takes priority over the graffiti file.
+	v.graffiti = nil
+	require.NoError(t, v.SetGraffiti(ctx, pubKey, []byte{'f'}))
+	got, err = v.getGraffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, bytesutil.PadTo([]byte{'f'}, 32), got)
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'f'}, got)
+
+	// The graffiti file is used the same way, without moving on to the next ordered graffiti.
+	require.NoError(t, v.DeleteGraffiti(ctx, pubKey))
+	for _, want := range []string{"c", "c"} {
+		got, err = v.Graffiti(ctx, pubKey)
+		require.NoError(t, err)
+		require.DeepEqual(t, []byte(want), got)
+	}
+	got, err = v.getGraffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, bytesutil.PadTo([]byte{'c'}, 32), got)
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'d'}, got)
+
+	v.graffitiStruct.Specific = map[primitives.ValidatorIndex]string{2: "g"}
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'g'}, got)
+}
+
+func TestGraffiti_ValidatorIndex(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
+	}
+	v := &validator{
+		db:              valDB,
+		validatorClient: m.validatorClient,
+		graffitiStruct: &graffiti.Graffiti{
+			Ordered: []string{"b"},
+			Default: "c",
+		},
+	}
+	ctx := context.Background()
+
+	// The validator index is not fetched without graffiti specific to validator indices.
+	got, err := v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'b'}, got)
+
+	// The graffiti is not known when the validator index can't be fetched.
+	v.graffitiStruct.Specific = map[primitives.ValidatorIndex]string{2: "d"}
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		Return(nil, errors.New("beacon node is unreachable"))
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{}, got)
+
+	// The graffiti specific to validator indices doesn't apply to a validator without an index.
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		Return(nil, status.Error(codes.NotFound, "not found"))
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'b'}, got)
+
+	// The graffiti picked from the random list is not known in advance.
+	v.graffitiStruct = &graffiti.Graffiti{
+		Random:  []string{"e", "f"},
+		Default: "c",
+	}
+	got, err = v.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{}, got)
+}
+
+func TestBuilderMinBid(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	otherKey := [fieldparams.BLSPubkeyLength]byte{'b'}
+	v := &validator{}
+	require.Equal(t, uint64(0), v.builderMinBid(pubKey))
+
+	v.proposerSettings = &validatorserviceconfig.ProposerSettings{
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			pubKey: {
+				BuilderConfig: &validatorserviceconfig.BuilderConfig{Enabled: true, MinBidGwei: 2000},
+			},
+		},
+		DefaultConfig: &validatorserviceconfig.ProposerOption{
+			BuilderConfig: &validatorserviceconfig.BuilderConfig{Enabled: true, MinBidGwei: 1000},
+		},
+	}
+	require.Equal(t, uint64(2000), v.builderMinBid(pubKey))
+	require.Equal(t, uint64(1000), v.builderMinBid(otherKey))
+
+	// The minimum bid is not sent when the builder is disabled for the validator.
+	v.proposerSettings.ProposeConfig[pubKey].BuilderConfig.Enabled = false
+	require.Equal(t, uint64(0), v.builderMinBid(pubKey))
+}
+
 func TestGetGraffitiOrdered_Ok(t *testing.T) {
 	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
 	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
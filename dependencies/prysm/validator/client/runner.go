@@ -59,12 +59,17 @@ func run(ctx context.Context, v iface.Validator) {
 		log.Warn("Validator client started without proposer settings such as fee recipient" +
 			" and will continue to use settings provided in the beacon node.")
 	}
-	deadline := time.Now().Add(5 * time.Minute)
-	if err := v.PushProposerSettings(ctx, km, headSlot, deadline); err != nil {
-		if errors.Is(err, ErrBuilderValidatorRegistration) {
-			log.WithError(err).Warn("Push proposer settings error")
-		} else {
-			log.WithError(err).Fatal("Failed to update proposer settings") // allow fatal. skipcq
+	v.StartLeaseElection(ctx)
+	// A standby client pushes its proposer settings once it starts signing.
+	signing := v.SigningEnabled()
+	if signing {
+		deadline := time.Now().Add(5 * time.Minute)
+		if err := v.PushProposerSettings(ctx, km, headSlot, deadline); err != nil {
+			if errors.Is(err, ErrBuilderValidatorRegistration) {
+				log.WithError(err).Warn("Push proposer settings error")
+			} else {
+				log.WithError(err).Fatal("Failed to update proposer settings") // allow fatal. skipcq
+			}
 		}
 	}
 	for {
@@ -101,9 +106,20 @@ func run(ctx context.Context, v iface.Validator) {
 				continue
 			}
 
+			if !v.SigningEnabled() {
+				if signing {
+					log.Info("Standing by while another validator client holds the lease")
+				}
+				signing = false
+				cancel()
+				span.End()
+				continue
+			}
+
 			// call push proposer setting at the start of each epoch to account for the following edge case:
-			// proposer is activated at the start of epoch and tries to propose immediately
-			if slots.IsEpochStart(slot) {
+			// proposer is activated at the start of epoch and tries to propose immediately.
+			// A client which just started signing pushes them as it did not before.
+			if slots.IsEpochStart(slot) || !signing {
 				go func() {
 					// deadline set for 1 epoch from call to not overlap.
 					epochDeadline := v.SlotDeadline(slot + params.BeaconConfig().SlotsPerEpoch - 1)
@@ -113,6 +129,8 @@ func run(ctx context.Context, v iface.Validator) {
 				}()
 			}
 
+			signing = true
+
 			// Start fetching domain data for the next epoch.
 			if slots.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
@@ -185,6 +185,27 @@ func TestBothProposesAndAttests_NextSlot(t *testing.T) {
 	assert.Equal(t, uint64(slot), v.ProposeBlockArg1, "ProposeBlock was called with wrong arg")
 }
 
+func TestStandby_NoDuties(t *testing.T) {
+	v := &testutil.FakeValidator{Km: &mockKeymanager{accountsChangedFeed: &event.Feed{}}, Standby: true}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	slot := primitives.Slot(55)
+	ticker := make(chan primitives.Slot)
+	v.NextSlotRet = ticker
+	v.RolesAtRet = []iface.ValidatorRole{iface.RoleAttester, iface.RoleProposer}
+	go func() {
+		ticker <- slot
+
+		cancel()
+	}()
+	timer := time.NewTimer(200 * time.Millisecond)
+	run(ctx, v)
+	<-timer.C
+	require.Equal(t, true, v.UpdateDutiesCalled, "Expected UpdateAssignments(%d) to be called", slot)
+	assert.Equal(t, false, v.AttestToBlockHeadCalled, "SubmitAttestation(%d) was called while standing by", slot)
+	assert.Equal(t, false, v.ProposeBlockCalled, "ProposeBlock(%d) was called while standing by", slot)
+}
+
 func TestKeyReload_ActiveKey(t *testing.T) {
 	ctx := context.Background()
 	km := &mockKeymanager{}
@@ -24,6 +24,7 @@ import (
 	beaconApi "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-chain-client-factory"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/lease"
 	nodeClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/node-client-factory"
 	validatorClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/validator-client-factory"
 	"github.com/prysmaticlabs/prysm/v4/validator/db"
@@ -76,6 +77,11 @@ type ValidatorService struct {
 	Web3SignerConfig       *remoteweb3signer.SetupConfig
 	proposerSettings       *validatorserviceconfig.ProposerSettings
 	validatorsRegBatchSize int
+	distributed            bool
+	leaseStore             lease.Store
+	leaseID                string
+	leaseTTL               time.Duration
+	leaseElector           *lease.Elector
 }
 
 // Config for the validator service.
@@ -102,6 +108,10 @@ type Config struct {
 	BeaconApiEndpoint          string
 	BeaconApiTimeout           time.Duration
 	ValidatorsRegBatchSize     int
+	Distributed                bool
+	LeaseStore                 lease.Store
+	LeaseID                    string
+	LeaseTTL                   time.Duration
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -131,6 +141,10 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		Web3SignerConfig:       cfg.Web3SignerConfig,
 		proposerSettings:       cfg.ProposerSettings,
 		validatorsRegBatchSize: cfg.ValidatorsRegBatchSize,
+		distributed:            cfg.Distributed,
+		leaseStore:             cfg.LeaseStore,
+		leaseID:                cfg.LeaseID,
+		leaseTTL:               cfg.LeaseTTL,
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -191,14 +205,20 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
-	restHandler := &beaconApi.BeaconApiJsonRestHandler{
-		HttpClient: http.Client{Timeout: v.conn.GetBeaconApiTimeout()},
-		Host:       v.conn.GetBeaconApiUrl(),
-	}
+	restHandler := beaconApi.NewJsonRestHandler(v.ctx, http.Client{Timeout: v.conn.GetBeaconApiTimeout()}, v.conn.GetBeaconApiUrl())
 
 	evHandler := beaconApi.NewEventHandler(http.DefaultClient, v.conn.GetBeaconApiUrl())
+	if failover, ok := restHandler.(*beaconApi.FailoverJsonRestHandler); ok {
+		// Events are streamed from the beacon node requests are currently sent to.
+		evHandler = beaconApi.NewFailoverEventHandler(http.DefaultClient, failover)
+	}
 	opts := []beaconApi.ValidatorClientOpt{beaconApi.WithEventHandler(evHandler)}
-	validatorClient := validatorClientFactory.NewValidatorClient(v.conn, restHandler, opts...)
+	var validatorClient iface.ValidatorClient
+	if v.distributed {
+		validatorClient = validatorClientFactory.NewDistributedValidatorClient(v.conn, restHandler, opts...)
+	} else {
+		validatorClient = validatorClientFactory.NewValidatorClient(v.conn, restHandler, opts...)
+	}
 
 	valStruct := &validator{
 		validatorClient:                validatorClient,
@@ -230,6 +250,12 @@ func (v *ValidatorService) Start() {
 		proposerSettings:               v.proposerSettings,
 		walletInitializedChannel:       make(chan *wallet.Wallet, 1),
 		validatorsRegBatchSize:         v.validatorsRegBatchSize,
+		distributed:                    v.distributed,
+	}
+
+	if v.leaseStore != nil {
+		v.leaseElector = lease.NewElector(v.leaseStore, v.leaseID, v.leaseTTL, valStruct.checkDoppelGangerOnTakeover)
+		valStruct.lease = v.leaseElector
 	}
 
 	v.validator = valStruct
@@ -254,6 +280,15 @@ func (v *ValidatorService) Status() error {
 	return nil
 }
 
+// LeaseStatus returns the status of the election of the validator client which signs among the
+// clients sharing the same keys, or nil if no lease is configured or the service did not start.
+func (v *ValidatorService) LeaseStatus() *lease.Status {
+	if v.leaseElector == nil {
+		return nil
+	}
+	return v.leaseElector.Status()
+}
+
 // InteropKeysConfig returns the useInteropKeys flag.
 func (v *ValidatorService) InteropKeysConfig() *local.InteropKeymanagerConfig {
 	return v.interopKeysConfig
@@ -284,6 +319,21 @@ func (v *ValidatorService) SetProposerSettings(ctx context.Context, settings *va
 	return v.validator.SetProposerSettings(ctx, settings)
 }
 
+// Graffiti returns the graffiti for the public key from the underlying validator.
+func (v *ValidatorService) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
+	return v.validator.Graffiti(ctx, pubKey)
+}
+
+// SetGraffiti sets the graffiti for the public key on the underlying validator.
+func (v *ValidatorService) SetGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
+	return v.validator.SetGraffiti(ctx, pubKey, graffiti)
+}
+
+// DeleteGraffiti deletes the graffiti for the public key on the underlying validator.
+func (v *ValidatorService) DeleteGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	return v.validator.DeleteGraffiti(ctx, pubKey)
+}
+
 // ConstructDialOptions constructs a list of grpc dial options
 func ConstructDialOptions(
 	maxCallRecvMsgSize int,
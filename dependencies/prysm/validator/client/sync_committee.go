@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	emptypb "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -18,6 +19,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -55,7 +57,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot primiti
 		return
 	}
 
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     r[:],
 		SignatureDomain: d.SignatureDomain,
@@ -117,7 +119,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot p
 		return
 	}
 
-	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes)
+	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes, duty.ValidatorIndex)
 	if err != nil {
 		log.WithError(err).Error("Could not get selection proofs")
 		return
@@ -188,8 +190,16 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot p
 }
 
 // Signs and returns selection proofs per validator for slot and pub key.
-func (v *validator) selectionProofs(ctx context.Context, slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse) ([][]byte, error) {
+// In distributed mode, the aggregated selection proofs received from the middleware are returned instead.
+func (v *validator) selectionProofs(
+	ctx context.Context,
+	slot primitives.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	indexRes *ethpb.SyncSubcommitteeIndexResponse,
+	validatorIndex primitives.ValidatorIndex,
+) ([][]byte, error) {
 	selectionProofs := make([][]byte, len(indexRes.Indices))
+	selections := make([]iface.SyncCommitteeSelection, len(indexRes.Indices))
 	cfg := params.BeaconConfig()
 	size := cfg.SyncCommitteeSize
 	subCount := cfg.SyncCommitteeSubnetCount
@@ -201,6 +211,34 @@ func (v *validator) selectionProofs(ctx context.Context, slot primitives.Slot, p
 			return nil, err
 		}
 		selectionProofs[i] = selectionProof
+		selections[i] = iface.SyncCommitteeSelection{
+			SelectionProof:    selectionProof,
+			Slot:              slot,
+			SubcommitteeIndex: primitives.CommitteeIndex(subnet),
+			ValidatorIndex:    validatorIndex,
+		}
+	}
+	if !v.distributed {
+		return selectionProofs, nil
+	}
+
+	aggregated, err := v.validatorClient.GetAggregatedSyncSelections(ctx, selections)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get aggregated sync selections")
+	}
+	aggregatedProofs := make(map[primitives.CommitteeIndex][]byte, len(aggregated))
+	for _, s := range aggregated {
+		if s.Slot != slot || s.ValidatorIndex != validatorIndex {
+			continue
+		}
+		aggregatedProofs[s.SubcommitteeIndex] = s.SelectionProof
+	}
+	for i, s := range selections {
+		proof, ok := aggregatedProofs[s.SubcommitteeIndex]
+		if !ok {
+			return nil, errors.Errorf("aggregated sync selection proof not found for subcommittee index %d", s.SubcommitteeIndex)
+		}
+		selectionProofs[i] = proof
 	}
 	return selectionProofs, nil
 }
@@ -219,7 +257,7 @@ func (v *validator) signSyncSelectionData(ctx context.Context, pubKey [fieldpara
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -242,7 +280,7 @@ func (v *validator) signContributionAndProof(ctx context.Context, pubKey [fieldp
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: d.SignatureDomain,
@@ -33,6 +33,7 @@ type FakeValidator struct {
 	DeleteProtectionCalled            bool
 	SlotDeadlineCalled                bool
 	HandleKeyReloadCalled             bool
+	Standby                           bool
 	WaitForChainStartCalled           int
 	WaitForSyncCalled                 int
 	WaitForActivationCalled           int
@@ -286,6 +287,21 @@ func (fv *FakeValidator) SetProposerSettings(_ context.Context, settings *valida
 	return nil
 }
 
+// Graffiti for mocking
+func (*FakeValidator) Graffiti(_ context.Context, _ [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
+	return []byte{}, nil
+}
+
+// SetGraffiti for mocking
+func (*FakeValidator) SetGraffiti(_ context.Context, _ [fieldparams.BLSPubkeyLength]byte, _ []byte) error {
+	return nil
+}
+
+// DeleteGraffiti for mocking
+func (*FakeValidator) DeleteGraffiti(_ context.Context, _ [fieldparams.BLSPubkeyLength]byte) error {
+	return nil
+}
+
 func (fv *FakeValidator) StartEventStream(_ context.Context) error {
 	return nil
 }
@@ -297,3 +313,11 @@ func (fv *FakeValidator) EventStreamIsRunning() bool {
 func (fv *FakeValidator) NodeIsHealthy(context.Context) bool {
 	return true
 }
+
+// StartLeaseElection for mocking
+func (*FakeValidator) StartLeaseElection(_ context.Context) {}
+
+// SigningEnabled for mocking
+func (fv *FakeValidator) SigningEnabled() bool {
+	return !fv.Standby
+}
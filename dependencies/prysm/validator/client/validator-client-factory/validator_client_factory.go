@@ -1,6 +1,8 @@
 package validator_client_factory
 
 import (
+	"context"
+
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	beaconApi "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
 	grpcApi "github.com/prysmaticlabs/prysm/v4/validator/client/grpc-api"
@@ -19,3 +21,36 @@ func NewValidatorClient(
 		return grpcApi.NewGrpcValidatorClient(validatorConn.GetGrpcClientConn())
 	}
 }
+
+// NewDistributedValidatorClient returns the validator client of the --distributed mode. The gRPC API has no
+// selection endpoints, so with the gRPC client the selection proofs are sent to the DVT middleware through
+// its beacon REST API, while every other request still goes through gRPC.
+func NewDistributedValidatorClient(
+	validatorConn validatorHelpers.NodeConnection,
+	jsonRestHandler beaconApi.JsonRestHandler,
+	opt ...beaconApi.ValidatorClientOpt,
+) iface.ValidatorClient {
+	c := NewValidatorClient(validatorConn, jsonRestHandler, opt...)
+	if features.Get().EnableBeaconRESTApi {
+		return c
+	}
+	return &grpcDistributedValidatorClient{
+		ValidatorClient: c,
+		selections:      beaconApi.NewBeaconApiValidatorClient(jsonRestHandler),
+	}
+}
+
+// grpcDistributedValidatorClient is a gRPC validator client which gets aggregated selections from the
+// beacon REST API of the DVT middleware.
+type grpcDistributedValidatorClient struct {
+	iface.ValidatorClient
+	selections iface.ValidatorClient
+}
+
+func (c *grpcDistributedValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return c.selections.GetAggregatedSelections(ctx, selections)
+}
+
+func (c *grpcDistributedValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return c.selections.GetAggregatedSyncSelections(ctx, selections)
+}
@@ -0,0 +1,54 @@
+package validator_client_factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	beaconApi "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	validatorHelpers "github.com/prysmaticlabs/prysm/v4/validator/helpers"
+)
+
+func TestNewDistributedValidatorClient_Grpc(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBeaconRESTApi: false})
+	defer resetCfg()
+
+	// The DVT middleware combines the selection proofs.
+	combined := bytes.Repeat([]byte{2}, 96)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/validator/beacon_committee_selections", func(w http.ResponseWriter, r *http.Request) {
+		var selections []iface.BeaconCommitteeSelection
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&selections))
+		selections[0].SelectionProof = combined
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": selections}))
+	})
+	mux.HandleFunc("/eth/v1/validator/sync_committee_selections", func(w http.ResponseWriter, r *http.Request) {
+		var selections []iface.SyncCommitteeSelection
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&selections))
+		selections[0].SelectionProof = combined
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": selections}))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	conn := validatorHelpers.NewNodeConnection(nil, srv.URL, time.Second)
+	c := NewDistributedValidatorClient(conn, beaconApi.NewJsonRestHandler(ctx, http.Client{Timeout: time.Second}, srv.URL))
+
+	selections, err := c.GetAggregatedSelections(ctx, []iface.BeaconCommitteeSelection{{SelectionProof: bytes.Repeat([]byte{1}, 96), Slot: 1, ValidatorIndex: 3}})
+	require.NoError(t, err)
+	require.DeepEqual(t, []iface.BeaconCommitteeSelection{{SelectionProof: combined, Slot: 1, ValidatorIndex: 3}}, selections)
+	syncSelections, err := c.GetAggregatedSyncSelections(ctx, []iface.SyncCommitteeSelection{{SelectionProof: bytes.Repeat([]byte{1}, 96), Slot: 1, SubcommitteeIndex: 2, ValidatorIndex: 3}})
+	require.NoError(t, err)
+	require.DeepEqual(t, []iface.SyncCommitteeSelection{{SelectionProof: combined, Slot: 1, SubcommitteeIndex: 2, ValidatorIndex: 3}}, syncSelections)
+}
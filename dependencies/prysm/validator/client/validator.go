@@ -28,14 +28,17 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	accountsiface "github.com/prysmaticlabs/prysm/v4/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
 	beacon_api "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/lease"
 	vdb "github.com/prysmaticlabs/prysm/v4/validator/db"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
 	"github.com/prysmaticlabs/prysm/v4/validator/graffiti"
@@ -56,6 +59,7 @@ import (
 var (
 	ErrBuilderValidatorRegistration = errors.New("Builder API validator registration unsuccessful")
 	ErrValidatorsAllExited          = errors.New("All validators are exited, no more work to perform...")
+	ErrLeaseLost                    = errors.New("lease lost to another validator client, not signing")
 )
 
 var (
@@ -105,6 +109,15 @@ type validator struct {
 	proposerSettings                   *validatorserviceconfig.ProposerSettings
 	walletInitializedChannel           chan *wallet.Wallet
 	validatorsRegBatchSize             int
+	distributed                        bool
+	attSelectionLock                   sync.Mutex
+	attSelections                      map[attSelectionKey]iface.BeaconCommitteeSelection
+	lease                              *lease.Elector
+}
+
+type attSelectionKey struct {
+	slot  primitives.Slot
+	index primitives.ValidatorIndex
 }
 
 type validatorStatus struct {
@@ -431,6 +444,38 @@ func (v *validator) CanonicalHeadSlot(ctx context.Context) (primitives.Slot, err
 	return head.HeadSlot, nil
 }
 
+// StartLeaseElection takes part in the election of the validator client which signs among the
+// clients sharing the same keys, if a lease is configured. A client taking over the lease only
+// signs once the doppelganger check passes.
+func (v *validator) StartLeaseElection(ctx context.Context) {
+	if v.lease == nil {
+		return
+	}
+	go v.lease.Start(ctx)
+}
+
+// SigningEnabled returns whether the validator client may sign, which is not the case while
+// another client sharing its keys holds the lease.
+func (v *validator) SigningEnabled() bool {
+	return v.lease == nil || v.lease.Active()
+}
+
+// sign signs the request with the keymanager, unless the lease was lost since the start of the
+// slot, in which case ErrLeaseLost is returned.
+func (v *validator) sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	return v.leaseSigner(v.keyManager.Sign)(ctx, req)
+}
+
+// leaseSigner wraps signer to return ErrLeaseLost instead of signing once the lease is lost.
+func (v *validator) leaseSigner(signer iface.SigningFunc) iface.SigningFunc {
+	return func(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+		if !v.SigningEnabled() {
+			return nil, ErrLeaseLost
+		}
+		return signer(ctx, req)
+	}
+}
+
 // NextSlot emits the next slot number at the start time of that slot.
 func (v *validator) NextSlot() <-chan primitives.Slot {
 	return v.ticker.C()
@@ -448,6 +493,21 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	if !features.Get().EnableDoppelGanger {
 		return nil
 	}
+	return v.checkDoppelGanger(ctx, false)
+}
+
+// checkDoppelGangerOnTakeover checks that no other instance of the actively provided keys is live
+// in the network before signing after taking over the lease. The slashing protection history is
+// ignored, as it may be shared with the previous lease holder, whose recent attestations would
+// make the beacon node skip the liveness check.
+func (v *validator) checkDoppelGangerOnTakeover(ctx context.Context) error {
+	return v.checkDoppelGanger(ctx, true)
+}
+
+// checkDoppelGanger checks that no other instance of the actively provided keys
+// is live in the network, regardless of whether the check is enabled on startup.
+// If the history is ignored, the keys are checked as if they never attested.
+func (v *validator) checkDoppelGanger(ctx context.Context, ignoreHistory bool) error {
 	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
 	if err != nil {
 		return err
@@ -460,9 +520,12 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
 	for _, pkey := range pubkeys {
 		copiedKey := pkey
-		attRec, err := v.db.AttestationHistoryForPubKey(ctx, copiedKey)
-		if err != nil {
-			return err
+		var attRec []*kv.AttestationRecord
+		if !ignoreHistory {
+			attRec, err = v.db.AttestationHistoryForPubKey(ctx, copiedKey)
+			if err != nil {
+				return err
+			}
 		}
 		if len(attRec) == 0 {
 			// If no history exists we simply send in a zero
@@ -629,6 +692,13 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 	subscribeValidatorIndices := make([]primitives.ValidatorIndex, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	alreadySubscribed := make(map[[64]byte]bool)
 
+	if v.distributed {
+		// Get aggregated selection proofs to calculate isAggregator.
+		if err := v.getAggregatedSelectionProofs(ctx, res); err != nil {
+			return errors.Wrap(err, "could not get aggregated selection proofs")
+		}
+	}
+
 	for _, duty := range res.CurrentEpochDuties {
 		pk := bytesutil.ToBytes48(duty.PublicKey)
 		if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
@@ -641,7 +711,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk)
+			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk, validatorIndex)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -667,7 +737,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey), validatorIndex)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -718,7 +788,7 @@ func (v *validator) RolesAt(ctx context.Context, slot primitives.Slot) (map[[fie
 		if duty.AttesterSlot == slot {
 			roles = append(roles, iface.RoleAttester)
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -743,7 +813,7 @@ func (v *validator) RolesAt(ctx context.Context, slot primitives.Slot) (map[[fie
 			}
 		}
 		if inSyncCommittee {
-			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is a sync committee aggregator")
 			}
@@ -773,13 +843,29 @@ func (v *validator) Keymanager() (keymanager.IKeymanager, error) {
 
 // isAggregator checks if a validator is an aggregator of a given slot and committee,
 // it uses a modulo calculated by validator count in committee and samples randomness around it.
-func (v *validator) isAggregator(ctx context.Context, committee []primitives.ValidatorIndex, slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+// In distributed mode, the aggregated selection proof received from the middleware is used
+// instead of the validator's own partial one.
+func (v *validator) isAggregator(
+	ctx context.Context,
+	committee []primitives.ValidatorIndex,
+	slot primitives.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	validatorIndex primitives.ValidatorIndex,
+) (bool, error) {
 	modulo := uint64(1)
 	if len(committee)/int(params.BeaconConfig().TargetAggregatorsPerCommittee) > 1 {
 		modulo = uint64(len(committee)) / params.BeaconConfig().TargetAggregatorsPerCommittee
 	}
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	var (
+		slotSig []byte
+		err     error
+	)
+	if v.distributed {
+		slotSig, err = v.attSelection(attSelectionKey{slot: slot, index: validatorIndex})
+	} else {
+		slotSig, err = v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	}
 	if err != nil {
 		return false, err
 	}
@@ -797,7 +883,12 @@ func (v *validator) isAggregator(ctx context.Context, committee []primitives.Val
 //
 //	modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
 //	return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
-func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+func (v *validator) isSyncCommitteeAggregator(
+	ctx context.Context,
+	slot primitives.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	validatorIndex primitives.ValidatorIndex,
+) (bool, error) {
 	res, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
 		PublicKey: pubKey[:],
 		Slot:      slot,
@@ -805,14 +896,15 @@ func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot primitiv
 	if err != nil {
 		return false, err
 	}
+	if len(res.Indices) == 0 {
+		return false, nil
+	}
 
-	for _, index := range res.Indices {
-		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
-		subnet := uint64(index) / subCommitteeSize
-		sig, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
-		if err != nil {
-			return false, err
-		}
+	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, res, validatorIndex)
+	if err != nil {
+		return false, err
+	}
+	for _, sig := range selectionProofs {
 		isAggregator, err := altair.IsSyncCommitteeAggregator(sig)
 		if err != nil {
 			return false, err
@@ -825,6 +917,63 @@ func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot primitiv
 	return false, nil
 }
 
+// getAggregatedSelectionProofs signs the selection proofs of all active validators for their attester slots
+// in the current and next epoch, sends them to the distributed validator middleware and stores
+// the aggregated selection proofs it returns for later aggregator checks.
+func (v *validator) getAggregatedSelectionProofs(ctx context.Context, duties *ethpb.DutiesResponse) error {
+	var req []iface.BeaconCommitteeSelection
+	for _, epochDuties := range [][]*ethpb.DutiesResponse_Duty{duties.CurrentEpochDuties, duties.NextEpochDuties} {
+		for _, duty := range epochDuties {
+			if duty.Status != ethpb.ValidatorStatus_ACTIVE && duty.Status != ethpb.ValidatorStatus_EXITING {
+				continue
+			}
+
+			pk := bytesutil.ToBytes48(duty.PublicKey)
+			slotSig, err := v.signSlotWithSelectionProof(ctx, pk, duty.AttesterSlot)
+			if err != nil {
+				return err
+			}
+
+			req = append(req, iface.BeaconCommitteeSelection{
+				SelectionProof: slotSig,
+				Slot:           duty.AttesterSlot,
+				ValidatorIndex: duty.ValidatorIndex,
+			})
+		}
+	}
+	if len(req) == 0 {
+		return nil
+	}
+
+	resp, err := v.validatorClient.GetAggregatedSelections(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	selections := make(map[attSelectionKey]iface.BeaconCommitteeSelection, len(resp))
+	for _, s := range resp {
+		selections[attSelectionKey{slot: s.Slot, index: s.ValidatorIndex}] = s
+	}
+
+	v.attSelectionLock.Lock()
+	v.attSelections = selections
+	v.attSelectionLock.Unlock()
+
+	return nil
+}
+
+// attSelection returns the aggregated selection proof of the validator for the given attester slot.
+func (v *validator) attSelection(key attSelectionKey) ([]byte, error) {
+	v.attSelectionLock.Lock()
+	defer v.attSelectionLock.Unlock()
+
+	s, ok := v.attSelections[key]
+	if !ok {
+		return nil, errors.Errorf("selection proof not found for the given slot=%d and validator_index=%d", key.slot, key.index)
+	}
+	return s.SelectionProof, nil
+}
+
 // UpdateDomainDataCaches by making calls for all of the possible domain data. These can change when
 // the fork version changes which can happen once per epoch. Although changing for the fork version
 // is very rare, a validator should check these data every epoch to be sure the validator is
@@ -1035,7 +1184,7 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 		return err
 	}
 
-	signedRegReqs := v.buildSignedRegReqs(ctx, filteredKeys, km.Sign)
+	signedRegReqs := v.buildSignedRegReqs(ctx, filteredKeys, v.leaseSigner(km.Sign))
 	if err := SubmitValidatorRegistrations(ctx, v.validatorClient, signedRegReqs, v.validatorsRegBatchSize); err != nil {
 		return errors.Wrap(ErrBuilderValidatorRegistration, err.Error())
 	}
@@ -34,6 +34,7 @@ import (
 	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/lease"
 	dbTest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/local"
@@ -1099,6 +1100,59 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 	}
 }
 
+func TestValidator_CheckDoppelGangerOnTakeover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := validatormock.NewMockValidatorClient(ctrl)
+	km := genMockKeymanager(t, 2)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	db := dbTest.SetupDB(t, keys)
+	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
+	resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
+	for _, k := range keys {
+		pkey := k
+		// Recent attestations of the previous lease holder, in the shared history, must not skip the liveness check.
+		att := createAttestation(10, 12)
+		rt, err := att.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
+		req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
+			PublicKey:  pkey[:],
+			Epoch:      0,
+			SignedRoot: make([]byte, fieldparams.RootLength),
+		})
+		resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
+	}
+	v := &validator{
+		validatorClient: client,
+		keyManager:      km,
+		db:              db,
+	}
+	client.EXPECT().CheckDoppelGanger(
+		gomock.Any(),                     // ctx
+		&doppelGangerRequestMatcher{req}, // request
+	).Return(resp, nil /*err*/)
+
+	require.ErrorContains(t, "Duplicate instances exists in the network", v.checkDoppelGangerOnTakeover(context.Background()))
+}
+
+func TestValidator_sign_LeaseLost(t *testing.T) {
+	km := genMockKeymanager(t, 1)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	req := &validatorpb.SignRequest{PublicKey: keys[0][:], SigningRoot: make([]byte, fieldparams.RootLength)}
+
+	v := &validator{keyManager: km}
+	_, err = v.sign(context.Background(), req)
+	require.NoError(t, err)
+
+	// An elector which does not hold the lease, e.g. after losing it during the slot.
+	v.lease = lease.NewElector(nil, "standby", time.Minute, nil)
+	_, err = v.sign(context.Background(), req)
+	require.ErrorIs(t, err, ErrLeaseLost)
+}
+
 func TestValidatorAttestationsAreOrdered(t *testing.T) {
 	km := genMockKeymanager(t, 10)
 	keys, err := km.FetchValidatingPublicKeys(context.Background())
@@ -1165,7 +1219,7 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 		},
 	).Return(&ethpb.SyncSubcommitteeIndexResponse{}, nil /*err*/)
 
-	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey))
+	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 0)
 	require.NoError(t, err)
 	require.Equal(t, false, aggregator)
 
@@ -1186,11 +1240,155 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 		},
 	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []primitives.CommitteeIndex{0}}, nil /*err*/)
 
-	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey))
+	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 0)
+	require.NoError(t, err)
+	require.Equal(t, true, aggregator)
+}
+
+func TestIsSyncCommitteeAggregator_Distributed_OK(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	v.distributed = true
+	slot := primitives.Slot(1)
+	pubKey := validatorKey.PublicKey().Marshal()
+
+	m.validatorClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		&ethpb.SyncSubcommitteeIndexRequest{
+			PublicKey: validatorKey.PublicKey().Marshal(),
+			Slot:      1,
+		},
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{}, nil /*err*/)
+
+	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 123)
+	require.NoError(t, err)
+	require.Equal(t, false, aggregator)
+
+	c := params.BeaconConfig().Copy()
+	c.TargetAggregatorsPerSyncSubcommittee = math.MaxUint64
+	params.OverrideBeaconConfig(c)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/).AnyTimes()
+
+	m.validatorClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		&ethpb.SyncSubcommitteeIndexRequest{
+			PublicKey: validatorKey.PublicKey().Marshal(),
+			Slot:      1,
+		},
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []primitives.CommitteeIndex{0}}, nil /*err*/)
+
+	sig, err := v.signSyncSelectionData(context.Background(), bytesutil.ToBytes48(pubKey), 0, slot)
+	require.NoError(t, err)
+
+	selection := iface.SyncCommitteeSelection{
+		SelectionProof:    sig,
+		Slot:              1,
+		ValidatorIndex:    123,
+		SubcommitteeIndex: 0,
+	}
+	m.validatorClient.EXPECT().GetAggregatedSyncSelections(
+		gomock.Any(), // ctx
+		[]iface.SyncCommitteeSelection{selection},
+	).Return([]iface.SyncCommitteeSelection{selection}, nil)
+
+	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 123)
 	require.NoError(t, err)
 	require.Equal(t, true, aggregator)
 }
 
+func TestIsAggregator_Distributed(t *testing.T) {
+	v, _, validatorKey, finish := setup(t)
+	defer finish()
+
+	v.distributed = true
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+
+	_, err := v.isAggregator(context.Background(), []primitives.ValidatorIndex{0, 123}, 1, pubKey, 123)
+	require.ErrorContains(t, "selection proof not found for the given slot=1 and validator_index=123", err)
+
+	v.attSelections = map[attSelectionKey]iface.BeaconCommitteeSelection{
+		{slot: 1, index: 123}: {
+			SelectionProof: make([]byte, 96),
+			Slot:           1,
+			ValidatorIndex: 123,
+		},
+	}
+	aggregator, err := v.isAggregator(context.Background(), []primitives.ValidatorIndex{0, 123}, 1, pubKey, 123)
+	require.NoError(t, err)
+	require.Equal(t, true, aggregator)
+}
+
+func TestGetAggregatedSelectionProofs(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	v.distributed = true
+	pubKey := validatorKey.PublicKey().Marshal()
+	duties := &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:   1,
+				ValidatorIndex: 123,
+				PublicKey:      pubKey,
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:   33,
+				ValidatorIndex: 123,
+				PublicKey:      pubKey,
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+			{
+				AttesterSlot:   34,
+				ValidatorIndex: 124,
+				PublicKey:      pubKey,
+				Status:         ethpb.ValidatorStatus_EXITED,
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/).AnyTimes()
+
+	aggregated := []iface.BeaconCommitteeSelection{
+		{
+			SelectionProof: bytesutil.PadTo([]byte{1}, 96),
+			Slot:           1,
+			ValidatorIndex: 123,
+		},
+		{
+			SelectionProof: bytesutil.PadTo([]byte{2}, 96),
+			Slot:           33,
+			ValidatorIndex: 123,
+		},
+	}
+	m.validatorClient.EXPECT().GetAggregatedSelections(
+		gomock.Any(), // ctx
+		gomock.Len(2),
+	).Return(aggregated, nil)
+
+	require.NoError(t, v.getAggregatedSelectionProofs(context.Background(), duties))
+
+	proof, err := v.attSelection(attSelectionKey{slot: 1, index: 123})
+	require.NoError(t, err)
+	assert.DeepEqual(t, aggregated[0].SelectionProof, proof)
+	proof, err = v.attSelection(attSelectionKey{slot: 33, index: 123})
+	require.NoError(t, err)
+	assert.DeepEqual(t, aggregated[1].SelectionProof, proof)
+	_, err = v.attSelection(attSelectionKey{slot: 34, index: 124})
+	require.ErrorContains(t, "selection proof not found", err)
+}
+
 func TestValidator_WaitForKeymanagerInitialization_web3Signer(t *testing.T) {
 	ctx := context.Background()
 	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
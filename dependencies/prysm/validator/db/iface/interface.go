@@ -11,10 +11,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/monitoring/backup"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/postgres"
 )
 
-// Ensure the kv store implements the interface.
+// Ensure the kv and postgres stores implement the interface.
 var _ = ValidatorDB(&kv.Store{})
+var _ = ValidatorDB(&kv.MinimalStore{})
+var _ = ValidatorDB(&postgres.Store{})
 
 // ValidatorDB defines the necessary methods for a Prysm validator DB.
 type ValidatorDB interface {
@@ -32,6 +35,8 @@ type ValidatorDB interface {
 
 	// Proposer protection related methods.
 	HighestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error)
+	// LowestSignedProposal returns the slot below which blocks are refused as per EIP-3076. In the
+	// minimal slashing protection mode only the highest signed slot is kept, which is returned instead.
 	LowestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error)
 	ProposalHistoryForPubKey(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) ([]*kv.Proposal, error)
 	ProposalHistoryForSlot(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot) ([32]byte, bool, bool, error)
@@ -44,6 +49,9 @@ type ValidatorDB interface {
 	EIPImportBlacklistedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error)
 	SaveEIPImportBlacklistedPublicKeys(ctx context.Context, publicKeys [][fieldparams.BLSPubkeyLength]byte) error
 	SigningRootAtTargetEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte, target primitives.Epoch) ([]byte, error)
+	// LowestSignedTargetEpoch and LowestSignedSourceEpoch return the epochs below which attestations
+	// are refused as per EIP-3076. In the minimal slashing protection mode only the highest signed
+	// epochs are kept, which are returned instead.
 	LowestSignedTargetEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error)
 	LowestSignedSourceEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error)
 	AttestedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error)
@@ -60,9 +68,12 @@ type ValidatorDB interface {
 		ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte,
 	) ([]*kv.AttestationRecord, error)
 
-	// Graffiti ordered index related methods
+	// Graffiti related methods
 	SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error
 	GraffitiOrderedIndex(ctx context.Context, fileHash [32]byte) (uint64, error)
+	SaveGraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error
+	GraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, bool, error)
+	DeleteGraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error
 
 	// ProposerSettings related methods
 	ProposerSettings(context.Context) (*validatorServiceConfig.ProposerSettings, error)
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+)
+
+// NewKVStore opens the validator database at the directory path specified. Only the minimal
+// slashing protection data is kept if the feature is enabled, and the complete history otherwise.
+func NewKVStore(ctx context.Context, dirPath string, config *kv.Config) (Database, error) {
+	if features.Get().EnableMinimalSlashingProtection {
+		valDB, err := kv.NewMinimalKVStore(ctx, dirPath, config)
+		if err != nil {
+			return nil, err
+		}
+		return valDB, nil
+	}
+	valDB, err := kv.NewKVStore(ctx, dirPath, config)
+	if err != nil {
+		return nil, err
+	}
+	return valDB, nil
+}
@@ -109,6 +109,35 @@ func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
 func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, error) {
+	kv, err := openKVStore(dirPath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Turn the watermarks saved by a minimal store back into a history when the
+	// database was last opened in minimal mode, so that switching between modes
+	// never loses slashing protection data.
+	if err := kv.migrateMinimalToComplete(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not convert minimal slashing protection data")
+	}
+
+	if features.Get().EnableSlashingProtectionPruning {
+		// Prune attesting records older than the current weak subjectivity period.
+		if err := kv.PruneAttestations(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not prune old attestations from DB")
+		}
+	}
+
+	// Batch save attestation records for slashing protection at timed
+	// intervals to our database.
+	go kv.batchAttestationWrites(ctx)
+
+	return kv, prometheus.Register(createBoltCollector(kv.db))
+}
+
+// openKVStore opens the boltDB key-value store at the directory path specified
+// and creates the kv-buckets based on the schema.
+func openKVStore(dirPath string, config *Config) (*Store, error) {
 	hasDir, err := file.HasDir(dirPath)
 	if err != nil {
 		return nil, err
@@ -152,7 +181,11 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			pubKeysBucket,
 			migrationsBucket,
 			graffitiBucket,
+			graffitiByPubKeyBucket,
 			proposerSettingsBucket,
+			minimalAttestationsBucket,
+			minimalProposalsBucket,
+			slashingProtectionModeBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -164,19 +197,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			return nil, err
 		}
 	}
-
-	if features.Get().EnableSlashingProtectionPruning {
-		// Prune attesting records older than the current weak subjectivity period.
-		if err := kv.PruneAttestations(ctx); err != nil {
-			return nil, errors.Wrap(err, "could not prune old attestations from DB")
-		}
-	}
-
-	// Batch save attestation records for slashing protection at timed
-	// intervals to our database.
-	go kv.batchAttestationWrites(ctx)
-
-	return kv, prometheus.Register(createBoltCollector(kv.db))
+	return kv, nil
 }
 
 // UpdatePublicKeysBuckets for a specified list of keys.
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
 )
 
 // SaveGraffitiOrderedIndex writes the current graffiti index to the db
@@ -37,3 +40,46 @@ func (s *Store) GraffitiOrderedIndex(_ context.Context, fileHash [32]byte) (uint
 	})
 	return orderedIndex, err
 }
+
+// SaveGraffitiForPubKey saves the graffiti set for a validator public key, overriding any graffiti
+// configured through the command line or graffiti file.
+func (s *Store) SaveGraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
+	_, span := trace.StartSpan(ctx, "validator.db.SaveGraffitiForPubKey")
+	defer span.End()
+	if len(graffiti) > fieldparams.RootLength {
+		return errors.Errorf("graffiti length %d exceeds maximum of %d bytes", len(graffiti), fieldparams.RootLength)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(graffitiByPubKeyBucket)
+		return bkt.Put(pubKey[:], graffiti)
+	})
+}
+
+// GraffitiForPubKey retrieves the graffiti saved for a validator public key, if any.
+func (s *Store) GraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, bool, error) {
+	_, span := trace.StartSpan(ctx, "validator.db.GraffitiForPubKey")
+	defer span.End()
+	var graffiti []byte
+	var exists bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(graffitiByPubKeyBucket)
+		g := bkt.Get(pubKey[:])
+		if g == nil {
+			return nil
+		}
+		exists = true
+		graffiti = bytesutil.SafeCopyBytes(g)
+		return nil
+	})
+	return graffiti, exists, err
+}
+
+// DeleteGraffitiForPubKey removes the graffiti saved for a validator public key.
+func (s *Store) DeleteGraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	_, span := trace.StartSpan(ctx, "validator.db.DeleteGraffitiForPubKey")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(graffitiByPubKeyBucket)
+		return bkt.Delete(pubKey[:])
+	})
+}
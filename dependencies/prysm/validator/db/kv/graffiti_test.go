@@ -58,3 +58,36 @@ func TestStore_GraffitiOrderedIndex_ReadAndWrite(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_GraffitiForPubKey(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+
+	_, exists, err := db.GraffitiForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+
+	require.NoError(t, db.SaveGraffitiForPubKey(ctx, pubKey, []byte("prysm")))
+	g, exists, err := db.GraffitiForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	require.DeepEqual(t, []byte("prysm"), g)
+
+	require.NoError(t, db.SaveGraffitiForPubKey(ctx, pubKey, []byte("lighthouse")))
+	g, _, err = db.GraffitiForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("lighthouse"), g)
+
+	require.NoError(t, db.DeleteGraffitiForPubKey(ctx, pubKey))
+	_, exists, err = db.GraffitiForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+}
+
+func TestStore_SaveGraffitiForPubKey_TooLong(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	err := db.SaveGraffitiForPubKey(ctx, [fieldparams.BLSPubkeyLength]byte{1}, make([]byte, 33))
+	require.ErrorContains(t, "exceeds maximum", err)
+}
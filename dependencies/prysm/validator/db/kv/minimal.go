@@ -0,0 +1,331 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+// MinimalStore is a validator database which only keeps the minimal slashing protection data
+// described in EIP-3076: the highest signed source and target epochs of each attester and the
+// highest signed slot of each proposer. Anything at or below these watermarks is refused, unless
+// it is a repeat of the very last message signed. Other data is saved as in the complete store.
+type MinimalStore struct {
+	*Store
+}
+
+// minimalAttestation is the highest signed attestation of a validator public key.
+type minimalAttestation struct {
+	source      primitives.Epoch
+	target      primitives.Epoch
+	signingRoot []byte
+}
+
+// minimalProposal is the highest signed proposal of a validator public key.
+type minimalProposal struct {
+	slot        primitives.Slot
+	signingRoot []byte
+}
+
+// NewMinimalKVStore initializes a minimal boltDB key-value store at the directory path specified.
+// The complete slashing protection history found in the database, if any, is replaced with its
+// watermarks.
+func NewMinimalKVStore(ctx context.Context, dirPath string, config *Config) (*MinimalStore, error) {
+	kv, err := openKVStore(dirPath, config)
+	if err != nil {
+		return nil, err
+	}
+	s := &MinimalStore{Store: kv}
+	if err := s.migrateCompleteToMinimal(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not convert slashing protection history")
+	}
+	return s, prometheus.Register(createBoltCollector(kv.db))
+}
+
+// RunUpMigrations runs the migrations of the complete store, as they may recover attesting
+// history from older schemas, and converts their result to watermarks.
+func (s *MinimalStore) RunUpMigrations(ctx context.Context) error {
+	if err := s.Store.RunUpMigrations(ctx); err != nil {
+		return err
+	}
+	return s.migrateCompleteToMinimal(ctx)
+}
+
+// migrateCompleteToMinimal merges the complete slashing protection history into the watermarks
+// in a single transaction, deletes the history and marks the database as minimal.
+func (s *MinimalStore) migrateCompleteToMinimal(ctx context.Context) error {
+	var converted int
+	err := s.update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(slashingProtectionModeBucket).Put(minimalModeKey, []byte{1}); err != nil {
+			return err
+		}
+		pubKeys := tx.Bucket(pubKeysBucket)
+		if err := pubKeys.ForEach(func(pubKey, _ []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			pkBucket := pubKeys.Bucket(pubKey)
+			if pkBucket == nil {
+				return nil
+			}
+			sourceEpochs := pkBucket.Bucket(attestationSourceEpochsBucket)
+			targetEpochs := pkBucket.Bucket(attestationTargetEpochsBucket)
+			if sourceEpochs == nil || targetEpochs == nil {
+				return nil
+			}
+			highestSource, _ := sourceEpochs.Cursor().Last()
+			highestTarget, _ := targetEpochs.Cursor().Last()
+			if highestSource == nil || highestTarget == nil {
+				return nil
+			}
+			att := &minimalAttestation{
+				source: bytesutil.BytesToEpochBigEndian(highestSource),
+				target: bytesutil.BytesToEpochBigEndian(highestTarget),
+			}
+			if signingRoots := pkBucket.Bucket(attestationSigningRootsBucket); signingRoots != nil {
+				att.signingRoot = bytesutil.SafeCopyBytes(signingRoots.Get(highestTarget))
+			}
+			converted++
+			return saveMinimalAttestation(tx, bytesutil.ToBytes48(pubKey), att)
+		}); err != nil {
+			return err
+		}
+
+		proposals := tx.Bucket(historicProposalsBucket)
+		if err := proposals.ForEach(func(pubKey, _ []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			valBucket := proposals.Bucket(pubKey)
+			if valBucket == nil {
+				return nil
+			}
+			highestSlot, signingRoot := valBucket.Cursor().Last()
+			if highestSlot == nil {
+				return nil
+			}
+			converted++
+			return saveMinimalProposal(tx, bytesutil.ToBytes48(pubKey), &minimalProposal{
+				slot:        bytesutil.BytesToSlotBigEndian(highestSlot),
+				signingRoot: bytesutil.SafeCopyBytes(signingRoot),
+			})
+		}); err != nil {
+			return err
+		}
+		if converted == 0 {
+			return nil
+		}
+
+		for _, bucket := range [][]byte{
+			pubKeysBucket,
+			lowestSignedSourceBucket,
+			lowestSignedTargetBucket,
+			historicProposalsBucket,
+			lowestSignedProposalsBucket,
+			highestSignedProposalsBucket,
+		} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return errors.Wrapf(err, "could not delete bucket %s", bucket)
+			}
+		}
+		return createBuckets(
+			tx,
+			pubKeysBucket,
+			lowestSignedSourceBucket,
+			lowestSignedTargetBucket,
+			historicProposalsBucket,
+			lowestSignedProposalsBucket,
+			highestSignedProposalsBucket,
+		)
+	})
+	if err != nil {
+		return err
+	}
+	if converted > 0 {
+		log.WithField("count", converted).Info("Converted slashing protection history to minimal watermarks")
+	}
+	return nil
+}
+
+// migrateMinimalToComplete saves the watermarks left by a minimal store as the slashing
+// protection history of the complete store and deletes them. It is a no-op unless the
+// database was last opened as a minimal store.
+func (s *Store) migrateMinimalToComplete(ctx context.Context) error {
+	var minimal bool
+	attestations := make([]*AttestationRecord, 0)
+	proposals := make(map[[fieldparams.BLSPubkeyLength]byte]*minimalProposal)
+	if err := s.view(func(tx *bolt.Tx) error {
+		minimal = tx.Bucket(slashingProtectionModeBucket).Get(minimalModeKey) != nil
+		if !minimal {
+			return nil
+		}
+		if err := tx.Bucket(minimalAttestationsBucket).ForEach(func(pubKey, enc []byte) error {
+			att, err := decodeMinimalAttestation(enc)
+			if err != nil {
+				return err
+			}
+			attestations = append(attestations, &AttestationRecord{
+				PubKey:      bytesutil.ToBytes48(pubKey),
+				Source:      att.source,
+				Target:      att.target,
+				SigningRoot: att.signingRoot,
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(minimalProposalsBucket).ForEach(func(pubKey, enc []byte) error {
+			proposal, err := decodeMinimalProposal(enc)
+			if err != nil {
+				return err
+			}
+			proposals[bytesutil.ToBytes48(pubKey)] = proposal
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	if !minimal {
+		return nil
+	}
+
+	// The history is saved before the watermarks and the mode are deleted, so that an
+	// interruption only leads to the conversion being done again.
+	if err := s.saveAttestationRecords(ctx, attestations); err != nil {
+		return err
+	}
+	for pubKey, proposal := range proposals {
+		if err := s.SaveProposalHistoryForSlot(ctx, pubKey, proposal.slot, proposal.signingRoot); err != nil {
+			return err
+		}
+	}
+	if err := s.update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(minimalAttestationsBucket); err != nil {
+			return err
+		}
+		if err := tx.DeleteBucket(minimalProposalsBucket); err != nil {
+			return err
+		}
+		if err := tx.Bucket(slashingProtectionModeBucket).Delete(minimalModeKey); err != nil {
+			return err
+		}
+		return createBuckets(tx, minimalAttestationsBucket, minimalProposalsBucket)
+	}); err != nil {
+		return err
+	}
+	if len(attestations) == 0 && len(proposals) == 0 {
+		return nil
+	}
+	log.WithField("count", len(attestations)+len(proposals)).Info("Converted minimal slashing protection watermarks to history")
+	return nil
+}
+
+// minimalAttestationForPubKey returns the highest signed attestation of the public key, or nil if
+// there is none.
+func minimalAttestationForPubKey(tx *bolt.Tx, pubKey [fieldparams.BLSPubkeyLength]byte) (*minimalAttestation, error) {
+	enc := tx.Bucket(minimalAttestationsBucket).Get(pubKey[:])
+	if enc == nil {
+		return nil, nil
+	}
+	return decodeMinimalAttestation(enc)
+}
+
+// saveMinimalAttestation raises the attestation watermarks of the public key. If an attestation
+// with another signing root was already saved at the target epoch, the signing root is dropped,
+// so that no attestation can be signed anymore at this epoch.
+func saveMinimalAttestation(tx *bolt.Tx, pubKey [fieldparams.BLSPubkeyLength]byte, att *minimalAttestation) error {
+	existing, err := minimalAttestationForPubKey(tx, pubKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		highest := &minimalAttestation{
+			source:      existing.source,
+			target:      existing.target,
+			signingRoot: existing.signingRoot,
+		}
+		if att.source > highest.source {
+			highest.source = att.source
+		}
+		if att.target > highest.target {
+			highest.target = att.target
+			highest.signingRoot = att.signingRoot
+		} else if att.target == highest.target && !bytes.Equal(att.signingRoot, highest.signingRoot) {
+			highest.signingRoot = nil
+		}
+		att = highest
+	}
+	return tx.Bucket(minimalAttestationsBucket).Put(pubKey[:], encodeMinimalAttestation(att))
+}
+
+// minimalProposalForPubKey returns the highest signed proposal of the public key, or nil if there
+// is none.
+func minimalProposalForPubKey(tx *bolt.Tx, pubKey [fieldparams.BLSPubkeyLength]byte) (*minimalProposal, error) {
+	enc := tx.Bucket(minimalProposalsBucket).Get(pubKey[:])
+	if enc == nil {
+		return nil, nil
+	}
+	return decodeMinimalProposal(enc)
+}
+
+// saveMinimalProposal raises the proposal watermark of the public key. If a proposal with another
+// signing root was already saved at the slot, the signing root is dropped, so that no block can be
+// signed anymore at this slot.
+func saveMinimalProposal(tx *bolt.Tx, pubKey [fieldparams.BLSPubkeyLength]byte, proposal *minimalProposal) error {
+	existing, err := minimalProposalForPubKey(tx, pubKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if proposal.slot < existing.slot {
+			return nil
+		}
+		if proposal.slot == existing.slot && !bytes.Equal(proposal.signingRoot, existing.signingRoot) {
+			proposal = &minimalProposal{slot: existing.slot}
+		}
+	}
+	return tx.Bucket(minimalProposalsBucket).Put(pubKey[:], encodeMinimalProposal(proposal))
+}
+
+// A minimal attestation is encoded as its source and target epochs, followed by its signing
+// root if it is known.
+func encodeMinimalAttestation(att *minimalAttestation) []byte {
+	enc := make([]byte, 0, 16+len(att.signingRoot))
+	enc = append(enc, bytesutil.EpochToBytesBigEndian(att.source)...)
+	enc = append(enc, bytesutil.EpochToBytesBigEndian(att.target)...)
+	return append(enc, att.signingRoot...)
+}
+
+func decodeMinimalAttestation(enc []byte) (*minimalAttestation, error) {
+	if len(enc) < 16 {
+		return nil, errors.Errorf("invalid minimal attestation length %d", len(enc))
+	}
+	return &minimalAttestation{
+		source:      bytesutil.BytesToEpochBigEndian(enc[:8]),
+		target:      bytesutil.BytesToEpochBigEndian(enc[8:16]),
+		signingRoot: bytesutil.SafeCopyBytes(enc[16:]),
+	}, nil
+}
+
+// A minimal proposal is encoded as its slot, followed by its signing root if it is known.
+func encodeMinimalProposal(proposal *minimalProposal) []byte {
+	enc := make([]byte, 0, 8+len(proposal.signingRoot))
+	enc = append(enc, bytesutil.SlotToBytesBigEndian(proposal.slot)...)
+	return append(enc, proposal.signingRoot...)
+}
+
+func decodeMinimalProposal(enc []byte) (*minimalProposal, error) {
+	if len(enc) < 8 {
+		return nil, errors.Errorf("invalid minimal proposal length %d", len(enc))
+	}
+	return &minimalProposal{
+		slot:        bytesutil.BytesToSlotBigEndian(enc[:8]),
+		signingRoot: bytesutil.SafeCopyBytes(enc[8:]),
+	}, nil
+}
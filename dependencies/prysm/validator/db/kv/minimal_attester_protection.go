@@ -0,0 +1,183 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/slashings"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// AttestationHistoryForPubKey returns the highest signed attestation of the validator public key,
+// which is its minimal attesting history.
+func (s *MinimalStore) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*AttestationRecord, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.AttestationHistoryForPubKey")
+	defer span.End()
+	records := make([]*AttestationRecord, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		att, err := minimalAttestationForPubKey(tx, pubKey)
+		if err != nil || att == nil {
+			return err
+		}
+		records = append(records, &AttestationRecord{
+			PubKey:      pubKey,
+			Source:      att.source,
+			Target:      att.target,
+			SigningRoot: att.signingRoot,
+		})
+		return nil
+	})
+	return records, err
+}
+
+// CheckSlashableAttestation verifies an incoming attestation is not a double vote with the
+// highest signed attestation of the validator public key. Attestations surrounding or surrounded
+// by a previous one are prevented by the lowest signed source and target epochs, which are the
+// watermarks in this store.
+func (s *MinimalStore) CheckSlashableAttestation(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot []byte, att *ethpb.IndexedAttestation,
+) (SlashingKind, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Minimal.CheckSlashableAttestation")
+	defer span.End()
+	var slashKind SlashingKind
+	err := s.view(func(tx *bolt.Tx) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		highest, err := minimalAttestationForPubKey(tx, pubKey)
+		if err != nil || highest == nil {
+			return err
+		}
+		if att.Data.Target.Epoch == highest.target && slashings.SigningRootsDiffer(highest.signingRoot, signingRoot) {
+			slashKind = DoubleVote
+			return fmt.Errorf(doubleVoteMessage, att.Data.Target.Epoch, highest.signingRoot)
+		}
+		return nil
+	})
+	tracing.AnnotateError(span, err)
+	return slashKind, err
+}
+
+// SaveAttestationsForPubKey raises the watermarks of the validator public key to the highest
+// source and target epochs of a batch of attestations.
+func (s *MinimalStore) SaveAttestationsForPubKey(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoots [][]byte, atts []*ethpb.IndexedAttestation,
+) error {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.SaveAttestationsForPubKey")
+	defer span.End()
+	if len(signingRoots) != len(atts) {
+		return fmt.Errorf(
+			"number of signing roots %d does not match number of attestations %d",
+			len(signingRoots),
+			len(atts),
+		)
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		for i, att := range atts {
+			if err := saveMinimalAttestation(tx, pubKey, &minimalAttestation{
+				source:      att.Data.Source.Epoch,
+				target:      att.Data.Target.Epoch,
+				signingRoot: signingRoots[i],
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveAttestationForPubKey raises the watermarks of the validator public key to the source and
+// target epochs of the attestation. Concurrent saves are coalesced into a single transaction.
+func (s *MinimalStore) SaveAttestationForPubKey(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot [32]byte, att *ethpb.IndexedAttestation,
+) error {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.SaveAttestationForPubKey")
+	defer span.End()
+	return s.db.Batch(func(tx *bolt.Tx) error {
+		return saveMinimalAttestation(tx, pubKey, &minimalAttestation{
+			source:      att.Data.Source.Epoch,
+			target:      att.Data.Target.Epoch,
+			signingRoot: signingRoot[:],
+		})
+	})
+}
+
+// AttestedPublicKeys retrieves all public keys that have attested.
+func (s *MinimalStore) AttestedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.AttestedPublicKeys")
+	defer span.End()
+	return s.publicKeysInBucket(minimalAttestationsBucket)
+}
+
+// SigningRootAtTargetEpoch returns the signing root of the highest signed attestation of the
+// validator public key if it is at the target epoch.
+func (s *MinimalStore) SigningRootAtTargetEpoch(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, target primitives.Epoch) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.SigningRootAtTargetEpoch")
+	defer span.End()
+	signingRoot := make([]byte, 0, fieldparams.RootLength)
+	err := s.view(func(tx *bolt.Tx) error {
+		att, err := minimalAttestationForPubKey(tx, pubKey)
+		if err != nil || att == nil || att.target != target {
+			return err
+		}
+		signingRoot = append(signingRoot, att.signingRoot...)
+		return nil
+	})
+	return signingRoot, err
+}
+
+// LowestSignedSourceEpoch returns the highest signed source epoch of the validator public key,
+// below which attestations are refused. Only this watermark is kept, so it stands in for the
+// lowest signed source epoch of the complete store.
+func (s *MinimalStore) LowestSignedSourceEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.LowestSignedSourceEpoch")
+	defer span.End()
+	var source primitives.Epoch
+	var exists bool
+	err := s.view(func(tx *bolt.Tx) error {
+		att, err := minimalAttestationForPubKey(tx, publicKey)
+		if err != nil || att == nil {
+			return err
+		}
+		source, exists = att.source, true
+		return nil
+	})
+	return source, exists, err
+}
+
+// LowestSignedTargetEpoch returns the highest signed target epoch of the validator public key,
+// at or below which attestations are refused unless they repeat the last signed one. Only this
+// watermark is kept, so it stands in for the lowest signed target epoch of the complete store.
+func (s *MinimalStore) LowestSignedTargetEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.LowestSignedTargetEpoch")
+	defer span.End()
+	var target primitives.Epoch
+	var exists bool
+	err := s.view(func(tx *bolt.Tx) error {
+		att, err := minimalAttestationForPubKey(tx, publicKey)
+		if err != nil || att == nil {
+			return err
+		}
+		target, exists = att.target, true
+		return nil
+	})
+	return target, exists, err
+}
+
+// publicKeysInBucket returns the public keys which are the keys of the bucket.
+func (s *MinimalStore) publicKeysInBucket(bucket []byte) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(pubKey, _ []byte) error {
+			pubKeys = append(pubKeys, bytesutil.ToBytes48(pubKey))
+			return nil
+		})
+	})
+	return pubKeys, err
+}
@@ -0,0 +1,134 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestMinimalStore_SaveAttestationForPubKey(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupMinimalDB(t, nil)
+
+	_, exists, err := db.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{1}, createAttestation(3, 4)))
+	// Lower epochs do not lower the watermarks.
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{2}, createAttestation(1, 2)))
+	source, exists, err := db.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(3), source)
+	target, exists, err := db.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(4), target)
+
+	signingRoot, err := db.SigningRootAtTargetEpoch(ctx, pubKey, 4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [32]byte{1}, [32]byte(signingRoot))
+	signingRoot, err = db.SigningRootAtTargetEpoch(ctx, pubKey, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(signingRoot))
+
+	// Source and target watermarks are raised independently.
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{3}, createAttestation(2, 6)))
+	history, err := db.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	assert.Equal(t, primitives.Epoch(3), history[0].Source)
+	assert.Equal(t, primitives.Epoch(6), history[0].Target)
+	assert.DeepEqual(t, [32]byte{3}, [32]byte(history[0].SigningRoot))
+
+	pubKeys, err := db.AttestedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, pubKeys)
+}
+
+func TestMinimalStore_SaveAttestationsForPubKey(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupMinimalDB(t, nil)
+
+	err := db.SaveAttestationsForPubKey(ctx, pubKey, [][]byte{{1}}, []*ethpb.IndexedAttestation{})
+	require.ErrorContains(t, "does not match number of attestations", err)
+
+	require.NoError(t, db.SaveAttestationsForPubKey(
+		ctx,
+		pubKey,
+		[][]byte{{1}, {2}, {3}},
+		[]*ethpb.IndexedAttestation{
+			createAttestation(5, 6),
+			createAttestation(1, 8),
+			createAttestation(2, 3),
+		},
+	))
+	history, err := db.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*AttestationRecord{{PubKey: pubKey, Source: 5, Target: 8, SigningRoot: []byte{2}}}, history)
+
+	// Another signing root at the target epoch is dropped, so that nothing can be signed at it.
+	require.NoError(t, db.SaveAttestationsForPubKey(ctx, pubKey, [][]byte{{4}}, []*ethpb.IndexedAttestation{createAttestation(5, 8)}))
+	signingRoot, err := db.SigningRootAtTargetEpoch(ctx, pubKey, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(signingRoot))
+	kind, err := db.CheckSlashableAttestation(ctx, pubKey, []byte{2}, createAttestation(5, 8))
+	assert.NotNil(t, err)
+	assert.Equal(t, DoubleVote, kind)
+}
+
+func TestMinimalStore_CheckSlashableAttestation(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupMinimalDB(t, nil)
+
+	kind, err := db.CheckSlashableAttestation(ctx, pubKey, []byte{1}, createAttestation(3, 4))
+	require.NoError(t, err)
+	assert.Equal(t, NotSlashable, kind)
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{1}, createAttestation(3, 4)))
+
+	tests := []struct {
+		name        string
+		signingRoot [32]byte
+		att         *ethpb.IndexedAttestation
+		want        SlashingKind
+	}{
+		{
+			name:        "repeat of the highest attestation",
+			signingRoot: [32]byte{1},
+			att:         createAttestation(3, 4),
+			want:        NotSlashable,
+		},
+		{
+			name:        "double vote",
+			signingRoot: [32]byte{2},
+			att:         createAttestation(3, 4),
+			want:        DoubleVote,
+		},
+		{
+			name:        "higher target epoch",
+			signingRoot: [32]byte{2},
+			att:         createAttestation(4, 5),
+			want:        NotSlashable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := db.CheckSlashableAttestation(ctx, pubKey, tt.signingRoot[:], tt.att)
+			assert.Equal(t, tt.want, kind)
+			if tt.want == NotSlashable {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, "double vote found", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package kv
+
+import (
+	"context"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// ProposedPublicKeys retrieves all public keys that have proposed.
+func (s *MinimalStore) ProposedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.ProposedPublicKeys")
+	defer span.End()
+	return s.publicKeysInBucket(minimalProposalsBucket)
+}
+
+// ProposalHistoryForSlot returns the signing root of the highest signed proposal of the validator
+// public key if it is at the slot, along with whether there is a proposal and a signing root.
+func (s *MinimalStore) ProposalHistoryForSlot(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot) ([32]byte, bool, bool, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.ProposalHistoryForSlot")
+	defer span.End()
+
+	var (
+		proposalExists, signingRootExists bool
+		signingRoot                       [32]byte
+	)
+	err := s.view(func(tx *bolt.Tx) error {
+		proposal, err := minimalProposalForPubKey(tx, publicKey)
+		if err != nil || proposal == nil || proposal.slot != slot {
+			return err
+		}
+		proposalExists = true
+		if len(proposal.signingRoot) == 0 {
+			return nil
+		}
+		signingRootExists = true
+		copy(signingRoot[:], proposal.signingRoot)
+		return nil
+	})
+	return signingRoot, proposalExists, signingRootExists, err
+}
+
+// ProposalHistoryForPubKey returns the highest signed proposal of the validator public key, which
+// is its minimal proposal history.
+func (s *MinimalStore) ProposalHistoryForPubKey(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) ([]*Proposal, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.ProposalHistoryForPubKey")
+	defer span.End()
+
+	proposals := make([]*Proposal, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		proposal, err := minimalProposalForPubKey(tx, publicKey)
+		if err != nil || proposal == nil {
+			return err
+		}
+		sr := make([]byte, fieldparams.RootLength)
+		copy(sr, proposal.signingRoot)
+		proposals = append(proposals, &Proposal{
+			Slot:        proposal.slot,
+			SigningRoot: sr,
+		})
+		return nil
+	})
+	return proposals, err
+}
+
+// SaveProposalHistoryForSlot raises the proposal watermark of the validator public key to the slot.
+func (s *MinimalStore) SaveProposalHistoryForSlot(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot []byte) error {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.SaveProposalHistoryForSlot")
+	defer span.End()
+	return s.update(func(tx *bolt.Tx) error {
+		return saveMinimalProposal(tx, pubKey, &minimalProposal{slot: slot, signingRoot: signingRoot})
+	})
+}
+
+// LowestSignedProposal returns the highest signed proposal slot of the validator public key, at or
+// below which blocks are refused unless they repeat the last signed one. Only this watermark is
+// kept, so it stands in for the lowest signed proposal slot of the complete store.
+func (s *MinimalStore) LowestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.LowestSignedProposal")
+	defer span.End()
+	return s.highestSignedProposal(publicKey)
+}
+
+// HighestSignedProposal returns the highest signed proposal slot of the validator public key.
+func (s *MinimalStore) HighestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	_, span := trace.StartSpan(ctx, "Validator.Minimal.HighestSignedProposal")
+	defer span.End()
+	return s.highestSignedProposal(publicKey)
+}
+
+func (s *MinimalStore) highestSignedProposal(publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	var slot primitives.Slot
+	var exists bool
+	err := s.view(func(tx *bolt.Tx) error {
+		proposal, err := minimalProposalForPubKey(tx, publicKey)
+		if err != nil || proposal == nil {
+			return err
+		}
+		slot, exists = proposal.slot, true
+		return nil
+	})
+	return slot, exists, err
+}
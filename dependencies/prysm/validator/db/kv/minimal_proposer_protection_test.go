@@ -0,0 +1,62 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestMinimalStore_SaveProposalHistoryForSlot(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupMinimalDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+
+	_, exists, err := db.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+	pubKeys, err := db.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pubKeys))
+
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 5, []byte{1}))
+	// A lower slot does not lower the watermark.
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 3, []byte{2}))
+	lowest, exists, err := db.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(5), lowest)
+	highest, exists, err := db.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(5), highest)
+
+	signingRoot, proposalExists, signingRootExists, err := db.ProposalHistoryForSlot(ctx, pubKey, 5)
+	require.NoError(t, err)
+	assert.Equal(t, true, proposalExists)
+	assert.Equal(t, true, signingRootExists)
+	assert.Equal(t, [32]byte{1}, signingRoot)
+	_, proposalExists, _, err = db.ProposalHistoryForSlot(ctx, pubKey, 3)
+	require.NoError(t, err)
+	assert.Equal(t, false, proposalExists)
+
+	// Another signing root at the slot is dropped, so that nothing can be signed at it.
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 5, []byte{2}))
+	_, proposalExists, signingRootExists, err = db.ProposalHistoryForSlot(ctx, pubKey, 5)
+	require.NoError(t, err)
+	assert.Equal(t, true, proposalExists)
+	assert.Equal(t, false, signingRootExists)
+
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 8, []byte{3}))
+	proposals, err := db.ProposalHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(proposals))
+	assert.Equal(t, primitives.Slot(8), proposals[0].Slot)
+	assert.Equal(t, byte(3), proposals[0].SigningRoot[0])
+	pubKeys, err = db.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, pubKeys)
+}
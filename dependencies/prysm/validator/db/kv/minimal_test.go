@@ -0,0 +1,150 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// setupMinimalDB instantiates and returns a minimal DB instance for the validator client.
+func setupMinimalDB(t testing.TB, pubkeys [][fieldparams.BLSPubkeyLength]byte) *MinimalStore {
+	db, err := NewMinimalKVStore(context.Background(), t.TempDir(), &Config{
+		PubKeys: pubkeys,
+	})
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, db.Close(), "Failed to close database")
+		require.NoError(t, db.ClearDB(), "Failed to clear database")
+	})
+	return db
+}
+
+func TestMinimalStore_ConvertsHistory(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}}
+	dir := t.TempDir()
+
+	complete, err := NewKVStore(ctx, dir, &Config{PubKeys: pubKeys})
+	require.NoError(t, err)
+	// The first key attested and proposed, while the second key only attested.
+	require.NoError(t, complete.SaveAttestationsForPubKey(
+		ctx,
+		pubKeys[0],
+		[][]byte{{1}, {2}, {3}},
+		[]*ethpb.IndexedAttestation{
+			createAttestation(1, 2),
+			createAttestation(2, 5),
+			createAttestation(3, 4),
+		},
+	))
+	require.NoError(t, complete.SaveAttestationsForPubKey(ctx, pubKeys[1], [][]byte{{4}}, []*ethpb.IndexedAttestation{createAttestation(7, 8)}))
+	require.NoError(t, complete.SaveProposalHistoryForSlot(ctx, pubKeys[0], 10, []byte{5}))
+	require.NoError(t, complete.SaveProposalHistoryForSlot(ctx, pubKeys[0], 12, []byte{6}))
+	require.NoError(t, complete.Close())
+
+	minimal, err := NewMinimalKVStore(ctx, dir, &Config{PubKeys: pubKeys})
+	require.NoError(t, err)
+	// Watermarks are the highest source and target epochs, which may come from different attestations.
+	history, err := minimal.AttestationHistoryForPubKey(ctx, pubKeys[0])
+	require.NoError(t, err)
+	require.DeepEqual(t, []*AttestationRecord{{PubKey: pubKeys[0], Source: 3, Target: 5, SigningRoot: []byte{2}}}, history)
+	history, err = minimal.AttestationHistoryForPubKey(ctx, pubKeys[1])
+	require.NoError(t, err)
+	require.DeepEqual(t, []*AttestationRecord{{PubKey: pubKeys[1], Source: 7, Target: 8, SigningRoot: []byte{4}}}, history)
+	slot, exists, err := minimal.HighestSignedProposal(ctx, pubKeys[0])
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(12), slot)
+	proposers, err := minimal.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, pubKeys[:1], proposers)
+
+	// The complete history was deleted.
+	history, err = minimal.Store.AttestationHistoryForPubKey(ctx, pubKeys[0])
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+	_, exists, err = minimal.Store.HighestSignedProposal(ctx, pubKeys[0])
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+
+	require.NoError(t, minimal.SaveProposalHistoryForSlot(ctx, pubKeys[0], 20, []byte{7}))
+	require.NoError(t, minimal.Close())
+
+	// Switching back to the complete store keeps the watermarks.
+	complete, err = NewKVStore(ctx, dir, &Config{PubKeys: pubKeys})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, complete.Close())
+	}()
+	history, err = complete.AttestationHistoryForPubKey(ctx, pubKeys[0])
+	require.NoError(t, err)
+	require.DeepEqual(t, []*AttestationRecord{{PubKey: pubKeys[0], Source: 3, Target: 5, SigningRoot: bytesutil.PadTo([]byte{2}, 32)}}, history)
+	source, exists, err := complete.LowestSignedSourceEpoch(ctx, pubKeys[1])
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(7), source)
+	slot, exists, err = complete.LowestSignedProposal(ctx, pubKeys[0])
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(20), slot)
+	signingRoot, exists, signingRootExists, err := complete.ProposalHistoryForSlot(ctx, pubKeys[0], 20)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, true, signingRootExists)
+	assert.Equal(t, byte(7), signingRoot[0])
+
+	assert.Equal(t, false, isMinimal(t, complete))
+}
+
+func TestStore_MigratesOnlyWhenModeChanged(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	dir := t.TempDir()
+
+	minimal, err := NewMinimalKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	assert.Equal(t, true, isMinimal(t, minimal.Store))
+	require.NoError(t, minimal.Close())
+
+	// The store was last opened in minimal mode, so it is converted and no longer minimal.
+	complete, err := NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	assert.Equal(t, false, isMinimal(t, complete))
+	require.NoError(t, complete.update(func(tx *bolt.Tx) error {
+		return saveMinimalAttestation(tx, pubKey, &minimalAttestation{source: 1, target: 2})
+	}))
+	require.NoError(t, complete.Close())
+
+	// The store was last opened in complete mode, so nothing is converted.
+	complete, err = NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, complete.Close())
+	}()
+	history, err := complete.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+	require.NoError(t, complete.view(func(tx *bolt.Tx) error {
+		att, err := minimalAttestationForPubKey(tx, pubKey)
+		require.NoError(t, err)
+		require.NotNil(t, att)
+		return nil
+	}))
+}
+
+// isMinimal returns whether the store was last opened in minimal mode.
+func isMinimal(t *testing.T, s *Store) bool {
+	var minimal bool
+	require.NoError(t, s.view(func(tx *bolt.Tx) error {
+		minimal = tx.Bucket(slashingProtectionModeBucket).Get(minimalModeKey) != nil
+		return nil
+	}))
+	return minimal
+}
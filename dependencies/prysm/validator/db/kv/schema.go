@@ -28,6 +28,15 @@ var (
 	attestationSourceEpochsBucket = []byte("att-source-epochs-bucket")
 	attestationTargetEpochsBucket = []byte("att-target-epochs-bucket")
 
+	// Minimal slashing protection buckets, holding the highest signed
+	// attestation and proposal for individual validator.
+	minimalAttestationsBucket = []byte("minimal-attestations-bucket")
+	minimalProposalsBucket    = []byte("minimal-proposals-bucket")
+
+	// Slashing protection mode the database was last opened with.
+	slashingProtectionModeBucket = []byte("slashing-protection-mode-bucket")
+	minimalModeKey               = []byte("minimal")
+
 	// Migrations
 	migrationsBucket = []byte("migrations")
 
@@ -38,6 +47,9 @@ var (
 	graffitiOrderedIndexKey = []byte("graffiti-ordered-index")
 	graffitiFileHashKey     = []byte("graffiti-file-hash")
 
+	// Graffiti set for individual validator public keys through the keymanager API
+	graffitiByPubKeyBucket = []byte("graffiti-by-pubkey-bucket")
+
 	// ProposerSettings stores the encoded proposer settings file
 	proposerSettingsBucket = []byte("proposer-settings-bucket")
 	proposerSettingsKey    = []byte("proposer-settings")
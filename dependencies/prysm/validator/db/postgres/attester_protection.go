@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/slashings"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"go.opencensus.io/trace"
+)
+
+var (
+	doubleVoteMessage      = "double vote found, existing attestation at target epoch %d with conflicting signing root %#x"
+	surroundingVoteMessage = "attestation with (source %d, target %d) surrounds another with (source %d, target %d)"
+	surroundedVoteMessage  = "attestation with (source %d, target %d) is surrounded by another with (source %d, target %d)"
+)
+
+// querier is implemented by both the database and its transactions.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// AttestationHistoryForPubKey retrieves the attestations stored for the validator public key, in
+// ascending order of source and target epochs.
+func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*kv.AttestationRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.AttestationHistoryForPubKey")
+	defer span.End()
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.source_epoch, e.target_epoch, r.signing_root
+		FROM attestation_epochs e
+		LEFT JOIN attestation_signing_roots r ON r.pubkey = e.pubkey AND r.target_epoch = e.target_epoch
+		WHERE e.pubkey = $1
+		ORDER BY e.source_epoch, e.target_epoch`, pubKey[:])
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+	records := make([]*kv.AttestationRecord, 0)
+	for rows.Next() {
+		var source, target int64
+		var signingRoot []byte
+		if err := rows.Scan(&source, &target, &signingRoot); err != nil {
+			return nil, err
+		}
+		record := &kv.AttestationRecord{
+			PubKey: pubKey,
+			Source: primitives.Epoch(source),
+			Target: primitives.Epoch(target),
+		}
+		if len(signingRoot) != 0 {
+			record.SigningRoot = signingRoot
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// CheckSlashableAttestation verifies an incoming attestation is
+// not a double vote for a validator public key nor a surround vote.
+func (s *Store) CheckSlashableAttestation(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot []byte, att *ethpb.IndexedAttestation,
+) (kv.SlashingKind, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.CheckSlashableAttestation")
+	defer span.End()
+	slashingKind, err := checkSlashableAttestation(ctx, s.db, pubKey, signingRoot, att)
+	tracing.AnnotateError(span, err)
+	return slashingKind, err
+}
+
+func checkSlashableAttestation(
+	ctx context.Context, q querier, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot []byte, att *ethpb.IndexedAttestation,
+) (kv.SlashingKind, error) {
+	// First we check for double votes.
+	existingSigningRoot, exists, err := signingRootAtTargetEpoch(ctx, q, pubKey, att.Data.Target.Epoch)
+	if err != nil {
+		return kv.NotSlashable, err
+	}
+	if exists && slashings.SigningRootsDiffer(existingSigningRoot, signingRoot) {
+		return kv.DoubleVote, fmt.Errorf(doubleVoteMessage, att.Data.Target.Epoch, existingSigningRoot)
+	}
+
+	// Is this attestation surrounding any other?
+	var source, target int64
+	err = q.QueryRowContext(ctx, `
+		SELECT source_epoch, target_epoch FROM attestation_epochs
+		WHERE pubkey = $1 AND source_epoch > $2 AND target_epoch < $3
+		LIMIT 1`, pubKey[:], int64(att.Data.Source.Epoch), int64(att.Data.Target.Epoch),
+	).Scan(&source, &target)
+	switch {
+	case err == nil:
+		return kv.SurroundingVote, fmt.Errorf(
+			surroundingVoteMessage, att.Data.Source.Epoch, att.Data.Target.Epoch, source, target,
+		)
+	case !errors.Is(err, sql.ErrNoRows):
+		return kv.NotSlashable, err
+	}
+
+	// Is this attestation surrounded by any other?
+	err = q.QueryRowContext(ctx, `
+		SELECT source_epoch, target_epoch FROM attestation_epochs
+		WHERE pubkey = $1 AND source_epoch < $2 AND target_epoch > $3
+		LIMIT 1`, pubKey[:], int64(att.Data.Source.Epoch), int64(att.Data.Target.Epoch),
+	).Scan(&source, &target)
+	switch {
+	case err == nil:
+		return kv.SurroundedVote, fmt.Errorf(
+			surroundedVoteMessage, att.Data.Source.Epoch, att.Data.Target.Epoch, source, target,
+		)
+	case !errors.Is(err, sql.ErrNoRows):
+		return kv.NotSlashable, err
+	}
+	return kv.NotSlashable, nil
+}
+
+// SaveAttestationsForPubKey stores a batch of attestations all at once.
+func (s *Store) SaveAttestationsForPubKey(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoots [][]byte, atts []*ethpb.IndexedAttestation,
+) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.SaveAttestationsForPubKey")
+	defer span.End()
+	if len(signingRoots) != len(atts) {
+		return fmt.Errorf(
+			"number of signing roots %d does not match number of attestations %d",
+			len(signingRoots),
+			len(atts),
+		)
+	}
+	return s.update(ctx, func(tx *sql.Tx) error {
+		if err := lockPubKey(ctx, tx, pubKey); err != nil {
+			return err
+		}
+		for i, att := range atts {
+			if err := saveAttestation(ctx, tx, pubKey, signingRoots[i], att); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveAttestationForPubKey saves an attestation signed by the validator public key. The row of the
+// key is locked while the attestation is checked again against the attestations saved by every
+// validator client using the database, so that two clients cannot both sign slashable attestations.
+func (s *Store) SaveAttestationForPubKey(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot [32]byte, att *ethpb.IndexedAttestation,
+) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.SaveAttestationForPubKey")
+	defer span.End()
+	err := s.update(ctx, func(tx *sql.Tx) error {
+		if err := lockPubKey(ctx, tx, pubKey); err != nil {
+			return err
+		}
+		if err := checkLowestSignedEpochs(ctx, tx, pubKey, signingRoot[:], att); err != nil {
+			return err
+		}
+		if _, err := checkSlashableAttestation(ctx, tx, pubKey, signingRoot[:], att); err != nil {
+			return err
+		}
+		return saveAttestation(ctx, tx, pubKey, signingRoot[:], att)
+	})
+	tracing.AnnotateError(span, err)
+	return err
+}
+
+// checkLowestSignedEpochs refuses attestations with a source epoch lower than the lowest signed
+// source epoch, or a different attestation with a target epoch lower than or equal to the lowest
+// signed target epoch, as defined by EIP-3076.
+func checkLowestSignedEpochs(
+	ctx context.Context, q querier, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot []byte, att *ethpb.IndexedAttestation,
+) error {
+	lowestSource, lowestTarget, err := lowestSignedEpochs(ctx, q, pubKey)
+	if err != nil {
+		return err
+	}
+	if lowestSource.Valid && int64(att.Data.Source.Epoch) < lowestSource.Int64 {
+		return fmt.Errorf(
+			"could not sign attestation lower than lowest source epoch in db, %d < %d",
+			att.Data.Source.Epoch,
+			lowestSource.Int64,
+		)
+	}
+	existingSigningRoot, _, err := signingRootAtTargetEpoch(ctx, q, pubKey, att.Data.Target.Epoch)
+	if err != nil {
+		return err
+	}
+	if lowestTarget.Valid && int64(att.Data.Target.Epoch) <= lowestTarget.Int64 &&
+		slashings.SigningRootsDiffer(existingSigningRoot, signingRoot) {
+		return fmt.Errorf(
+			"could not sign attestation lower than or equal to lowest target epoch in db, %d <= %d",
+			att.Data.Target.Epoch,
+			lowestTarget.Int64,
+		)
+	}
+	return nil
+}
+
+func saveAttestation(
+	ctx context.Context, tx *sql.Tx, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot []byte, att *ethpb.IndexedAttestation,
+) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO attestation_epochs (pubkey, source_epoch, target_epoch) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`, pubKey[:], int64(att.Data.Source.Epoch), int64(att.Data.Target.Epoch),
+	); err != nil {
+		return errors.Wrap(err, "could not save attestation epochs")
+	}
+	if signingRoot == nil {
+		signingRoot = []byte{}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO attestation_signing_roots (pubkey, target_epoch, signing_root) VALUES ($1, $2, $3)
+		ON CONFLICT (pubkey, target_epoch) DO UPDATE SET signing_root = EXCLUDED.signing_root`,
+		pubKey[:], int64(att.Data.Target.Epoch), signingRoot,
+	); err != nil {
+		return errors.Wrap(err, "could not save attestation signing root")
+	}
+	return nil
+}
+
+// AttestedPublicKeys retrieves all public keys which have attested.
+func (s *Store) AttestedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.AttestedPublicKeys")
+	defer span.End()
+	return s.publicKeys(ctx, "SELECT DISTINCT pubkey FROM attestation_epochs ORDER BY pubkey")
+}
+
+// SigningRootAtTargetEpoch checks for an existing signing root at a specified
+// target epoch for a given validator public key.
+func (s *Store) SigningRootAtTargetEpoch(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, target primitives.Epoch) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.SigningRootAtTargetEpoch")
+	defer span.End()
+	signingRoot, _, err := signingRootAtTargetEpoch(ctx, s.db, pubKey, target)
+	if err != nil {
+		return nil, err
+	}
+	if len(signingRoot) == 0 {
+		return make([]byte, 0, fieldparams.RootLength), nil
+	}
+	return signingRoot, nil
+}
+
+// signingRootAtTargetEpoch returns the signing root saved at the target epoch, which may be empty,
+// and whether an attestation exists at the target epoch.
+func signingRootAtTargetEpoch(
+	ctx context.Context, q querier, pubKey [fieldparams.BLSPubkeyLength]byte, target primitives.Epoch,
+) ([]byte, bool, error) {
+	var signingRoot []byte
+	err := q.QueryRowContext(
+		ctx, "SELECT signing_root FROM attestation_signing_roots WHERE pubkey = $1 AND target_epoch = $2",
+		pubKey[:], int64(target),
+	).Scan(&signingRoot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return signingRoot, true, nil
+}
+
+// LowestSignedSourceEpoch returns the lowest signed source epoch for a validator public key.
+// If no data exists, returning 0 is a sensible default.
+func (s *Store) LowestSignedSourceEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.LowestSignedSourceEpoch")
+	defer span.End()
+	source, _, err := lowestSignedEpochs(ctx, s.db, publicKey)
+	return primitives.Epoch(source.Int64), source.Valid, err
+}
+
+// LowestSignedTargetEpoch returns the lowest signed target epoch for a validator public key.
+// If no data exists, returning 0 is a sensible default.
+func (s *Store) LowestSignedTargetEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.LowestSignedTargetEpoch")
+	defer span.End()
+	_, target, err := lowestSignedEpochs(ctx, s.db, publicKey)
+	return primitives.Epoch(target.Int64), target.Valid, err
+}
+
+// lowestSignedEpochs returns the lowest source and target epochs attested by the validator public
+// key, which are null if the key never attested.
+func lowestSignedEpochs(
+	ctx context.Context, q querier, pubKey [fieldparams.BLSPubkeyLength]byte,
+) (sql.NullInt64, sql.NullInt64, error) {
+	var source, target sql.NullInt64
+	err := q.QueryRowContext(
+		ctx, "SELECT MIN(source_epoch), MIN(target_epoch) FROM attestation_epochs WHERE pubkey = $1", pubKey[:],
+	).Scan(&source, &target)
+	return source, target, err
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+)
+
+func createAttestation(source, target primitives.Epoch) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: source},
+			Target: &ethpb.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func TestStore_CheckSlashableAttestation(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	require.NoError(t, db.SaveAttestationsForPubKey(
+		ctx, pubKey, [][]byte{{1}, {2}}, []*ethpb.IndexedAttestation{createAttestation(2, 3), createAttestation(3, 6)},
+	))
+
+	tests := []struct {
+		name        string
+		signingRoot [32]byte
+		att         *ethpb.IndexedAttestation
+		want        kv.SlashingKind
+	}{
+		{name: "same attestation", signingRoot: [32]byte{2}, att: createAttestation(3, 6), want: kv.NotSlashable},
+		{name: "double vote", signingRoot: [32]byte{3}, att: createAttestation(4, 6), want: kv.DoubleVote},
+		{name: "surrounding vote", signingRoot: [32]byte{4}, att: createAttestation(1, 4), want: kv.SurroundingVote},
+		{name: "surrounded vote", signingRoot: [32]byte{5}, att: createAttestation(4, 5), want: kv.SurroundedVote},
+		{name: "new attestation", signingRoot: [32]byte{6}, att: createAttestation(6, 7), want: kv.NotSlashable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := db.CheckSlashableAttestation(ctx, pubKey, tt.signingRoot[:], tt.att)
+			assert.Equal(t, tt.want, kind)
+			assert.Equal(t, tt.want == kv.NotSlashable, err == nil)
+		})
+	}
+
+	source, exists, err := db.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(2), source)
+	target, exists, err := db.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(3), target)
+
+	history, err := db.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, primitives.Epoch(6), history[1].Target)
+	assert.DeepEqual(t, []byte{2}, history[1].SigningRoot)
+}
+
+func TestStore_SaveAttestationForPubKey_RejectsSlashable(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupDB(t, nil)
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{1}, createAttestation(2, 3)))
+	// Signing the same attestation again is allowed.
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{1}, createAttestation(2, 3)))
+
+	assert.ErrorContains(t, "double vote found", db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{2}, createAttestation(2, 3)))
+	assert.ErrorContains(t, "lower than lowest source epoch", db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{2}, createAttestation(1, 4)))
+	assert.ErrorContains(t, "lower than or equal to lowest target epoch", db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{2}, createAttestation(2, 2)))
+
+	keys, err := db.AttestedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, keys)
+}
+
+func TestStore_SaveAttestationForPubKey_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupDB(t, nil)
+
+	// Several validator clients try to sign conflicting attestations for the same target, only one
+	// of them may succeed.
+	const clients = 8
+	errs := make([]error, clients)
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = db.SaveAttestationForPubKey(ctx, pubKey, [32]byte{byte(i + 1)}, createAttestation(2, 3))
+		}(i)
+	}
+	wg.Wait()
+	saved := 0
+	for _, err := range errs {
+		if err == nil {
+			saved++
+		}
+	}
+	assert.Equal(t, 1, saved)
+}
@@ -0,0 +1,194 @@
+// Package postgres defines a validator database backed by PostgreSQL, which several validator clients
+// can share to protect the same keys against slashing.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	// Registers the postgres driver of database/sql.
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+)
+
+// Key of the advisory lock taken while creating the schema, so that validator clients starting
+// at the same time do not race to create the tables.
+const schemaLockKey = 0x70727973
+
+var tables = []string{
+	"validators",
+	"proposals",
+	"attestation_epochs",
+	"attestation_signing_roots",
+	"eip_blacklisted_keys",
+	"graffiti",
+	"metadata",
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS validators (
+	pubkey BYTEA PRIMARY KEY
+);
+CREATE TABLE IF NOT EXISTS proposals (
+	pubkey       BYTEA  NOT NULL,
+	slot         BIGINT NOT NULL,
+	signing_root BYTEA  NOT NULL,
+	PRIMARY KEY (pubkey, slot)
+);
+CREATE TABLE IF NOT EXISTS attestation_epochs (
+	pubkey       BYTEA  NOT NULL,
+	source_epoch BIGINT NOT NULL,
+	target_epoch BIGINT NOT NULL,
+	PRIMARY KEY (pubkey, source_epoch, target_epoch)
+);
+CREATE INDEX IF NOT EXISTS attestation_epochs_by_target ON attestation_epochs (pubkey, target_epoch);
+CREATE TABLE IF NOT EXISTS attestation_signing_roots (
+	pubkey       BYTEA  NOT NULL,
+	target_epoch BIGINT NOT NULL,
+	signing_root BYTEA  NOT NULL,
+	PRIMARY KEY (pubkey, target_epoch)
+);
+CREATE TABLE IF NOT EXISTS eip_blacklisted_keys (
+	pubkey BYTEA PRIMARY KEY
+);
+CREATE TABLE IF NOT EXISTS graffiti (
+	pubkey   BYTEA PRIMARY KEY,
+	graffiti BYTEA NOT NULL
+);
+CREATE TABLE IF NOT EXISTS metadata (
+	key   TEXT PRIMARY KEY,
+	value BYTEA NOT NULL
+);`
+
+// Store defines an implementation of the validator database interface using PostgreSQL. Writes made
+// when signing lock the row of the validator public key, so that validator clients sharing the
+// database cannot both sign slashable messages.
+type Store struct {
+	db           *sql.DB
+	databasePath string
+}
+
+// NewStore connects to the PostgreSQL database at the given connection URL and creates its
+// schema if needed.
+func NewStore(ctx context.Context, dataSourceName string, config *kv.Config) (*Store, error) {
+	db, err := sql.Open("postgres", dataSourceName)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open postgres database")
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not connect to postgres database")
+	}
+	s := &Store{db: db, databasePath: redact(dataSourceName)}
+	if err := s.update(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", schemaLockKey); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, schema)
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not create postgres schema")
+	}
+	if config != nil {
+		if err := s.UpdatePublicKeysBuckets(config.PubKeys); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Close closes the connections to the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// ClearDB removes all the data stored in the database, keeping its schema.
+func (s *Store) ClearDB() error {
+	for _, table := range tables {
+		if _, err := s.db.Exec("TRUNCATE TABLE " + table); err != nil {
+			return errors.Wrapf(err, "could not clear table %s", table)
+		}
+	}
+	return nil
+}
+
+// DatabasePath returns the connection URL of the database, without its password.
+func (s *Store) DatabasePath() string {
+	return s.databasePath
+}
+
+// RunUpMigrations is a no-op, as the schema is created when opening the database.
+func (*Store) RunUpMigrations(context.Context) error {
+	return nil
+}
+
+// RunDownMigrations is a no-op, as the schema is created when opening the database.
+func (*Store) RunDownMigrations(context.Context) error {
+	return nil
+}
+
+// Backup is not supported, as PostgreSQL databases are backed up with the PostgreSQL tooling.
+func (*Store) Backup(context.Context, string, bool) error {
+	return errors.New("postgres validator databases must be backed up with pg_dump")
+}
+
+// UpdatePublicKeysBuckets registers the public keys in the database.
+func (s *Store) UpdatePublicKeysBuckets(pubKeys [][fieldparams.BLSPubkeyLength]byte) error {
+	ctx := context.Background()
+	return s.update(ctx, func(tx *sql.Tx) error {
+		for _, pubKey := range pubKeys {
+			if _, err := tx.ExecContext(
+				ctx, "INSERT INTO validators (pubkey) VALUES ($1) ON CONFLICT DO NOTHING", pubKey[:],
+			); err != nil {
+				return errors.Wrapf(err, "could not register public key %#x", pubKey)
+			}
+		}
+		return nil
+	})
+}
+
+// update runs the function in a transaction, which is committed if the function succeeds.
+func (s *Store) update(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.WithError(rollbackErr).Error("Could not roll back transaction")
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockPubKey locks the row of the validator public key until the end of the transaction, so that
+// concurrent signing checks of the key are serialized across validator clients.
+func lockPubKey(ctx context.Context, tx *sql.Tx, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	if _, err := tx.ExecContext(
+		ctx, "INSERT INTO validators (pubkey) VALUES ($1) ON CONFLICT DO NOTHING", pubKey[:],
+	); err != nil {
+		return errors.Wrapf(err, "could not register public key %#x", pubKey)
+	}
+	if _, err := tx.ExecContext(
+		ctx, "SELECT pubkey FROM validators WHERE pubkey = $1 FOR UPDATE", pubKey[:],
+	); err != nil {
+		return errors.Wrapf(err, "could not lock public key %#x", pubKey)
+	}
+	return nil
+}
+
+// redact removes the password from a connection URL. Connection strings which are not URLs are
+// not returned, as their password cannot be told apart.
+func redact(dataSourceName string) string {
+	u, err := url.Parse(dataSourceName)
+	if err != nil || u.Scheme == "" {
+		return "postgres"
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	}
+	return u.String()
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	validatorServiceConfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// Keys of the metadata table.
+const (
+	genesisValidatorsRootKey = "genesis-val-root"
+	graffitiOrderedIndexKey  = "graffiti-ordered-index"
+	graffitiFileHashKey      = "graffiti-file-hash"
+	proposerSettingsKey      = "proposer-settings"
+)
+
+// SaveGenesisValidatorsRoot saves the genesis validators root to db.
+func (s *Store) SaveGenesisValidatorsRoot(ctx context.Context, genValRoot []byte) error {
+	return s.update(ctx, func(tx *sql.Tx) error {
+		enc, err := metadata(ctx, tx, genesisValidatorsRootKey, true)
+		if err != nil {
+			return err
+		}
+		if len(enc) != 0 && !bytes.Equal(enc, genValRoot) {
+			return fmt.Errorf("cannot overwrite existing genesis validators root: %#x", enc)
+		}
+		return saveMetadata(ctx, tx, genesisValidatorsRootKey, genValRoot)
+	})
+}
+
+// GenesisValidatorsRoot retrieves the genesis validators root from db.
+func (s *Store) GenesisValidatorsRoot(ctx context.Context) ([]byte, error) {
+	enc, err := metadata(ctx, s.db, genesisValidatorsRootKey, false)
+	if err != nil || len(enc) == 0 {
+		return nil, err
+	}
+	return enc, nil
+}
+
+// SaveGraffitiOrderedIndex writes the current graffiti index to the db
+func (s *Store) SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error {
+	return saveMetadata(ctx, s.db, graffitiOrderedIndexKey, bytesutil.Uint64ToBytesBigEndian(index))
+}
+
+// GraffitiOrderedIndex fetches the ordered index, resetting if the file hash changed
+func (s *Store) GraffitiOrderedIndex(ctx context.Context, fileHash [32]byte) (uint64, error) {
+	orderedIndex := uint64(0)
+	err := s.update(ctx, func(tx *sql.Tx) error {
+		dbFileHash, err := metadata(ctx, tx, graffitiFileHashKey, true)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(dbFileHash, fileHash[:]) {
+			indexBytes, err := metadata(ctx, tx, graffitiOrderedIndexKey, false)
+			if err != nil {
+				return err
+			}
+			orderedIndex = bytesutil.BytesToUint64BigEndian(indexBytes)
+			return nil
+		}
+		if err := saveMetadata(ctx, tx, graffitiOrderedIndexKey, bytesutil.Uint64ToBytesBigEndian(0)); err != nil {
+			return err
+		}
+		return saveMetadata(ctx, tx, graffitiFileHashKey, fileHash[:])
+	})
+	return orderedIndex, err
+}
+
+// SaveGraffitiForPubKey saves the graffiti set for a validator public key, overriding any graffiti
+// configured through the command line or graffiti file.
+func (s *Store) SaveGraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
+	ctx, span := trace.StartSpan(ctx, "validator.db.SaveGraffitiForPubKey")
+	defer span.End()
+	if len(graffiti) > fieldparams.RootLength {
+		return errors.Errorf("graffiti length %d exceeds maximum of %d bytes", len(graffiti), fieldparams.RootLength)
+	}
+	if graffiti == nil {
+		graffiti = []byte{}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO graffiti (pubkey, graffiti) VALUES ($1, $2)
+		ON CONFLICT (pubkey) DO UPDATE SET graffiti = EXCLUDED.graffiti`, pubKey[:], graffiti)
+	return err
+}
+
+// GraffitiForPubKey retrieves the graffiti saved for a validator public key, if any.
+func (s *Store) GraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "validator.db.GraffitiForPubKey")
+	defer span.End()
+	var graffiti []byte
+	err := s.db.QueryRowContext(ctx, "SELECT graffiti FROM graffiti WHERE pubkey = $1", pubKey[:]).Scan(&graffiti)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return graffiti, true, nil
+}
+
+// DeleteGraffitiForPubKey removes the graffiti saved for a validator public key.
+func (s *Store) DeleteGraffitiForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	ctx, span := trace.StartSpan(ctx, "validator.db.DeleteGraffitiForPubKey")
+	defer span.End()
+	_, err := s.db.ExecContext(ctx, "DELETE FROM graffiti WHERE pubkey = $1", pubKey[:])
+	return err
+}
+
+// EIPImportBlacklistedPublicKeys returns keys that were marked as blacklisted during EIP-3076 slashing
+// protection imports, ensuring that we can prevent these keys from having duties at runtime.
+func (s *Store) EIPImportBlacklistedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.EIPImportBlacklistedPublicKeys")
+	defer span.End()
+	return s.publicKeys(ctx, "SELECT pubkey FROM eip_blacklisted_keys ORDER BY pubkey")
+}
+
+// SaveEIPImportBlacklistedPublicKeys stores a list of blacklisted public keys that
+// were determined during EIP-3076 slashing protection imports.
+func (s *Store) SaveEIPImportBlacklistedPublicKeys(ctx context.Context, publicKeys [][fieldparams.BLSPubkeyLength]byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.SaveEIPImportBlacklistedPublicKeys")
+	defer span.End()
+	return s.update(ctx, func(tx *sql.Tx) error {
+		for _, pubKey := range publicKeys {
+			if _, err := tx.ExecContext(
+				ctx, "INSERT INTO eip_blacklisted_keys (pubkey) VALUES ($1) ON CONFLICT DO NOTHING", pubKey[:],
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateProposerSettingsForPubkey updates the existing settings for an internal representation of the proposers settings file at a particular public key
+func (s *Store) UpdateProposerSettingsForPubkey(ctx context.Context, pubkey [fieldparams.BLSPubkeyLength]byte, options *validatorServiceConfig.ProposerOption) error {
+	ctx, span := trace.StartSpan(ctx, "validator.db.UpdateProposerSettingsForPubkey")
+	defer span.End()
+	return s.updateProposerSettings(ctx, func(settings *validatorServiceConfig.ProposerSettings) {
+		if settings.ProposeConfig == nil {
+			settings.ProposeConfig = make(map[[fieldparams.BLSPubkeyLength]byte]*validatorServiceConfig.ProposerOption)
+		}
+		settings.ProposeConfig[pubkey] = options
+	})
+}
+
+// UpdateProposerSettingsDefault updates the existing default settings for proposer settings
+func (s *Store) UpdateProposerSettingsDefault(ctx context.Context, options *validatorServiceConfig.ProposerOption) error {
+	ctx, span := trace.StartSpan(ctx, "validator.db.UpdateProposerSettingsDefault")
+	defer span.End()
+	if options == nil {
+		return errors.New("proposer settings option was empty")
+	}
+	if options.FeeRecipientConfig == nil {
+		return errors.New("fee recipient cannot be empty")
+	}
+	return s.updateProposerSettings(ctx, func(settings *validatorServiceConfig.ProposerSettings) {
+		settings.DefaultConfig = options
+	})
+}
+
+// updateProposerSettings applies the update to the saved proposer settings within a transaction.
+func (s *Store) updateProposerSettings(ctx context.Context, update func(*validatorServiceConfig.ProposerSettings)) error {
+	return s.update(ctx, func(tx *sql.Tx) error {
+		b, err := metadata(ctx, tx, proposerSettingsKey, true)
+		if err != nil {
+			return err
+		}
+		if len(b) == 0 {
+			return kv.ErrNoProposerSettingsFound
+		}
+		to := &validatorpb.ProposerSettingsPayload{}
+		if err := proto.Unmarshal(b, to); err != nil {
+			return errors.Wrap(err, "failed to unmarshal proposer settings")
+		}
+		settings, err := validatorServiceConfig.ToSettings(to)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert payload to proposer settings")
+		}
+		update(settings)
+		m, err := proto.Marshal(settings.ToPayload())
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal proposer settings")
+		}
+		return saveMetadata(ctx, tx, proposerSettingsKey, m)
+	})
+}
+
+// ProposerSettings gets the current proposer settings
+func (s *Store) ProposerSettings(ctx context.Context) (*validatorServiceConfig.ProposerSettings, error) {
+	ctx, span := trace.StartSpan(ctx, "validator.db.ProposerSettings")
+	defer span.End()
+	b, err := metadata(ctx, s.db, proposerSettingsKey, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, kv.ErrNoProposerSettingsFound
+	}
+	to := &validatorpb.ProposerSettingsPayload{}
+	if err := proto.Unmarshal(b, to); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposer settings")
+	}
+	return validatorServiceConfig.ToSettings(to)
+}
+
+// ProposerSettingsExists returns true or false if the settings exist or not
+func (s *Store) ProposerSettingsExists(ctx context.Context) (bool, error) {
+	ps, err := s.ProposerSettings(ctx)
+	if err != nil {
+		if errors.Is(err, kv.ErrNoProposerSettingsFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return ps != nil, nil
+}
+
+// SaveProposerSettings saves the entire proposer setting overriding the existing settings
+func (s *Store) SaveProposerSettings(ctx context.Context, settings *validatorServiceConfig.ProposerSettings) error {
+	ctx, span := trace.StartSpan(ctx, "validator.db.SaveProposerSettings")
+	defer span.End()
+	// nothing to save
+	if !settings.ShouldBeSaved() {
+		log.Warn("proposer settings are empty, nothing has been saved")
+		return nil
+	}
+	m, err := proto.Marshal(settings.ToPayload())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal proposer settings")
+	}
+	return saveMetadata(ctx, s.db, proposerSettingsKey, m)
+}
+
+// metadata returns the value saved at the key of the metadata table, or nil if there is none. With
+// forUpdate, the row of the key is locked until the end of the transaction.
+func metadata(ctx context.Context, q querier, key string, forUpdate bool) ([]byte, error) {
+	query := "SELECT value FROM metadata WHERE key = $1"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	var value []byte
+	err := q.QueryRowContext(ctx, query, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return value, err
+}
+
+func saveMetadata(ctx context.Context, q querier, key string, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`, key, value)
+	return errors.Wrapf(err, "could not save %s", key)
+}
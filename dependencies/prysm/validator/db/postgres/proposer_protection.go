@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"go.opencensus.io/trace"
+)
+
+// ProposedPublicKeys retrieves all public keys which have proposed.
+func (s *Store) ProposedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.ProposedPublicKeys")
+	defer span.End()
+	return s.publicKeys(ctx, "SELECT DISTINCT pubkey FROM proposals ORDER BY pubkey")
+}
+
+// ProposalHistoryForSlot accepts a validator public key and returns the corresponding signing root as well
+// as a boolean that tells us if we have a proposal history stored at the slot and a boolean that tells us if we have
+// a signed root at the slot.
+func (s *Store) ProposalHistoryForSlot(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot) ([32]byte, bool, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.ProposalHistoryForSlot")
+	defer span.End()
+	var signingRoot [32]byte
+	existing, exists, err := proposalAtSlot(ctx, s.db, publicKey, slot)
+	if err != nil || len(existing) == 0 {
+		return signingRoot, exists, false, err
+	}
+	copy(signingRoot[:], existing)
+	return signingRoot, true, true, nil
+}
+
+// ProposalHistoryForPubKey returns the entire proposal history for a given public key.
+func (s *Store) ProposalHistoryForPubKey(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) ([]*kv.Proposal, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.ProposalHistoryForPubKey")
+	defer span.End()
+	rows, err := s.db.QueryContext(
+		ctx, "SELECT slot, signing_root FROM proposals WHERE pubkey = $1 ORDER BY slot", publicKey[:],
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+	proposals := make([]*kv.Proposal, 0)
+	for rows.Next() {
+		var slot int64
+		var signingRoot []byte
+		if err := rows.Scan(&slot, &signingRoot); err != nil {
+			return nil, err
+		}
+		sr := make([]byte, fieldparams.RootLength)
+		copy(sr, signingRoot)
+		proposals = append(proposals, &kv.Proposal{Slot: primitives.Slot(slot), SigningRoot: sr})
+	}
+	return proposals, rows.Err()
+}
+
+// SaveProposalHistoryForSlot saves the proposal history for the requested validator public key. The
+// row of the key is locked while checking that no other validator client using the database saved
+// a different block at the slot, so that two clients cannot both sign a slashable block.
+func (s *Store) SaveProposalHistoryForSlot(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot []byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.SaveProposalHistoryForSlot")
+	defer span.End()
+	err := s.update(ctx, func(tx *sql.Tx) error {
+		if err := lockPubKey(ctx, tx, pubKey); err != nil {
+			return err
+		}
+		existing, _, err := proposalAtSlot(ctx, tx, pubKey, slot)
+		if err != nil {
+			return err
+		}
+		if len(existing) != 0 && len(signingRoot) != 0 && !bytes.Equal(existing, signingRoot) {
+			return fmt.Errorf(
+				"double proposal found, existing block at slot %d with conflicting signing root %#x", slot, existing,
+			)
+		}
+		if signingRoot == nil {
+			signingRoot = []byte{}
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO proposals (pubkey, slot, signing_root) VALUES ($1, $2, $3)
+			ON CONFLICT (pubkey, slot) DO UPDATE SET signing_root = EXCLUDED.signing_root`,
+			pubKey[:], int64(slot), signingRoot,
+		); err != nil {
+			return errors.Wrap(err, "could not save proposal")
+		}
+		return nil
+	})
+	tracing.AnnotateError(span, err)
+	return err
+}
+
+// proposalAtSlot returns the signing root saved at the slot, which may be empty, and whether a
+// proposal exists at the slot.
+func proposalAtSlot(
+	ctx context.Context, q querier, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot,
+) ([]byte, bool, error) {
+	var signingRoot []byte
+	err := q.QueryRowContext(
+		ctx, "SELECT signing_root FROM proposals WHERE pubkey = $1 AND slot = $2", pubKey[:], int64(slot),
+	).Scan(&signingRoot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return signingRoot, true, nil
+}
+
+// LowestSignedProposal returns the lowest signed proposal slot for a validator public key.
+// If no data exists, a boolean of value false is returned.
+func (s *Store) LowestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.LowestSignedProposal")
+	defer span.End()
+	var slot sql.NullInt64
+	err := s.db.QueryRowContext(ctx, "SELECT MIN(slot) FROM proposals WHERE pubkey = $1", publicKey[:]).Scan(&slot)
+	return primitives.Slot(slot.Int64), slot.Valid, err
+}
+
+// HighestSignedProposal returns the highest signed proposal slot for a validator public key.
+// If no data exists, a boolean of value false is returned.
+func (s *Store) HighestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Postgres.HighestSignedProposal")
+	defer span.End()
+	var slot sql.NullInt64
+	err := s.db.QueryRowContext(ctx, "SELECT MAX(slot) FROM proposals WHERE pubkey = $1", publicKey[:]).Scan(&slot)
+	return primitives.Slot(slot.Int64), slot.Valid, err
+}
+
+// publicKeys returns the public keys selected by the query.
+func (s *Store) publicKeys(ctx context.Context, query string) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	for rows.Next() {
+		var key []byte
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		var pubKey [fieldparams.BLSPubkeyLength]byte
+		copy(pubKey[:], key)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, rows.Err()
+}
+
+func closeRows(rows *sql.Rows) {
+	if err := rows.Close(); err != nil {
+		log.WithError(err).Error("Could not close rows")
+	}
+}
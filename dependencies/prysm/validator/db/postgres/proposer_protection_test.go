@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ProposalHistory(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	db := setupDB(t, nil)
+
+	_, exists, signingRootExists, err := db.ProposalHistoryForSlot(ctx, pubKey, 10)
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+	assert.Equal(t, false, signingRootExists)
+
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 10, []byte{1}))
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 12, nil))
+	// Saving the same block again is allowed, a different one is a double proposal.
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 10, []byte{1}))
+	assert.ErrorContains(t, "double proposal found", db.SaveProposalHistoryForSlot(ctx, pubKey, 10, []byte{2}))
+
+	signingRoot, exists, signingRootExists, err := db.ProposalHistoryForSlot(ctx, pubKey, 10)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, true, signingRootExists)
+	assert.Equal(t, [32]byte{1}, signingRoot)
+	_, exists, signingRootExists, err = db.ProposalHistoryForSlot(ctx, pubKey, 12)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, false, signingRootExists)
+
+	lowest, exists, err := db.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(10), lowest)
+	highest, exists, err := db.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(12), highest)
+
+	proposals, err := db.ProposalHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(proposals))
+	assert.Equal(t, primitives.Slot(12), proposals[1].Slot)
+
+	keys, err := db.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, keys)
+}
@@ -27,3 +27,22 @@ func SetupDB(t testing.TB, pubkeys [][fieldparams.BLSPubkeyLength]byte) iface.Va
 	})
 	return db
 }
+
+// SetupMinimalDB instantiates and returns a minimal slashing protection DB instance for the validator client.
+func SetupMinimalDB(t testing.TB, pubkeys [][fieldparams.BLSPubkeyLength]byte) iface.ValidatorDB {
+	db, err := kv.NewMinimalKVStore(context.Background(), t.TempDir(), &kv.Config{
+		PubKeys: pubkeys,
+	})
+	if err != nil {
+		t.Fatalf("Failed to instantiate DB: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("Failed to close database: %v", err)
+		}
+		if err := db.ClearDB(); err != nil {
+			t.Fatalf("Failed to clear database: %v", err)
+		}
+	})
+	return db
+}
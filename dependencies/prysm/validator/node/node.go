@@ -51,8 +51,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v4/validator/client"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/lease"
+	validatordb "github.com/prysmaticlabs/prysm/v4/validator/db"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/postgres"
 	g "github.com/prysmaticlabs/prysm/v4/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer"
@@ -70,7 +73,7 @@ type ValidatorClient struct {
 	cliCtx            *cli.Context
 	ctx               context.Context
 	cancel            context.CancelFunc
-	db                *kv.Store
+	db                iface.ValidatorDB
 	services          *runtime.ServiceRegistry // Lifecycle and service store.
 	lock              sync.RWMutex
 	wallet            *wallet.Wallet
@@ -292,18 +295,14 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context, router *mux.Rou
 		if err := clearDB(cliCtx.Context, dataDir, forceClearFlag); err != nil {
 			return err
 		}
-	} else {
+	} else if !cliCtx.IsSet(flags.PostgresDatabaseURLFlag.Name) {
 		if !file.Exists(dataFile) {
 			log.Warnf("Slashing protection file %s is missing.\n"+
 				"If you changed your --datadir, please copy your previous \"validator.db\" file into your current --datadir.\n"+
 				"Disregard this warning if this is the first time you are running this set of keys.", dataFile)
 		}
 	}
-	log.WithField("databasePath", dataDir).Info("Checking DB")
-
-	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys: nil,
-	})
+	valDB, err := openDB(cliCtx, dataDir)
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
 	}
@@ -331,6 +330,23 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context, router *mux.Rou
 	return nil
 }
 
+// openDB opens the PostgreSQL validator database if its URL is set, or the database in the data
+// directory otherwise.
+func openDB(cliCtx *cli.Context, dataDir string) (iface.ValidatorDB, error) {
+	if dsn := cliCtx.String(flags.PostgresDatabaseURLFlag.Name); dsn != "" {
+		valDB, err := postgres.NewStore(cliCtx.Context, dsn, &kv.Config{})
+		if err != nil {
+			return nil, err
+		}
+		log.WithField("databasePath", valDB.DatabasePath()).Info("Checking DB")
+		return valDB, nil
+	}
+	log.WithField("databasePath", dataDir).Info("Checking DB")
+	return validatordb.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
+		PubKeys: nil,
+	})
+}
+
 func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context, router *mux.Router) error {
 	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
 	dataFile := filepath.Join(dataDir, kv.ProtectionDbFileName)
@@ -374,10 +390,7 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context, router *mux.Rout
 			return err
 		}
 	}
-	log.WithField("databasePath", dataDir).Info("Checking DB")
-	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys: nil,
-	})
+	valDB, err := openDB(cliCtx, dataDir)
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
 	}
@@ -410,6 +423,40 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context, router *mux.Rout
 	return nil
 }
 
+// leaseConfig returns the store of the lease shared with standby validator clients and the id of
+// this client, or a nil store if no lease is configured.
+func leaseConfig(cliCtx *cli.Context) (lease.Store, string, error) {
+	var store lease.Store
+	switch {
+	case cliCtx.IsSet(flags.LeaseFileFlag.Name) && cliCtx.IsSet(flags.LeaseEtcdEndpointFlag.Name):
+		return nil, "", fmt.Errorf("--%s and --%s cannot be used together", flags.LeaseFileFlag.Name, flags.LeaseEtcdEndpointFlag.Name)
+	case cliCtx.IsSet(flags.LeaseFileFlag.Name):
+		fileStore, err := lease.NewFileStore(cliCtx.String(flags.LeaseFileFlag.Name))
+		if err != nil {
+			return nil, "", err
+		}
+		store = fileStore
+	case cliCtx.IsSet(flags.LeaseEtcdEndpointFlag.Name):
+		store = lease.NewEtcdStore(
+			&http.Client{Timeout: cliCtx.Duration(flags.LeaseTTLFlag.Name) / 3},
+			cliCtx.String(flags.LeaseEtcdEndpointFlag.Name),
+			cliCtx.String(flags.LeaseEtcdKeyFlag.Name),
+		)
+	default:
+		return nil, "", nil
+	}
+	id := cliCtx.String(flags.LeaseIDFlag.Name)
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, "", errors.Wrap(err, "could not get hostname to use as lease id")
+		}
+		id = hostname
+	}
+	log.WithField("id", id).Info("Running as an active or standby validator client, signing only while holding the lease")
+	return store, id, nil
+}
+
 func (c *ValidatorClient) registerPrometheusService(cliCtx *cli.Context) error {
 	var additionalHandlers []prometheus.Handler
 	if cliCtx.IsSet(cmd.EnableBackupWebhookFlag.Name) {
@@ -475,6 +522,11 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	leaseStore, leaseID, err := leaseConfig(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
 	validatorService, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
 		DataDir:                    dataDir,
@@ -497,6 +549,10 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		BeaconApiTimeout:           time.Second * 30,
 		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
 		ValidatorsRegBatchSize:     c.cliCtx.Int(flags.ValidatorsRegistrationBatchSizeFlag.Name),
+		Distributed:                c.cliCtx.Bool(flags.EnableDistributed.Name),
+		LeaseStore:                 leaseStore,
+		LeaseID:                    leaseID,
+		LeaseTTL:                   c.cliCtx.Duration(flags.LeaseTTLFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -624,6 +680,10 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 		if config.GasLimit == validator.Uint64(params.BeaconConfig().DefaultBuilderGasLimit) && vpSettings.DefaultConfig.BuilderConfig != nil {
 			config.GasLimit = vpSettings.DefaultConfig.BuilderConfig.GasLimit
 		}
+		if vpSettings.DefaultConfig.BuilderConfig != nil {
+			// The minimum builder bid can only be set in the proposer settings file.
+			config.MinBidGwei = vpSettings.DefaultConfig.BuilderConfig.MinBidGwei
+		}
 		vpSettings.DefaultConfig.BuilderConfig = config
 	} else if vpSettings.DefaultConfig.BuilderConfig != nil {
 		vpSettings.DefaultConfig.BuilderConfig.GasLimit = reviewGasLimit(vpSettings.DefaultConfig.BuilderConfig.GasLimit)
@@ -655,6 +715,9 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 				if config.GasLimit == validator.Uint64(params.BeaconConfig().DefaultBuilderGasLimit) && currentBuilderConfig != nil {
 					config.GasLimit = currentBuilderConfig.GasLimit
 				}
+				if currentBuilderConfig != nil {
+					config.MinBidGwei = currentBuilderConfig.MinBidGwei
+				}
 				currentBuilderConfig = config
 			} else if currentBuilderConfig != nil {
 				currentBuilderConfig.GasLimit = reviewGasLimit(currentBuilderConfig.GasLimit)
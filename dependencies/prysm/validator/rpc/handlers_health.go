@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/network/httputil"
@@ -162,3 +163,31 @@ func (s *Server) StreamValidatorLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// GetLease returns the state of this validator client in the election of the client which signs
+// among the clients sharing the same keys, and the current holder of the lease.
+func (s *Server) GetLease(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.web.health.GetLease")
+	defer span.End()
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready.", http.StatusServiceUnavailable)
+		return
+	}
+	status := s.validatorService.LeaseStatus()
+	if status == nil {
+		httputil.WriteJson(w, &LeaseResponse{Enabled: false})
+		return
+	}
+	resp := &LeaseResponse{
+		Enabled:     true,
+		Id:          status.ID,
+		Nonce:       status.Nonce,
+		State:       string(status.State),
+		Holder:      status.Holder,
+		HolderNonce: status.HolderNonce,
+	}
+	if !status.Expiry.IsZero() {
+		resp.Expiry = status.Expiry.UTC().Format(time.RFC3339)
+	}
+	httputil.WriteJson(w, resp)
+}
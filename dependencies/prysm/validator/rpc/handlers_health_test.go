@@ -3,6 +3,7 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -17,6 +18,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/client"
 	"google.golang.org/grpc"
 )
 
@@ -190,3 +192,28 @@ func TestServer_GetVersion(t *testing.T) {
 	require.NotNil(t, body)
 	require.StringContains(t, `{"beacon":"4.10.1","validator":"Prysm/Unknown/Local build. Built at: Moments ago"}`, string(body))
 }
+
+func TestServer_GetLease(t *testing.T) {
+	t.Run("validator service not ready", func(t *testing.T) {
+		s := &Server{}
+		r := httptest.NewRequest("GET", "/v2/validator/health/lease", nil)
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetLease(w, r)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+	t.Run("lease disabled", func(t *testing.T) {
+		vs, err := client.NewValidatorService(context.Background(), &client.Config{})
+		require.NoError(t, err)
+		s := &Server{validatorService: vs}
+		r := httptest.NewRequest("GET", "/v2/validator/health/lease", nil)
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetLease(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &LeaseResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		require.Equal(t, false, resp.Enabled)
+		require.Equal(t, "", resp.State)
+	})
+}
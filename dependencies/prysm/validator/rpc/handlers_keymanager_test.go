@@ -1854,3 +1854,123 @@ func TestServer_DeleteFeeRecipientByPubkey_InvalidPubKey(t *testing.T) {
 
 	require.StringContains(t, "pubkey is invalid", w.Body.String())
 }
+
+func TestServer_Graffiti(t *testing.T) {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+
+	m := &mock.Validator{}
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: m,
+		ValDB:     validatorDB,
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+		valDB:            validatorDB,
+	}
+
+	request := &SetGraffitiRequest{Graffiti: "hex:0x707279736d"}
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(request))
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), &buf)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.SetGraffiti(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), nil)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.GetGraffiti(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &GetGraffitiResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, pubkey, resp.Data.Pubkey)
+	assert.Equal(t, "prysm", resp.Data.Graffiti)
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), nil)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.DeleteGraffiti(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), nil)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.GetGraffiti(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &GetGraffitiResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, "", resp.Data.Graffiti)
+}
+
+func TestServer_SetGraffiti_GraffitiFlag(t *testing.T) {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+
+	m := &mock.Validator{GraffitiFlag: []byte("flag")}
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: m,
+		ValDB:     validatorDB,
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+		valDB:            validatorDB,
+	}
+
+	request := &SetGraffitiRequest{Graffiti: "prysm"}
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(request))
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), &buf)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.SetGraffiti(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.StringContains(t, "--graffiti flag", w.Body.String())
+
+	// The graffiti of the flag is still the one used.
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), nil)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.GetGraffiti(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &GetGraffitiResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, "flag", resp.Data.Graffiti)
+}
+
+func TestServer_SetGraffiti_TooLong(t *testing.T) {
+	s := &Server{
+		validatorService: &client.ValidatorService{},
+	}
+	request := &SetGraffitiRequest{Graffiti: "this graffiti is far too long to fit in a block"}
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(request))
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), &buf)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"})
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.SetGraffiti(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.StringContains(t, "Graffiti exceeds 32 bytes", w.Body.String())
+}
+
+func TestServer_Graffiti_ValidatorServiceNil(t *testing.T) {
+	s := &Server{}
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), nil)
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.GetGraffiti(w, req)
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	require.StringContains(t, "Validator service not ready", w.Body.String())
+}
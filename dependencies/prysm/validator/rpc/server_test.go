@@ -20,10 +20,12 @@ func TestServer_InitializeRoutes(t *testing.T) {
 		"/eth/v1/remotekeys":                         {http.MethodGet, http.MethodPost, http.MethodDelete},
 		"/eth/v1/validator/{pubkey}/gas_limit":       {http.MethodGet, http.MethodPost, http.MethodDelete},
 		"/eth/v1/validator/{pubkey}/feerecipient":    {http.MethodGet, http.MethodPost, http.MethodDelete},
+		"/eth/v1/validator/{pubkey}/graffiti":        {http.MethodGet, http.MethodPost, http.MethodDelete},
 		"/eth/v1/validator/{pubkey}/voluntary_exit":  {http.MethodPost},
 		"/v2/validator/health/version":               {http.MethodGet},
 		"/v2/validator/health/logs/validator/stream": {http.MethodGet},
 		"/v2/validator/health/logs/beacon/stream":    {http.MethodGet},
+		"/v2/validator/health/lease":                 {http.MethodGet},
 		"/v2/validator/wallet":                       {http.MethodGet},
 		"/v2/validator/wallet/create":                {http.MethodPost},
 		"/v2/validator/wallet/keystores/validate":    {http.MethodPost},
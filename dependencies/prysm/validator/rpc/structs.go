@@ -62,6 +62,19 @@ type SetGasLimitRequest struct {
 	GasLimit string `json:"gas_limit"`
 }
 
+type GraffitiMetaData struct {
+	Pubkey   string `json:"pubkey"`
+	Graffiti string `json:"graffiti"`
+}
+
+type GetGraffitiResponse struct {
+	Data *GraffitiMetaData `json:"data"`
+}
+
+type SetGraffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
+
 // remote keymanager api
 type ListRemoteKeysResponse struct {
 	Data []*RemoteKey `json:"data"`
@@ -444,3 +457,13 @@ func (m *ChainHead) ToConsensus() (*eth.ChainHead, error) {
 		OptimisticStatus:           m.OptimisticStatus,
 	}, nil
 }
+
+type LeaseResponse struct {
+	Enabled     bool   `json:"enabled"`
+	Id          string `json:"id,omitempty"`
+	Nonce       string `json:"nonce,omitempty"`
+	State       string `json:"state,omitempty"`
+	Holder      string `json:"holder,omitempty"`
+	HolderNonce string `json:"holder_nonce,omitempty"`
+	Expiry      string `json:"expiry,omitempty"`
+}
@@ -0,0 +1,315 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/slashings"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+)
+
+// SlashableHistory is signing history of a validator public key in EIP-3076 slashing protection
+// data which is slashable, or which cannot be told apart from slashable history because of
+// missing signing roots.
+type SlashableHistory struct {
+	PubKey [fieldparams.BLSPubkeyLength]byte
+	Reason string
+}
+
+// interchangeHistory is the signing history of EIP-3076 slashing protection data by validator
+// public key, sorted by slot and epochs and without duplicate entries.
+type interchangeHistory struct {
+	genesisValidatorsRoot [32]byte
+	proposals             map[[fieldparams.BLSPubkeyLength]byte][]kv.Proposal
+	attestations          map[[fieldparams.BLSPubkeyLength]byte][]*kv.AttestationRecord
+}
+
+// MergeStandardProtectionJSON merges the slashing protection data of several EIP-3076 JSON files
+// into a single one, with a single entry per validator public key. All files must be on the
+// same chain. Merged history is not checked for slashable entries, which is done by
+// VerifyStandardProtectionJSON.
+func MergeStandardProtectionJSON(interchanges ...*format.EIPSlashingProtectionFormat) (*format.EIPSlashingProtectionFormat, error) {
+	if len(interchanges) == 0 {
+		return nil, errors.New("no slashing protection JSON to merge")
+	}
+	merged := &format.EIPSlashingProtectionFormat{}
+	merged.Metadata = interchanges[0].Metadata
+	for i, interchangeJSON := range interchanges {
+		if interchangeJSON.Metadata.InterchangeFormatVersion != merged.Metadata.InterchangeFormatVersion {
+			return nil, fmt.Errorf(
+				"slashing protection JSON %d has version '%s', wanted '%s'",
+				i,
+				interchangeJSON.Metadata.InterchangeFormatVersion,
+				merged.Metadata.InterchangeFormatVersion,
+			)
+		}
+		if interchangeJSON.Metadata.GenesisValidatorsRoot != merged.Metadata.GenesisValidatorsRoot {
+			return nil, fmt.Errorf(
+				"slashing protection JSON %d has genesis validators root %s, wanted %s",
+				i,
+				interchangeJSON.Metadata.GenesisValidatorsRoot,
+				merged.Metadata.GenesisValidatorsRoot,
+			)
+		}
+		merged.Data = append(merged.Data, interchangeJSON.Data...)
+	}
+	history, err := parseInterchangeHistory(merged)
+	if err != nil {
+		return nil, err
+	}
+	return history.toStandardProtectionJSON()
+}
+
+// VerifyStandardProtectionJSON checks that EIP-3076 slashing protection data is on the chain of
+// the genesis validators root, and returns its slashable history. The chain is not checked if
+// the genesis validators root is nil.
+func VerifyStandardProtectionJSON(interchangeJSON *format.EIPSlashingProtectionFormat, genesisValidatorsRoot []byte) ([]*SlashableHistory, error) {
+	history, err := parseInterchangeHistory(interchangeJSON)
+	if err != nil {
+		return nil, err
+	}
+	if genesisValidatorsRoot != nil && !bytes.Equal(genesisValidatorsRoot, history.genesisValidatorsRoot[:]) {
+		return nil, fmt.Errorf(
+			"genesis validators root %s of slashing protection JSON does not match %#x of the chain",
+			interchangeJSON.Metadata.GenesisValidatorsRoot,
+			genesisValidatorsRoot,
+		)
+	}
+
+	slashable := make([]*SlashableHistory, 0)
+	for _, pubKey := range history.publicKeys() {
+		if reason := slashableProposals(history.proposals[pubKey]); reason != "" {
+			slashable = append(slashable, &SlashableHistory{PubKey: pubKey, Reason: reason})
+		}
+		if reason := slashableAttestations(history.attestations[pubKey]); reason != "" {
+			slashable = append(slashable, &SlashableHistory{PubKey: pubKey, Reason: reason})
+		}
+	}
+	return slashable, nil
+}
+
+// MinifyStandardProtectionJSON reduces EIP-3076 slashing protection data to a single block at
+// the highest signed slot and a single attestation at the highest signed source and target
+// epochs of each validator public key, as described in the EIP. A signing root is only kept if
+// that exact block or attestation was signed.
+func MinifyStandardProtectionJSON(interchangeJSON *format.EIPSlashingProtectionFormat) (*format.EIPSlashingProtectionFormat, error) {
+	history, err := parseInterchangeHistory(interchangeJSON)
+	if err != nil {
+		return nil, err
+	}
+	for pubKey, proposals := range history.proposals {
+		if len(proposals) == 0 {
+			continue
+		}
+		// Proposals are sorted by slot, so the highest ones are last.
+		highest := proposals[len(proposals)-1]
+		if len(proposals) > 1 && proposals[len(proposals)-2].Slot == highest.Slot {
+			highest.SigningRoot = nil
+		}
+		history.proposals[pubKey] = []kv.Proposal{highest}
+	}
+	for pubKey, atts := range history.attestations {
+		if len(atts) == 0 {
+			continue
+		}
+		var source, target primitives.Epoch
+		for _, att := range atts {
+			if att.Source > source {
+				source = att.Source
+			}
+			if att.Target > target {
+				target = att.Target
+			}
+		}
+		watermark := &kv.AttestationRecord{PubKey: pubKey, Source: source, Target: target}
+		matching := 0
+		for _, att := range atts {
+			if att.Source == source && att.Target == target {
+				watermark.SigningRoot = att.SigningRoot
+				matching++
+			}
+		}
+		if matching > 1 {
+			watermark.SigningRoot = nil
+		}
+		history.attestations[pubKey] = []*kv.AttestationRecord{watermark}
+	}
+	return history.toStandardProtectionJSON()
+}
+
+func parseInterchangeHistory(interchangeJSON *format.EIPSlashingProtectionFormat) (*interchangeHistory, error) {
+	version := interchangeJSON.Metadata.InterchangeFormatVersion
+	if version != format.InterchangeFormatVersion {
+		return nil, fmt.Errorf(
+			"slashing protection JSON version '%s' is not supported, wanted '%s'",
+			version,
+			format.InterchangeFormatVersion,
+		)
+	}
+	gvr, err := RootFromHex(interchangeJSON.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid root: %w", interchangeJSON.Metadata.GenesisValidatorsRoot, err)
+	}
+	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(interchangeJSON.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for blocks by public key")
+	}
+	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(interchangeJSON.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for attestations by public key")
+	}
+
+	history := &interchangeHistory{
+		genesisValidatorsRoot: gvr,
+		proposals:             make(map[[fieldparams.BLSPubkeyLength]byte][]kv.Proposal),
+		attestations:          make(map[[fieldparams.BLSPubkeyLength]byte][]*kv.AttestationRecord),
+	}
+	// Every public key has an entry in the proposals, even without signed blocks, so that
+	// keys without any history remain in the resulting JSON.
+	for _, validatorData := range interchangeJSON.Data {
+		pubKey, err := PubKeyFromHex(validatorData.Pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid public key: %w", validatorData.Pubkey, err)
+		}
+		history.proposals[pubKey] = nil
+	}
+	for pubKey, signedBlocks := range signedBlocksByPubKey {
+		proposalHistory, err := transformSignedBlocks(context.Background(), signedBlocks)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse signed blocks for key %#x", pubKey)
+		}
+		proposals := proposalHistory.Proposals
+		sort.Slice(proposals, func(i, j int) bool {
+			if proposals[i].Slot != proposals[j].Slot {
+				return proposals[i].Slot < proposals[j].Slot
+			}
+			return bytes.Compare(proposals[i].SigningRoot, proposals[j].SigningRoot) < 0
+		})
+		unique := make([]kv.Proposal, 0, len(proposals))
+		for i, proposal := range proposals {
+			if i > 0 && proposal.Slot == proposals[i-1].Slot && bytes.Equal(proposal.SigningRoot, proposals[i-1].SigningRoot) {
+				continue
+			}
+			unique = append(unique, proposal)
+		}
+		history.proposals[pubKey] = unique
+	}
+	for pubKey, signedAtts := range signedAttsByPubKey {
+		atts, err := transformSignedAttestations(pubKey, signedAtts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse signed attestations for key %#x", pubKey)
+		}
+		sort.Slice(atts, func(i, j int) bool {
+			if atts[i].Target != atts[j].Target {
+				return atts[i].Target < atts[j].Target
+			}
+			if atts[i].Source != atts[j].Source {
+				return atts[i].Source < atts[j].Source
+			}
+			return bytes.Compare(atts[i].SigningRoot, atts[j].SigningRoot) < 0
+		})
+		unique := make([]*kv.AttestationRecord, 0, len(atts))
+		for i, att := range atts {
+			if i > 0 && att.Source == atts[i-1].Source && att.Target == atts[i-1].Target && bytes.Equal(att.SigningRoot, atts[i-1].SigningRoot) {
+				continue
+			}
+			unique = append(unique, att)
+		}
+		history.attestations[pubKey] = unique
+	}
+	return history, nil
+}
+
+// publicKeys returns the validator public keys of the history in increasing order.
+func (h *interchangeHistory) publicKeys() [][fieldparams.BLSPubkeyLength]byte {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(h.proposals))
+	for pubKey := range h.proposals {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+	return pubKeys
+}
+
+func (h *interchangeHistory) toStandardProtectionJSON() (*format.EIPSlashingProtectionFormat, error) {
+	interchangeJSON := &format.EIPSlashingProtectionFormat{}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", h.genesisValidatorsRoot)
+	interchangeJSON.Data = make([]*format.ProtectionData, 0, len(h.proposals))
+	for _, pubKey := range h.publicKeys() {
+		pubKeyHex, err := pubKeyToHexString(pubKey[:])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert public key to hex string")
+		}
+		data := &format.ProtectionData{
+			Pubkey:             pubKeyHex,
+			SignedBlocks:       make([]*format.SignedBlock, 0, len(h.proposals[pubKey])),
+			SignedAttestations: make([]*format.SignedAttestation, 0, len(h.attestations[pubKey])),
+		}
+		for _, proposal := range h.proposals[pubKey] {
+			signingRootHex, err := rootToHexString(proposal.SigningRoot)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not convert signing root to hex string")
+			}
+			data.SignedBlocks = append(data.SignedBlocks, &format.SignedBlock{
+				Slot:        fmt.Sprintf("%d", proposal.Slot),
+				SigningRoot: signingRootHex,
+			})
+		}
+		for _, att := range h.attestations[pubKey] {
+			signingRootHex, err := rootToHexString(att.SigningRoot)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not convert signing root to hex string")
+			}
+			data.SignedAttestations = append(data.SignedAttestations, &format.SignedAttestation{
+				SourceEpoch: fmt.Sprintf("%d", att.Source),
+				TargetEpoch: fmt.Sprintf("%d", att.Target),
+				SigningRoot: signingRootHex,
+			})
+		}
+		interchangeJSON.Data = append(interchangeJSON.Data, data)
+	}
+	return interchangeJSON, nil
+}
+
+// slashableProposals returns why sorted and unique proposals are slashable, or an empty
+// string if they are not.
+func slashableProposals(proposals []kv.Proposal) string {
+	for i := 1; i < len(proposals); i++ {
+		if proposals[i].Slot == proposals[i-1].Slot {
+			return fmt.Sprintf("double proposal at slot %d", proposals[i].Slot)
+		}
+	}
+	return ""
+}
+
+// slashableAttestations returns why sorted and unique attestations are slashable, or an empty
+// string if they are not.
+func slashableAttestations(atts []*kv.AttestationRecord) string {
+	for i, att := range atts {
+		if att.Source > att.Target {
+			return fmt.Sprintf("source epoch %d greater than target epoch %d", att.Source, att.Target)
+		}
+		for _, prev := range atts[:i] {
+			if prev.Target == att.Target && slashings.SigningRootsDiffer(prev.SigningRoot, att.SigningRoot) {
+				return fmt.Sprintf("double vote at target epoch %d", att.Target)
+			}
+			a := createAttestation(prev.Source, prev.Target)
+			b := createAttestation(att.Source, att.Target)
+			if slashings.IsSurround(a, b) || slashings.IsSurround(b, a) {
+				return fmt.Sprintf(
+					"surround vote of source %d and target %d with source %d and target %d",
+					att.Source, att.Target, prev.Source, prev.Target,
+				)
+			}
+		}
+	}
+	return ""
+}
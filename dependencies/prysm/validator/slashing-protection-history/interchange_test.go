@@ -0,0 +1,202 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+)
+
+const (
+	testGenesisValidatorsRoot = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	testPubKeyA               = "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+	testPubKeyB               = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+)
+
+func testRoot(b byte) string {
+	return fmt.Sprintf("%#x", [32]byte{b})
+}
+
+func testInterchange(data ...*format.ProtectionData) *format.EIPSlashingProtectionFormat {
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: data}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = testGenesisValidatorsRoot
+	return interchangeJSON
+}
+
+func TestMergeStandardProtectionJSON(t *testing.T) {
+	first := testInterchange(&format.ProtectionData{
+		Pubkey:       testPubKeyA,
+		SignedBlocks: []*format.SignedBlock{{Slot: "3", SigningRoot: testRoot(1)}, {Slot: "1"}},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRoot(2)},
+		},
+	})
+	second := testInterchange(
+		&format.ProtectionData{
+			Pubkey:       testPubKeyA,
+			SignedBlocks: []*format.SignedBlock{{Slot: "3", SigningRoot: testRoot(1)}, {Slot: "2"}},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRoot(2)},
+				{SourceEpoch: "0", TargetEpoch: "1"},
+			},
+		},
+		&format.ProtectionData{Pubkey: testPubKeyB},
+	)
+
+	merged, err := MergeStandardProtectionJSON(first, second)
+	require.NoError(t, err)
+	assert.Equal(t, testGenesisValidatorsRoot, merged.Metadata.GenesisValidatorsRoot)
+	// Public keys are sorted, with a single entry each, and duplicate entries are dropped.
+	require.DeepEqual(t, []*format.ProtectionData{
+		{
+			Pubkey:             testPubKeyB,
+			SignedBlocks:       []*format.SignedBlock{},
+			SignedAttestations: []*format.SignedAttestation{},
+		},
+		{
+			Pubkey:       testPubKeyA,
+			SignedBlocks: []*format.SignedBlock{{Slot: "1"}, {Slot: "2"}, {Slot: "3", SigningRoot: testRoot(1)}},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "0", TargetEpoch: "1"},
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRoot(2)},
+			},
+		},
+	}, merged.Data)
+
+	other := testInterchange()
+	other.Metadata.GenesisValidatorsRoot = testRoot(9)
+	_, err = MergeStandardProtectionJSON(first, other)
+	require.ErrorContains(t, "has genesis validators root", err)
+	_, err = MergeStandardProtectionJSON()
+	require.ErrorContains(t, "no slashing protection JSON", err)
+}
+
+func TestVerifyStandardProtectionJSON(t *testing.T) {
+	gvr, err := RootFromHex(testGenesisValidatorsRoot)
+	require.NoError(t, err)
+	pubKeyA, err := PubKeyFromHex(testPubKeyA)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		data    *format.ProtectionData
+		reasons []string
+	}{
+		{
+			name: "not slashable",
+			data: &format.ProtectionData{
+				Pubkey:       testPubKeyA,
+				SignedBlocks: []*format.SignedBlock{{Slot: "1"}, {Slot: "2", SigningRoot: testRoot(1)}},
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "1", TargetEpoch: "2"},
+					{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: testRoot(1)},
+				},
+			},
+		},
+		{
+			name: "double proposal",
+			data: &format.ProtectionData{
+				Pubkey:       testPubKeyA,
+				SignedBlocks: []*format.SignedBlock{{Slot: "2", SigningRoot: testRoot(1)}, {Slot: "2", SigningRoot: testRoot(2)}},
+			},
+			reasons: []string{"double proposal at slot 2"},
+		},
+		{
+			name: "double vote without signing root",
+			data: &format.ProtectionData{
+				Pubkey: testPubKeyA,
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRoot(1)},
+					{SourceEpoch: "1", TargetEpoch: "2"},
+				},
+			},
+			reasons: []string{"double vote at target epoch 2"},
+		},
+		{
+			name: "surround vote and double proposal",
+			data: &format.ProtectionData{
+				Pubkey:       testPubKeyA,
+				SignedBlocks: []*format.SignedBlock{{Slot: "2"}, {Slot: "2", SigningRoot: testRoot(2)}},
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "2", TargetEpoch: "3"},
+					{SourceEpoch: "1", TargetEpoch: "4"},
+				},
+			},
+			reasons: []string{"double proposal at slot 2", "surround vote of source 1 and target 4 with source 2 and target 3"},
+		},
+		{
+			name: "source greater than target",
+			data: &format.ProtectionData{
+				Pubkey:             testPubKeyA,
+				SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "2"}},
+			},
+			reasons: []string{"source epoch 3 greater than target epoch 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slashable, err := VerifyStandardProtectionJSON(testInterchange(tt.data), gvr[:])
+			require.NoError(t, err)
+			require.Equal(t, len(tt.reasons), len(slashable))
+			for i, reason := range tt.reasons {
+				assert.Equal(t, pubKeyA, slashable[i].PubKey)
+				assert.Equal(t, reason, slashable[i].Reason)
+			}
+		})
+	}
+
+	_, err = VerifyStandardProtectionJSON(testInterchange(), []byte{1})
+	require.ErrorContains(t, "does not match", err)
+	bad := testInterchange()
+	bad.Metadata.InterchangeFormatVersion = "4"
+	_, err = VerifyStandardProtectionJSON(bad, nil)
+	require.ErrorContains(t, "is not supported", err)
+	_, err = VerifyStandardProtectionJSON(testInterchange(&format.ProtectionData{
+		Pubkey:       testPubKeyA,
+		SignedBlocks: []*format.SignedBlock{{Slot: "a"}},
+	}), nil)
+	require.ErrorContains(t, "is not a valid slot", err)
+}
+
+func TestMinifyStandardProtectionJSON(t *testing.T) {
+	minified, err := MinifyStandardProtectionJSON(testInterchange(
+		&format.ProtectionData{
+			Pubkey:       testPubKeyA,
+			SignedBlocks: []*format.SignedBlock{{Slot: "5", SigningRoot: testRoot(1)}, {Slot: "3"}},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRoot(1)},
+				{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: testRoot(2)},
+			},
+		},
+		&format.ProtectionData{
+			Pubkey:       testPubKeyB,
+			SignedBlocks: []*format.SignedBlock{{Slot: "5", SigningRoot: testRoot(1)}, {Slot: "5", SigningRoot: testRoot(2)}},
+			// The highest source and target epochs are from different attestations.
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "4", TargetEpoch: "5", SigningRoot: testRoot(1)},
+				{SourceEpoch: "2", TargetEpoch: "6", SigningRoot: testRoot(2)},
+			},
+		},
+	))
+	require.NoError(t, err)
+	require.DeepEqual(t, []*format.ProtectionData{
+		{
+			Pubkey:             testPubKeyB,
+			SignedBlocks:       []*format.SignedBlock{{Slot: "5"}},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "4", TargetEpoch: "6"}},
+		},
+		{
+			Pubkey:             testPubKeyA,
+			SignedBlocks:       []*format.SignedBlock{{Slot: "5", SigningRoot: testRoot(1)}},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: testRoot(2)}},
+		},
+	}, minified.Data)
+
+	// A minified history has no slashable entries.
+	slashable, err := VerifyStandardProtectionJSON(minified, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slashable))
+}
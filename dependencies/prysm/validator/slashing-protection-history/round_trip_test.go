@@ -377,3 +377,74 @@ func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 		)
 	}
 }
+
+func TestImportExport_RoundTrip_Minimal(t *testing.T) {
+	ctx := context.Background()
+	pubKeys, err := slashtest.CreateRandomPubKeys(1)
+	require.NoError(t, err)
+	validatorDB := dbtest.SetupMinimalDB(t, pubKeys)
+	interchange := func(atts []*format.SignedAttestation, blocks []*format.SignedBlock) *bytes.Buffer {
+		blob, err := json.Marshal(&format.EIPSlashingProtectionFormat{
+			Metadata: struct {
+				InterchangeFormatVersion string `json:"interchange_format_version"`
+				GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+			}{
+				InterchangeFormatVersion: format.InterchangeFormatVersion,
+				GenesisValidatorsRoot:    fmt.Sprintf("%#x", [32]byte{1}),
+			},
+			Data: []*format.ProtectionData{
+				{
+					Pubkey:             fmt.Sprintf("%#x", pubKeys[0]),
+					SignedAttestations: atts,
+					SignedBlocks:       blocks,
+				},
+			},
+		})
+		require.NoError(t, err)
+		return bytes.NewBuffer(blob)
+	}
+
+	// The complete history is imported as its watermarks.
+	err = history.ImportStandardProtectionJSON(ctx, validatorDB, interchange(
+		[]*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: fmt.Sprintf("%#x", [32]byte{1})},
+			{SourceEpoch: "8", TargetEpoch: "9", SigningRoot: fmt.Sprintf("%#x", [32]byte{2})},
+			{SourceEpoch: "9", TargetEpoch: "10", SigningRoot: fmt.Sprintf("%#x", [32]byte{3})},
+		},
+		[]*format.SignedBlock{
+			{Slot: "5", SigningRoot: fmt.Sprintf("%#x", [32]byte{4})},
+			{Slot: "7", SigningRoot: fmt.Sprintf("%#x", [32]byte{5})},
+		},
+	))
+	require.NoError(t, err)
+
+	// Importing older data does not lower the watermarks.
+	err = history.ImportStandardProtectionJSON(ctx, validatorDB, interchange(
+		[]*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: fmt.Sprintf("%#x", [32]byte{6})}},
+		[]*format.SignedBlock{{Slot: "6", SigningRoot: fmt.Sprintf("%#x", [32]byte{7})}},
+	))
+	require.NoError(t, err)
+	blacklisted, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(blacklisted))
+
+	eipStandard, err := history.ExportStandardProtectionJSON(ctx, validatorDB)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(eipStandard.Data))
+	require.DeepEqual(t, []*format.SignedAttestation{
+		{SourceEpoch: "9", TargetEpoch: "10", SigningRoot: fmt.Sprintf("%#x", [32]byte{3})},
+	}, eipStandard.Data[0].SignedAttestations)
+	require.DeepEqual(t, []*format.SignedBlock{
+		{Slot: "7", SigningRoot: fmt.Sprintf("%#x", [32]byte{5})},
+	}, eipStandard.Data[0].SignedBlocks)
+
+	// A double vote with the highest signed attestation is still detected.
+	err = history.ImportStandardProtectionJSON(ctx, validatorDB, interchange(
+		[]*format.SignedAttestation{{SourceEpoch: "9", TargetEpoch: "10", SigningRoot: fmt.Sprintf("%#x", [32]byte{8})}},
+		nil,
+	))
+	require.NoError(t, err)
+	blacklisted, err = validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, pubKeys, blacklisted)
+}